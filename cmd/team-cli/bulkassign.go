@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thearchitectit/guardrail-mcp/internal/team"
+)
+
+// teamRoles lists the roles of each of the 12 standard teams. It mirrors the role
+// whitelist the MCP server checks assignments against (TEAM_STRUCTURE.md).
+var teamRoles = map[int][]string{
+	1:  {"Business Relationship Manager", "Lead Product Manager", "Business Systems Analyst", "Financial Controller (FinOps)"},
+	2:  {"Chief Architect", "Domain Architect", "Solution Architect", "Standards Lead"},
+	3:  {"Compliance Officer", "Internal Auditor", "Privacy Engineer", "Policy Manager"},
+	4:  {"Cloud Architect", "IaC Engineer", "Network Security Engineer", "Storage Engineer"},
+	5:  {"Platform Product Manager", "CI/CD Architect", "Kubernetes Administrator", "Developer Advocate"},
+	6:  {"Data Architect", "DBA", "Data Privacy Officer", "ETL Developer"},
+	7:  {"Technical Lead", "Senior Backend Engineer", "Senior Frontend Engineer", "Accessibility (A11y) Expert", "Technical Writer"},
+	8:  {"API Product Manager", "Integration Engineer", "Messaging Engineer", "IAM Specialist"},
+	9:  {"Security Architect", "Vulnerability Researcher", "Penetration Tester", "DevSecOps Engineer"},
+	10: {"QA Architect", "SDET", "Performance/Load Engineer", "Manual QA / UAT Coordinator"},
+	11: {"SRE Lead", "Observability Engineer", "Chaos Engineer", "Incident Manager"},
+	12: {"NOC Analyst", "Change Manager", "Release Manager", "L3 Support Engineer"},
+}
+
+// Bulk assignment row statuses
+const (
+	rowAssigned    = "assigned"
+	rowWouldAssign = "would_assign"
+	rowInvalid     = "invalid"
+	rowFailed      = "failed"
+)
+
+// assignmentRow is one assignment from a sheet in the format 'team template' writes
+type assignmentRow struct {
+	Row      int    `json:"row"` // CSV line or JSON array position, starting at 1
+	TeamID   int    `json:"team_id"`
+	Role     string `json:"role_name"`
+	Assignee string `json:"assignee"`
+	Status   string `json:"status"`
+	Output   string `json:"output,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// bulkAssignReport is the per-row result of a bulk assignment
+type bulkAssignReport struct {
+	Project  string          `json:"project"`
+	File     string          `json:"file"`
+	DryRun   bool            `json:"dry_run"`
+	Assigned int             `json:"assigned"`
+	Invalid  int             `json:"invalid"`
+	Failed   int             `json:"failed"`
+	Rows     []assignmentRow `json:"rows"`
+}
+
+// parseAssignmentSheet reads the assignments in a CSV or JSON sheet, chosen by the
+// file extension. Values that are not usable at all, such as a non-numeric team id,
+// are left for validateAssignment to report against their row.
+func parseAssignmentSheet(path string) ([]assignmentRow, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("assignment sheet %s: %w", path, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		var sheet struct {
+			Assignments []struct {
+				TeamID   json.Number `json:"team_id"`
+				Role     string      `json:"role_name"`
+				Assignee string      `json:"assignee"`
+			} `json:"assignments"`
+		}
+		if err := json.Unmarshal(data, &sheet); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+		rows := make([]assignmentRow, 0, len(sheet.Assignments))
+		for i, a := range sheet.Assignments {
+			teamID, _ := strconv.Atoi(a.TeamID.String())
+			rows = append(rows, assignmentRow{Row: i + 1, TeamID: teamID, Role: a.Role, Assignee: a.Assignee})
+		}
+		return rows, nil
+	case ".csv":
+		r := csv.NewReader(strings.NewReader(string(data)))
+		r.FieldsPerRecord = -1
+		header, err := r.Read()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s header: %w", path, err)
+		}
+		columns := make(map[string]int)
+		for i, name := range header {
+			columns[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+		}
+		for _, name := range []string{"team_id", "role_name", "assignee"} {
+			if _, ok := columns[name]; !ok {
+				return nil, fmt.Errorf("%s is missing the %s column (see 'team template')", path, name)
+			}
+		}
+
+		var rows []assignmentRow
+		for line := 2; ; line++ {
+			record, err := r.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+			}
+			field := func(name string) string {
+				if i := columns[name]; i < len(record) {
+					return strings.TrimSpace(record[i])
+				}
+				return ""
+			}
+			if strings.Join(record, "") == "" {
+				continue
+			}
+			teamID, _ := strconv.Atoi(field("team_id"))
+			rows = append(rows, assignmentRow{Row: line, TeamID: teamID, Role: field("role_name"), Assignee: field("assignee")})
+		}
+		return rows, nil
+	default:
+		return nil, fmt.Errorf("unsupported assignment sheet format %q (use .json or .csv, see 'team template')", filepath.Ext(path))
+	}
+}
+
+// validateAssignment applies the team id, role and assignee rules the MCP server
+// enforces for a single assignment
+func validateAssignment(row assignmentRow) error {
+	if err := team.ValidateTeamID(row.TeamID); err != nil {
+		return fmt.Errorf("invalid team_id %d: %s", row.TeamID, validationMessage(err))
+	}
+	roles := teamRoles[row.TeamID]
+	if row.Role == "" {
+		return fmt.Errorf("role_name is required")
+	}
+	if !slices.Contains(roles, row.Role) {
+		for id, other := range teamRoles {
+			if slices.Contains(other, row.Role) {
+				return fmt.Errorf("role %q belongs to team %d, not team %d", row.Role, id, row.TeamID)
+			}
+		}
+		return fmt.Errorf("unknown role %q for team %d (roles: %s)", row.Role, row.TeamID, strings.Join(roles, ", "))
+	}
+	if row.Assignee == "" {
+		return fmt.Errorf("assignee is required")
+	}
+	if len(row.Assignee) > 256 {
+		return fmt.Errorf("assignee must be 256 characters or less")
+	}
+	for _, r := range row.Assignee {
+		if r < 32 || r == 127 {
+			return fmt.Errorf("assignee contains invalid control characters")
+		}
+	}
+	for _, pattern := range []string{";", "|", "&&", "||", "`", "$", "<", ">", "..", "\\"} {
+		if strings.Contains(row.Assignee, pattern) {
+			return fmt.Errorf("assignee contains invalid characters")
+		}
+	}
+	return nil
+}
+
+// runBulkAssign validates every row, then assigns the valid ones one by one.
+// Invalid rows and rows the backend rejects are reported without stopping the
+// others. A dry run only validates. The error is the first backend failure, or a
+// validation failure when only invalid rows stopped the sheet from applying.
+func runBulkAssign(project, file string, rows []assignmentRow, dryRun bool, run teamManagerFunc) (*bulkAssignReport, error) {
+	report := &bulkAssignReport{Project: project, File: file, DryRun: dryRun, Rows: rows}
+
+	seen := make(map[string]int)
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		err := validateAssignment(*row)
+		key := fmt.Sprintf("%d/%s", row.TeamID, row.Role)
+		if first, dup := seen[key]; err == nil && dup {
+			err = fmt.Errorf("team %d %s is already assigned by row %d", row.TeamID, row.Role, first)
+		}
+		if err != nil {
+			row.Status, row.Error = rowInvalid, err.Error()
+			report.Invalid++
+			continue
+		}
+		seen[key] = row.Row
+		row.Status = rowWouldAssign
+	}
+
+	var firstErr error
+	for i := range report.Rows {
+		row := &report.Rows[i]
+		if dryRun || row.Status != rowWouldAssign {
+			continue
+		}
+		result, err := run(project, "assign", "--team", strconv.Itoa(row.TeamID), "--role", row.Role, "--person", row.Assignee)
+		row.Output = strings.TrimSpace(string(result))
+		if err != nil {
+			row.Status, row.Error = rowFailed, err.Error()
+			report.Failed++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("row %d: %w", row.Row, err)
+			}
+			continue
+		}
+		row.Status = rowAssigned
+		report.Assigned++
+	}
+
+	if firstErr == nil && report.Invalid > 0 {
+		firstErr = validationFailure(fmt.Errorf("%d of %d rows are invalid", report.Invalid, len(report.Rows)))
+	}
+	return report, firstErr
+}
+
+// printBulkAssignReport prints each row's outcome followed by the totals
+func printBulkAssignReport(report *bulkAssignReport) {
+	for _, row := range report.Rows {
+		label := fmt.Sprintf("row %d: Team %d %s → %s", row.Row, row.TeamID, row.Role, row.Assignee)
+		switch row.Status {
+		case rowAssigned:
+			fmt.Println(successStyle.Render("✓ " + label))
+		case rowWouldAssign:
+			fmt.Println(infoStyle.Render("• " + label + " (would assign)"))
+		default:
+			fmt.Println(errorStyle.Render("✗ " + label))
+			fmt.Println("    " + row.Error)
+		}
+	}
+	fmt.Println()
+	if report.DryRun {
+		fmt.Printf("Dry run: %d valid, %d invalid\n", len(report.Rows)-report.Invalid, report.Invalid)
+		return
+	}
+	fmt.Printf("Assigned %d, invalid %d, failed %d\n", report.Assigned, report.Invalid, report.Failed)
+}
+
+// bulkAssignCmd creates the bulk-assign command
+func bulkAssignCmd() *cobra.Command {
+	var file string
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "bulk-assign",
+		Short: "Assign roles from a CSV or JSON assignment sheet",
+		Long: `Apply an assignment sheet in the format produced by 'team template'.
+
+Every row is checked first: the team id must be 1-12, the role must be one of that
+team's roles and the assignee a valid name. Valid rows are then assigned one at a
+time; invalid or rejected rows are reported and do not stop the others. Use
+--dry-run to only check the sheet and show what would be assigned.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if projectName == "" {
+				return fmt.Errorf("--project flag is required")
+			}
+
+			rows, err := parseAssignmentSheet(file)
+			if err != nil {
+				return err
+			}
+
+			report, err := runBulkAssign(projectName, file, rows, dryRun, runTeamManager)
+
+			if output == "json" {
+				data, _ := json.Marshal(report)
+				if perr := prettyPrintJSON(os.Stdout, data); perr != nil {
+					return perr
+				}
+				return err
+			}
+
+			fmt.Println(titleStyle.Render("Bulk Assigning Roles"))
+			fmt.Printf("Project: %s\n", textStyle.Render(projectName))
+			fmt.Printf("File: %s\n\n", textStyle.Render(file))
+			printBulkAssignReport(report)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Assignment sheet (.csv or .json)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Validate the sheet and show what would be assigned without assigning")
+
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const assignmentSheetCSV = `team_id,role_name,assignee
+7,Technical Lead,Jane Smith
+99,Technical Lead,John Doe
+7,Chief Architect,John Doe
+8,Messaging Engineer,Ana Lopez
+7,Technical Lead,Sam Lee
+`
+
+// TestParseAssignmentSheet tests that both template formats parse into numbered rows
+func TestParseAssignmentSheet(t *testing.T) {
+	csvRows, err := parseAssignmentSheet(writeRoster(t, "sheet.csv", assignmentSheetCSV))
+	if err != nil {
+		t.Fatalf("parseAssignmentSheet(csv) error = %v", err)
+	}
+	if len(csvRows) != 5 || csvRows[1].Row != 3 || csvRows[1].TeamID != 99 || csvRows[3].Role != "Messaging Engineer" {
+		t.Errorf("csv rows = %+v", csvRows)
+	}
+
+	jsonRows, err := parseAssignmentSheet(writeRoster(t, "sheet.json", `{"assignments": [{"team_id": 1, "role_name": "Business Relationship Manager", "assignee": "John Doe"}]}`))
+	if err != nil {
+		t.Fatalf("parseAssignmentSheet(json) error = %v", err)
+	}
+	if len(jsonRows) != 1 || jsonRows[0].Row != 1 || jsonRows[0].TeamID != 1 || jsonRows[0].Assignee != "John Doe" {
+		t.Errorf("json rows = %+v", jsonRows)
+	}
+
+	if _, err := parseAssignmentSheet(writeRoster(t, "sheet.csv", "team,role\n7,SDET\n")); err == nil || !strings.Contains(err.Error(), "team_id column") {
+		t.Errorf("parseAssignmentSheet(bad header) error = %v, want missing column", err)
+	}
+	if _, err := parseAssignmentSheet(writeRoster(t, "sheet.txt", "")); err == nil {
+		t.Error("parseAssignmentSheet(.txt) expected error")
+	}
+}
+
+// TestRunBulkAssign tests that only valid rows reach the backend and every row is reported
+func TestRunBulkAssign(t *testing.T) {
+	rows, err := parseAssignmentSheet(writeRoster(t, "sheet.csv", assignmentSheetCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := &fakeTeamManager{}
+
+	report, err := runBulkAssign("web-platform", "sheet.csv", rows, false, tm.run)
+	if err == nil || exitCodeFor(err) != exitValidation {
+		t.Errorf("runBulkAssign() error = %v, want a validation failure", err)
+	}
+	want := []string{
+		"assign --team 7 --role Technical Lead --person Jane Smith",
+		"assign --team 8 --role Messaging Engineer --person Ana Lopez",
+	}
+	if strings.Join(tm.calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %v, want %v", tm.calls, want)
+	}
+	if report.Assigned != 2 || report.Invalid != 3 || report.Failed != 0 {
+		t.Errorf("report = %+v, want 2 assigned and 3 invalid", report)
+	}
+	for i, wantErr := range map[int]string{1: "between 1 and 12", 2: "belongs to team 2", 4: "already assigned by row 2"} {
+		if row := report.Rows[i]; row.Status != rowInvalid || !strings.Contains(row.Error, wantErr) {
+			t.Errorf("row %d = %+v, want invalid with %q", row.Row, row, wantErr)
+		}
+	}
+}
+
+// TestRunBulkAssign_DryRun tests that a dry run validates without calling the backend
+func TestRunBulkAssign_DryRun(t *testing.T) {
+	rows := []assignmentRow{{Row: 2, TeamID: 10, Role: "SDET", Assignee: "Jane Smith"}}
+	tm := &fakeTeamManager{}
+
+	report, err := runBulkAssign("web-platform", "sheet.csv", rows, true, tm.run)
+	if err != nil {
+		t.Fatalf("runBulkAssign(dry run) error = %v", err)
+	}
+	if len(tm.calls) != 0 {
+		t.Errorf("calls = %v, want none", tm.calls)
+	}
+	if report.Rows[0].Status != rowWouldAssign || report.Assigned != 0 {
+		t.Errorf("report = %+v, want the row marked would_assign", report)
+	}
+}
+
+// TestRunBulkAssign_BackendFailure tests that a rejected row does not stop the others
+func TestRunBulkAssign_BackendFailure(t *testing.T) {
+	rows := []assignmentRow{
+		{Row: 2, TeamID: 10, Role: "SDET", Assignee: "Jane Smith"},
+		{Row: 3, TeamID: 11, Role: "SRE Lead", Assignee: "John Doe"},
+	}
+	tm := &fakeTeamManager{failures: map[string]error{"assign": errors.New("Role already assigned")}}
+
+	report, err := runBulkAssign("web-platform", "sheet.csv", rows, false, tm.run)
+	if err == nil || !strings.Contains(err.Error(), "row 2") || exitCodeFor(err) != exitBackend {
+		t.Errorf("runBulkAssign() error = %v, want the first row's backend failure", err)
+	}
+	if len(tm.calls) != 2 || report.Failed != 2 {
+		t.Errorf("calls = %v, report = %+v, want both rows attempted", tm.calls, report)
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thearchitectit/guardrail-mcp/internal/team"
+)
+
+// Bulk transition team statuses
+const (
+	teamTransitioned = "done"
+	teamFailed       = "failed"
+	teamSkipped      = "skipped"
+)
+
+// teamTransition is the outcome of starting or completing one team
+type teamTransition struct {
+	TeamID int    `json:"team_id"`
+	Status string `json:"status"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// bulkTransitionReport is the per-team result of a bulk start or complete
+type bulkTransitionReport struct {
+	Project   string           `json:"project"`
+	Command   string           `json:"command"`
+	Strict    bool             `json:"strict"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Skipped   int              `json:"skipped"`
+	Teams     []teamTransition `json:"teams"`
+}
+
+// teamsInPhase returns the IDs of the standard teams in a phase given as a
+// --phase style filter ("Phase 2")
+func teamsInPhase(phase string) ([]int, error) {
+	if phase == "" {
+		return nil, fmt.Errorf("--all-in-phase needs a phase, e.g. \"Phase 1\"")
+	}
+	if err := validatePhaseFlag(phase); err != nil {
+		return nil, err
+	}
+	var ids []int
+	for id, t := range team.StandardTeams {
+		if strings.HasPrefix(t.Phase, phase+":") {
+			ids = append(ids, id)
+		}
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+// bulkTeamIDs validates the --teams list, dropping repeated IDs
+func bulkTeamIDs(ids []int) ([]int, error) {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if err := team.ValidateTeamID(id); err != nil {
+			return nil, fmt.Errorf("invalid --teams entry %d: %s", id, validationMessage(err))
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique, nil
+}
+
+// runBulkTransition runs command (start or complete) for each team in turn. A team
+// the backend rejects is reported and the others still run, unless strict, which
+// stops at the first failure and skips the remaining teams. The error is the first
+// failure.
+func runBulkTransition(project, command string, teams []int, strict bool, run teamManagerFunc) (*bulkTransitionReport, error) {
+	report := &bulkTransitionReport{Project: project, Command: command, Strict: strict}
+
+	var firstErr error
+	for _, id := range teams {
+		outcome := teamTransition{TeamID: id}
+		switch {
+		case strict && firstErr != nil:
+			outcome.Status = teamSkipped
+			report.Skipped++
+		default:
+			result, err := run(project, command, "--team", strconv.Itoa(id))
+			outcome.Output = strings.TrimSpace(string(result))
+			if err != nil {
+				outcome.Status, outcome.Error = teamFailed, err.Error()
+				report.Failed++
+				if firstErr == nil {
+					firstErr = fmt.Errorf("team %d: %w", id, err)
+				}
+				break
+			}
+			outcome.Status = teamTransitioned
+			report.Succeeded++
+		}
+		report.Teams = append(report.Teams, outcome)
+	}
+	return report, firstErr
+}
+
+// printBulkTransitionReport prints each team's outcome followed by the totals
+func printBulkTransitionReport(report *bulkTransitionReport) {
+	for _, t := range report.Teams {
+		label := fmt.Sprintf("Team %d", t.TeamID)
+		switch t.Status {
+		case teamTransitioned:
+			fmt.Println(successStyle.Render("✓ " + label))
+		case teamSkipped:
+			fmt.Println(warnStyle.Render("- " + label + " (skipped)"))
+		default:
+			fmt.Println(errorStyle.Render("✗ " + label))
+			fmt.Println("    " + t.Error)
+		}
+	}
+	fmt.Println()
+	fmt.Printf("Succeeded %d, failed %d, skipped %d\n", report.Succeeded, report.Failed, report.Skipped)
+}
+
+// bulkTransitionFlags are the start/complete options for several teams at once
+type bulkTransitionFlags struct {
+	teams      []int
+	allInPhase string
+	strict     bool
+}
+
+// register adds --teams, --all-in-phase and --strict next to --team
+func (f *bulkTransitionFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntSliceVar(&f.teams, "teams", nil, "Several team IDs, comma separated (e.g. 1,3,5)")
+	cmd.Flags().StringVar(&f.allInPhase, "all-in-phase", "", "Every team in a phase (e.g. 'Phase 2')")
+	cmd.Flags().BoolVar(&f.strict, "strict", false, "Stop at the first team that fails instead of continuing")
+	cmd.MarkFlagsOneRequired("team", "teams", "all-in-phase")
+	cmd.MarkFlagsMutuallyExclusive("team", "teams", "all-in-phase")
+}
+
+// bulk reports whether several teams were selected
+func (f *bulkTransitionFlags) bulk() bool {
+	return f.teams != nil || f.allInPhase != ""
+}
+
+// run transitions the selected teams and prints the report for the output format
+func (f *bulkTransitionFlags) run(project, command, title string) error {
+	teams, err := bulkTeamIDs(f.teams)
+	if f.allInPhase != "" {
+		teams, err = teamsInPhase(f.allInPhase)
+	}
+	if err != nil {
+		return err
+	}
+
+	report, err := runBulkTransition(project, command, teams, f.strict, runTeamManager)
+
+	if output == "json" {
+		data, _ := json.Marshal(report)
+		if perr := prettyPrintJSON(os.Stdout, data); perr != nil {
+			return perr
+		}
+		return err
+	}
+
+	fmt.Println(titleStyle.Render(title))
+	fmt.Printf("Project: %s\n", textStyle.Render(project))
+	if f.allInPhase != "" {
+		fmt.Printf("Phase: %s\n", textStyle.Render(f.allInPhase))
+	}
+	fmt.Println()
+	printBulkTransitionReport(report)
+	return err
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// failingTeam wraps a fakeTeamManager so that only one team's transition fails
+func failingTeam(tm *fakeTeamManager, id int) teamManagerFunc {
+	return func(project, command string, args ...string) ([]byte, error) {
+		out, err := tm.run(project, command, args...)
+		if strings.Join(args, " ") == fmt.Sprintf("--team %d", id) {
+			return []byte("❌ team is blocked"), backendFailure(errors.New("team_manager.py failed: team is blocked"))
+		}
+		return out, err
+	}
+}
+
+// TestRunBulkTransition tests that each team is transitioned separately and the
+// results are aggregated, continuing past failures unless strict
+func TestRunBulkTransition(t *testing.T) {
+	tests := []struct {
+		name          string
+		command       string
+		teams         []int
+		failTeam      int
+		strict        bool
+		wantCalls     []string
+		wantStatuses  []string
+		wantSucceeded int
+		wantFailed    int
+		wantSkipped   int
+	}{
+		{
+			name:          "start three teams",
+			command:       "start",
+			teams:         []int{1, 3, 5},
+			wantCalls:     []string{"start --team 1", "start --team 3", "start --team 5"},
+			wantStatuses:  []string{teamTransitioned, teamTransitioned, teamTransitioned},
+			wantSucceeded: 3,
+		},
+		{
+			name:          "failure continues",
+			command:       "complete",
+			teams:         []int{1, 3, 5},
+			failTeam:      3,
+			wantCalls:     []string{"complete --team 1", "complete --team 3", "complete --team 5"},
+			wantStatuses:  []string{teamTransitioned, teamFailed, teamTransitioned},
+			wantSucceeded: 2,
+			wantFailed:    1,
+		},
+		{
+			name:          "strict stops at first failure",
+			command:       "start",
+			teams:         []int{1, 3, 5},
+			failTeam:      3,
+			strict:        true,
+			wantCalls:     []string{"start --team 1", "start --team 3"},
+			wantStatuses:  []string{teamTransitioned, teamFailed, teamSkipped},
+			wantSucceeded: 1,
+			wantFailed:    1,
+			wantSkipped:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := &fakeTeamManager{}
+			report, err := runBulkTransition("web-platform", tt.command, tt.teams, tt.strict, failingTeam(tm, tt.failTeam))
+
+			if tt.wantFailed > 0 {
+				if err == nil || !strings.Contains(err.Error(), fmt.Sprintf("team %d", tt.failTeam)) {
+					t.Errorf("error = %v, want failure of team %d", err, tt.failTeam)
+				}
+				if exitCodeFor(err) != exitBackend {
+					t.Errorf("exit code = %d, want %d", exitCodeFor(err), exitBackend)
+				}
+			} else if err != nil {
+				t.Fatalf("runBulkTransition() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(tm.calls, tt.wantCalls) {
+				t.Errorf("calls = %v, want %v", tm.calls, tt.wantCalls)
+			}
+			var statuses []string
+			for _, team := range report.Teams {
+				statuses = append(statuses, team.Status)
+			}
+			if !reflect.DeepEqual(statuses, tt.wantStatuses) {
+				t.Errorf("statuses = %v, want %v", statuses, tt.wantStatuses)
+			}
+			if report.Succeeded != tt.wantSucceeded || report.Failed != tt.wantFailed || report.Skipped != tt.wantSkipped {
+				t.Errorf("succeeded/failed/skipped = %d/%d/%d, want %d/%d/%d",
+					report.Succeeded, report.Failed, report.Skipped, tt.wantSucceeded, tt.wantFailed, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+// TestBulkTransitionFlags tests team selection for start and complete
+func TestBulkTransitionFlags(t *testing.T) {
+	useFakeTeamManager(t)
+	output = "text"
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantErr   string
+		wantTeams []int
+	}{
+		{name: "teams list", args: []string{"start", "--teams", "1,3,5"}, wantTeams: []int{1, 3, 5}},
+		{name: "repeated team", args: []string{"start", "--teams", "7,7"}, wantTeams: []int{7}},
+		{name: "all in phase", args: []string{"complete", "--all-in-phase", "Phase 2"}, wantTeams: []int{4, 5, 6}},
+		{name: "teams out of range", args: []string{"start", "--teams", "1,13"}, wantErr: "invalid --teams entry 13"},
+		{name: "bad phase", args: []string{"start", "--all-in-phase", "Phase 9"}, wantErr: "invalid phase"},
+		{name: "team and teams", args: []string{"start", "--team", "1", "--teams", "2,3"}, wantErr: "none of the others can be"},
+		{name: "no team", args: []string{"complete"}, wantErr: "at least one of the flags"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			stdout := captureStdout(t, func() {
+				cmd := newRootCmd()
+				cmd.SetArgs(append(tt.args, "-p", "demo"))
+				cmd.SilenceErrors, cmd.SilenceUsage = true, true
+				err = cmd.Execute()
+			})
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("team %s error = %v", strings.Join(tt.args, " "), err)
+			}
+			for _, id := range tt.wantTeams {
+				if !strings.Contains(stdout, fmt.Sprintf("✓ Team %d\n", id)) {
+					t.Errorf("output missing team %d:\n%s", id, stdout)
+				}
+			}
+			if got := strings.Count(stdout, "✓ Team"); got != len(tt.wantTeams) {
+				t.Errorf("transitioned %d teams, want %d:\n%s", got, len(tt.wantTeams), stdout)
+			}
+		})
+	}
+}
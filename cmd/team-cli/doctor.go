@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// noTeamManager annotates commands that do not run team_manager.py, so the script
+// is not required before they start
+const noTeamManager = "no-team-manager"
+
+// doctorReport is how the CLI resolved its Python interpreter and backend script
+type doctorReport struct {
+	OK            bool                `json:"ok"`
+	Python        string              `json:"python,omitempty"`
+	PythonVersion string              `json:"python_version,omitempty"`
+	PythonError   string              `json:"python_error,omitempty"`
+	Script        teamManagerLocation `json:"script"`
+	ScriptExists  bool                `json:"script_exists"`
+	ScriptError   string              `json:"script_error,omitempty"`
+	EnvPath       string              `json:"team_manager_path_env"`
+	Server        string              `json:"server,omitempty"`
+	ServerError   string              `json:"server_error,omitempty"`
+}
+
+// runDoctor resolves the Python interpreter and team_manager.py the way every
+// other command does and reports what it found, and checks --server when set
+func runDoctor() *doctorReport {
+	report := &doctorReport{Script: teamManagerPath(), EnvPath: os.Getenv("TEAM_MANAGER_PATH")}
+	if abs, err := filepath.Abs(report.Script.Path); err == nil {
+		report.Script.Path = abs
+	}
+
+	if python, err := pythonBinary(); err != nil {
+		report.PythonError = err.Error()
+	} else {
+		report.Python = python
+		if path, err := exec.LookPath(python); err == nil {
+			report.Python = path
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if out, err := exec.CommandContext(ctx, python, "--version").CombinedOutput(); err == nil {
+			report.PythonVersion = strings.TrimSpace(string(out))
+		}
+	}
+
+	if info, err := os.Stat(report.Script.Path); err != nil {
+		report.ScriptError = err.Error()
+	} else if info.IsDir() {
+		report.ScriptError = report.Script.Path + " is a directory"
+	} else {
+		report.ScriptExists = true
+	}
+
+	report.OK = report.PythonError == "" && report.ScriptExists
+
+	// With --server, commands run on the server and need neither Python nor the script
+	if serverURL != "" {
+		report.Server = serverURL
+		if _, err := runRemote("", "health"); err != nil {
+			report.ServerError = err.Error()
+		}
+		report.OK = report.ServerError == ""
+	}
+	return report
+}
+
+// doctorCmd creates the doctor command
+func doctorCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "doctor",
+		Short: "Show the Python interpreter and team_manager.py the CLI uses",
+		Long: `Report the Python interpreter and the team_manager.py script every command
+runs, where the script path came from (TEAM_MANAGER_PATH, next to the executable or
+the scripts/team_manager.py default) and whether both exist. With --server, check
+that the server answers instead. Include this output when reporting a problem with
+the CLI.`,
+		Annotations: map[string]string{noTeamManager: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			report := runDoctor()
+			var err error
+			if !report.OK {
+				err = backendFailure(fmt.Errorf("team CLI is not set up correctly"))
+			}
+
+			if output == "json" {
+				data, _ := json.Marshal(report)
+				if perr := prettyPrintJSON(os.Stdout, data); perr != nil {
+					return perr
+				}
+				return err
+			}
+
+			fmt.Println(titleStyle.Render("Team CLI Doctor"))
+			fmt.Printf("Version: %s\n\n", textStyle.Render(version))
+
+			if report.PythonError != "" {
+				fmt.Println(errorStyle.Render("✗ Python: " + report.PythonError))
+			} else {
+				fmt.Println(successStyle.Render(fmt.Sprintf("✓ Python: %s (%s)", report.Python, report.PythonVersion)))
+			}
+
+			if report.ScriptExists {
+				fmt.Println(successStyle.Render("✓ team_manager.py: " + report.Script.Path))
+			} else {
+				fmt.Println(errorStyle.Render("✗ team_manager.py: " + report.Script.Path))
+				fmt.Println("    " + report.ScriptError)
+				fmt.Println("    set TEAM_MANAGER_PATH to the path of team_manager.py")
+			}
+			fmt.Printf("    resolved from: %s\n", report.Script.Source)
+			for _, candidate := range report.Script.Tried {
+				fmt.Printf("    not found: %s\n", candidate)
+			}
+			if report.EnvPath == "" {
+				fmt.Println(infoStyle.Render("    TEAM_MANAGER_PATH is not set"))
+			}
+
+			if report.Server != "" {
+				if report.ServerError != "" {
+					fmt.Println(errorStyle.Render("✗ Server: " + report.Server))
+					fmt.Println("    " + report.ServerError)
+				} else {
+					fmt.Println(successStyle.Render("✓ Server: " + report.Server + " (commands run on the server)"))
+				}
+			}
+			return err
+		},
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// resetTeamManagerPath drops the memoized script path so the test's
+// TEAM_MANAGER_PATH is used, and drops it again afterwards
+func resetTeamManagerPath(t *testing.T) {
+	t.Helper()
+	teamManagerPath = sync.OnceValue(locateTeamManager)
+	t.Cleanup(func() { teamManagerPath = sync.OnceValue(locateTeamManager) })
+}
+
+// TestTeamManagerPath_Memoized tests that the script path is resolved once per process
+func TestTeamManagerPath_Memoized(t *testing.T) {
+	t.Setenv("TEAM_MANAGER_PATH", "/opt/first/team_manager.py")
+	resetTeamManagerPath(t)
+
+	if got := getTeamManagerPath(); got != "/opt/first/team_manager.py" {
+		t.Fatalf("getTeamManagerPath() = %q", got)
+	}
+	os.Setenv("TEAM_MANAGER_PATH", "/opt/second/team_manager.py")
+	if got := teamManagerPath(); got.Path != "/opt/first/team_manager.py" || got.Source != scriptFromEnv {
+		t.Errorf("teamManagerPath() = %+v, want the first resolution", got)
+	}
+}
+
+// TestCheckTeamManagerScript tests the up-front error for a missing script
+func TestCheckTeamManagerScript(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TEAM_MANAGER_PATH", filepath.Join(dir, "missing.py"))
+	resetTeamManagerPath(t)
+
+	err := checkTeamManagerScript()
+	if err == nil || !strings.Contains(err.Error(), "TEAM_MANAGER_PATH is set to") || exitCodeFor(err) != exitBackend {
+		t.Errorf("checkTeamManagerScript() error = %v, want a backend failure naming TEAM_MANAGER_PATH", err)
+	}
+	if _, err := runTeamManager("demo", "status"); err == nil || !strings.Contains(err.Error(), "team doctor") {
+		t.Errorf("runTeamManager() error = %v, want the missing script reported before Python runs", err)
+	}
+
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"status", "-p", "demo"})
+	cmd.SilenceErrors, cmd.SilenceUsage = true, true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "TEAM_MANAGER_PATH") {
+		t.Errorf("team status error = %v, want the missing script reported at command start", err)
+	}
+
+	script := filepath.Join(dir, "team_manager.py")
+	if err := os.WriteFile(script, []byte("print('ok')\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEAM_MANAGER_PATH", script)
+	resetTeamManagerPath(t)
+	if err := checkTeamManagerScript(); err != nil {
+		t.Errorf("checkTeamManagerScript() error = %v, want nil", err)
+	}
+}
+
+// TestDoctorCmd tests that doctor runs without a script and reports what it resolved
+func TestDoctorCmd(t *testing.T) {
+	t.Setenv("TEAM_MANAGER_PATH", filepath.Join(t.TempDir(), "missing.py"))
+	resetTeamManagerPath(t)
+
+	var err error
+	out := captureStdout(t, func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"doctor", "-o", "json"})
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		err = cmd.Execute()
+	})
+	if err == nil || exitCodeFor(err) != exitBackend {
+		t.Errorf("doctor error = %v, want a backend failure for the missing script", err)
+	}
+	for _, want := range []string{`"script_exists": false`, `"source": "TEAM_MANAGER_PATH"`, "missing.py"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("doctor output %s does not contain %q", out, want)
+		}
+	}
+
+	useFakeTeamManager(t)
+	out = captureStdout(t, func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"doctor", "-o", "json"})
+		err = cmd.Execute()
+	})
+	if err != nil || !strings.Contains(out, `"ok": true`) {
+		t.Errorf("doctor error = %v, output = %s, want ok", err, out)
+	}
+}
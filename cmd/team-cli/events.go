@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// eventSeverityRank orders severities for the --severity minimum filter
+var eventSeverityRank = map[string]int{
+	"info":     0,
+	"warning":  1,
+	"critical": 2,
+}
+
+// auditEvent is a single event received from the server's /api/events stream
+type auditEvent struct {
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Type      string    `json:"type"`
+	Severity  string    `json:"severity"`
+	Actor     string    `json:"actor"`
+	Action    string    `json:"action"`
+	Resource  string    `json:"resource"`
+	Status    string    `json:"status"`
+}
+
+// eventFilter drops events older than Since or below MinSeverity
+type eventFilter struct {
+	Since       time.Time
+	MinSeverity string
+}
+
+func (f eventFilter) match(ev auditEvent) bool {
+	if !f.Since.IsZero() && ev.Timestamp.Before(f.Since) {
+		return false
+	}
+	if f.MinSeverity != "" && eventSeverityRank[ev.Severity] < eventSeverityRank[f.MinSeverity] {
+		return false
+	}
+	return true
+}
+
+// parseEventStream reads Server-Sent Events from r and calls emit for each audit
+// event that passes the filter. Comment lines (keepalives) are ignored.
+func parseEventStream(r io.Reader, filter eventFilter, emit func(auditEvent)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var data strings.Builder
+	dispatch := func() error {
+		if data.Len() == 0 {
+			return nil
+		}
+		var ev auditEvent
+		err := json.Unmarshal([]byte(data.String()), &ev)
+		data.Reset()
+		if err != nil {
+			return fmt.Errorf("invalid event payload: %w", err)
+		}
+		if filter.match(ev) {
+			emit(ev)
+		}
+		return nil
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if err := dispatch(); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, ":"):
+			// keepalive comment
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return dispatch()
+}
+
+// formatEventRow renders an event as a single line, colored by severity
+func formatEventRow(ev auditEvent) string {
+	severity := fmt.Sprintf("%-8s", strings.ToUpper(ev.Severity))
+	switch ev.Severity {
+	case "critical":
+		severity = errorStyle.Render(severity)
+	case "warning":
+		severity = warnStyle.Render(severity)
+	default:
+		severity = infoStyle.Render(severity)
+	}
+
+	row := fmt.Sprintf("%s  %s  %-16s %-10s", ev.Timestamp.Local().Format("2006-01-02 15:04:05"), severity, ev.Type, ev.Action)
+	if ev.Resource != "" {
+		row += " " + ev.Resource
+	}
+	if ev.Status != "" {
+		row += " [" + ev.Status + "]"
+	}
+	if ev.Actor != "" {
+		row += textStyle.Render(" by " + ev.Actor)
+	}
+	return row
+}
+
+// eventsCmd creates the events command
+func eventsCmd() *cobra.Command {
+	var webURL, severity string
+	var since time.Duration
+
+	cmd := &cobra.Command{
+		Use:         "events",
+		Short:       "Tail audit events from the guardrail server",
+		Annotations: map[string]string{noTeamManager: "true"},
+		Long: `Stream audit events live from the guardrail web server's /api/events feed.
+
+Events are printed as they arrive, colored by severity. Use --since to replay
+recently buffered events and --severity to hide lower-severity events. The web
+server is set with --web-url; the global --server names the MCP server instead.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			severity = strings.ToLower(severity)
+			if _, ok := eventSeverityRank[severity]; severity != "" && !ok {
+				return fmt.Errorf("--severity must be one of info, warning, critical")
+			}
+			if apiKey == "" {
+				return fmt.Errorf("--api-key flag or GUARDRAIL_API_KEY is required")
+			}
+
+			filter := eventFilter{MinSeverity: severity}
+			query := url.Values{}
+			if severity != "" {
+				query.Set("severity", severity)
+			}
+			if since > 0 {
+				filter.Since = time.Now().Add(-since)
+				query.Set("since", filter.Since.UTC().Format(time.RFC3339))
+			}
+
+			endpoint := strings.TrimRight(webURL, "/") + "/api/events"
+			if len(query) > 0 {
+				endpoint += "?" + query.Encode()
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+			if err != nil {
+				return fmt.Errorf("invalid --web-url: %w", err)
+			}
+			req.Header.Set("Authorization", "Bearer "+apiKey)
+			req.Header.Set("Accept", "text/event-stream")
+
+			resp, err := http.DefaultClient.Do(req)
+			if err != nil {
+				return backendFailure(fmt.Errorf("failed to connect to %s: %w", webURL, err))
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+				err := fmt.Errorf("event stream returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+				if resp.StatusCode == http.StatusNotFound {
+					return notFoundFailure(err)
+				}
+				return backendFailure(err)
+			}
+
+			if output != "json" {
+				fmt.Println(titleStyle.Render("Guardrail Events"))
+				fmt.Printf("Server: %s\n\n", textStyle.Render(webURL))
+			}
+
+			err = parseEventStream(resp.Body, filter, func(ev auditEvent) {
+				if output == "json" {
+					line, _ := json.Marshal(ev)
+					fmt.Println(string(line))
+					return
+				}
+				fmt.Println(formatEventRow(ev))
+			})
+			if ctx.Err() != nil {
+				// Interrupted by the user
+				return nil
+			}
+			return err
+		},
+	}
+
+	defaultWebURL := os.Getenv("GUARDRAIL_WEB_URL")
+	if defaultWebURL == "" {
+		defaultWebURL = "http://localhost:8081"
+	}
+
+	cmd.Flags().StringVar(&webURL, "web-url", defaultWebURL, "Guardrail web server URL (env GUARDRAIL_WEB_URL)")
+	cmd.Flags().DurationVar(&since, "since", 0, "Replay buffered events from this far back (e.g. 15m)")
+	cmd.Flags().StringVar(&severity, "severity", "", "Minimum severity to show: info, warning, critical")
+
+	return cmd
+}
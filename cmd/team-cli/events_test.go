@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleEventStream = `: keepalive
+
+event: audit
+data: {"id":"1","timestamp":"2026-01-02T10:00:00Z","type":"document_change","severity":"info","actor":"ab12****ef34","action":"update","resource":"doc-1","status":"success"}
+
+event: audit
+data: {"id":"2","timestamp":"2026-01-02T10:05:00Z","type":"rule_change","severity":"critical","actor":"ab12****ef34","action":"delete","resource":"rule-1","status":"success"}
+
+event: audit
+data: {"id":"3","timestamp":"2026-01-02T10:06:00Z","type":"auth_failure","severity":"warning","actor":"unknown","action":"authenticate","status":"failure"}
+`
+
+// TestParseEventStream tests that a sample SSE stream is parsed and filtered into printed rows
+func TestParseEventStream(t *testing.T) {
+	tests := []struct {
+		name    string
+		filter  eventFilter
+		wantIDs []string
+	}{
+		{name: "no filter", wantIDs: []string{"1", "2", "3"}},
+		{name: "minimum severity warning", filter: eventFilter{MinSeverity: "warning"}, wantIDs: []string{"2", "3"}},
+		{name: "since", filter: eventFilter{Since: time.Date(2026, 1, 2, 10, 5, 30, 0, time.UTC)}, wantIDs: []string{"3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ids []string
+			err := parseEventStream(strings.NewReader(sampleEventStream), tt.filter, func(ev auditEvent) {
+				ids = append(ids, ev.ID)
+			})
+			if err != nil {
+				t.Fatalf("parseEventStream() error = %v", err)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.wantIDs, ",") {
+				t.Errorf("parsed ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+
+	var rows []string
+	if err := parseEventStream(strings.NewReader(sampleEventStream), eventFilter{}, func(ev auditEvent) {
+		rows = append(rows, formatEventRow(ev))
+	}); err != nil {
+		t.Fatalf("parseEventStream() error = %v", err)
+	}
+	for _, want := range []string{"CRITICAL", "rule_change", "delete", "rule-1", "[success]"} {
+		if !strings.Contains(rows[1], want) {
+			t.Errorf("row %q missing %q", rows[1], want)
+		}
+	}
+
+	if err := parseEventStream(strings.NewReader("data: {not json}\n\n"), eventFilter{}, func(auditEvent) {}); err == nil {
+		t.Error("parseEventStream() expected error for malformed payload")
+	}
+}
+
+// TestEventsCmd_Flags tests that events reads the web server from --web-url and takes
+// the global --api-key, leaving the global --server for the MCP server
+func TestEventsCmd_Flags(t *testing.T) {
+	var gotAuth string
+	web := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, sampleEventStream)
+	}))
+	defer web.Close()
+	t.Cleanup(func() { serverURL, output = "", "text" })
+
+	var err error
+	stdout := captureStdout(t, func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"--server", "http://mcp.invalid:8080", "--api-key", "test-key", "-o", "json", "events", "--web-url", web.URL})
+		cmd.SilenceErrors, cmd.SilenceUsage = true, true
+		err = cmd.Execute()
+	})
+	if err != nil {
+		t.Fatalf("team events error = %v", err)
+	}
+	if gotAuth != "Bearer test-key" {
+		t.Errorf("Authorization = %q, want the --api-key", gotAuth)
+	}
+	if serverURL != "http://mcp.invalid:8080" {
+		t.Errorf("--server = %q, want the MCP server URL", serverURL)
+	}
+	if lines := strings.Count(stdout, "\n"); lines != 3 {
+		t.Errorf("printed %d events, want 3:\n%s", lines, stdout)
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Exit codes returned by every command, so CI jobs can gate on the failure class
+const (
+	exitOK         = 0
+	exitGeneric    = 1 // usage errors and anything unclassified
+	exitValidation = 2 // a check ran and failed (team sizes, phase gate, ...)
+	exitBackend    = 3 // team_manager.py, Python or the guardrail server failed
+	exitNotFound   = 4 // project, team, role or backup does not exist
+	exitTimeout    = 5 // the operation did not finish in time
+)
+
+// validationCommands are team_manager.py commands whose non-zero exit means a check failed
+var validationCommands = map[string]bool{
+	"validate-size":    true,
+	"phase-gate-check": true,
+}
+
+// cliError attaches an exit code to an error returned from a command
+type cliError struct {
+	code int
+	err  error
+}
+
+func (e *cliError) Error() string { return e.err.Error() }
+func (e *cliError) Unwrap() error { return e.err }
+
+// validationFailure marks err as a failed check (exit code 2)
+func validationFailure(err error) error {
+	return &cliError{code: exitValidation, err: err}
+}
+
+// backendFailure marks err as a backend or Python failure (exit code 3)
+func backendFailure(err error) error {
+	return &cliError{code: exitBackend, err: err}
+}
+
+// notFoundFailure marks err as a missing resource (exit code 4)
+func notFoundFailure(err error) error {
+	return &cliError{code: exitNotFound, err: err}
+}
+
+// classifyBackendFailure picks the exit class for a failed team_manager.py command
+// from the command name and the script's output
+func classifyBackendFailure(command, output string, err error) error {
+	switch {
+	case strings.Contains(strings.ToLower(output), "not found"):
+		return notFoundFailure(err)
+	case validationCommands[command]:
+		return validationFailure(err)
+	default:
+		return backendFailure(err)
+	}
+}
+
+// exitCodeFor maps an error returned by a command to the process exit code.
+// Timeouts win over any other classification.
+func exitCodeFor(err error) int {
+	if err == nil {
+		return exitOK
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, os.ErrDeadlineExceeded) {
+		return exitTimeout
+	}
+	var timeoutErr interface{ Timeout() bool }
+	if errors.As(err, &timeoutErr) && timeoutErr.Timeout() {
+		return exitTimeout
+	}
+
+	var ce *cliError
+	if errors.As(err, &ce) {
+		return ce.code
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		return exitNotFound
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitBackend
+	}
+	return exitGeneric
+}
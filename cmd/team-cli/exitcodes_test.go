@@ -0,0 +1,67 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os/exec"
+	"testing"
+)
+
+// timeoutError is a net-style error reporting a timeout
+type timeoutError struct{}
+
+func (timeoutError) Error() string { return "i/o timeout" }
+func (timeoutError) Timeout() bool { return true }
+
+// TestExitCodeFor tests that each error class maps to its documented exit code
+func TestExitCodeFor(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", err: nil, want: exitOK},
+		{name: "usage error", err: fmt.Errorf("--project flag is required"), want: exitGeneric},
+		{name: "validation failure", err: validationFailure(errors.New("team size violations")), want: exitValidation},
+		{name: "backend failure", err: backendFailure(errors.New("Python not found")), want: exitBackend},
+		{name: "not found", err: notFoundFailure(errors.New("project not found")), want: exitNotFound},
+		{name: "wrapped classification", err: fmt.Errorf("assign: %w", notFoundFailure(errors.New("role not found"))), want: exitNotFound},
+		{name: "context deadline", err: fmt.Errorf("run: %w", context.DeadlineExceeded), want: exitTimeout},
+		{name: "network timeout", err: backendFailure(&url.Error{Op: "Get", URL: "http://x", Err: timeoutError{}}), want: exitTimeout},
+		{name: "missing file", err: fmt.Errorf("open backup: %w", fs.ErrNotExist), want: exitNotFound},
+		{name: "unclassified process exit", err: fmt.Errorf("run: %w", &exec.ExitError{}), want: exitBackend},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCodeFor(tt.err); got != tt.want {
+				t.Errorf("exitCodeFor(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestClassifyBackendFailure tests exit classes for failed team_manager.py commands
+func TestClassifyBackendFailure(t *testing.T) {
+	err := errors.New("team_manager.py failed")
+	tests := []struct {
+		command string
+		output  string
+		want    int
+	}{
+		{command: "validate-size", output: "❌ Team size violations found:", want: exitValidation},
+		{command: "phase-gate-check", output: "Phase 1 incomplete", want: exitValidation},
+		{command: "assign", output: "❌ Role 'Chef' not found in Team 2", want: exitNotFound},
+		{command: "status", output: "❌ Project 'demo' not found.", want: exitNotFound},
+		{command: "assign", output: "Traceback (most recent call last):", want: exitBackend},
+	}
+
+	for _, tt := range tests {
+		if got := exitCodeFor(classifyBackendFailure(tt.command, tt.output, err)); got != tt.want {
+			t.Errorf("classifyBackendFailure(%s, %q) exit = %d, want %d", tt.command, tt.output, got, tt.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ require (
 	github.com/charmbracelet/lipgloss v0.10.0
 	github.com/charmbracelet/log v0.4.0
 	github.com/spf13/cobra v1.8.0
+	github.com/thearchitectit/guardrail-mcp v0.0.0
 )
 
 require (
@@ -22,3 +23,6 @@ require (
 	golang.org/x/exp v0.0.0-20231006140011-7918f672742d // indirect
 	golang.org/x/sys v0.29.0 // indirect
 )
+
+// Shared validators live in the MCP server module
+replace github.com/thearchitectit/guardrail-mcp => ../../mcp-server
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// extractJSON returns the JSON object or array in team_manager.py output. The script
+// may print warnings or other text around the JSON, so when the whole output is not
+// JSON the first line starting a complete JSON value is used, along with the text
+// outside it. ok is false when the output holds no JSON value.
+func extractJSON(output []byte) (value []byte, extra string, ok bool) {
+	trimmed := bytes.TrimSpace(output)
+	if len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') && json.Valid(trimmed) {
+		return trimmed, "", true
+	}
+
+	for start := 0; start < len(output); {
+		end := bytes.IndexByte(output[start:], '\n')
+		if end < 0 {
+			end = len(output)
+		} else {
+			end += start + 1
+		}
+		offset := start + len(output[start:end]) - len(bytes.TrimLeft(output[start:end], " \t"))
+		if offset < end && (output[offset] == '{' || output[offset] == '[') {
+			var raw json.RawMessage
+			dec := json.NewDecoder(bytes.NewReader(output[offset:]))
+			if err := dec.Decode(&raw); err == nil {
+				before := bytes.TrimSpace(output[:offset])
+				after := bytes.TrimSpace(output[offset+int(dec.InputOffset()):])
+				return raw, strings.TrimSpace(string(before) + "\n" + string(after)), true
+			}
+		}
+		start = end
+	}
+	return nil, "", false
+}
+
+// jsonFormatCommands are the team_manager.py commands that print JSON when given
+// --format json
+var jsonFormatCommands = map[string]bool{
+	"query":            true,
+	"audit":            true,
+	"team-history":     true,
+	"project-timeline": true,
+}
+
+// commandOutput is the JSON written for a command whose output is not JSON
+type commandOutput struct {
+	Command string `json:"command"`
+	Output  string `json:"output"`
+}
+
+// writeJSONOutput pretty-prints the JSON in team_manager.py output to w, so that
+// --output json stays parseable when the script also prints warnings. The other text
+// is logged as a warning. Output with no JSON at all is wrapped in a JSON object
+// with the command name.
+func writeJSONOutput(w io.Writer, command string, output []byte) error {
+	value, extra, ok := extractJSON(output)
+	if !ok {
+		wrapped, err := json.Marshal(commandOutput{Command: command, Output: strings.TrimSpace(string(output))})
+		if err != nil {
+			return err
+		}
+		return prettyPrintJSON(w, wrapped)
+	}
+	if extra != "" {
+		log.Warn("Ignoring non-JSON output from team_manager.py", "output", extra)
+	}
+	return prettyPrintJSON(w, value)
+}
+
+// prettyPrintJSON writes a JSON value to w indented, keeping its key order
+func prettyPrintJSON(w io.Writer, data []byte) error {
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, data, "", "  "); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w, pretty.String())
+	return err
+}
@@ -1,13 +1,18 @@
 package main
 
 import (
-	"encoding/json"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
@@ -27,6 +32,9 @@ var (
 	roleName    string
 	person      string
 	output      string
+	timeout     time.Duration
+	serverURL   string
+	apiKey      string
 
 	// Styles
 	titleStyle   = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#7C3AED"))
@@ -38,6 +46,14 @@ var (
 )
 
 func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		log.Error(err)
+		os.Exit(exitCodeFor(err))
+	}
+}
+
+// newRootCmd creates the team command with its flags and subcommands
+func newRootCmd() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "team",
 		Short: "Team Manager CLI - Manage standardized team layouts",
@@ -47,11 +63,23 @@ A command-line tool for managing standardized team structures across projects.
 Integrates with the team_manager.py backend to provide team initialization,
 role assignments, and status tracking.`,
 		Version: fmt.Sprintf("%s (built: %s, commit: %s)", version, buildTime, gitCommit),
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := validateOutputFormat(output); err != nil {
+				return err
+			}
+			if serverURL == "" && usesTeamManager(cmd) {
+				return checkTeamManagerScript()
+			}
+			return nil
+		},
 	}
 
 	// Global flags
 	rootCmd.PersistentFlags().StringVarP(&projectName, "project", "p", "", "Project name (required for most commands)")
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "text", "Output format: text, json")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "text", "Output format: text, json (files are written with --out-file)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Second, "Time limit for each team_manager.py call, 0 for none")
+	rootCmd.PersistentFlags().StringVar(&serverURL, "server", os.Getenv("GUARDRAIL_MCP_URL"), "Guardrail MCP server URL; commands run on the server instead of team_manager.py (env GUARDRAIL_MCP_URL)")
+	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", os.Getenv("GUARDRAIL_API_KEY"), "API key sent to --server and to the web server by events (env GUARDRAIL_API_KEY)")
 
 	// Add subcommands
 	rootCmd.AddCommand(initCmd())
@@ -61,6 +89,7 @@ role assignments, and status tracking.`,
 	rootCmd.AddCommand(startCmd())
 	rootCmd.AddCommand(completeCmd())
 	rootCmd.AddCommand(statusCmd())
+	rootCmd.AddCommand(watchCmd())
 	rootCmd.AddCommand(validateCmd())
 	rootCmd.AddCommand(phaseGateCmd())
 	rootCmd.AddCommand(queryCmd())
@@ -73,22 +102,66 @@ role assignments, and status tracking.`,
 	rootCmd.AddCommand(auditCmd())
 	rootCmd.AddCommand(historyCmd())
 	rootCmd.AddCommand(templateCmd())
+	rootCmd.AddCommand(bulkAssignCmd())
 	rootCmd.AddCommand(healthCmd())
+	rootCmd.AddCommand(eventsCmd())
+	rootCmd.AddCommand(onboardCmd())
+	rootCmd.AddCommand(doctorCmd())
 
-	if err := rootCmd.Execute(); err != nil {
-		log.Error(err)
-		os.Exit(1)
+	return rootCmd
+}
+
+// validateOutputFormat rejects anything but text or json for -o, pointing users
+// who pass a file path at --out-file
+func validateOutputFormat(format string) error {
+	switch format {
+	case "text", "json":
+		return nil
 	}
+	if strings.ContainsAny(format, "./\\") {
+		return fmt.Errorf("unsupported output format %q: -o/--output takes text or json; use --out-file to write to a file", format)
+	}
+	return fmt.Errorf("unsupported output format %q (use text or json)", format)
+}
+
+// Sources of the resolved team_manager.py path
+const (
+	scriptFromEnv        = "TEAM_MANAGER_PATH"
+	scriptFromExecutable = "executable"
+	scriptFromDefault    = "default"
+)
+
+// teamManagerLocation is the resolved team_manager.py path and how it was found
+type teamManagerLocation struct {
+	Path   string   `json:"path"`
+	Source string   `json:"source"`
+	Tried  []string `json:"tried,omitempty"` // candidates next to the executable that did not exist
 }
 
+var (
+	// teamManagerPath resolves the script once per process instead of walking the
+	// candidate paths on every team_manager.py call
+	teamManagerPath = sync.OnceValue(locateTeamManager)
+
+	// pythonBinary resolves the Python interpreter once per process
+	pythonBinary = sync.OnceValues(locatePython)
+)
+
 // getTeamManagerPath returns the path to the team_manager.py script
 func getTeamManagerPath() string {
+	return teamManagerPath().Path
+}
+
+// locateTeamManager finds team_manager.py from TEAM_MANAGER_PATH, then relative to
+// the executable, then falls back to scripts/team_manager.py in the working directory
+func locateTeamManager() teamManagerLocation {
 	// Check if TEAM_MANAGER_PATH env var is set
 	if path := os.Getenv("TEAM_MANAGER_PATH"); path != "" {
-		return path
+		return teamManagerLocation{Path: path, Source: scriptFromEnv}
 	}
 
 	// Try to find relative to executable
+	var tried []string
 	exe, err := os.Executable()
 	if err == nil {
 		dir := filepath.Dir(exe)
@@ -101,27 +174,98 @@ func getTeamManagerPath() string {
 		}
 		for _, candidate := range candidates {
 			if _, err := os.Stat(candidate); err == nil {
-				return candidate
+				return teamManagerLocation{Path: candidate, Source: scriptFromExecutable, Tried: tried}
 			}
+			tried = append(tried, candidate)
 		}
 	}
 
 	// Default path
-	return "scripts/team_manager.py"
+	return teamManagerLocation{Path: "scripts/team_manager.py", Source: scriptFromDefault, Tried: tried}
 }
 
-// runTeamManager executes the team_manager.py script with the given arguments
-// The Python script expects: --project PROJECT COMMAND [args...]
+// locatePython returns the Python interpreter used to run team_manager.py
+func locatePython() (string, error) {
+	names := []string{"python3", "python"}
+	if runtime.GOOS == "windows" {
+		names = []string{"python", "python3"}
+	}
+	for _, name := range names {
+		if _, err := exec.LookPath(name); err == nil {
+			return name, nil
+		}
+	}
+	return "", backendFailure(fmt.Errorf("Python not found. Please install Python 3"))
+}
+
+// checkTeamManagerScript fails early, with a pointer to TEAM_MANAGER_PATH, when the
+// resolved script does not exist, rather than letting Python report that it cannot
+// open the file
+func checkTeamManagerScript() error {
+	loc := teamManagerPath()
+	info, err := os.Stat(loc.Path)
+	if err == nil && !info.IsDir() {
+		return nil
+	}
+	if loc.Source == scriptFromEnv {
+		return backendFailure(fmt.Errorf("TEAM_MANAGER_PATH is set to %s, which is not a file; point it at team_manager.py (run 'team doctor' for details)", loc.Path))
+	}
+	return backendFailure(fmt.Errorf("team_manager.py not found (looked next to the executable and at %s); set TEAM_MANAGER_PATH to the script's path (run 'team doctor' for details)", loc.Path))
+}
+
+// usesTeamManager reports whether cmd runs team_manager.py, so the script must be
+// checked before it starts. Commands that do not are annotated with noTeamManager.
+func usesTeamManager(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[noTeamManager] != "" {
+			return false
+		}
+		switch c.Name() {
+		case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+	return cmd.Runnable()
+}
+
+// runTeamManager executes the team_manager.py script with the given arguments,
+// or calls the matching tool on the MCP server when --server is set, and returns
+// its output. Use streamTeamManager when the output is only printed.
 func runTeamManager(project string, command string, args ...string) ([]byte, error) {
+	var stdout bytes.Buffer
+	if err := streamTeamManager(&stdout, project, command, args...); err != nil {
+		return nil, err
+	}
+	return stdout.Bytes(), nil
+}
+
+// teamManagerOutputTail is how much of the script's stdout is kept to report
+// when it fails; the script prints its error messages last
+const teamManagerOutputTail = 64 * 1024
+
+// streamTeamManager runs a team_manager.py command like runTeamManager but copies
+// its stdout to w as it is produced, so large results are neither held in memory
+// nor delayed until the script exits
+// The Python script expects: --project PROJECT COMMAND [args...]
+func streamTeamManager(w io.Writer, project string, command string, args ...string) error {
+	if serverURL != "" {
+		result, err := runRemote(project, command, args...)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(result)
+		return err
+	}
+
+	if err := checkTeamManagerScript(); err != nil {
+		return err
+	}
 	scriptPath := getTeamManagerPath()
 
 	// Check if Python is available
-	pythonCmd := "python3"
-	if _, err := exec.LookPath("python3"); err != nil {
-		pythonCmd = "python"
-		if _, err := exec.LookPath("python"); err != nil {
-			return nil, fmt.Errorf("Python not found. Please install Python 3")
-		}
+	pythonCmd, err := pythonBinary()
+	if err != nil {
+		return err
 	}
 
 	// Build command: script --project PROJECT command [args...]
@@ -134,18 +278,98 @@ func runTeamManager(project string, command string, args ...string) ([]byte, err
 	cmdArgs = append(cmdArgs, command)
 	cmdArgs = append(cmdArgs, args...)
 
-	cmd := exec.Command(pythonCmd, cmdArgs...)
-	cmd.Stderr = os.Stderr
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	// stderr is streamed to the terminal and kept so a timeout can report it
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, pythonCmd, cmdArgs...)
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	cmd.WaitDelay = 2 * time.Second
 
-	output, err := cmd.Output()
+	stdoutPipe, err := cmd.StdoutPipe()
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return nil, fmt.Errorf("team_manager.py failed: %s", string(exitErr.Stderr))
+		return backendFailure(fmt.Errorf("failed to run team_manager.py: %w", err))
+	}
+	if err := cmd.Start(); err != nil {
+		return backendFailure(fmt.Errorf("failed to run team_manager.py: %w", err))
+	}
+	tail := &tailBuffer{max: teamManagerOutputTail}
+	_, copyErr := io.Copy(io.MultiWriter(w, tail), stdoutPipe)
+	if copyErr != nil {
+		// Keep draining so the script is not blocked on a full pipe
+		io.Copy(tail, stdoutPipe)
+	}
+	err = cmd.Wait()
+
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			msg := fmt.Sprintf("team_manager.py %s timed out after %s", command, timeout)
+			if detail := strings.TrimSpace(stderr.String()); detail != "" {
+				msg += ": " + lastLines(detail, 5)
+			}
+			return fmt.Errorf("%s (raise --timeout if the command is expected to be slow): %w", msg, context.DeadlineExceeded)
 		}
-		return nil, fmt.Errorf("failed to run team_manager.py: %w", err)
+		if _, ok := err.(*exec.ExitError); ok {
+			// stderr was streamed to the terminal, so the script's own message is on stdout
+			detail := strings.TrimSpace(tail.String())
+			if detail == "" {
+				detail = strings.TrimSpace(stderr.String())
+			}
+			return classifyBackendFailure(command, detail, fmt.Errorf("team_manager.py failed: %s", detail))
+		}
+		return backendFailure(fmt.Errorf("failed to run team_manager.py: %w", err))
+	}
+	if copyErr != nil {
+		return fmt.Errorf("failed to write team_manager.py output: %w", copyErr)
+	}
+
+	return nil
+}
+
+// tailBuffer keeps the last max bytes written to it
+type tailBuffer struct {
+	max int
+	buf []byte
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	if over := len(t.buf) - t.max; over > 0 {
+		t.buf = append(t.buf[:0], t.buf[over:]...)
 	}
+	return len(p), nil
+}
+
+func (t *tailBuffer) String() string {
+	return string(t.buf)
+}
 
-	return output, nil
+// lastLines returns the last n lines of text
+func lastLines(text string, n int) string {
+	lines := strings.Split(text, "\n")
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runTeamManagerJSON runs a team_manager.py command for --output json and
+// pretty-prints its JSON result to stdout. --format json is passed to the commands
+// that support it; the text output of the others is wrapped in a JSON object.
+func runTeamManagerJSON(project string, command string, args ...string) error {
+	if jsonFormatCommands[command] {
+		args = append(args, "--format", "json")
+	}
+	result, err := runTeamManager(project, command, args...)
+	if err != nil {
+		return err
+	}
+	return writeJSONOutput(os.Stdout, command, result)
 }
 
 // initCmd creates the init command
@@ -159,12 +383,7 @@ func initCmd() *cobra.Command {
 			project := args[0]
 
 			if output == "json" {
-				result, err := runTeamManager(project, "init", "--format", "json")
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(project, "init")
 			}
 
 			fmt.Println(titleStyle.Render("Initializing Team Structure"))
@@ -172,7 +391,7 @@ func initCmd() *cobra.Command {
 
 			result, err := runTeamManager(project, "init")
 			if err != nil {
-				return fmt.Errorf("%s %v", errorStyle.Render("Failed to initialize project:"), err)
+				return fmt.Errorf("%s %w", errorStyle.Render("Failed to initialize project:"), err)
 			}
 
 			fmt.Println(string(result))
@@ -196,30 +415,25 @@ func listCmd() *cobra.Command {
 				return fmt.Errorf("--project flag is required")
 			}
 
+			if err := validatePhaseFlag(phase); err != nil {
+				return err
+			}
+
 			listExtraArgs := []string{}
 			if phase != "" {
 				listExtraArgs = append(listExtraArgs, "--phase", phase)
 			}
 
 			if output == "json" {
-				listExtraArgs = append(listExtraArgs, "--format", "json")
-				result, err := runTeamManager(projectName, "list", listExtraArgs...)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "list", listExtraArgs...)
 			}
 
 			fmt.Println(titleStyle.Render("Team List"))
 			fmt.Printf("Project: %s\n\n", textStyle.Render(projectName))
 
-			result, err := runTeamManager(projectName, "list", listExtraArgs...)
-			if err != nil {
+			if err := streamTeamManager(os.Stdout, projectName, "list", listExtraArgs...); err != nil {
 				return err
 			}
-
-			fmt.Println(string(result))
 			return nil
 		},
 	}
@@ -230,16 +444,21 @@ func listCmd() *cobra.Command {
 
 // assignCmd creates the assign command
 func assignCmd() *cobra.Command {
+	var replace bool
+
 	cmd := &cobra.Command{
 		Use:   "assign",
 		Short: "Assign a person to a role",
-		Long:  `Assign a person to a specific role within a team.`,
+		Long: `Assign a person to a specific role within a team.
+
+With --replace, a role that is already filled is first unassigned from its
+current holder, and both changes are recorded in the team history.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
-			if teamID == 0 {
-				return fmt.Errorf("--team flag is required")
+			if err := validateTeamFlag("--team", teamID); err != nil {
+				return err
 			}
 			if roleName == "" {
 				return fmt.Errorf("--role flag is required")
@@ -253,15 +472,12 @@ func assignCmd() *cobra.Command {
 				"--role", roleName,
 				"--person", person,
 			}
+			if replace {
+				assignArgs = append(assignArgs, "--replace")
+			}
 
 			if output == "json" {
-				assignArgs = append(assignArgs, "--format", "json")
-				result, err := runTeamManager(projectName, "assign", assignArgs...)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "assign", assignArgs...)
 			}
 
 			fmt.Println(titleStyle.Render("Assigning Role"))
@@ -283,6 +499,7 @@ func assignCmd() *cobra.Command {
 	cmd.Flags().IntVarP(&teamID, "team", "t", 0, "Team ID (1-12)")
 	cmd.Flags().StringVarP(&roleName, "role", "r", "", "Role name to assign")
 	cmd.Flags().StringVar(&person, "person", "", "Person to assign")
+	cmd.Flags().BoolVar(&replace, "replace", false, "Unassign the current holder of the role first")
 
 	cmd.MarkFlagRequired("team")
 	cmd.MarkFlagRequired("role")
@@ -301,8 +518,8 @@ func unassignCmd() *cobra.Command {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
-			if teamID == 0 {
-				return fmt.Errorf("--team flag is required")
+			if err := validateTeamFlag("--team", teamID); err != nil {
+				return err
 			}
 			if roleName == "" {
 				return fmt.Errorf("--role flag is required")
@@ -314,13 +531,7 @@ func unassignCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				unassignArgs = append(unassignArgs, "--format", "json")
-				result, err := runTeamManager(projectName, "unassign", unassignArgs...)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "unassign", unassignArgs...)
 			}
 
 			fmt.Println(titleStyle.Render("Unassigning Role"))
@@ -349,28 +560,30 @@ func unassignCmd() *cobra.Command {
 
 // startCmd creates the start command
 func startCmd() *cobra.Command {
+	var bulk bulkTransitionFlags
+
 	cmd := &cobra.Command{
 		Use:   "start",
 		Short: "Start a team",
-		Long:  `Mark a team as started/in-progress.`,
+		Long: `Mark a team as started/in-progress.
+
+--teams 1,3,5 or --all-in-phase "Phase 2" starts several teams, one at a time.
+A team that fails is reported and the others still run, unless --strict is given.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
-			if teamID == 0 {
-				return fmt.Errorf("--team flag is required")
+			if bulk.bulk() {
+				return bulk.run(projectName, "start", "Starting Teams")
+			}
+			if err := validateTeamFlag("--team", teamID); err != nil {
+				return err
 			}
 
 			startArgs := []string{"--team", fmt.Sprintf("%d", teamID)}
 
 			if output == "json" {
-				startArgs = append(startArgs, "--format", "json")
-				result, err := runTeamManager(projectName, "start", startArgs...)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "start", startArgs...)
 			}
 
 			fmt.Println(titleStyle.Render("Starting Team"))
@@ -388,35 +601,40 @@ func startCmd() *cobra.Command {
 	}
 
 	cmd.Flags().IntVarP(&teamID, "team", "t", 0, "Team ID (1-12)")
-	cmd.MarkFlagRequired("team")
+	bulk.register(cmd)
 
 	return cmd
 }
 
 // completeCmd creates the complete command
 func completeCmd() *cobra.Command {
+	var bulk bulkTransitionFlags
+
 	cmd := &cobra.Command{
 		Use:   "complete",
 		Short: "Complete a team",
-		Long:  `Mark a team as completed.`,
+		Long: `Mark a team as completed.
+
+--teams 1,3,5 or --all-in-phase "Phase 2" completes several teams, one at a time.
+A team that fails is reported and the others still run, unless --strict is given.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
-			if teamID == 0 {
-				return fmt.Errorf("--team flag is required")
+			if err := checkRemoteCommand("complete"); err != nil {
+				return err
+			}
+			if bulk.bulk() {
+				return bulk.run(projectName, "complete", "Completing Teams")
+			}
+			if err := validateTeamFlag("--team", teamID); err != nil {
+				return err
 			}
 
 			completeArgs := []string{"--team", fmt.Sprintf("%d", teamID)}
 
 			if output == "json" {
-				completeArgs = append(completeArgs, "--format", "json")
-				result, err := runTeamManager(projectName, "complete", completeArgs...)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "complete", completeArgs...)
 			}
 
 			fmt.Println(titleStyle.Render("Completing Team"))
@@ -434,7 +652,7 @@ func completeCmd() *cobra.Command {
 	}
 
 	cmd.Flags().IntVarP(&teamID, "team", "t", 0, "Team ID (1-12)")
-	cmd.MarkFlagRequired("team")
+	bulk.register(cmd)
 
 	return cmd
 }
@@ -450,30 +668,25 @@ func statusCmd() *cobra.Command {
 				return fmt.Errorf("--project flag is required")
 			}
 
+			if err := validatePhaseFlag(phase); err != nil {
+				return err
+			}
+
 			statusArgs := []string{}
 			if phase != "" {
 				statusArgs = append(statusArgs, "--phase", phase)
 			}
 
 			if output == "json" {
-				statusArgs = append(statusArgs, "--format", "json")
-				result, err := runTeamManager(projectName, "status", statusArgs...)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "status", statusArgs...)
 			}
 
 			fmt.Println(titleStyle.Render("Project Status"))
 			fmt.Printf("Project: %s\n\n", textStyle.Render(projectName))
 
-			result, err := runTeamManager(projectName, "status", statusArgs...)
-			if err != nil {
+			if err := streamTeamManager(os.Stdout, projectName, "status", statusArgs...); err != nil {
 				return err
 			}
-
-			fmt.Println(string(result))
 			return nil
 		},
 	}
@@ -494,12 +707,7 @@ func validateCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				result, err := runTeamManager(projectName, "validate-size", "--format", "json")
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "validate-size")
 			}
 
 			fmt.Println(titleStyle.Render("Team Size Validation"))
@@ -538,13 +746,7 @@ func phaseGateCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				phaseGateArgs = append(phaseGateArgs, "--format", "json")
-				result, err := runTeamManager(projectName, "phase-gate-check", phaseGateArgs...)
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "phase-gate-check", phaseGateArgs...)
 			}
 
 			fmt.Println(titleStyle.Render("Phase Gate Check"))
@@ -595,15 +797,12 @@ func queryCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				queryArgs = append(queryArgs, "--format", "json")
+				return runTeamManagerJSON(projectName, "query", queryArgs...)
 			}
 
-			result, err := runTeamManager(projectName, "query", queryArgs...)
-			if err != nil {
+			if err := streamTeamManager(os.Stdout, projectName, "query", queryArgs...); err != nil {
 				return err
 			}
-
-			fmt.Println(string(result))
 			return nil
 		},
 	}
@@ -629,6 +828,13 @@ func reassignCmd() *cobra.Command {
 				return fmt.Errorf("--project flag is required")
 			}
 
+			if err := validateTeamFlag("--from-team", fromTeam); err != nil {
+				return err
+			}
+			if err := validateTeamFlag("--to-team", toTeam); err != nil {
+				return err
+			}
+
 			reassignArgs := []string{
 				"--from-team", fmt.Sprintf("%d", fromTeam),
 				"--from-role", fromRole,
@@ -638,7 +844,7 @@ func reassignCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				reassignArgs = append(reassignArgs, "--format", "json")
+				return runTeamManagerJSON(projectName, "reassign", reassignArgs...)
 			}
 
 			result, err := runTeamManager(projectName, "reassign", reassignArgs...)
@@ -685,15 +891,12 @@ func auditCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				auditArgs = append(auditArgs, "--format", "json")
+				return runTeamManagerJSON(projectName, "audit", auditArgs...)
 			}
 
-			result, err := runTeamManager(projectName, "audit", auditArgs...)
-			if err != nil {
+			if err := streamTeamManager(os.Stdout, projectName, "audit", auditArgs...); err != nil {
 				return err
 			}
-
-			fmt.Println(string(result))
 			return nil
 		},
 	}
@@ -715,8 +918,8 @@ func historyCmd() *cobra.Command {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
-			if teamID == 0 {
-				return fmt.Errorf("--team flag is required")
+			if err := validateTeamFlag("--team", teamID); err != nil {
+				return err
 			}
 
 			historyArgs := []string{
@@ -730,15 +933,12 @@ func historyCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				historyArgs = append(historyArgs, "--format", "json")
+				return runTeamManagerJSON(projectName, "team-history", historyArgs...)
 			}
 
-			result, err := runTeamManager(projectName, "team-history", historyArgs...)
-			if err != nil {
+			if err := streamTeamManager(os.Stdout, projectName, "team-history", historyArgs...); err != nil {
 				return err
 			}
-
-			fmt.Println(string(result))
 			return nil
 		},
 	}
@@ -754,31 +954,38 @@ func historyCmd() *cobra.Command {
 
 // templateCmd creates the template command
 func templateCmd() *cobra.Command {
-	var format, outputFile string
+	var format, outFile string
 
 	cmd := &cobra.Command{
 		Use:   "template",
 		Short: "Create template for bulk assignments",
-		Long:  `Create a CSV or JSON template for bulk role assignments.`,
+		Long: `Create a CSV or JSON template for bulk role assignments, written to
+--out-file or to assignments_template.<format> in the current directory.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
 
 			var cmdName string
-			var fileFlag string
 			switch format {
 			case "csv":
 				cmdName = "template-csv"
-				fileFlag = "--file"
 			case "json":
 				cmdName = "template-json"
-				fileFlag = "--file"
 			default:
 				return fmt.Errorf("unsupported format: %s (use csv or json)", format)
 			}
 
-			result, err := runTeamManager(projectName, cmdName, fileFlag, outputFile)
+			var cmdArgs []string
+			if outFile != "" {
+				cmdArgs = append(cmdArgs, "--file", outFile)
+			}
+
+			if output == "json" {
+				return runTeamManagerJSON(projectName, cmdName, cmdArgs...)
+			}
+
+			result, err := runTeamManager(projectName, cmdName, cmdArgs...)
 			if err != nil {
 				return err
 			}
@@ -789,7 +996,7 @@ func templateCmd() *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&format, "format", "f", "csv", "Template format (csv or json)")
-	cmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file path")
+	cmd.Flags().StringVar(&outFile, "out-file", "", "File to write the template to (default: assignments_template.<format>)")
 
 	return cmd
 }
@@ -802,6 +1009,10 @@ func healthCmd() *cobra.Command {
 		Long:  `Check the health status of the team manager.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Health check doesn't require a project
+			if output == "json" {
+				return runTeamManagerJSON("", "health")
+			}
+
 			result, err := runTeamManager("", "health")
 			if err != nil {
 				return err
@@ -815,43 +1026,81 @@ func healthCmd() *cobra.Command {
 
 // exportCmd creates the export command
 func exportCmd() *cobra.Command {
-	var format string
+	var format, outFile string
 
 	cmd := &cobra.Command{
 		Use:   "export",
 		Short: "Export project data",
-		Long:  `Export team assignments and project data to a file.`,
+		Long: `Export team assignments and project data to a file, or to stdout when
+no --out-file is given.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
 
-			var result []byte
-			var err error
-
+			var command string
 			switch format {
 			case "json":
-				result, err = runTeamManager(projectName, "export-json")
+				command = "export-json"
 			case "csv":
-				result, err = runTeamManager(projectName, "export-csv")
+				command = "export-csv"
 			default:
 				return fmt.Errorf("unsupported export format: %s", format)
 			}
 
-			if err != nil {
-				return err
+			if outFile != "" {
+				if output == "json" {
+					return runTeamManagerJSON(projectName, command, "--file", outFile)
+				}
+				result, err := runTeamManager(projectName, command, "--file", outFile)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(result))
+				fmt.Println(successStyle.Render(fmt.Sprintf("✓ Exported %s to %s", projectName, outFile)))
+				return nil
 			}
 
-			// Write to stdout or file
-			fmt.Println(string(result))
-			return nil
+			if output == "json" {
+				var payload bytes.Buffer
+				if err := exportTo(&payload, projectName, command, format); err != nil {
+					return err
+				}
+				return writeJSONOutput(os.Stdout, command, payload.Bytes())
+			}
+			return exportTo(os.Stdout, projectName, command, format)
 		},
 	}
 
 	cmd.Flags().StringVarP(&format, "format", "f", "json", "Export format (json, csv)")
+	cmd.Flags().StringVar(&outFile, "out-file", "", "File to write the export to (default: stdout)")
 	return cmd
 }
 
+// exportTo runs an export command into a temporary file and copies it to w,
+// since team_manager.py only exports to files
+func exportTo(w io.Writer, project, command, format string) error {
+	dir, err := os.MkdirTemp("", "team-export-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary export directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, project+"."+format)
+	if _, err := runTeamManager(project, command, "--file", path); err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return backendFailure(fmt.Errorf("failed to read export: %w", err))
+	}
+	defer f.Close()
+	if _, err := io.Copy(w, f); err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+	return nil
+}
+
 // importCmd creates the import command
 func importCmd() *cobra.Command {
 	var filePath string
@@ -869,18 +1118,21 @@ func importCmd() *cobra.Command {
 				return fmt.Errorf("--file flag is required")
 			}
 
-			var result []byte
-			var err error
-
+			var command string
 			switch format {
 			case "json":
-				result, err = runTeamManager(projectName, "import-json", "--file", filePath)
+				command = "import-json"
 			case "csv":
-				result, err = runTeamManager(projectName, "import-csv", "--file", filePath)
+				command = "import-csv"
 			default:
 				return fmt.Errorf("unsupported import format: %s", format)
 			}
 
+			if output == "json" {
+				return runTeamManagerJSON(projectName, command, "--file", filePath)
+			}
+
+			result, err := runTeamManager(projectName, command, "--file", filePath)
 			if err != nil {
 				return err
 			}
@@ -910,23 +1162,15 @@ func backupCmd() *cobra.Command {
 			}
 
 			if output == "json" {
-				result, err := runTeamManager(projectName, "list-backups", "--format", "json")
-				if err != nil {
-					return err
-				}
-				fmt.Println(string(result))
-				return nil
+				return runTeamManagerJSON(projectName, "list-backups")
 			}
 
 			fmt.Println(titleStyle.Render("Available Backups"))
 			fmt.Printf("Project: %s\n\n", textStyle.Render(projectName))
 
-			result, err := runTeamManager(projectName, "list-backups")
-			if err != nil {
+			if err := streamTeamManager(os.Stdout, projectName, "list-backups"); err != nil {
 				return err
 			}
-
-			fmt.Println(string(result))
 			return nil
 		},
 	}
@@ -948,6 +1192,10 @@ func restoreCmd() *cobra.Command {
 				return fmt.Errorf("--backup flag is required")
 			}
 
+			if output == "json" {
+				return runTeamManagerJSON(projectName, "restore", "--backup", backupFile)
+			}
+
 			fmt.Println(titleStyle.Render("Restoring from Backup"))
 			fmt.Printf("Project: %s\n", textStyle.Render(projectName))
 			fmt.Printf("Backup: %s\n\n", textStyle.Render(backupFile))
@@ -980,6 +1228,23 @@ func deleteCmd() *cobra.Command {
 			if projectName == "" {
 				return fmt.Errorf("--project flag is required")
 			}
+			// Without --team the whole project is deleted
+			if cmd.Flags().Changed("team") {
+				if err := validateTeamFlag("--team", teamID); err != nil {
+					return err
+				}
+			}
+
+			if output == "json" {
+				// There is no one to answer a prompt when the output is being parsed
+				if !force {
+					return fmt.Errorf("--force is required with --output json")
+				}
+				if teamID > 0 {
+					return runTeamManagerJSON(projectName, "delete-team", "--team", fmt.Sprintf("%d", teamID))
+				}
+				return runTeamManagerJSON(projectName, "delete-project")
+			}
 
 			var result []byte
 			var err error
@@ -1034,32 +1299,8 @@ func deleteCmd() *cobra.Command {
 	return cmd
 }
 
-// Helper function to pretty print JSON
-func prettyPrintJSON(data []byte) error {
-	var v interface{}
-	if err := json.Unmarshal(data, &v); err != nil {
-		return err
-	}
-	pretty, err := json.MarshalIndent(v, "", "  ")
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(pretty))
-	return nil
-}
-
 // CheckPython returns an error if Python is not available
 func CheckPython() error {
-	pythonCmd := "python3"
-	if runtime.GOOS == "windows" {
-		pythonCmd = "python"
-	}
-	_, err := exec.LookPath(pythonCmd)
-	if err != nil {
-		_, err = exec.LookPath("python")
-		if err != nil {
-			return fmt.Errorf("Python is required but not found. Please install Python 3")
-		}
-	}
-	return nil
+	_, err := pythonBinary()
+	return err
 }
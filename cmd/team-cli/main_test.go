@@ -0,0 +1,185 @@
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestExportCmd tests that export writes to --out-file when given and to stdout otherwise
+func TestExportCmd(t *testing.T) {
+	useFakeTeamManager(t)
+	output = "text"
+
+	run := func(args ...string) string {
+		t.Helper()
+		return captureStdout(t, func() {
+			cmd := newRootCmd()
+			cmd.SetArgs(append([]string{"export", "-p", "demo"}, args...))
+			if err := cmd.Execute(); err != nil {
+				t.Fatalf("team export error = %v", err)
+			}
+		})
+	}
+
+	if stdout := run(); !strings.Contains(stdout, `"teams": []`) {
+		t.Errorf("stdout = %q, want the export payload", stdout)
+	}
+
+	path := filepath.Join(t.TempDir(), "demo.json")
+	stdout := run("--out-file", path)
+	if strings.Contains(stdout, `"teams"`) || !strings.Contains(stdout, "Exported demo to "+path) {
+		t.Errorf("stdout = %q, want only a summary", stdout)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), `"teams": []`) {
+		t.Errorf("export file = %q (err %v), want the export payload", data, err)
+	}
+}
+
+// TestTemplateCmd tests that the template path is --out-file and -o stays the output format
+func TestTemplateCmd(t *testing.T) {
+	useFakeTeamManager(t)
+	output = "text"
+
+	path := filepath.Join(t.TempDir(), "roster.csv")
+	stdout := captureStdout(t, func() {
+		cmd := newRootCmd()
+		cmd.SetArgs([]string{"template", "-p", "demo", "--out-file", path})
+		if err := cmd.Execute(); err != nil {
+			t.Fatalf("team template error = %v", err)
+		}
+	})
+	if !strings.Contains(stdout, "template-csv --file "+path) {
+		t.Errorf("stdout = %q, want template-csv called with --file %s", stdout, path)
+	}
+
+	cmd := newRootCmd()
+	cmd.SetArgs([]string{"template", "-p", "demo", "-o", "roster.csv"})
+	cmd.SilenceErrors, cmd.SilenceUsage = true, true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--out-file") {
+		t.Errorf("team template -o roster.csv error = %v, want a pointer to --out-file", err)
+	}
+	output = "text"
+}
+
+// TestValidateOutputFormat tests the accepted -o values
+func TestValidateOutputFormat(t *testing.T) {
+	tests := []struct {
+		format  string
+		wantErr string
+	}{
+		{format: "text"},
+		{format: "json"},
+		{format: "yaml", wantErr: "use text or json"},
+		{format: "out/team.json", wantErr: "use --out-file"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			err := validateOutputFormat(tt.format)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateOutputFormat(%q) error = %v", tt.format, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateOutputFormat(%q) error = %v, want %q", tt.format, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestRunTeamManager_Timeout tests that a hung script is killed and reported as a timeout with its stderr
+func TestRunTeamManager_Timeout(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not found")
+	}
+	script := filepath.Join(t.TempDir(), "team_manager.py")
+	hang := "import sys, time\nprint('waiting for lock', file=sys.stderr, flush=True)\ntime.sleep(30)\n"
+	if err := os.WriteFile(script, []byte(hang), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEAM_MANAGER_PATH", script)
+	resetTeamManagerPath(t)
+
+	previous := timeout
+	timeout = 300 * time.Millisecond
+	defer func() { timeout = previous }()
+
+	start := time.Now()
+	_, err := runTeamManager("demo", "status")
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("runTeamManager() took %s, want it killed after the timeout", elapsed)
+	}
+	if err == nil {
+		t.Fatal("runTeamManager() expected a timeout error")
+	}
+	if exitCodeFor(err) != exitTimeout {
+		t.Errorf("exit code = %d, want %d", exitCodeFor(err), exitTimeout)
+	}
+	if !strings.Contains(err.Error(), "timed out after 300ms") || !strings.Contains(err.Error(), "waiting for lock") {
+		t.Errorf("error = %q, want the timeout and the script's stderr", err)
+	}
+}
+
+// firstWriteRecorder records when output first arrives
+type firstWriteRecorder struct {
+	strings.Builder
+	first time.Time
+}
+
+func (r *firstWriteRecorder) Write(p []byte) (int, error) {
+	if r.first.IsZero() {
+		r.first = time.Now()
+	}
+	return r.Builder.Write(p)
+}
+
+// TestStreamTeamManager tests that output is passed on while the script runs and
+// that a failing script's last stdout lines are still reported
+func TestStreamTeamManager(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not found")
+	}
+	script := filepath.Join(t.TempDir(), "team_manager.py")
+	body := `import sys, time
+if sys.argv[3] == "list":
+    print("Team 1", flush=True)
+    time.sleep(0.5)
+    print("Team 2")
+else:
+    print("x" * 100000)
+    print("Error: Team 99 not found")
+    sys.exit(1)
+`
+	if err := os.WriteFile(script, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("TEAM_MANAGER_PATH", script)
+	resetTeamManagerPath(t)
+
+	var out firstWriteRecorder
+	if err := streamTeamManager(&out, "demo", "list"); err != nil {
+		t.Fatalf("streamTeamManager(list) error = %v", err)
+	}
+	if out.String() != "Team 1\nTeam 2\n" {
+		t.Errorf("output = %q, want both teams", out.String())
+	}
+	if wait := time.Since(out.first); wait < 300*time.Millisecond {
+		t.Errorf("first output arrived %s before the script exited, want it streamed", wait)
+	}
+
+	err := streamTeamManager(io.Discard, "demo", "status")
+	if err == nil || !strings.Contains(err.Error(), "Team 99 not found") || exitCodeFor(err) != exitNotFound {
+		t.Errorf("streamTeamManager(status) error = %v, want the script's last message classified as not found", err)
+	}
+	if len(err.Error()) > teamManagerOutputTail+100 {
+		t.Errorf("error is %d bytes, want it limited to the output tail", len(err.Error()))
+	}
+}
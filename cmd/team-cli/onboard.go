@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// teamManagerFunc runs a team_manager.py command; runTeamManager in production
+type teamManagerFunc func(project string, command string, args ...string) ([]byte, error)
+
+// Onboarding step statuses
+const (
+	stepPassed  = "passed"
+	stepFailed  = "failed"
+	stepSkipped = "skipped"
+)
+
+// onboardStep is the outcome of one step of an onboarding run
+type onboardStep struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Output string `json:"output,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// onboardReport is the consolidated result of an onboarding run
+type onboardReport struct {
+	Project string        `json:"project"`
+	Roster  string        `json:"roster"`
+	Passed  bool          `json:"passed"`
+	Steps   []onboardStep `json:"steps"`
+}
+
+// rosterImportCommand returns the team_manager.py bulk import command for a roster
+// file, chosen by its extension
+func rosterImportCommand(roster string) (string, error) {
+	switch strings.ToLower(filepath.Ext(roster)) {
+	case ".json":
+		return "import-json", nil
+	case ".csv":
+		return "import-csv", nil
+	default:
+		return "", fmt.Errorf("unsupported roster format %q (use .json or .csv, see 'team template')", filepath.Ext(roster))
+	}
+}
+
+// runOnboarding initializes project, bulk-assigns the roster and validates team
+// sizes. The roster is checked before anything is created. A failed step stops the
+// run, leaving the remaining steps skipped, unless continueOnError is set; the
+// returned error is that of the first failed step.
+func runOnboarding(project, roster string, continueOnError bool, run teamManagerFunc) (*onboardReport, error) {
+	importCommand, err := rosterImportCommand(roster)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(roster); err != nil {
+		return nil, fmt.Errorf("roster %s: %w", roster, err)
+	}
+
+	steps := []struct {
+		name    string
+		command string
+		args    []string
+	}{
+		{name: "init", command: "init"},
+		{name: "assign", command: importCommand, args: []string{"--file", roster}},
+		{name: "validate", command: "validate-size"},
+	}
+
+	report := &onboardReport{Project: project, Roster: roster, Passed: true}
+	var firstErr error
+	for _, step := range steps {
+		if firstErr != nil && !continueOnError {
+			report.Steps = append(report.Steps, onboardStep{Name: step.name, Status: stepSkipped})
+			continue
+		}
+
+		result, err := run(project, step.command, step.args...)
+		if err != nil {
+			report.Passed = false
+			report.Steps = append(report.Steps, onboardStep{Name: step.name, Status: stepFailed, Output: strings.TrimSpace(string(result)), Error: err.Error()})
+			if firstErr == nil {
+				firstErr = fmt.Errorf("onboarding step %s failed: %w", step.name, err)
+			}
+			continue
+		}
+		report.Steps = append(report.Steps, onboardStep{Name: step.name, Status: stepPassed, Output: strings.TrimSpace(string(result))})
+	}
+
+	return report, firstErr
+}
+
+// printOnboardReport prints each step of an onboarding run with its output
+func printOnboardReport(report *onboardReport) {
+	for _, step := range report.Steps {
+		switch step.Status {
+		case stepPassed:
+			fmt.Println(successStyle.Render("✓ " + step.Name))
+		case stepFailed:
+			fmt.Println(errorStyle.Render("✗ " + step.Name))
+		default:
+			fmt.Println(textStyle.Render("- " + step.Name + " (skipped)"))
+		}
+		for _, text := range []string{step.Output, step.Error} {
+			for _, line := range strings.Split(text, "\n") {
+				if line != "" {
+					fmt.Println("    " + line)
+				}
+			}
+		}
+	}
+	fmt.Println()
+	if report.Passed {
+		fmt.Println(successStyle.Render("✓ Project onboarded successfully"))
+	} else {
+		fmt.Println(errorStyle.Render("✗ Onboarding incomplete"))
+	}
+}
+
+// onboardCmd creates the onboard command
+func onboardCmd() *cobra.Command {
+	var roster string
+	var continueOnError bool
+
+	cmd := &cobra.Command{
+		Use:   "onboard [project-name]",
+		Short: "Initialize a project, assign its roster and validate team sizes",
+		Long: `Onboard a new project in one step: run init, bulk-assign the roles listed
+in a roster file (the JSON or CSV format produced by 'team template'), then run
+team size validation and print a consolidated report.
+
+Onboarding stops at the first failed step unless --continue is given.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			project := args[0]
+
+			report, err := runOnboarding(project, roster, continueOnError, runTeamManager)
+			if report == nil {
+				return err
+			}
+
+			if output == "json" {
+				data, _ := json.MarshalIndent(report, "", "  ")
+				fmt.Println(string(data))
+				return err
+			}
+
+			fmt.Println(titleStyle.Render("Onboarding Project"))
+			fmt.Printf("Project: %s\n", textStyle.Render(project))
+			fmt.Printf("Roster: %s\n\n", textStyle.Render(roster))
+			printOnboardReport(report)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&roster, "roster", "", "Roster file of role assignments (.json or .csv)")
+	cmd.Flags().BoolVar(&continueOnError, "continue", false, "Run the remaining steps after a failed step")
+
+	cmd.MarkFlagRequired("roster")
+
+	return cmd
+}
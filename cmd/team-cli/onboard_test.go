@@ -0,0 +1,116 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeTeamManager records team_manager.py commands and fails those listed in failures
+type fakeTeamManager struct {
+	calls    []string
+	failures map[string]error
+}
+
+func (f *fakeTeamManager) run(project string, command string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, strings.TrimSpace(command+" "+strings.Join(args, " ")))
+	if err := f.failures[command]; err != nil {
+		return []byte("❌ " + err.Error()), classifyBackendFailure(command, err.Error(), fmt.Errorf("team_manager.py failed: %w", err))
+	}
+	return []byte("✅ " + command + " ok"), nil
+}
+
+// writeRoster writes a roster file into a temporary directory
+func writeRoster(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestRunOnboarding_ValidRoster tests that a valid roster runs init, the bulk import and a passing validation
+func TestRunOnboarding_ValidRoster(t *testing.T) {
+	roster := writeRoster(t, "roster.json", `{"assignments": [{"team_id": 7, "role_name": "Technical Lead", "assignee": "Jane Smith"}]}`)
+	tm := &fakeTeamManager{}
+
+	report, err := runOnboarding("web-platform", roster, false, tm.run)
+	if err != nil {
+		t.Fatalf("runOnboarding() error = %v", err)
+	}
+	want := []string{"init", "import-json --file " + roster, "validate-size"}
+	if strings.Join(tm.calls, "|") != strings.Join(want, "|") {
+		t.Errorf("calls = %v, want %v", tm.calls, want)
+	}
+	if !report.Passed {
+		t.Errorf("report.Passed = false, want true: %+v", report.Steps)
+	}
+	if last := report.Steps[len(report.Steps)-1]; last.Name != "validate" || last.Status != stepPassed {
+		t.Errorf("last step = %+v, want passing validate", last)
+	}
+}
+
+// TestRunOnboarding_InvalidRoster tests that a rejected roster stops onboarding before validation
+func TestRunOnboarding_InvalidRoster(t *testing.T) {
+	roster := writeRoster(t, "roster.csv", "team_id,role_name,assignee\n99,Chef,Jane Smith\n")
+	tm := &fakeTeamManager{failures: map[string]error{"import-csv": errors.New("Row 2: Invalid team ID 99")}}
+
+	report, err := runOnboarding("web-platform", roster, false, tm.run)
+	if err == nil {
+		t.Fatal("runOnboarding() expected error for rejected roster")
+	}
+	if !strings.Contains(err.Error(), "onboarding step assign failed") || !strings.Contains(err.Error(), "Invalid team ID 99") {
+		t.Errorf("error = %q, want the failed step and the script's message", err)
+	}
+	if exitCodeFor(err) != exitBackend {
+		t.Errorf("exit code = %d, want %d", exitCodeFor(err), exitBackend)
+	}
+	if len(tm.calls) != 2 {
+		t.Errorf("calls = %v, want init and import only", tm.calls)
+	}
+	if report.Passed || report.Steps[2].Status != stepSkipped {
+		t.Errorf("steps = %+v, want validate skipped", report.Steps)
+	}
+
+	// --continue runs validation anyway and still reports the first failure
+	tm = &fakeTeamManager{failures: map[string]error{"import-csv": errors.New("Row 2: Invalid team ID 99")}}
+	report, err = runOnboarding("web-platform", roster, true, tm.run)
+	if err == nil || !strings.Contains(err.Error(), "assign") {
+		t.Errorf("runOnboarding(continue) error = %v, want the assign failure", err)
+	}
+	if len(tm.calls) != 3 || report.Steps[2].Status != stepPassed {
+		t.Errorf("runOnboarding(continue) calls = %v, steps = %+v, want validation run", tm.calls, report.Steps)
+	}
+}
+
+// TestRunOnboarding_RosterChecks tests that unusable roster files are rejected before init runs
+func TestRunOnboarding_RosterChecks(t *testing.T) {
+	tests := []struct {
+		name     string
+		roster   string
+		wantExit int
+	}{
+		{name: "unsupported format", roster: writeRoster(t, "roster.txt", "Jane Smith"), wantExit: exitGeneric},
+		{name: "missing file", roster: filepath.Join(t.TempDir(), "missing.json"), wantExit: exitNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tm := &fakeTeamManager{}
+			report, err := runOnboarding("web-platform", tt.roster, false, tm.run)
+			if err == nil || report != nil {
+				t.Fatalf("runOnboarding() = %v, %v, want error", report, err)
+			}
+			if got := exitCodeFor(err); got != tt.wantExit {
+				t.Errorf("exit code = %d, want %d", got, tt.wantExit)
+			}
+			if len(tm.calls) != 0 {
+				t.Errorf("calls = %v, want none", tm.calls)
+			}
+		})
+	}
+}
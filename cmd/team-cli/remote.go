@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// remoteArg is how a team_manager.py flag is passed to an MCP tool
+type remoteArg struct {
+	name   string // tool argument
+	number bool
+	flag   bool // takes no value and is passed as true
+}
+
+// remoteTool is the MCP tool that performs a team_manager.py command on the server
+type remoteTool struct {
+	name  string
+	args  map[string]remoteArg // team_manager.py flag -> tool argument
+	fixed map[string]interface{}
+}
+
+var (
+	teamArg  = remoteArg{name: "team_id", number: true}
+	roleArg  = remoteArg{name: "role_name"}
+	phaseArg = remoteArg{name: "phase"}
+)
+
+// remoteTools lists the team_manager.py commands the server exposes as MCP tools.
+// The others need the local script.
+var remoteTools = map[string]remoteTool{
+	"init":     {name: "guardrail_team_init"},
+	"list":     {name: "guardrail_team_list", args: map[string]remoteArg{"--phase": phaseArg}},
+	"assign":   {name: "guardrail_team_assign", args: map[string]remoteArg{"--team": teamArg, "--role": roleArg, "--person": {name: "person"}, "--replace": {name: "replace", flag: true}}},
+	"unassign": {name: "guardrail_team_unassign", args: map[string]remoteArg{"--team": teamArg, "--role": roleArg}},
+	"start":    {name: "guardrail_team_start", args: map[string]remoteArg{"--team": teamArg}},
+	"status":   {name: "guardrail_team_status", args: map[string]remoteArg{"--phase": phaseArg}},
+	"phase-gate-check": {name: "guardrail_phase_gate_check", args: map[string]remoteArg{
+		"--from": {name: "from_phase", number: true},
+		"--to":   {name: "to_phase", number: true},
+	}},
+	"validate-size":  {name: "guardrail_team_size_validate"},
+	"health":         {name: "guardrail_team_health"},
+	"delete-project": {name: "guardrail_project_delete", fixed: map[string]interface{}{"confirmed": true}},
+}
+
+// checkRemoteCommand fails a command the server has no tool for when --server is
+// set, before the command prints or changes anything
+func checkRemoteCommand(command string) error {
+	if _, ok := remoteTools[command]; serverURL != "" && !ok {
+		return validationFailure(fmt.Errorf("%s is not available with --server; run without --server to use team_manager.py", command))
+	}
+	return nil
+}
+
+// remoteToolArgs converts a team_manager.py command line into MCP tool arguments
+func remoteToolArgs(project, command string, args []string) (string, map[string]interface{}, error) {
+	tool, ok := remoteTools[command]
+	if !ok {
+		return "", nil, fmt.Errorf("%s is not available with --server; run without --server to use team_manager.py", command)
+	}
+
+	toolArgs := map[string]interface{}{}
+	if project != "" {
+		toolArgs["project_name"] = project
+	}
+	for k, v := range tool.fixed {
+		toolArgs[k] = v
+	}
+	for i := 0; i < len(args); i++ {
+		spec, ok := tool.args[args[i]]
+		if !ok {
+			return "", nil, fmt.Errorf("%s %s is not available with --server", command, args[i])
+		}
+		if spec.flag {
+			toolArgs[spec.name] = true
+			continue
+		}
+		if i+1 >= len(args) {
+			return "", nil, fmt.Errorf("%s needs a value", args[i])
+		}
+		i++
+		if !spec.number {
+			toolArgs[spec.name] = args[i]
+			continue
+		}
+		n, err := strconv.Atoi(args[i])
+		if err != nil {
+			return "", nil, fmt.Errorf("%s must be a number, got %q", args[i-1], args[i])
+		}
+		toolArgs[spec.name] = n
+	}
+	return tool.name, toolArgs, nil
+}
+
+// runRemote performs a team_manager.py command by calling the matching MCP tool on
+// the server, returning the tool's text like the script's stdout
+func runRemote(project, command string, args ...string) ([]byte, error) {
+	tool, toolArgs, err := remoteToolArgs(project, command, args)
+	if err != nil {
+		return nil, validationFailure(err)
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	text, isError, err := callMCPTool(ctx, serverURL, apiKey, tool, toolArgs)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, fmt.Errorf("%s on %s timed out after %s (raise --timeout if the command is expected to be slow): %w", tool, serverURL, timeout, context.DeadlineExceeded)
+		}
+		return nil, backendFailure(err)
+	}
+	if isError {
+		return nil, classifyBackendFailure(command, text, fmt.Errorf("%s failed: %s", tool, text))
+	}
+	return []byte(text), nil
+}
+
+// jsonRPCResponse is a JSON-RPC response received on the MCP event stream
+type jsonRPCResponse struct {
+	ID     json.RawMessage `json:"id"`
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// callMCPTool calls one tool over the server's MCP SSE transport: it opens the
+// event stream, initializes the MCP session on the endpoint the stream announces,
+// then calls the tool and waits for its result on the stream
+func callMCPTool(ctx context.Context, server, apiKey, tool string, args map[string]interface{}) (string, bool, error) {
+	base, err := url.Parse(strings.TrimRight(server, "/"))
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return "", false, fmt.Errorf("invalid --server URL %q", server)
+	}
+	streamURL := base
+	if !strings.HasSuffix(base.Path, "/mcp") {
+		streamURL = base.JoinPath("mcp")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, streamURL.String(), nil)
+	if err != nil {
+		return "", false, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to connect to %s: %w", server, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", false, fmt.Errorf("%s returned %s: %s", streamURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	events := bufio.NewReader(resp.Body)
+	var endpoint *url.URL
+	for endpoint == nil {
+		event, data, err := readSSEEvent(events)
+		if err != nil {
+			return "", false, fmt.Errorf("no MCP endpoint from %s: %w", streamURL, err)
+		}
+		if event == "endpoint" {
+			if endpoint, err = streamURL.Parse(data); err != nil {
+				return "", false, fmt.Errorf("invalid MCP endpoint %q: %w", data, err)
+			}
+		}
+	}
+
+	call := func(id int, method string, params interface{}) (json.RawMessage, error) {
+		message := map[string]interface{}{"jsonrpc": "2.0", "method": method, "params": params}
+		if id != 0 {
+			message["id"] = id
+		}
+		body, _ := json.Marshal(message)
+		post, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint.String(), bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		post.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			post.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		presp, err := http.DefaultClient.Do(post)
+		if err != nil {
+			return nil, fmt.Errorf("%s request failed: %w", method, err)
+		}
+		presp.Body.Close()
+		if presp.StatusCode >= 300 {
+			return nil, fmt.Errorf("%s request returned %s", method, presp.Status)
+		}
+		if id == 0 {
+			return nil, nil
+		}
+
+		for {
+			event, data, err := readSSEEvent(events)
+			if err != nil {
+				return nil, fmt.Errorf("no response to %s: %w", method, err)
+			}
+			if event != "message" {
+				continue
+			}
+			var rpc jsonRPCResponse
+			if json.Unmarshal([]byte(data), &rpc) != nil || string(rpc.ID) != strconv.Itoa(id) {
+				continue
+			}
+			if rpc.Error != nil {
+				return nil, fmt.Errorf("%s: %s", method, rpc.Error.Message)
+			}
+			return rpc.Result, nil
+		}
+	}
+
+	if _, err := call(1, "initialize", map[string]interface{}{
+		"protocolVersion": "2024-11-05",
+		"capabilities":    map[string]interface{}{},
+		"clientInfo":      map[string]interface{}{"name": "team-cli", "version": version},
+	}); err != nil {
+		return "", false, err
+	}
+	if _, err := call(0, "notifications/initialized", map[string]interface{}{}); err != nil {
+		return "", false, err
+	}
+	raw, err := call(2, "tools/call", map[string]interface{}{"name": tool, "arguments": args})
+	if err != nil {
+		return "", false, err
+	}
+
+	var result struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+		IsError bool `json:"isError"`
+	}
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return "", false, fmt.Errorf("invalid %s result: %w", tool, err)
+	}
+	var text []string
+	for _, c := range result.Content {
+		if c.Type == "text" {
+			text = append(text, c.Text)
+		}
+	}
+	return strings.Join(text, "\n"), result.IsError, nil
+}
+
+// readSSEEvent reads the next server-sent event, returning its type and data
+func readSSEEvent(r *bufio.Reader) (event, data string, err error) {
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "" && (event != "" || len(lines) > 0):
+			return event, strings.Join(lines, "\n"), nil
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			lines = append(lines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+		if err != nil {
+			return "", "", err
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// toolCall is a tools/call request received by fakeMCPServer
+type toolCall struct {
+	Name      string                 `json:"name"`
+	Arguments map[string]interface{} `json:"arguments"`
+}
+
+// fakeMCPServer serves the MCP SSE transport the way the guardrail server does:
+// each stream announces its own endpoint, and responses to messages posted there
+// arrive on that stream. Tool calls are answered by respond and recorded in calls.
+func fakeMCPServer(t *testing.T, respond func(toolCall) (string, bool)) (*httptest.Server, *[]toolCall) {
+	t.Helper()
+	var calls []toolCall
+	var mu sync.Mutex
+	streams := map[string]chan []byte{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /mcp", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		messages := make(chan []byte, 10)
+		mu.Lock()
+		id := fmt.Sprintf("s%d", len(streams)+1)
+		streams[id] = messages
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprintf(w, "event: endpoint\ndata: /mcp?sessionId=%s\n\n", id)
+		w.(http.Flusher).Flush()
+		for {
+			select {
+			case m := <-messages:
+				fmt.Fprintf(w, "event: message\ndata: %s\n\n", m)
+				w.(http.Flusher).Flush()
+			case <-r.Context().Done():
+				return
+			}
+		}
+	})
+	mux.HandleFunc("POST /mcp", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+			Params toolCall        `json:"params"`
+		}
+		mu.Lock()
+		messages, ok := streams[r.URL.Query().Get("sessionId")]
+		mu.Unlock()
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || !ok {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		var result interface{} = map[string]interface{}{}
+		switch req.Method {
+		case "notifications/initialized":
+			w.WriteHeader(http.StatusAccepted)
+			return
+		case "tools/call":
+			mu.Lock()
+			calls = append(calls, req.Params)
+			mu.Unlock()
+			text, isError := respond(req.Params)
+			result = map[string]interface{}{
+				"content": []map[string]string{{"type": "text", "text": text}},
+				"isError": isError,
+			}
+		}
+		data, _ := json.Marshal(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+		messages <- data
+		w.WriteHeader(http.StatusAccepted)
+	})
+
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &calls
+}
+
+// TestRemoteToolArgs tests the mapping from team_manager.py commands to MCP tools
+func TestRemoteToolArgs(t *testing.T) {
+	tool, args, err := remoteToolArgs("demo", "assign", []string{"--team", "7", "--role", "Technical Lead", "--person", "Jane"})
+	if err != nil {
+		t.Fatalf("remoteToolArgs(assign) error = %v", err)
+	}
+	want := map[string]interface{}{"project_name": "demo", "team_id": 7, "role_name": "Technical Lead", "person": "Jane"}
+	if tool != "guardrail_team_assign" || fmt.Sprint(args) != fmt.Sprint(want) {
+		t.Errorf("remoteToolArgs(assign) = %s %v, want guardrail_team_assign %v", tool, args, want)
+	}
+
+	if _, args, _ := remoteToolArgs("demo", "delete-project", nil); args["confirmed"] != true {
+		t.Errorf("delete-project args = %v, want confirmed", args)
+	}
+	if _, _, err := remoteToolArgs("demo", "query", nil); err == nil || !strings.Contains(err.Error(), "run without --server") {
+		t.Errorf("remoteToolArgs(query) error = %v, want not available", err)
+	}
+	_, args, err = remoteToolArgs("demo", "assign", []string{"--team", "7", "--role", "Technical Lead", "--person", "Sam", "--replace"})
+	if err != nil || args["replace"] != true || args["person"] != "Sam" {
+		t.Errorf("remoteToolArgs(assign --replace) = %v, %v, want replace=true", args, err)
+	}
+	if _, _, err := remoteToolArgs("demo", "unassign", []string{"--team", "7", "--replace"}); err == nil || !strings.Contains(err.Error(), "--replace") {
+		t.Errorf("remoteToolArgs(unassign --replace) error = %v, want not available", err)
+	}
+	if _, _, err := remoteToolArgs("demo", "start", []string{"--team", "seven"}); err == nil {
+		t.Error("remoteToolArgs(--team seven) expected error")
+	}
+}
+
+// TestServerMode tests that --server runs commands on the server without Python
+// or team_manager.py
+func TestServerMode(t *testing.T) {
+	srv, calls := fakeMCPServer(t, func(call toolCall) (string, bool) {
+		if call.Arguments["role_name"] == "Chief Architect" {
+			return "Error: role not found in team 7", true
+		}
+		return "✅ Assigned Jane to Technical Lead", false
+	})
+	t.Setenv("GUARDRAIL_API_KEY", "test-key")
+	t.Setenv("TEAM_MANAGER_PATH", filepath.Join(t.TempDir(), "missing.py"))
+	resetTeamManagerPath(t)
+	t.Cleanup(func() { serverURL = "" })
+	output = "text"
+
+	run := func(role string) (string, error) {
+		var err error
+		stdout := captureStdout(t, func() {
+			cmd := newRootCmd()
+			cmd.SetArgs([]string{"--server", srv.URL, "assign", "-p", "demo", "--team", "7", "--role", role, "--person", "Jane"})
+			cmd.SilenceErrors, cmd.SilenceUsage = true, true
+			err = cmd.Execute()
+		})
+		return stdout, err
+	}
+
+	stdout, err := run("Technical Lead")
+	if err != nil {
+		t.Fatalf("team assign --server error = %v", err)
+	}
+	if !strings.Contains(stdout, "Assigned Jane to Technical Lead") {
+		t.Errorf("stdout = %q, want the tool result", stdout)
+	}
+	if len(*calls) != 1 || (*calls)[0].Name != "guardrail_team_assign" || (*calls)[0].Arguments["team_id"] != float64(7) {
+		t.Errorf("calls = %+v, want one guardrail_team_assign for team 7", *calls)
+	}
+
+	if _, err := run("Chief Architect"); err == nil || !strings.Contains(err.Error(), "role not found") || exitCodeFor(err) != exitNotFound {
+		t.Errorf("team assign --server error = %v, want the tool error classified as not found", err)
+	}
+
+	t.Setenv("GUARDRAIL_API_KEY", "wrong")
+	if _, err := run("Technical Lead"); err == nil || !strings.Contains(err.Error(), "401") || exitCodeFor(err) != exitBackend {
+		t.Errorf("team assign --server error = %v, want a backend failure for the rejected key", err)
+	}
+}
+
+// TestServerMode_LocalOnlyCommand tests that a command the server has no tool for
+// fails with --server instead of running team_manager.py
+func TestServerMode_LocalOnlyCommand(t *testing.T) {
+	srv, calls := fakeMCPServer(t, func(toolCall) (string, bool) { return "", false })
+	t.Setenv("GUARDRAIL_API_KEY", "test-key")
+	t.Cleanup(func() { serverURL = "" })
+	output = "text"
+
+	for _, args := range [][]string{
+		{"--server", srv.URL, "complete", "-p", "demo", "--team", "7"},
+		{"--server", srv.URL, "complete", "-p", "demo", "--teams", "1,2"},
+	} {
+		var err error
+		stdout := captureStdout(t, func() {
+			cmd := newRootCmd()
+			cmd.SetArgs(args)
+			cmd.SilenceErrors, cmd.SilenceUsage = true, true
+			err = cmd.Execute()
+		})
+		if err == nil || !strings.Contains(err.Error(), "not available with --server") || exitCodeFor(err) != exitValidation {
+			t.Errorf("%v error = %v, want complete rejected in remote mode", args, err)
+		}
+		if stdout != "" {
+			t.Errorf("%v printed %q before failing", args, stdout)
+		}
+	}
+	if len(*calls) != 0 {
+		t.Errorf("calls = %+v, want none", *calls)
+	}
+}
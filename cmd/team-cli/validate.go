@@ -0,0 +1,40 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thearchitectit/guardrail-mcp/internal/team"
+)
+
+// The team ID and phase checks are shared with the MCP server's team tools, so
+// the CLI rejects the same values the server would without running Python.
+
+// validationMessage returns the message of a shared validator error without its
+// "validation error for <field>" prefix
+func validationMessage(err error) string {
+	var ve *team.ValidationError
+	if errors.As(err, &ve) {
+		return ve.Message
+	}
+	return err.Error()
+}
+
+// validateTeamFlag checks a required team ID flag such as --team
+func validateTeamFlag(flag string, id int) error {
+	if id == 0 {
+		return fmt.Errorf("%s flag is required", flag)
+	}
+	if err := team.ValidateTeamID(id); err != nil {
+		return fmt.Errorf("invalid %s %d: %s", flag, id, validationMessage(err))
+	}
+	return nil
+}
+
+// validatePhaseFlag checks an optional --phase filter
+func validatePhaseFlag(phase string) error {
+	if err := team.ValidatePhaseFilter(phase); err != nil {
+		return fmt.Errorf("%s (got %q)", validationMessage(err), phase)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestClientSideValidation tests that out-of-range team IDs and malformed phases
+// are rejected before team_manager.py runs
+func TestClientSideValidation(t *testing.T) {
+	useFakeTeamManager(t)
+	output = "text"
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "assign team out of range", args: []string{"assign", "--team", "99", "--role", "Technical Lead", "--person", "Jane"}, wantErr: "invalid --team 99: team_id must be between 1 and 12"},
+		{name: "start team zero", args: []string{"start", "--team", "0"}, wantErr: "--team flag is required"},
+		{name: "history negative team", args: []string{"history", "--team", "-3"}, wantErr: "invalid --team -3"},
+		{name: "reassign target out of range", args: []string{"reassign", "--from-team", "7", "--from-role", "A", "--to-team", "13", "--to-role", "B", "--person", "Jane"}, wantErr: "invalid --to-team 13"},
+		{name: "delete team out of range", args: []string{"delete", "--team", "42", "--force"}, wantErr: "invalid --team 42"},
+		{name: "status full phase name", args: []string{"status", "--phase", "Phase 1: Strategy, Governance & Planning"}, wantErr: "invalid phase: must be 'Phase 1', 'Phase 2', or 'Phase 3'"},
+		{name: "list injected phase", args: []string{"list", "--phase", "Phase 1; rm -rf /"}, wantErr: "invalid phase"},
+		{name: "valid team", args: []string{"start", "--team", "12"}},
+		{name: "valid phase", args: []string{"status", "--phase", "Phase 2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			phase = ""
+			var err error
+			stdout := captureStdout(t, func() {
+				cmd := newRootCmd()
+				cmd.SetArgs(append(tt.args, "-p", "demo"))
+				cmd.SilenceErrors, cmd.SilenceUsage = true, true
+				err = cmd.Execute()
+			})
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("team %s error = %v", strings.Join(tt.args, " "), err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("team %s error = %v, want %q", strings.Join(tt.args, " "), err, tt.wantErr)
+			}
+			if strings.Contains(stdout, "Done:") {
+				t.Errorf("team_manager.py ran for an invalid command: %q", stdout)
+			}
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// clearScreen moves the cursor home and clears the terminal before a redraw
+const clearScreen = "\033[H\033[2J"
+
+// watchTeam is a team's status as reported by query --format json
+type watchTeam struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Phase  string `json:"phase"`
+	Status string `json:"status"`
+}
+
+// watchSnapshot is one poll of a project: the status summary, every team's status
+// and the teams whose status changed since the previous poll
+type watchSnapshot struct {
+	Time    time.Time      `json:"time"`
+	Summary string         `json:"summary"`
+	Teams   []watchTeam    `json:"teams"`
+	Changed map[int]string `json:"changed,omitempty"` // team ID -> previous status
+}
+
+// pollStatus runs the status backend and queries every team's status, marking the
+// teams whose status differs from previous (nil on the first poll)
+func pollStatus(project, phase string, previous map[int]string, run teamManagerFunc) (*watchSnapshot, error) {
+	var phaseArgs []string
+	if phase != "" {
+		phaseArgs = []string{"--phase", phase}
+	}
+
+	summary, err := run(project, "status", phaseArgs...)
+	if err != nil {
+		return nil, err
+	}
+	result, err := run(project, "query", append(phaseArgs, "--format", "json")...)
+	if err != nil {
+		return nil, err
+	}
+	value, _, ok := extractJSON(result)
+	if !ok {
+		return nil, backendFailure(fmt.Errorf("query returned no JSON team list"))
+	}
+	snap := &watchSnapshot{Time: time.Now(), Summary: strings.TrimSpace(string(summary))}
+	if err := json.Unmarshal(value, &snap.Teams); err != nil {
+		return nil, backendFailure(fmt.Errorf("invalid query result: %w", err))
+	}
+
+	if previous != nil {
+		for _, team := range snap.Teams {
+			if was, ok := previous[team.ID]; ok && was != team.Status {
+				if snap.Changed == nil {
+					snap.Changed = map[int]string{}
+				}
+				snap.Changed[team.ID] = was
+			}
+		}
+	}
+	return snap, nil
+}
+
+// statuses returns the team ID -> status map the next poll is compared with
+func (s *watchSnapshot) statuses() map[int]string {
+	m := make(map[int]string, len(s.Teams))
+	for _, team := range s.Teams {
+		m[team.ID] = team.Status
+	}
+	return m
+}
+
+// renderWatch draws a snapshot: the status summary followed by one row per team,
+// with changed teams highlighted and their previous status shown
+func renderWatch(w io.Writer, project string, snap *watchSnapshot) {
+	fmt.Fprintln(w, titleStyle.Render("Project Status"))
+	fmt.Fprintf(w, "Project: %s  %s\n\n", textStyle.Render(project), infoStyle.Render("updated "+snap.Time.Format("15:04:05")))
+	if snap.Summary != "" {
+		fmt.Fprintln(w, snap.Summary)
+		fmt.Fprintln(w)
+	}
+
+	for _, team := range snap.Teams {
+		status := fmt.Sprintf("%-12s", team.Status)
+		switch team.Status {
+		case "completed":
+			status = successStyle.Render(status)
+		case "active":
+			status = infoStyle.Render(status)
+		case "blocked":
+			status = errorStyle.Render(status)
+		default:
+			status = textStyle.Render(status)
+		}
+		row := fmt.Sprintf("Team %-3d %s %s", team.ID, status, team.Name)
+		if was, ok := snap.Changed[team.ID]; ok {
+			row = warnStyle.Render("* ") + row + warnStyle.Render(" (was "+was+")")
+		} else {
+			row = "  " + row
+		}
+		fmt.Fprintln(w, row)
+	}
+	if len(snap.Changed) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, warnStyle.Render(fmt.Sprintf("%d team(s) changed since the last update", len(snap.Changed))))
+	}
+}
+
+// watchCmd creates the watch command
+func watchCmd() *cobra.Command {
+	var interval time.Duration
+	var once bool
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Show live project status",
+		Long: `Poll the project status every --interval and redraw it, highlighting the
+teams whose status changed since the previous update. Press Ctrl-C to stop.
+
+With --once the status is printed a single time. With -o json one JSON object is
+written per update instead of redrawing the screen.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if projectName == "" {
+				return fmt.Errorf("--project flag is required")
+			}
+			if err := validatePhaseFlag(phase); err != nil {
+				return err
+			}
+			if interval <= 0 {
+				return fmt.Errorf("--interval must be positive, got %s", interval)
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+			defer stop()
+
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			var previous map[int]string
+			for {
+				snap, err := pollStatus(projectName, phase, previous, runTeamManager)
+				if err != nil {
+					if ctx.Err() != nil {
+						// Interrupted by the user while the backend was running
+						return nil
+					}
+					return err
+				}
+				previous = snap.statuses()
+
+				if output == "json" {
+					line, _ := json.Marshal(snap)
+					fmt.Println(string(line))
+				} else {
+					if !once {
+						fmt.Print(clearScreen)
+					}
+					renderWatch(os.Stdout, projectName, snap)
+				}
+				if once {
+					return nil
+				}
+
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+
+	cmd.Flags().DurationVar(&interval, "interval", 5*time.Second, "Time between updates (e.g. 5s, 1m)")
+	cmd.Flags().BoolVar(&once, "once", false, "Print the status once and exit")
+	cmd.Flags().StringVar(&phase, "phase", "", "Only watch teams in this phase")
+	return cmd
+}
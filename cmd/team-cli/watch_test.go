@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestPollStatus tests that a second poll marks the teams whose status changed
+// and that the redraw highlights them
+func TestPollStatus(t *testing.T) {
+	statuses := map[string]string{"1": "active", "2": "not_started"}
+	run := func(project string, command string, args ...string) ([]byte, error) {
+		if command == "status" {
+			return []byte("\nPhase 1: Strategy: 0% complete\n"), nil
+		}
+		return []byte(`Warning: encryption key not set
+[{"id": 1, "name": "Business & Product Strategy", "phase": "Phase 1", "status": "` + statuses["1"] + `"},
+ {"id": 2, "name": "Enterprise Architecture", "phase": "Phase 1", "status": "` + statuses["2"] + `"}]`), nil
+	}
+
+	first, err := pollStatus("demo", "", nil, run)
+	if err != nil {
+		t.Fatalf("pollStatus() error = %v", err)
+	}
+	if len(first.Teams) != 2 || len(first.Changed) != 0 {
+		t.Errorf("first poll = %+v, want two teams and no changes", first)
+	}
+
+	statuses["1"] = "completed"
+	second, err := pollStatus("demo", "", first.statuses(), run)
+	if err != nil {
+		t.Fatalf("pollStatus() error = %v", err)
+	}
+	if len(second.Changed) != 1 || second.Changed[1] != "active" {
+		t.Errorf("changed = %v, want team 1 changed from active", second.Changed)
+	}
+
+	var out bytes.Buffer
+	renderWatch(&out, "demo", second)
+	text := out.String()
+	for _, want := range []string{"Phase 1: Strategy: 0% complete", "Business & Product Strategy", "(was active)", "1 team(s) changed"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("render = %q, want %q", text, want)
+		}
+	}
+	if strings.Count(text, "(was ") != 1 {
+		t.Errorf("render = %q, want only team 1 highlighted", text)
+	}
+}
+
+// TestPollStatus_InvalidQuery tests that a query without a JSON team list is a backend failure
+func TestPollStatus_InvalidQuery(t *testing.T) {
+	run := func(project string, command string, args ...string) ([]byte, error) {
+		return []byte("Done"), nil
+	}
+	if _, err := pollStatus("demo", "", nil, run); err == nil || exitCodeFor(err) != exitBackend {
+		t.Errorf("pollStatus() error = %v, want a backend failure", err)
+	}
+}
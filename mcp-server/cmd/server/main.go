@@ -99,6 +99,14 @@ func main() {
 	dbMetricsCollector.Start()
 	defer dbMetricsCollector.Stop()
 
+	// Prune persisted audit events past the retention window (opt-in)
+	if cfg.AuditRetentionDays > 0 {
+		auditPruner := audit.NewPruner(database.NewAuditStore(db), cfg.AuditRetentionDays, time.Hour)
+		auditPruner.Start()
+		defer auditPruner.Stop()
+		slog.Info("Audit retention enabled", "retention_days", cfg.AuditRetentionDays)
+	}
+
 	// Connect to Redis
 	redisClient, err := cache.New(cfg)
 	if err != nil {
@@ -129,6 +137,7 @@ func main() {
 	mcpSrv.SetWebhookStore(webhookStore)
 	mcpSrv.SetWebhookDispatcher(webhookDispatcher)
 	slog.Info("Webhook notifications initialized")
+	webServer.SetRateLimitStats(mcpSrv.RateLimitStats)
 
 	// Register vision HTTP routes on the web server if vision is enabled
 	if vt := mcpSrv.VisionTools(); vt != nil {
@@ -201,7 +210,8 @@ func main() {
 		slog.Error("Web server shutdown error", "error", err)
 	}
 
-	// Shutdown MCP server
+	// Shutdown MCP server - waits for in-flight tool calls so handlers
+	// don't hit a closed database or Redis connection
 	if err := mcpSrv.Shutdown(shutdownCtx); err != nil {
 		slog.Error("MCP server shutdown error", "error", err)
 	}
@@ -39,6 +39,11 @@ const (
 	EventAccessDenied   EventType = "access_denied"
 	EventSessionCreated EventType = "session_created"
 	EventSessionExpired EventType = "session_expired"
+	EventSessionClosed  EventType = "session_closed"
+	EventViolation      EventType = "violation"
+	EventHaltRecorded   EventType = "halt_recorded"
+	EventHaltAcked      EventType = "halt_acknowledged"
+	EventRoleReplaced   EventType = "role_replaced"
 )
 
 // Severity represents event severity
@@ -66,12 +71,20 @@ type Event struct {
 	RequestID string                 `json:"request_id"`
 }
 
+// recentEventsSize is how many processed events are kept for stream replay
+const recentEventsSize = 256
+
 // Logger handles audit event recording
 type Logger struct {
 	backend    chan Event
 	done       chan struct{}
 	wg         sync.WaitGroup
 	auditStore AuditStoreInterface
+
+	// Live subscribers and a ring of recent events for the event stream
+	subMu       sync.RWMutex
+	subscribers map[chan Event]struct{}
+	recent      []Event
 }
 
 // AuditStoreInterface defines the interface for audit storage
@@ -82,8 +95,9 @@ type AuditStoreInterface interface {
 // NewLogger creates an audit logger
 func NewLogger(bufferSize int) *Logger {
 	l := &Logger{
-		backend: make(chan Event, bufferSize),
-		done:    make(chan struct{}),
+		backend:     make(chan Event, bufferSize),
+		done:        make(chan struct{}),
+		subscribers: make(map[chan Event]struct{}),
 	}
 	l.wg.Add(1)
 	go l.process()
@@ -93,9 +107,10 @@ func NewLogger(bufferSize int) *Logger {
 // NewLoggerWithStore creates an audit logger with database persistence
 func NewLoggerWithStore(bufferSize int, store AuditStoreInterface) *Logger {
 	l := &Logger{
-		backend:    make(chan Event, bufferSize),
-		done:       make(chan struct{}),
-		auditStore: store,
+		backend:     make(chan Event, bufferSize),
+		done:        make(chan struct{}),
+		auditStore:  store,
+		subscribers: make(map[chan Event]struct{}),
 	}
 	l.wg.Add(1)
 	go l.process()
@@ -107,6 +122,60 @@ func (l *Logger) SetStore(store AuditStoreInterface) {
 	l.auditStore = store
 }
 
+// Subscribe registers a live listener for processed events. Events that arrive while
+// the listener's buffer is full are dropped for that listener only. The returned
+// function unsubscribes and closes the channel.
+func (l *Logger) Subscribe(bufferSize int) (<-chan Event, func()) {
+	ch := make(chan Event, bufferSize)
+
+	l.subMu.Lock()
+	l.subscribers[ch] = struct{}{}
+	l.subMu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			l.subMu.Lock()
+			delete(l.subscribers, ch)
+			l.subMu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+// Recent returns buffered events recorded at or after since, oldest first
+func (l *Logger) Recent(since time.Time) []Event {
+	l.subMu.RLock()
+	defer l.subMu.RUnlock()
+
+	events := make([]Event, 0, len(l.recent))
+	for _, event := range l.recent {
+		if !event.Timestamp.Before(since) {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+// publish records an event in the replay ring and fans it out to subscribers
+func (l *Logger) publish(event Event) {
+	l.subMu.Lock()
+	defer l.subMu.Unlock()
+
+	l.recent = append(l.recent, event)
+	if len(l.recent) > recentEventsSize {
+		l.recent = l.recent[len(l.recent)-recentEventsSize:]
+	}
+
+	for ch := range l.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// Slow subscriber - drop rather than block audit processing
+		}
+	}
+}
+
 // Stop gracefully shuts down the audit logger
 func (l *Logger) Stop() {
 	close(l.done)
@@ -171,6 +240,8 @@ func (l *Logger) process() {
 			// Return buffer to pool
 			bufferPool.Put(buf)
 
+			l.publish(event)
+
 			// Write to database for long-term storage if store is configured
 			if l.auditStore != nil {
 				dbEvent := &database.AuditEvent{
@@ -283,6 +354,86 @@ func (l *Logger) LogSession(ctx context.Context, eventType EventType, token, pro
 	})
 }
 
+// LogViolation logs a guardrail denial: a rule that blocked a command, edit or push.
+// Like every event it is dropped rather than queued when the buffer is full.
+func (l *Logger) LogViolation(ctx context.Context, sessionID, projectSlug, tool, ruleID, message string) {
+	details := map[string]interface{}{
+		"rule_id": ruleID,
+		"message": message,
+		"project": projectSlug,
+	}
+	if sessionID != "" {
+		details["session_hash"] = hashToken(sessionID)
+	}
+
+	l.Log(ctx, Event{
+		Type:     EventViolation,
+		Severity: SevWarning,
+		Actor:    "agent",
+		Action:   "validate",
+		Resource: tool,
+		Status:   "denied",
+		Details:  details,
+	})
+}
+
+// LogHalt logs a halt being recorded or acknowledged. A recorded halt is critical
+// or a warning according to its own severity; acknowledging one is informational.
+func (l *Logger) LogHalt(ctx context.Context, eventType EventType, sessionID, haltID, haltType, haltSeverity, detail string) {
+	severity := SevInfo
+	if eventType == EventHaltRecorded {
+		switch haltSeverity {
+		case "critical", "high":
+			severity = SevCritical
+		case "medium":
+			severity = SevWarning
+		}
+	}
+
+	details := map[string]interface{}{
+		"halt_id":       haltID,
+		"halt_type":     haltType,
+		"halt_severity": haltSeverity,
+		"session_hash":  hashToken(sessionID),
+	}
+	action := "record_halt"
+	if eventType == EventHaltAcked {
+		action = "acknowledge_halt"
+		details["resolution"] = detail
+	} else {
+		details["description"] = detail
+	}
+
+	l.Log(ctx, Event{
+		Type:     eventType,
+		Severity: severity,
+		Actor:    "agent",
+		Action:   action,
+		Resource: haltType,
+		Status:   "success",
+		Details:  details,
+	})
+}
+
+// LogRoleReplaced logs a team role being taken from its holder and given to someone
+// else, naming both so the handover can be traced
+func (l *Logger) LogRoleReplaced(ctx context.Context, projectName string, teamID int, roleName, previous, assignee string) {
+	l.Log(ctx, Event{
+		Type:     EventRoleReplaced,
+		Severity: SevInfo,
+		Actor:    "agent",
+		Action:   "assign_role",
+		Resource: projectName,
+		Status:   "success",
+		Details: map[string]interface{}{
+			"team_id":  teamID,
+			"role":     roleName,
+			"previous": previous,
+			"assignee": assignee,
+		},
+	})
+}
+
 // hashToken creates a short hash for logging
 func hashToken(token string) string {
 	if len(token) < 8 {
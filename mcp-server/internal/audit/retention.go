@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// RetentionStore deletes persisted audit events older than a cutoff
+type RetentionStore interface {
+	DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// Pruner periodically deletes persisted audit events older than the retention window
+type Pruner struct {
+	store     RetentionStore
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewPruner creates a pruner that keeps retentionDays of audit history and runs every interval
+func NewPruner(store RetentionStore, retentionDays int, interval time.Duration) *Pruner {
+	return &Pruner{
+		store:     store,
+		retention: time.Duration(retentionDays) * 24 * time.Hour,
+		interval:  interval,
+		now:       time.Now,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Start prunes immediately and then on every interval until Stop is called
+func (p *Pruner) Start() {
+	go func() {
+		defer close(p.done)
+
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+
+		p.runOnce()
+		for {
+			select {
+			case <-ticker.C:
+				p.runOnce()
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop halts the pruning loop and waits for an in-progress prune to finish
+func (p *Pruner) Stop() {
+	close(p.stop)
+	<-p.done
+}
+
+// Prune deletes events older than the retention window and returns how many were removed
+func (p *Pruner) Prune(ctx context.Context) (int64, error) {
+	cutoff := p.now().Add(-p.retention)
+	removed, err := p.store.DeleteOlderThan(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	slog.Info("Pruned audit events", "removed", removed, "cutoff", cutoff.Format(time.RFC3339))
+	return removed, nil
+}
+
+func (p *Pruner) runOnce() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if _, err := p.Prune(ctx); err != nil {
+		slog.Error("Failed to prune audit events", "error", err)
+	}
+}
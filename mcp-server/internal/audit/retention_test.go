@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryRetentionStore is an in-memory RetentionStore holding event timestamps
+type memoryRetentionStore struct {
+	mu     sync.Mutex
+	events map[string]time.Time
+}
+
+func (m *memoryRetentionStore) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var removed int64
+	for id, ts := range m.events {
+		if ts.Before(cutoff) {
+			delete(m.events, id)
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// TestPruner_RemovesOnlyOldEvents tests that pruning deletes events outside the retention window
+func TestPruner_RemovesOnlyOldEvents(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := &memoryRetentionStore{events: map[string]time.Time{
+		"ancient":  now.AddDate(0, 0, -90),
+		"old":      now.AddDate(0, 0, -31),
+		"boundary": now.AddDate(0, 0, -30).Add(time.Minute),
+		"recent":   now.AddDate(0, 0, -2),
+		"just-now": now,
+	}}
+
+	p := NewPruner(store, 30, time.Hour)
+	p.now = func() time.Time { return now }
+
+	removed, err := p.Prune(context.Background())
+	if err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+	if removed != 2 {
+		t.Errorf("Prune() removed = %d, want 2", removed)
+	}
+	for _, id := range []string{"boundary", "recent", "just-now"} {
+		if _, ok := store.events[id]; !ok {
+			t.Errorf("event %q was pruned but is within retention", id)
+		}
+	}
+	for _, id := range []string{"ancient", "old"} {
+		if _, ok := store.events[id]; ok {
+			t.Errorf("event %q should have been pruned", id)
+		}
+	}
+}
+
+// TestPruner_StartStop tests that the background job prunes on start and stops cleanly
+func TestPruner_StartStop(t *testing.T) {
+	store := &memoryRetentionStore{events: map[string]time.Time{
+		"old": time.Now().AddDate(0, 0, -10),
+		"new": time.Now(),
+	}}
+
+	p := NewPruner(store, 7, time.Hour)
+	p.Start()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		store.mu.Lock()
+		n := len(store.events)
+		store.mu.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	p.Stop()
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if _, ok := store.events["old"]; ok {
+		t.Error("old event was not pruned on start")
+	}
+	if _, ok := store.events["new"]; !ok {
+		t.Error("new event was pruned")
+	}
+}
@@ -113,6 +113,29 @@ func (c *Client) Set(ctx context.Context, key string, value []byte, ttl time.Dur
 	return err
 }
 
+// SetExisting replaces a value and its TTL only if the key still exists (SET XX),
+// reporting false when it does not
+func (c *Client) SetExisting(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+
+	start := time.Now()
+	ok, err := c.client.SetXX(ctx, key, value, ttl).Result()
+	duration := time.Since(start)
+
+	if err != nil {
+		metrics.RecordCacheError("set")
+	} else if ok {
+		metrics.RecordCacheHit("set")
+	} else {
+		metrics.RecordCacheMiss("set")
+	}
+	metrics.RecordCacheOperation("set", duration)
+
+	return ok, err
+}
+
 // Delete removes a key from cache
 func (c *Client) Delete(ctx context.Context, key string) error {
 	start := time.Now()
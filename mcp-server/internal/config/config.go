@@ -3,10 +3,13 @@ package config
 import (
 	"fmt"
 	"math/bits"
+	"reflect"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/caarlos0/env/v11"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
 )
 
 // SchemaVersion tracks the configuration schema version for migrations
@@ -84,6 +87,12 @@ type Config struct {
 	JWTExpiry        time.Duration `env:"JWT_EXPIRY" envDefault:"15m"`
 	JWTRotationHours time.Duration `env:"JWT_ROTATION_HOURS" envDefault:"168h"` // 7 days
 
+	// Guardrail sessions are kept in Redis and expire after SessionIdleTimeout
+	// without activity (0 uses JWTExpiry); each replica caches up to
+	// SessionCacheSize of them in memory
+	SessionIdleTimeout time.Duration `env:"SESSION_IDLE_TIMEOUT" envDefault:"0"`
+	SessionCacheSize   int           `env:"SESSION_CACHE_SIZE" envDefault:"1024"`
+
 	// Rate Limiting Configuration (prefixed consistently)
 	RateLimitMCP         int           `env:"RATE_LIMIT_MCP" envDefault:"1000"`
 	RateLimitIDE         int           `env:"RATE_LIMIT_IDE" envDefault:"500"`
@@ -91,6 +100,25 @@ type Config struct {
 	RateLimitWindow      time.Duration `env:"RATE_LIMIT_WINDOW" envDefault:"1m"`
 	RateLimitBurstFactor float64       `env:"RATE_LIMIT_BURST_FACTOR" envDefault:"1.5"`
 
+	// Team tool rate limit: requests per caller per window for the mutating team
+	// tools. Read-only team tools get a separate bucket of at least 600 per window.
+	RateLimitRequests      int `env:"RATE_LIMIT_TEAM_REQUESTS" envDefault:"100"`
+	RateLimitWindowSeconds int `env:"RATE_LIMIT_TEAM_WINDOW_SECONDS" envDefault:"60"`
+
+	// MCP SSE Endpoint Configuration
+	SSEAuthEnabled       bool          `env:"SSE_AUTH_ENABLED" envDefault:"false"`
+	SSEConnectRateLimit  int           `env:"SSE_CONNECT_RATE_LIMIT" envDefault:"30"` // connections per IP per window, 0 disables
+	SSEConnectRateWindow time.Duration `env:"SSE_CONNECT_RATE_WINDOW" envDefault:"1m"`
+	SSEResumeGracePeriod time.Duration `env:"SSE_RESUME_GRACE_PERIOD" envDefault:"2m"` // how long a dropped SSE session can be resumed
+	SSEPingInterval      time.Duration `env:"SSE_PING_INTERVAL" envDefault:"30s"`
+	SSEIdleTimeout       time.Duration `env:"SSE_IDLE_TIMEOUT" envDefault:"0"`           // close streams with no client messages for this long, 0 disables
+	SSEQueueSize         int           `env:"SSE_QUEUE_SIZE" envDefault:"100"`           // responses buffered per session
+	SSEEnqueueTimeout    time.Duration `env:"SSE_ENQUEUE_TIMEOUT" envDefault:"1s"`       // wait for room in a full queue before applying the policy
+	SSEQueuePolicy       string        `env:"SSE_QUEUE_POLICY" envDefault:"drop_oldest"` // drop_oldest or reject_newest
+
+	// Tool Configuration
+	DisabledTools []string `env:"DISABLED_TOOLS"` // tool names hidden from tools/list and rejected on call
+
 	// Cache TTL Configuration
 	CacheTTLRules  time.Duration `env:"CACHE_TTL_RULES" envDefault:"5m"`
 	CacheTTLDocs   time.Duration `env:"CACHE_TTL_DOCS" envDefault:"10m"`
@@ -105,6 +133,7 @@ type Config struct {
 	// Audit Logging Configuration
 	AuditBufferSize    int           `env:"AUDIT_BUFFER_SIZE" envDefault:"1000"`
 	AuditFlushInterval time.Duration `env:"AUDIT_FLUSH_INTERVAL" envDefault:"5s"`
+	AuditRetentionDays int           `env:"AUDIT_RETENTION_DAYS" envDefault:"0"` // 0 keeps persisted events forever
 
 	// Circuit Breaker Configuration
 	CircuitBreakerEnabled          bool          `env:"CIRCUIT_BREAKER_ENABLED" envDefault:"true"`
@@ -114,8 +143,19 @@ type Config struct {
 	CircuitBreakerMaxRequests      int           `env:"CIRCUIT_BREAKER_MAX_REQUESTS" envDefault:"3"`
 	CircuitBreakerInterval         time.Duration `env:"CIRCUIT_BREAKER_INTERVAL" envDefault:"10s"`
 
+	// Three Strikes Configuration: failed attempts allowed before halting, with
+	// optional per error category overrides (e.g. "test_flake:5,syntax:2")
+	ThreeStrikesMaxAttempts    int            `env:"THREE_STRIKES_MAX_ATTEMPTS" envDefault:"3"`
+	ThreeStrikesCategoryLimits map[string]int `env:"THREE_STRIKES_CATEGORY_LIMITS"`
+
+	// Team Management Configuration
+	TeamsBaseDir string `env:"TEAMS_BASE_DIR" envDefault:".teams"`
+
 	// Production Mode Indicator
 	ProductionMode bool `env:"PRODUCTION_MODE" envDefault:"false"`
+
+	// Maintenance Mode (initial state; can be toggled at runtime via the admin API)
+	MaintenanceMode bool `env:"MAINTENANCE_MODE" envDefault:"false"`
 }
 
 // Load reads configuration from environment variables
@@ -202,6 +242,59 @@ func (c *Config) Validate() error {
 	if c.RateLimitBurstFactor < 1.0 || c.RateLimitBurstFactor > 5.0 {
 		return fmt.Errorf("RATE_LIMIT_BURST_FACTOR must be between 1.0 and 5.0, got %.2f", c.RateLimitBurstFactor)
 	}
+	if c.RateLimitRequests < 1 {
+		return fmt.Errorf("RATE_LIMIT_TEAM_REQUESTS must be at least 1, got %d", c.RateLimitRequests)
+	}
+	if c.RateLimitWindowSeconds < 1 || c.RateLimitWindowSeconds > 3600 {
+		return fmt.Errorf("RATE_LIMIT_TEAM_WINDOW_SECONDS must be between 1 and 3600, got %d", c.RateLimitWindowSeconds)
+	}
+
+	if c.AuditRetentionDays < 0 {
+		return fmt.Errorf("AUDIT_RETENTION_DAYS must be non-negative, got %d", c.AuditRetentionDays)
+	}
+
+	if c.SSEConnectRateLimit < 0 {
+		return fmt.Errorf("SSE_CONNECT_RATE_LIMIT must be non-negative, got %d", c.SSEConnectRateLimit)
+	}
+	if c.SSEConnectRateLimit > 0 {
+		if err := ValidateTimeout("SSE_CONNECT_RATE_WINDOW", c.SSEConnectRateWindow, 1*time.Second, 1*time.Hour); err != nil {
+			return err
+		}
+	}
+	if err := ValidateTimeout("SSE_RESUME_GRACE_PERIOD", c.SSEResumeGracePeriod, 1*time.Second, 1*time.Hour); err != nil {
+		return err
+	}
+	if err := ValidateTimeout("SSE_PING_INTERVAL", c.SSEPingInterval, 1*time.Second, 10*time.Minute); err != nil {
+		return err
+	}
+	if c.SSEIdleTimeout < 0 {
+		return fmt.Errorf("SSE_IDLE_TIMEOUT must be non-negative, got %v", c.SSEIdleTimeout)
+	}
+	if c.SSEIdleTimeout > 0 {
+		if err := ValidateTimeout("SSE_IDLE_TIMEOUT", c.SSEIdleTimeout, 1*time.Second, 24*time.Hour); err != nil {
+			return err
+		}
+	}
+	if err := ValidateTimeout("JWT_EXPIRY", c.JWTExpiry, 1*time.Minute, 24*time.Hour); err != nil {
+		return err
+	}
+	if c.SessionIdleTimeout != 0 {
+		if err := ValidateTimeout("SESSION_IDLE_TIMEOUT", c.SessionIdleTimeout, 1*time.Minute, 7*24*time.Hour); err != nil {
+			return err
+		}
+	}
+	if c.SessionCacheSize < 1 || c.SessionCacheSize > 1000000 {
+		return fmt.Errorf("SESSION_CACHE_SIZE must be between 1 and 1000000, got %d", c.SessionCacheSize)
+	}
+	if c.SSEQueueSize < 1 || c.SSEQueueSize > 10000 {
+		return fmt.Errorf("SSE_QUEUE_SIZE must be between 1 and 10000, got %d", c.SSEQueueSize)
+	}
+	if c.SSEEnqueueTimeout < 0 || c.SSEEnqueueTimeout > 30*time.Second {
+		return fmt.Errorf("SSE_ENQUEUE_TIMEOUT must be between 0 and 30s, got %v", c.SSEEnqueueTimeout)
+	}
+	if c.SSEQueuePolicy != "drop_oldest" && c.SSEQueuePolicy != "reject_newest" {
+		return fmt.Errorf("SSE_QUEUE_POLICY must be drop_oldest or reject_newest, got %s", c.SSEQueuePolicy)
+	}
 
 	// Validate TLS configuration
 	if c.TLSEnabled {
@@ -236,6 +329,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("AUDIT_BUFFER_SIZE must be at most 10000, got %d", c.AuditBufferSize)
 	}
 
+	if err := ValidateThreeStrikesLimits(c.ThreeStrikesMaxAttempts, c.ThreeStrikesCategoryLimits); err != nil {
+		return err
+	}
+
 	// Validate CORS settings
 	if len(c.CORSAllowedOrigins) == 0 {
 		return fmt.Errorf("CORS_ALLOWED_ORIGINS must not be empty")
@@ -322,6 +419,30 @@ func ValidateAPIKey(key, name string) error {
 	return nil
 }
 
+// ValidateThreeStrikesLimits validates the three strikes default and per category limits
+func ValidateThreeStrikesLimits(maxAttempts int, categoryLimits map[string]int) error {
+	if maxAttempts < 1 || maxAttempts > 100 {
+		return fmt.Errorf("THREE_STRIKES_MAX_ATTEMPTS must be between 1 and 100, got %d", maxAttempts)
+	}
+	for category, limit := range categoryLimits {
+		if !models.IsValidErrorCategory(category) {
+			return fmt.Errorf("THREE_STRIKES_CATEGORY_LIMITS has unknown error category %q", category)
+		}
+		if limit < 1 || limit > 100 {
+			return fmt.Errorf("THREE_STRIKES_CATEGORY_LIMITS limit for %s must be between 1 and 100, got %d", category, limit)
+		}
+	}
+	return nil
+}
+
+// ThreeStrikesLimits returns the configured three strikes limits
+func (c *Config) ThreeStrikesLimits() models.ThreeStrikesLimits {
+	return models.ThreeStrikesLimits{
+		Default:     c.ThreeStrikesMaxAttempts,
+		PerCategory: c.ThreeStrikesCategoryLimits,
+	}
+}
+
 // ValidateTimeout validates a timeout is within acceptable bounds
 func ValidateTimeout(name string, value, min, max time.Duration) error {
 	if value < min {
@@ -333,6 +454,15 @@ func ValidateTimeout(name string, value, min, max time.Duration) error {
 	return nil
 }
 
+// SessionTTL returns how long a guardrail session lasts without activity: the
+// advertised expires_at of a new session and when it is evicted
+func (c *Config) SessionTTL() time.Duration {
+	if c.SessionIdleTimeout > 0 {
+		return c.SessionIdleTimeout
+	}
+	return c.JWTExpiry
+}
+
 // DatabaseURL returns the PostgreSQL connection string
 func (c *Config) DatabaseURL() string {
 	return fmt.Sprintf("postgresql://%s:%s@%s:%d/%s?sslmode=%s&connect_timeout=%d",
@@ -398,3 +528,23 @@ func (c *Config) Masked() *Config {
 	masked.JWTSecret = "***"
 	return &masked
 }
+
+// Effective returns the settings in effect keyed by environment variable name,
+// with secrets masked as in Masked and durations rendered like "30s"
+func (c *Config) Effective() map[string]interface{} {
+	v := reflect.ValueOf(c.Masked()).Elem()
+	t := v.Type()
+	settings := make(map[string]interface{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("env"), ",")
+		if name == "" {
+			continue
+		}
+		value := v.Field(i).Interface()
+		if d, ok := value.(time.Duration); ok {
+			value = d.String()
+		}
+		settings[name] = value
+	}
+	return settings
+}
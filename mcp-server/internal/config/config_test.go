@@ -223,6 +223,82 @@ func TestValidateTimeout(t *testing.T) {
 	}
 }
 
+func TestValidateThreeStrikesLimits(t *testing.T) {
+	tests := []struct {
+		name           string
+		maxAttempts    int
+		categoryLimits map[string]int
+		wantErr        bool
+		errMsg         string
+	}{
+		{
+			name:        "default only",
+			maxAttempts: 3,
+			wantErr:     false,
+		},
+		{
+			name:           "valid category limits",
+			maxAttempts:    3,
+			categoryLimits: map[string]int{"test_flake": 5, "syntax": 2},
+			wantErr:        false,
+		},
+		{
+			name:        "default out of range",
+			maxAttempts: 0,
+			wantErr:     true,
+			errMsg:      "THREE_STRIKES_MAX_ATTEMPTS",
+		},
+		{
+			name:           "unknown category",
+			maxAttempts:    3,
+			categoryLimits: map[string]int{"flaky": 5},
+			wantErr:        true,
+			errMsg:         "unknown error category",
+		},
+		{
+			name:           "category limit out of range",
+			maxAttempts:    3,
+			categoryLimits: map[string]int{"syntax": 0},
+			wantErr:        true,
+			errMsg:         "limit for syntax",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateThreeStrikesLimits(tt.maxAttempts, tt.categoryLimits)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateThreeStrikesLimits() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr && err != nil && !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ValidateThreeStrikesLimits() error message = %v, want containing %v", err.Error(), tt.errMsg)
+			}
+		})
+	}
+}
+
+// TestConfig_SessionTTL tests that the session idle timeout falls back to the JWT expiry
+func TestConfig_SessionTTL(t *testing.T) {
+	tests := []struct {
+		name        string
+		idleTimeout time.Duration
+		want        time.Duration
+	}{
+		{name: "unset uses JWT expiry", idleTimeout: 0, want: 15 * time.Minute},
+		{name: "explicit idle timeout", idleTimeout: 2 * time.Hour, want: 2 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{JWTExpiry: 15 * time.Minute, SessionIdleTimeout: tt.idleTimeout}
+			if got := cfg.SessionTTL(); got != tt.want {
+				t.Errorf("SessionTTL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsHotReloadable(t *testing.T) {
 	tests := []struct {
 		name string
@@ -306,6 +382,36 @@ func TestConfig_Masked(t *testing.T) {
 	}
 }
 
+func TestConfig_Effective(t *testing.T) {
+	cfg := &Config{
+		MCPPort:        8080,
+		RequestTimeout: 30 * time.Second,
+		JWTSecret:      "secret-jwt-secret",
+		DBPassword:     "secret-db-password",
+		DisabledTools:  []string{"guardrail_validate_bash"},
+	}
+
+	settings := cfg.Effective()
+
+	if settings["MCP_PORT"] != 8080 {
+		t.Errorf("MCP_PORT = %v, want 8080", settings["MCP_PORT"])
+	}
+	if settings["REQUEST_TIMEOUT"] != "30s" {
+		t.Errorf("REQUEST_TIMEOUT = %v, want 30s", settings["REQUEST_TIMEOUT"])
+	}
+	if tools, _ := settings["DISABLED_TOOLS"].([]string); len(tools) != 1 {
+		t.Errorf("DISABLED_TOOLS = %v, want the disabled tool", settings["DISABLED_TOOLS"])
+	}
+	for _, key := range []string{"JWT_SECRET", "DB_PASSWORD", "REDIS_PASSWORD", "MCP_API_KEY", "IDE_API_KEY"} {
+		if settings[key] != "***" {
+			t.Errorf("%s = %v, want ***", key, settings[key])
+		}
+	}
+	if cfg.JWTSecret != "secret-jwt-secret" {
+		t.Error("Effective() must not modify the config")
+	}
+}
+
 func TestConfig_DatabaseURL(t *testing.T) {
 	cfg := &Config{
 		DBUser:           "testuser",
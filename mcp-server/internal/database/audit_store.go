@@ -183,6 +183,20 @@ func (s *AuditStore) InsertAsync(event *AuditEvent) {
 	}()
 }
 
+// DeleteOlderThan removes audit events recorded before cutoff and returns how many were deleted
+func (s *AuditStore) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx, `DELETE FROM audit_log WHERE timestamp < $1`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune audit events: %w", err)
+	}
+
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count pruned audit events: %w", err)
+	}
+	return removed, nil
+}
+
 // GetRecent retrieves recent audit events
 func (s *AuditStore) GetRecent(ctx context.Context, limit int) ([]AuditEvent, error) {
 	return s.List(ctx, "", "", limit, 0)
@@ -248,3 +248,19 @@ func (s *FailureStore) Count(ctx context.Context) (int64, error) {
 	err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM failure_registry`).Scan(&count)
 	return count, err
 }
+
+// CountFiltered returns the number of failures matching the List filters; empty
+// filters match everything
+func (s *FailureStore) CountFiltered(ctx context.Context, status, category, projectSlug string) (int, error) {
+	var count int
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM failure_registry
+		WHERE ($1 = '' OR status = $1)
+		  AND ($2 = '' OR category = $2)
+		  AND ($3 = '' OR project_slug = $3)
+	`, status, category, projectSlug).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count failures: %w", err)
+	}
+	return count, nil
+}
@@ -86,29 +86,29 @@ func (s *FileReadStore) ListBySession(ctx context.Context, sessionID string, lim
 	return scanFileReads(rows)
 }
 
-// DeleteBySession removes all file read records for a session
-func (s *FileReadStore) DeleteBySession(ctx context.Context, sessionID string) error {
+// DeleteBySession removes all file read records for a session and returns how many were removed
+func (s *FileReadStore) DeleteBySession(ctx context.Context, sessionID string) (int, error) {
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
 	result, err := tx.ExecContext(ctx, `DELETE FROM file_reads WHERE session_id = $1`, sessionID)
 	if err != nil {
-		return fmt.Errorf("failed to delete file read records: %w", err)
+		return 0, fmt.Errorf("failed to delete file read records: %w", err)
 	}
 
-	_, err = result.RowsAffected()
+	rows, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
 	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return nil
+	return int(rows), nil
 }
 
 // Delete removes a specific file read record
@@ -0,0 +1,4 @@
+-- Migration: Remove secret scanning baseline table
+-- Version: 017
+
+DROP TABLE IF EXISTS secret_baselines CASCADE;
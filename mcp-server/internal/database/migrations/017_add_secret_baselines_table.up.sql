@@ -0,0 +1,15 @@
+-- Migration: Add secret scanning baseline table
+-- Version: 017
+
+CREATE TABLE IF NOT EXISTS secret_baselines (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_slug VARCHAR(100) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    finding_type VARCHAR(100) NOT NULL,
+    file_path TEXT,
+    reason TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (project_slug, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_baselines_project_slug ON secret_baselines(project_slug);
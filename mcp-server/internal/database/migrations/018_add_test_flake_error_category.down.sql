@@ -0,0 +1,10 @@
+-- Migration: Remove test_flake error category from task attempts
+-- Version: 018
+
+UPDATE task_attempts SET error_category = 'other' WHERE error_category = 'test_flake';
+
+ALTER TABLE task_attempts DROP CONSTRAINT IF EXISTS valid_error_category;
+ALTER TABLE task_attempts ADD CONSTRAINT valid_error_category CHECK (
+    error_category IS NULL OR
+    error_category IN ('syntax', 'runtime', 'logic', 'timeout', 'other')
+);
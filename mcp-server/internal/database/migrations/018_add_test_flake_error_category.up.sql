@@ -0,0 +1,8 @@
+-- Migration: Add test_flake error category for task attempts
+-- Version: 018
+
+ALTER TABLE task_attempts DROP CONSTRAINT IF EXISTS valid_error_category;
+ALTER TABLE task_attempts ADD CONSTRAINT valid_error_category CHECK (
+    error_category IS NULL OR
+    error_category IN ('syntax', 'runtime', 'logic', 'timeout', 'test_flake', 'other')
+);
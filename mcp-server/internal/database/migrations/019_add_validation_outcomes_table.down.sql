@@ -0,0 +1,4 @@
+-- Migration: Remove validation outcome history
+-- Version: 019
+
+DROP TABLE IF EXISTS validation_outcomes CASCADE;
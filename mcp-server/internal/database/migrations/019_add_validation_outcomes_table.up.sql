@@ -0,0 +1,16 @@
+-- Migration: Add validation outcome history for per-project trends
+-- Version: 019
+
+CREATE TABLE IF NOT EXISTS validation_outcomes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_slug VARCHAR(100) NOT NULL,
+    tool_name VARCHAR(100) NOT NULL,
+    valid BOOLEAN NOT NULL,
+    critical_count INTEGER NOT NULL DEFAULT 0,
+    error_count INTEGER NOT NULL DEFAULT 0,
+    warning_count INTEGER NOT NULL DEFAULT 0,
+    info_count INTEGER NOT NULL DEFAULT 0,
+    recorded_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_validation_outcomes_project_recorded ON validation_outcomes(project_slug, recorded_at);
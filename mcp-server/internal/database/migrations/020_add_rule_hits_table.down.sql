@@ -0,0 +1,4 @@
+-- Migration: Remove per-rule match counts
+-- Version: 020
+
+DROP TABLE IF EXISTS rule_hits CASCADE;
@@ -0,0 +1,9 @@
+-- Migration: Add per-rule match counts for rule coverage reports
+-- Version: 020
+
+CREATE TABLE IF NOT EXISTS rule_hits (
+    rule_id VARCHAR(50) PRIMARY KEY,
+    hit_count BIGINT NOT NULL DEFAULT 0,
+    first_hit_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_hit_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
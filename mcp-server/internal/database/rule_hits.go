@@ -0,0 +1,57 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// RuleHitStore counts how often each prevention rule matches in validations.
+type RuleHitStore struct {
+	db *DB
+}
+
+// NewRuleHitStore creates a new rule hit store.
+func NewRuleHitStore(db *DB) *RuleHitStore {
+	return &RuleHitStore{db: db}
+}
+
+// RecordHits adds one match to each of the rules.
+func (s *RuleHitStore) RecordHits(ctx context.Context, ruleIDs []string) error {
+	for _, ruleID := range ruleIDs {
+		_, err := s.db.ExecContext(ctx, `
+			INSERT INTO rule_hits (rule_id, hit_count, first_hit_at, last_hit_at)
+			VALUES ($1, 1, NOW(), NOW())
+			ON CONFLICT (rule_id) DO UPDATE
+			SET hit_count = rule_hits.hit_count + 1, last_hit_at = NOW()
+		`, ruleID)
+		if err != nil {
+			return fmt.Errorf("failed to record hit for rule %s: %w", ruleID, err)
+		}
+	}
+	return nil
+}
+
+// List returns the match counts of every rule that has matched at least once.
+func (s *RuleHitStore) List(ctx context.Context) ([]models.RuleHit, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT rule_id, hit_count, last_hit_at
+		FROM rule_hits
+		ORDER BY rule_id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list rule hits: %w", err)
+	}
+	defer rows.Close()
+
+	var hits []models.RuleHit
+	for rows.Next() {
+		var h models.RuleHit
+		if err := rows.Scan(&h.RuleID, &h.HitCount, &h.LastHitAt); err != nil {
+			return nil, fmt.Errorf("failed to scan rule hit: %w", err)
+		}
+		hits = append(hits, h)
+	}
+	return hits, rows.Err()
+}
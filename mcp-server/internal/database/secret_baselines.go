@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// SecretBaselineStore handles the per-project secret scanning baseline.
+type SecretBaselineStore struct {
+	db *DB
+}
+
+// NewSecretBaselineStore creates a new secret baseline store.
+func NewSecretBaselineStore(db *DB) *SecretBaselineStore {
+	return &SecretBaselineStore{db: db}
+}
+
+// Add records a finding in the project's baseline. A finding already in the
+// baseline is left unchanged.
+func (s *SecretBaselineStore) Add(ctx context.Context, entry *models.SecretBaselineEntry) error {
+	if entry.ID == "" {
+		entry.ID = generateUUID()
+	}
+	entry.CreatedAt = time.Now().UTC()
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO secret_baselines (id, project_slug, fingerprint, finding_type, file_path, reason, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (project_slug, fingerprint) DO NOTHING
+	`, entry.ID, entry.ProjectSlug, entry.Fingerprint, entry.Type, entry.FilePath, entry.Reason, entry.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to add secret baseline entry: %w", err)
+	}
+	return nil
+}
+
+// List returns the project's baseline, oldest first.
+func (s *SecretBaselineStore) List(ctx context.Context, projectSlug string) ([]models.SecretBaselineEntry, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, project_slug, fingerprint, finding_type, file_path, reason, created_at
+		FROM secret_baselines
+		WHERE project_slug = $1
+		ORDER BY created_at
+	`, projectSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secret baseline: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []models.SecretBaselineEntry
+	for rows.Next() {
+		var entry models.SecretBaselineEntry
+		var filePath, reason sql.NullString
+		if err := rows.Scan(&entry.ID, &entry.ProjectSlug, &entry.Fingerprint, &entry.Type, &filePath, &reason, &entry.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan secret baseline entry: %w", err)
+		}
+		entry.FilePath = filePath.String
+		entry.Reason = reason.String
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// Remove deletes a finding from the project's baseline. It reports whether the
+// finding was in the baseline.
+func (s *SecretBaselineStore) Remove(ctx context.Context, projectSlug, fingerprint string) (bool, error) {
+	result, err := s.db.ExecContext(ctx, `
+		DELETE FROM secret_baselines WHERE project_slug = $1 AND fingerprint = $2
+	`, projectSlug, fingerprint)
+	if err != nil {
+		return false, fmt.Errorf("failed to remove secret baseline entry: %w", err)
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to remove secret baseline entry: %w", err)
+	}
+	return n > 0, nil
+}
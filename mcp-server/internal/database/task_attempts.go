@@ -89,6 +89,37 @@ func (s *TaskAttemptStore) GetRecentAttemptCount(ctx context.Context, sessionID,
 	return count, nil
 }
 
+// GetRecentAttemptCountsByCategory returns the number of attempts in the last 30
+// minutes for each error category. Attempts without a category count as other.
+func (s *TaskAttemptStore) GetRecentAttemptCountsByCategory(ctx context.Context, sessionID, taskID string) (map[string]int, error) {
+	query := `
+		SELECT COALESCE(error_category, 'other'), COUNT(*) FROM task_attempts
+		WHERE session_id = $1
+		AND ($2 = '' OR task_id = $2)
+		AND attempted_at > NOW() - INTERVAL '30 minutes'
+		AND resolution = 'pending'
+		GROUP BY 1
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, sessionID, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count attempts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var category string
+		var count int
+		if err := rows.Scan(&category, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan attempt count: %w", err)
+		}
+		counts[category] = count
+	}
+
+	return counts, rows.Err()
+}
+
 // GetPendingAttempts returns all pending attempts for a session/task
 func (s *TaskAttemptStore) GetPendingAttempts(ctx context.Context, sessionID, taskID string) ([]*models.TaskAttempt, error) {
 	query := `
@@ -152,12 +183,16 @@ type ThreeStrikesStatus struct {
 	RemainingStrikes int        `json:"remaining_strikes"`
 	ShouldHalt       bool       `json:"should_halt"`
 	ShouldEscalate   bool       `json:"should_escalate"`
+	ErrorCategory    string     `json:"error_category,omitempty"`
+	LimitSource      string     `json:"limit_source"`
 	LastAttemptAt    *time.Time `json:"last_attempt_at,omitempty"`
 }
 
-// GetThreeStrikesStatus returns the three strikes status for a session/task
-func (s *TaskAttemptStore) GetThreeStrikesStatus(ctx context.Context, sessionID, taskID string) (*ThreeStrikesStatus, error) {
-	count, err := s.GetRecentAttemptCount(ctx, sessionID, taskID)
+// GetThreeStrikesStatus returns the three strikes status for a session/task. The
+// limit for category is applied; without a category, the limit closest to being
+// reached is reported.
+func (s *TaskAttemptStore) GetThreeStrikesStatus(ctx context.Context, sessionID, taskID, category string, limits models.ThreeStrikesLimits) (*ThreeStrikesStatus, error) {
+	counts, err := s.GetRecentAttemptCountsByCategory(ctx, sessionID, taskID)
 	if err != nil {
 		return nil, err
 	}
@@ -177,18 +212,16 @@ func (s *TaskAttemptStore) GetThreeStrikesStatus(ctx context.Context, sessionID,
 		lastAttemptAt = &t
 	}
 
-	const maxAttempts = 3
-	remaining := maxAttempts - count
-	if remaining < 0 {
-		remaining = 0
-	}
+	check := limits.Check(counts, category)
 
 	return &ThreeStrikesStatus{
-		AttemptsCount:    count,
-		MaxAttempts:      maxAttempts,
-		RemainingStrikes: remaining,
-		ShouldHalt:       count >= maxAttempts,
-		ShouldEscalate:   count >= maxAttempts,
+		AttemptsCount:    check.AttemptsCount,
+		MaxAttempts:      check.MaxAttempts,
+		RemainingStrikes: check.Remaining(),
+		ShouldHalt:       check.Remaining() == 0,
+		ShouldEscalate:   check.Remaining() == 0,
+		ErrorCategory:    check.ErrorCategory,
+		LimitSource:      check.LimitSource,
 		LastAttemptAt:    lastAttemptAt,
 	}, nil
 }
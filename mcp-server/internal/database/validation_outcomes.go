@@ -0,0 +1,64 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// ValidationOutcomeStore handles the per-project history of validation outcomes.
+type ValidationOutcomeStore struct {
+	db *DB
+}
+
+// NewValidationOutcomeStore creates a new validation outcome store.
+func NewValidationOutcomeStore(db *DB) *ValidationOutcomeStore {
+	return &ValidationOutcomeStore{db: db}
+}
+
+// Record stores the outcome of a validation.
+func (s *ValidationOutcomeStore) Record(ctx context.Context, outcome *models.ValidationOutcome) error {
+	if outcome.ID == "" {
+		outcome.ID = generateUUID()
+	}
+	if outcome.RecordedAt.IsZero() {
+		outcome.RecordedAt = time.Now().UTC()
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO validation_outcomes (id, project_slug, tool_name, valid, critical_count, error_count, warning_count, info_count, recorded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, outcome.ID, outcome.ProjectSlug, outcome.ToolName, outcome.Valid,
+		outcome.Critical, outcome.Errors, outcome.Warnings, outcome.Info, outcome.RecordedAt)
+	if err != nil {
+		return fmt.Errorf("failed to record validation outcome: %w", err)
+	}
+	return nil
+}
+
+// ListSince returns the project's outcomes recorded at or after since, oldest first.
+func (s *ValidationOutcomeStore) ListSince(ctx context.Context, projectSlug string, since time.Time) ([]models.ValidationOutcome, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, project_slug, tool_name, valid, critical_count, error_count, warning_count, info_count, recorded_at
+		FROM validation_outcomes
+		WHERE project_slug = $1 AND recorded_at >= $2
+		ORDER BY recorded_at
+	`, projectSlug, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list validation outcomes: %w", err)
+	}
+	defer rows.Close()
+
+	var outcomes []models.ValidationOutcome
+	for rows.Next() {
+		var o models.ValidationOutcome
+		if err := rows.Scan(&o.ID, &o.ProjectSlug, &o.ToolName, &o.Valid,
+			&o.Critical, &o.Errors, &o.Warnings, &o.Info, &o.RecordedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan validation outcome: %w", err)
+		}
+		outcomes = append(outcomes, o)
+	}
+	return outcomes, rows.Err()
+}
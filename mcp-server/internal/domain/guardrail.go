@@ -149,6 +149,7 @@ type PatternMatcher interface {
 type ValidationResult struct {
 	Passed     bool        `json:"passed"`
 	Violations []Violation `json:"violations"`
+	Warnings   []string    `json:"warnings,omitempty"` // concerns that do not fail the check
 	CheckedAt  time.Time   `json:"checked_at"`
 }
 
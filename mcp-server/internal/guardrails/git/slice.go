@@ -65,15 +65,66 @@ func (e *Evaluator) Evaluate(ctx context.Context, command string) ([]domain.Viol
 	return violations, nil
 }
 
-// DetectForcePush detects force push operations
-func (e *Evaluator) DetectForcePush(command string) bool {
-	forceFlags := []string{"--force", "-f", "--force-with-lease", "-ff"}
-	for _, flag := range forceFlags {
-		if strings.Contains(command, flag) {
-			return true
+// ForceMode is how a git command overrides history on the remote or in the work tree
+type ForceMode int
+
+const (
+	// ForceNone does not force
+	ForceNone ForceMode = iota
+	// ForceWithLease forces only if the remote branch is where it was last fetched
+	ForceWithLease
+	// ForcePlain forces unconditionally: --force, -f (alone or combined) or a "+" push refspec
+	ForcePlain
+)
+
+// forceWithLeaseWarning is reported for --force-with-lease, which is allowed
+const forceWithLeaseWarning = "Push uses --force-with-lease: remote history is rewritten, but only if no one else has pushed since your last fetch"
+
+// ParseForceMode reads the force flags of a git command. Plain force wins over
+// --force-with-lease, as it does in git.
+func ParseForceMode(command string) ForceMode {
+	mode := ForceNone
+	isPush := false
+	for _, field := range strings.Fields(command) {
+		switch {
+		case field == "--force":
+			return ForcePlain
+		case field == "--force-with-lease" || strings.HasPrefix(field, "--force-with-lease="):
+			mode = ForceWithLease
+		case len(field) > 1 && field[0] == '-' && field[1] != '-' && strings.ContainsRune(field[1:], 'f'):
+			return ForcePlain
+		case isPush && len(field) > 1 && field[0] == '+':
+			return ForcePlain
+		case field == "push":
+			isPush = true
 		}
 	}
-	return false
+	return mode
+}
+
+// DetectForcePush detects unconditional force operations; --force-with-lease is not one
+func (e *Evaluator) DetectForcePush(command string) bool {
+	return ParseForceMode(command) == ForcePlain
+}
+
+// ApplyForceCheck adds the force-operation outcome to result: plain force, or a force
+// flag the command does not explain, is a critical violation; --force-with-lease
+// passes with a warning
+func ApplyForceCheck(result *domain.ValidationResult, command string, isForceFlag bool) {
+	switch mode := ParseForceMode(command); {
+	case mode == ForcePlain || (isForceFlag && mode == ForceNone):
+		result.Violations = append(result.Violations, domain.Violation{
+			RuleID:    "PREVENT-FORCE-001",
+			RuleName:  "No Force Operation",
+			Severity:  domain.SeverityCritical,
+			Message:   "Force operations are not allowed. Use --force-with-lease or standard push instead.",
+			Category:  "git",
+			Timestamp: time.Now(),
+		})
+		result.Passed = false
+	case mode == ForceWithLease:
+		result.Warnings = append(result.Warnings, forceWithLeaseWarning)
+	}
 }
 
 // Store handles data access for git rules
@@ -128,32 +179,10 @@ func (h *Handler) HandleWithForceCheck(ctx context.Context, command string, isFo
 		return nil, err
 	}
 
-	// Auto-detect force flag if not explicitly provided
-	shouldCheck := isForceFlag || h.hasForceFlag(command)
-	if shouldCheck {
-		result.Violations = append(result.Violations, domain.Violation{
-			RuleID:   "PREVENT-FORCE-001",
-			RuleName: "No Force Operation",
-			Severity: domain.SeverityCritical,
-			Message:  "Force operations are not allowed. Use --force-with-lease or standard push instead.",
-			Category: "git",
-			Timestamp: time.Now(),
-		})
-	}
-
+	ApplyForceCheck(result, command, isForceFlag)
 	return result, nil
 }
 
-func (h *Handler) hasForceFlag(command string) bool {
-	forceFlags := []string{"--force", "-f", "--force-with-lease", "-ff"}
-	for _, flag := range forceFlags {
-		if strings.Contains(command, flag) {
-			return true
-		}
-	}
-	return false
-}
-
 func (h *Handler) loadRules(ctx context.Context) ([]Rule, error) {
 	rules, err := h.cache.GetGitRules(ctx)
 	if err == nil && len(rules) > 0 {
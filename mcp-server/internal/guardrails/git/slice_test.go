@@ -0,0 +1,30 @@
+package git
+
+import "testing"
+
+func TestParseForceMode(t *testing.T) {
+	tests := []struct {
+		command string
+		want    ForceMode
+	}{
+		{"git push origin feature", ForceNone},
+		{"git push origin feature-fix", ForceNone},
+		{"git merge --ff-only main", ForceNone},
+		{"git push --force-with-lease origin feature", ForceWithLease},
+		{"git push --force-with-lease=feature:abc123 origin feature", ForceWithLease},
+		{"git push --force origin feature", ForcePlain},
+		{"git push -f", ForcePlain},
+		{"git push -uf origin feature", ForcePlain},
+		{"git push origin +feature", ForcePlain},
+		{"git push --force-with-lease --force origin feature", ForcePlain},
+		{"git clean -fd", ForcePlain},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			if got := ParseForceMode(tt.command); got != tt.want {
+				t.Errorf("ParseForceMode(%q) = %v, want %v", tt.command, got, tt.want)
+			}
+		})
+	}
+}
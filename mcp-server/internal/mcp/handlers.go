@@ -19,6 +19,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/thearchitectit/guardrail-mcp/internal/domain"
+	gitguard "github.com/thearchitectit/guardrail-mcp/internal/guardrails/git"
 )
 
 // GuardrailHandlers contains CQRS-wired handlers for MCP tools
@@ -99,17 +100,8 @@ func (h *GuardrailHandlers) ValidateGit(ctx context.Context, command string, isF
 		return errorResult(fmt.Sprintf(`{"error":"validation failed: %s"}`, err.Error())), nil
 	}
 
-	// Add force push violation via domain model (not hardcoded in handler)
-	if isForce {
-		result.Violations = append(result.Violations, domain.Violation{
-			RuleID:   "PREVENT-FORCE-001",
-			RuleName: "No Force Operation",
-			Severity: domain.SeverityCritical,
-			Message:  "Force operations are not allowed. Use --force-with-lease or standard push instead.",
-			Category: "git",
-			Timestamp: time.Now(),
-		})
-	}
+	// Plain force is a violation; --force-with-lease passes with a warning
+	gitguard.ApplyForceCheck(result, command, isForce)
 
 	return &mcp.CallToolResult{
 		Content: []interface{}{mcp.TextContent{
@@ -172,7 +164,7 @@ func formatValidationResult(result *domain.ValidationResult, command string) str
 	sb.Grow(512)
 
 	if result.Passed {
-		sb.WriteString(`{"valid":true,"violations":[],"meta":{"checked_at":"`)
+		sb.WriteString(`{"valid":true,"violations":[]`)
 	} else {
 		sb.WriteString(`{"valid":false,"violations":[`)
 		for i, v := range result.Violations {
@@ -181,9 +173,22 @@ func formatValidationResult(result *domain.ValidationResult, command string) str
 			}
 			sb.WriteString(formatViolation(v))
 		}
-		sb.WriteString(`],"meta":{"checked_at":"`)
+		sb.WriteString(`]`)
+	}
+	if len(result.Warnings) > 0 {
+		sb.WriteString(`,"warnings":[`)
+		for i, w := range result.Warnings {
+			if i > 0 {
+				sb.WriteString(",")
+			}
+			sb.WriteString(`"`)
+			jsonEscape(&sb, w)
+			sb.WriteString(`"`)
+		}
+		sb.WriteString(`]`)
 	}
 
+	sb.WriteString(`,"meta":{"checked_at":"`)
 	sb.WriteString(result.CheckedAt.Format(time.RFC3339))
 	sb.WriteString(`","command_analyzed":"`)
 	jsonEscape(&sb, command)
@@ -214,7 +219,7 @@ func formatValidationResultWithFile(result *domain.ValidationResult, filePath st
 	jsonEscape(&sb, filePath)
 	sb.WriteString(`","changes_size":`)
 	sb.WriteString(strconv.Itoa(contentSize))
-	sb.WriteString(`"}}`)
+	sb.WriteString(`}}`)
 
 	return sb.String()
 }
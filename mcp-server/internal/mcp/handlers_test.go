@@ -0,0 +1,65 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/domain"
+)
+
+// stubGuardrailService reports no rule violations for git commands
+type stubGuardrailService struct {
+	domain.GuardrailService
+}
+
+func (stubGuardrailService) EvaluateGit(ctx context.Context, command string) ([]domain.Violation, error) {
+	return nil, nil
+}
+
+// TestGuardrailHandlers_ValidateGitForce tests that --force-with-lease passes with a warning and plain force fails
+func TestGuardrailHandlers_ValidateGitForce(t *testing.T) {
+	h := &GuardrailHandlers{evalGitHandler: domain.NewEvaluateGitHandler(stubGuardrailService{})}
+
+	tests := []struct {
+		name         string
+		command      string
+		isForce      bool
+		wantValid    bool
+		wantWarnings int
+	}{
+		{name: "force with lease allowed", command: "git push --force-with-lease origin feature", isForce: true, wantValid: true, wantWarnings: 1},
+		{name: "short force rejected", command: "git push -f", wantValid: false},
+		{name: "long force rejected", command: "git push --force origin feature", wantValid: false},
+		{name: "force flag without force in command rejected", command: "git push origin feature", isForce: true, wantValid: false},
+		{name: "plain push allowed", command: "git push origin feature", wantValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := h.ValidateGit(context.Background(), tt.command, tt.isForce)
+			if err != nil {
+				t.Fatalf("ValidateGit() error = %v", err)
+			}
+			var result struct {
+				Valid      bool              `json:"valid"`
+				Violations []json.RawMessage `json:"violations"`
+				Warnings   []string          `json:"warnings"`
+			}
+			text := res.Content[0].(mcp.TextContent).Text
+			if err := json.Unmarshal([]byte(text), &result); err != nil {
+				t.Fatalf("invalid JSON %s: %v", text, err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, text)
+			}
+			if !tt.wantValid && len(result.Violations) == 0 {
+				t.Errorf("result = %s, want a force violation", text)
+			}
+			if len(result.Warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", result.Warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
@@ -19,3 +19,15 @@ func (s *MCPServer) getRepoPath() string {
 	}
 	return path
 }
+
+// stringSliceArg extracts a string array argument, skipping non-string elements
+func stringSliceArg(args map[string]interface{}, key string) []string {
+	raw, _ := args[key].([]interface{})
+	values := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if str, ok := v.(string); ok {
+			values = append(values, str)
+		}
+	}
+	return values
+}
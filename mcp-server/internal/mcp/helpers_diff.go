@@ -0,0 +1,208 @@
+package mcp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// diffLine is a line of the post-change file view reconstructed from a unified diff
+type diffLine struct {
+	File   string // path from the "+++ b/..." header, empty if unknown
+	Number int    // line number in the new file (1-based)
+	Text   string // line content without the diff prefix
+	Added  bool   // true if the line was added, false for context lines
+}
+
+var hunkHeaderPattern = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// hunkRangePattern captures the old and new line counts of a hunk header; a
+// missing count means one line
+var hunkRangePattern = regexp.MustCompile(`^@@ -\d+(?:,(\d+))? \+\d+(?:,(\d+))? @@`)
+
+// parseDiffLines returns the added and context lines of a unified diff in new-file
+// order. Removed lines are dropped. Input without any diff markers is treated as
+// plain file content where every line is added.
+func parseDiffLines(diff string) []diffLine {
+	lines := strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n")
+
+	isDiff := false
+	for _, line := range lines {
+		if strings.HasPrefix(line, "@@") || strings.HasPrefix(line, "+++ ") ||
+			(strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "++")) {
+			isDiff = true
+			break
+		}
+	}
+
+	result := make([]diffLine, 0, len(lines))
+	if !isDiff {
+		for i, line := range lines {
+			result = append(result, diffLine{Number: i + 1, Text: line, Added: true})
+		}
+		return result
+	}
+
+	currentFile := ""
+	lineNum := 0
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++ "):
+			currentFile = strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, "+++ ")), "b/")
+			if currentFile == "/dev/null" {
+				currentFile = ""
+			}
+		case strings.HasPrefix(line, "--- "), strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "):
+			// File headers carry no line content
+		case strings.HasPrefix(line, "@@"):
+			if m := hunkHeaderPattern.FindStringSubmatch(line); m != nil {
+				start, _ := strconv.Atoi(m[1])
+				lineNum = start - 1
+			}
+		case strings.HasPrefix(line, "+"):
+			lineNum++
+			result = append(result, diffLine{File: currentFile, Number: lineNum, Text: line[1:], Added: true})
+		case strings.HasPrefix(line, "-"), strings.HasPrefix(line, `\`):
+			// Removed lines and "\ No newline at end of file" are not in the new file
+		case strings.HasPrefix(line, " "):
+			lineNum++
+			result = append(result, diffLine{File: currentFile, Number: lineNum, Text: line[1:]})
+		}
+	}
+	return result
+}
+
+// addedLines returns only the added lines of a unified diff
+func addedLines(diff string) []diffLine {
+	all := parseDiffLines(diff)
+	added := make([]diffLine, 0, len(all))
+	for _, l := range all {
+		if l.Added {
+			added = append(added, l)
+		}
+	}
+	return added
+}
+
+// diffFile is one file's section of a unified diff
+type diffFile struct {
+	Path    string   // path from the "+++ b/..." header, or from "--- a/..." for a deleted file
+	Added   []string // added lines without the "+" prefix
+	Removed []string // removed lines without the "-" prefix
+}
+
+// parseDiffFiles splits a unified diff into the files it changes, in order. Each
+// hunk header says how many old and new lines follow it, so inside a hunk a removed
+// line starting with "-- " or an added one starting with "++ " is counted as a
+// change rather than taken for a file header. Changes after a file header but
+// outside any hunk, as in hand-written diffs without "@@" lines, still count.
+func parseDiffFiles(diff string) []diffFile {
+	var files []diffFile
+	oldPath := ""
+	oldLeft, newLeft := 0, 0
+	record := func(line string) {
+		if len(files) == 0 {
+			return
+		}
+		current := &files[len(files)-1]
+		if line[0] == '+' {
+			current.Added = append(current.Added, line[1:])
+		} else {
+			current.Removed = append(current.Removed, line[1:])
+		}
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(diff, "\r\n", "\n"), "\n") {
+		if oldLeft > 0 || newLeft > 0 {
+			switch {
+			case strings.HasPrefix(line, "+"):
+				newLeft--
+				record(line)
+				continue
+			case strings.HasPrefix(line, "-"):
+				oldLeft--
+				record(line)
+				continue
+			case line == "" || strings.HasPrefix(line, " "):
+				oldLeft--
+				newLeft--
+				continue
+			case strings.HasPrefix(line, `\`):
+				// "\ No newline at end of file"
+				continue
+			}
+			// Any other line ends a hunk that was cut short
+			oldLeft, newLeft = 0, 0
+		}
+
+		switch {
+		case strings.HasPrefix(line, "--- "):
+			oldPath = strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, "--- ")), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			path := strings.TrimPrefix(strings.TrimSpace(strings.TrimPrefix(line, "+++ ")), "b/")
+			if path == "/dev/null" {
+				path = oldPath
+			}
+			files = append(files, diffFile{Path: path})
+		case strings.HasPrefix(line, "@@"):
+			if m := hunkRangePattern.FindStringSubmatch(line); m != nil {
+				oldLeft, newLeft = hunkLineCount(m[1]), hunkLineCount(m[2])
+			}
+		case strings.HasPrefix(line, "+"), strings.HasPrefix(line, "-"):
+			record(line)
+		}
+	}
+	return files
+}
+
+// hunkLineCount reads a line count captured from a hunk header
+func hunkLineCount(count string) int {
+	if count == "" {
+		return 1
+	}
+	n, _ := strconv.Atoi(count)
+	return n
+}
+
+// changedFiles returns the distinct file paths touched by a unified diff, in order.
+// Deleted files are reported by their old path.
+func changedFiles(diff string) []string {
+	files := []string{}
+	seen := make(map[string]bool)
+	for _, file := range parseDiffFiles(diff) {
+		if file.Path != "" && file.Path != "/dev/null" && !seen[file.Path] {
+			seen[file.Path] = true
+			files = append(files, file.Path)
+		}
+	}
+	return files
+}
+
+// newDiffScanResult builds a DiffScanResult for a diff scanning tool. The diff is
+// valid unless an error-severity violation was found; warnings are reported only.
+func newDiffScanResult(subject string, violations []models.DiffViolation, scanned int) models.DiffScanResult {
+	errors := 0
+	for _, v := range violations {
+		if v.Severity == "error" {
+			errors++
+		}
+	}
+
+	result := models.DiffScanResult{
+		Valid:        errors == 0,
+		Violations:   violations,
+		LinesScanned: scanned,
+	}
+	switch {
+	case len(violations) == 0:
+		result.Message = fmt.Sprintf("No %s issues found in %d added lines", subject, scanned)
+	case errors == 0:
+		result.Message = fmt.Sprintf("%d %s warning(s) found", len(violations), subject)
+	default:
+		result.Message = fmt.Sprintf("%d %s violation(s) found (%d blocking)", len(violations), subject, errors)
+	}
+	return result
+}
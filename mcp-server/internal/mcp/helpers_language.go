@@ -0,0 +1,106 @@
+package mcp
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// languagePatterns recognise declarations in source lines (without a diff prefix)
+// of one language
+type languagePatterns struct {
+	function *regexp.Regexp // function or method declaration
+	notFunc  *regexp.Regexp // control statements the function pattern would otherwise match; may be nil
+	typeHint *regexp.Regexp // type annotation on a name; nil for languages without annotations
+	class    *regexp.Regexp // class, struct, interface or trait declaration
+	imp      *regexp.Regexp // import of a package or module
+}
+
+// isFunction reports whether line declares a function or method
+func (p languagePatterns) isFunction(line string) bool {
+	return p.function.MatchString(line) && (p.notFunc == nil || !p.notFunc.MatchString(line))
+}
+
+// hasTypeHint reports whether line annotates a name with a type
+func (p languagePatterns) hasTypeHint(line string) bool {
+	return p.typeHint != nil && p.typeHint.MatchString(line)
+}
+
+// cLikeControlPattern matches statements that look like method declarations in
+// Java and JavaScript, e.g. "} else if (ok) {"
+var cLikeControlPattern = regexp.MustCompile(`^\s*(?:}\s*)?(?:if|else|for|while|switch|catch|return|new|throw|do|try|synchronized)\b`)
+
+// defaultLanguagePatterns is used when no language is given. It matches declarations
+// of any of the supported languages, at the cost of some false positives.
+var defaultLanguagePatterns = languagePatterns{
+	function: regexp.MustCompile(`^\s*(?:(?:pub(?:\([^)]*\))?|export|default|async|public|protected|private|static|final)\s+)*(?:func|def|function|fn)\b\s*(?:\([^)]*\)\s*)?\*?\s*\w+`),
+	typeHint: regexp.MustCompile(`^\s*(?:(?:let|const|var|mut)\s+)*\w+\??\s*:\s*[\w\[&(]|\)\s*(?:->|:)\s*\w`),
+	class:    regexp.MustCompile(`\b(?:class|struct|interface|trait)\s+\w+|^\s*type\s+\w+\s+(?:struct|interface)\b`),
+	imp:      regexp.MustCompile(`^\s*(?:import|from\s+\S+\s+import|use)\s+`),
+}
+
+// languagePatternSets holds the per-language patterns, keyed by language name
+var languagePatternSets = map[string]languagePatterns{
+	"go": {
+		function: regexp.MustCompile(`^\s*func\s+(?:\([^)]*\)\s*)?\w+\s*[\[(]`),
+		// Go has no annotations; struct fields and tags are not type changes
+		class: regexp.MustCompile(`^\s*type\s+\w+(?:\[[^\]]*\])?\s+(?:struct|interface)\b`),
+		imp:   regexp.MustCompile(`^\s*import\b|^\s*(?:[\w.]+\s+)?"[\w.\-]+(?:/[\w.\-]+)*"\s*$`),
+	},
+	"python": {
+		function: regexp.MustCompile(`^\s*(?:async\s+)?def\s+\w+\s*\(`),
+		typeHint: regexp.MustCompile(`^\s*(?:self\.)?\w+\s*:\s*[\w\[]|\)\s*->\s*\w|[(,]\s*\w+\s*:\s*[\w\[]`),
+		class:    regexp.MustCompile(`^\s*class\s+\w+`),
+		imp:      regexp.MustCompile(`^\s*(?:import|from)\s+[\w.]`),
+	},
+	"javascript": {
+		function: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*\w+\s*\(|^\s*(?:export\s+)?(?:const|let|var)\s+\w+\s*=\s*(?:async\s+)?(?:function\b|\([^)]*\)\s*=>|\w+\s*=>)|^\s*(?:static\s+|async\s+|get\s+|set\s+)*\w+\s*\([^)]*\)\s*\{\s*$`),
+		notFunc:  cLikeControlPattern,
+		class:    regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?class\s+\w+`),
+		imp:      regexp.MustCompile(`^\s*import\b|\brequire\(\s*['"]`),
+	},
+	"typescript": {
+		function: regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:async\s+)?function\s*\*?\s*\w+\s*[<(]|^\s*(?:export\s+)?(?:const|let|var)\s+\w+(?:\s*:\s*[^=]+)?\s*=\s*(?:async\s+)?(?:function\b|\([^)]*\)(?:\s*:\s*[^=]+)?\s*=>|\w+\s*=>)|^\s*(?:(?:public|private|protected|static|async|readonly|abstract|override)\s+)*\w+\s*(?:<[^>]*>)?\([^)]*\)\s*(?::\s*[^{]+)?\{\s*$`),
+		notFunc:  cLikeControlPattern,
+		typeHint: regexp.MustCompile(`^\s*(?:export\s+)?(?:(?:const|let|var|readonly|public|private|protected)\s+)*\w+\??\s*:\s*[\w\[{(<]|\)\s*:\s*[\w\[{(<]`),
+		class:    regexp.MustCompile(`^\s*(?:export\s+)?(?:default\s+)?(?:abstract\s+)?(?:class|interface)\s+\w+`),
+		imp:      regexp.MustCompile(`^\s*import\b|\brequire\(\s*['"]`),
+	},
+	"rust": {
+		function: regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:(?:const|async|unsafe)\s+)*(?:extern\s+"[^"]*"\s+)?fn\s+\w+`),
+		typeHint: regexp.MustCompile(`^\s*let\s+(?:mut\s+)?\w+\s*:\s*[\w&\[(]|^\s*(?:pub(?:\([^)]*\))?\s+)?\w+\s*:\s*[\w&\[(]|[(,]\s*(?:mut\s+)?\w+\s*:\s*[\w&\[(]|\)\s*->\s*[\w&\[(]`),
+		class:    regexp.MustCompile(`^\s*(?:pub(?:\([^)]*\))?\s+)?(?:struct|enum|trait|union)\s+\w+`),
+		imp:      regexp.MustCompile(`^\s*(?:pub\s+)?(?:use|extern\s+crate)\s+`),
+	},
+	"java": {
+		function: regexp.MustCompile(`^\s*(?:@\w+\s+)*(?:(?:public|protected|private|static|final|abstract|synchronized|native|default)\s+)*(?:<[^>]*>\s+)?[\w<>\[\],.? ]+\s+\w+\s*\([^)]*\)\s*(?:throws\s+[\w.,\s]+)?\{?\s*$`),
+		notFunc:  cLikeControlPattern,
+		class:    regexp.MustCompile(`^\s*(?:(?:public|protected|private|static|final|abstract|sealed)\s+)*(?:class|interface|enum|record)\s+\w+`),
+		imp:      regexp.MustCompile(`^\s*import\s+`),
+	},
+}
+
+// patternsForLanguage returns the patterns for a language name, or the default
+// multi-language patterns when language is empty
+func patternsForLanguage(language string) (languagePatterns, error) {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		return defaultLanguagePatterns, nil
+	}
+	patterns, ok := languagePatternSets[language]
+	if !ok {
+		return defaultLanguagePatterns, fmt.Errorf("unsupported language %q (supported: %s)", language, strings.Join(supportedLanguages(), ", "))
+	}
+	return patterns, nil
+}
+
+// supportedLanguages returns the language names accepted by patternsForLanguage
+func supportedLanguages() []string {
+	names := make([]string, 0, len(languagePatternSets))
+	for name := range languagePatternSets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,154 @@
+package mcp
+
+// Line edit operations
+const (
+	lineKept    = ' '
+	lineRemoved = '-'
+	lineAdded   = '+'
+)
+
+// lineEdit is one line of a line-level diff
+type lineEdit struct {
+	op   byte // lineKept, lineRemoved or lineAdded
+	text string
+}
+
+// Diff size limits. Tracing the edit path back keeps the furthest points of every
+// edit distance, so memory grows with the square of the distance searched; past
+// either limit the changed region is reported as a full rewrite instead.
+const (
+	maxDiffLines = 20000 // lines on either side, after the common prefix and suffix
+	maxDiffEdits = 1000  // edit distance searched
+)
+
+// diffLines returns a shortest edit script turning a into b, using Myers' O(ND)
+// algorithm. Unlike comparing line sets, duplicate lines are matched one to one and
+// a moved block shows up as removed at its old position and added at its new one.
+// Inputs over maxDiffLines or maxDiffEdits give every changed line as removed and
+// re-added.
+func diffLines(a, b []string) []lineEdit {
+	// Common prefix and suffix need no search
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits := make([]lineEdit, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		edits = append(edits, lineEdit{op: lineKept, text: line})
+	}
+	changedA, changedB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	middle, ok := []lineEdit(nil), false
+	if len(changedA) <= maxDiffLines && len(changedB) <= maxDiffLines {
+		middle, ok = myersDiff(changedA, changedB, maxDiffEdits)
+	}
+	if !ok {
+		middle = rewriteEdits(changedA, changedB)
+	}
+	edits = append(edits, middle...)
+	for _, line := range a[len(a)-suffix:] {
+		edits = append(edits, lineEdit{op: lineKept, text: line})
+	}
+	return edits
+}
+
+// rewriteEdits is the edit script that removes all of a and adds all of b
+func rewriteEdits(a, b []string) []lineEdit {
+	edits := make([]lineEdit, 0, len(a)+len(b))
+	for _, line := range a {
+		edits = append(edits, lineEdit{op: lineRemoved, text: line})
+	}
+	for _, line := range b {
+		edits = append(edits, lineEdit{op: lineAdded, text: line})
+	}
+	return edits
+}
+
+// myersDiff finds the edit script by walking diagonals of the edit graph. After each
+// edit distance d it keeps the furthest points reached on diagonals -d..d, the only
+// ones the next distance reads, so that the path can be traced back afterwards. It
+// gives up, returning false, when the distance would exceed maxEdits.
+func myersDiff(a, b []string, maxEdits int) ([]lineEdit, bool) {
+	n, m := len(a), len(b)
+	maxD := n + m
+	if maxD > maxEdits {
+		maxD = maxEdits
+	}
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // step down: insert from b
+			} else {
+				x = v[offset+k-1] + 1 // step right: delete from a
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackEdits(a, b, trace, d), true
+			}
+		}
+
+		window := make([]int, 2*d+1)
+		copy(window, v[offset-d:offset+d+1])
+		trace = append(trace, window)
+	}
+	return nil, false
+}
+
+// backtrackEdits rebuilds the edit script from the furthest points recorded by
+// myersDiff; trace[d][k+d] is the furthest x on diagonal k after d edits
+func backtrackEdits(a, b []string, trace [][]int, d int) []lineEdit {
+	x, y := len(a), len(b)
+	reversed := []lineEdit{}
+
+	for ; d > 0; d-- {
+		prev := trace[d-1]
+		at := func(k int) int { return prev[k+d-1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			reversed = append(reversed, lineEdit{op: lineKept, text: a[x]})
+		}
+		if x == prevX {
+			y--
+			reversed = append(reversed, lineEdit{op: lineAdded, text: b[y]})
+		} else {
+			x--
+			reversed = append(reversed, lineEdit{op: lineRemoved, text: a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		reversed = append(reversed, lineEdit{op: lineKept, text: a[x]})
+	}
+
+	edits := make([]lineEdit, len(reversed))
+	for i, edit := range reversed {
+		edits[len(reversed)-1-i] = edit
+	}
+	return edits
+}
@@ -0,0 +1,130 @@
+package mcp
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// TestDiffLines tests the line-level edit script, including duplicate and moved lines
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        string
+		wantAdded   int
+		wantRemoved int
+	}{
+		{name: "identical", a: "a\nb\nc", b: "a\nb\nc"},
+		{name: "replaced line", a: "a\nb\nc", b: "a\nx\nc", wantAdded: 1, wantRemoved: 1},
+		{name: "duplicate line added", a: "a\nb", b: "a\na\nb", wantAdded: 1},
+		{name: "duplicate lines removed", a: "x\nx\nx", b: "x", wantRemoved: 2},
+		{name: "block moved", a: "a\nb\nc\nd", b: "c\nd\na\nb", wantAdded: 2, wantRemoved: 2},
+		{name: "empty original", a: "", b: "a\nb", wantAdded: 2, wantRemoved: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edits := diffLines(strings.Split(tt.a, "\n"), strings.Split(tt.b, "\n"))
+
+			var rebuiltA, rebuiltB []string
+			added, removed := 0, 0
+			for _, edit := range edits {
+				switch edit.op {
+				case lineAdded:
+					added++
+					rebuiltB = append(rebuiltB, edit.text)
+				case lineRemoved:
+					removed++
+					rebuiltA = append(rebuiltA, edit.text)
+				default:
+					rebuiltA = append(rebuiltA, edit.text)
+					rebuiltB = append(rebuiltB, edit.text)
+				}
+			}
+			if strings.Join(rebuiltA, "\n") != tt.a || strings.Join(rebuiltB, "\n") != tt.b {
+				t.Fatalf("edit script does not reproduce both sides: %+v", edits)
+			}
+			if added != tt.wantAdded || removed != tt.wantRemoved {
+				t.Errorf("added/removed = %d/%d, want %d/%d", added, removed, tt.wantAdded, tt.wantRemoved)
+			}
+		})
+	}
+}
+
+// numberedLines returns n lines "<prefix> 0", "<prefix> 1", ...
+func numberedLines(prefix string, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("%s %d", prefix, i)
+	}
+	return lines
+}
+
+// scatteredEdits replaces every nth line
+func scatteredEdits(lines []string, n int) []string {
+	edited := append([]string(nil), lines...)
+	for i := 0; i < len(edited); i += n {
+		edited[i] = "changed " + edited[i]
+	}
+	return edited
+}
+
+// TestDiffLines_LargeInputs tests that large diffs stay within bounded memory and
+// fall back to a full rewrite past the edit limit
+func TestDiffLines_LargeInputs(t *testing.T) {
+	tests := []struct {
+		name        string
+		a, b        []string
+		wantAdded   int
+		wantRemoved int
+	}{
+		{
+			name:        "nothing in common",
+			a:           numberedLines("old", 4000),
+			b:           numberedLines("new", 4000),
+			wantAdded:   4000,
+			wantRemoved: 4000,
+		},
+		{
+			name:        "over the line limit",
+			a:           numberedLines("old", maxDiffLines+1),
+			b:           numberedLines("new", 10),
+			wantAdded:   10,
+			wantRemoved: maxDiffLines + 1,
+		},
+		{
+			name:        "scattered edits in a large file",
+			a:           numberedLines("line", 20000),
+			b:           scatteredEdits(numberedLines("line", 20000), 100),
+			wantAdded:   200,
+			wantRemoved: 200,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			edits := diffLines(tt.a, tt.b)
+			runtime.ReadMemStats(&after)
+
+			if allocated := after.TotalAlloc - before.TotalAlloc; allocated > 64<<20 {
+				t.Errorf("diffLines allocated %dMB, want at most 64MB", allocated>>20)
+			}
+			added, removed := 0, 0
+			for _, edit := range edits {
+				switch edit.op {
+				case lineAdded:
+					added++
+				case lineRemoved:
+					removed++
+				}
+			}
+			if added != tt.wantAdded || removed != tt.wantRemoved {
+				t.Errorf("added/removed = %d/%d, want %d/%d", added, removed, tt.wantAdded, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/domain"
+)
+
+// readBeforeEditRuleID identifies violations for edits to files the session never read
+const readBeforeEditRuleID = "READ-BEFORE-EDIT"
+
+// fileReadChecker reports whether a session has recorded reading a file
+type fileReadChecker interface {
+	Exists(ctx context.Context, sessionID, filePath string) (bool, error)
+}
+
+// editPolicyFile is the project's .guardrails/edit-policy.json
+type editPolicyFile struct {
+	RequireReadBeforeEdit bool `json:"require_read_before_edit"`
+}
+
+// requireReadBeforeEdit reports whether the project's .guardrails/edit-policy.json
+// enables strict read-before-edit checking. The file is read on every call so that
+// edits take effect without a restart.
+func (s *MCPServer) requireReadBeforeEdit() bool {
+	data, err := os.ReadFile(filepath.Join(s.getRepoPath(), ".guardrails", "edit-policy.json"))
+	if err != nil {
+		return false
+	}
+	var policy editPolicyFile
+	if err := json.Unmarshal(data, &policy); err != nil {
+		slog.Warn("Ignoring invalid edit policy", "error", err)
+		return false
+	}
+	return policy.RequireReadBeforeEdit
+}
+
+// enforceReadBeforeEdit returns a denial for a guardrail_validate_file_edit call
+// when the project's edit policy requires read-before-edit and the session has no
+// read record for the file, or nil to validate the edit as usual. A file read store
+// outage is logged and does not block edits.
+func (s *MCPServer) enforceReadBeforeEdit(ctx context.Context, args map[string]interface{}) *mcp.CallToolResult {
+	filePath, _ := args["file_path"].(string)
+	if filePath == "" || s.fileReads == nil || !s.requireReadBeforeEdit() {
+		return nil
+	}
+	sessionToken, _ := args["session_token"].(string)
+
+	violation, err := checkReadBeforeEdit(ctx, s.fileReads, sessionToken, filePath)
+	if err != nil {
+		slog.Warn("Read-before-edit check failed", "file", filePath, "error", err)
+		return nil
+	}
+	if violation == nil {
+		return nil
+	}
+
+	newString, _ := args["new_string"].(string)
+	result := domain.NewValidationResult([]domain.Violation{*violation})
+	return &mcp.CallToolResult{
+		Content: []interface{}{mcp.TextContent{
+			Type: "text",
+			Text: formatValidationResultWithFile(result, filePath, len(newString)),
+		}},
+		IsError: true,
+	}
+}
+
+// checkReadBeforeEdit returns a READ-BEFORE-EDIT violation when the session has no
+// read record for filePath, or nil when it has. An edit without a session cannot be
+// verified and is a violation too.
+func checkReadBeforeEdit(ctx context.Context, reads fileReadChecker, sessionID, filePath string) (*domain.Violation, error) {
+	message := "File must be read in this session before it is edited"
+	if sessionID == "" {
+		message = "session_token is required to verify the file was read before editing"
+	} else {
+		read, err := reads.Exists(ctx, sessionID, filePath)
+		if err != nil {
+			return nil, err
+		}
+		if read {
+			return nil, nil
+		}
+	}
+
+	return &domain.Violation{
+		RuleID:       readBeforeEditRuleID,
+		RuleName:     "Read before edit",
+		Severity:     domain.SeverityHigh,
+		Message:      message,
+		Category:     "file_edit",
+		MatchedInput: filePath,
+		Timestamp:    time.Now(),
+	}, nil
+}
@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// writeEditPolicy writes the project's .guardrails/edit-policy.json and points the
+// server's repo path at it
+func writeEditPolicy(t *testing.T, requireRead bool) {
+	t.Helper()
+	repo := t.TempDir()
+	data, _ := json.Marshal(editPolicyFile{RequireReadBeforeEdit: requireRead})
+	if err := os.MkdirAll(filepath.Join(repo, ".guardrails"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(repo, ".guardrails", "edit-policy.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GUARDRAILS_REPO_PATH", repo)
+}
+
+// TestHandleToolCall_ReadBeforeEdit tests that guardrail_validate_file_edit denies
+// edits to files the session has not read when the edit policy requires it
+func TestHandleToolCall_ReadBeforeEdit(t *testing.T) {
+	tests := []struct {
+		name         string
+		required     bool
+		sessionToken string
+		filePath     string
+		wantDenied   bool
+	}{
+		{name: "read recorded", required: true, sessionToken: "sess-1", filePath: "main.go"},
+		{name: "read not recorded", required: true, sessionToken: "sess-1", filePath: "server.go", wantDenied: true},
+		{name: "read by another session", required: true, sessionToken: "sess-2", filePath: "main.go", wantDenied: true},
+		{name: "no session in strict mode", required: true, filePath: "main.go", wantDenied: true},
+		{name: "not recorded with strict mode off", required: false, sessionToken: "sess-1", filePath: "server.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writeEditPolicy(t, tt.required)
+			s := mockMCPServer()
+			s.fileReads = &fakeFileReads{reads: map[string][]models.FileRead{
+				"sess-1": {{SessionID: "sess-1", FilePath: "main.go"}},
+			}}
+			args := map[string]interface{}{
+				"file_path":  tt.filePath,
+				"old_string": "package main",
+				"new_string": "package server",
+			}
+			if tt.sessionToken != "" {
+				args["session_token"] = tt.sessionToken
+			}
+
+			if !tt.wantDenied {
+				if denied := s.enforceReadBeforeEdit(context.Background(), args); denied != nil {
+					t.Errorf("edit denied: %s", denied.Content[0].(mcp.TextContent).Text)
+				}
+				return
+			}
+
+			res, err := s.handleToolCall(context.Background(), "guardrail_validate_file_edit", args)
+			if err != nil {
+				t.Fatalf("handleToolCall() error = %v", err)
+			}
+			var result struct {
+				Valid      bool `json:"valid"`
+				Violations []struct {
+					RuleID string `json:"rule_id"`
+				} `json:"violations"`
+			}
+			text := res.Content[0].(mcp.TextContent).Text
+			if err := json.Unmarshal([]byte(text), &result); err != nil {
+				t.Fatalf("invalid JSON %s: %v", text, err)
+			}
+			if !res.IsError || result.Valid {
+				t.Errorf("result = %s, want the edit denied", text)
+			}
+			if len(result.Violations) != 1 || result.Violations[0].RuleID != readBeforeEditRuleID {
+				t.Errorf("violations = %s, want one %s violation", text, readBeforeEditRuleID)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+)
+
+// clientIPKey and sseSessionKey carry the caller's identity from the HTTP transport
+// into tool handlers through the request context
+type (
+	clientIPKey   struct{}
+	sseSessionKey struct{}
+)
+
+// withClientIP returns a context carrying the client IP of the HTTP request
+func withClientIP(ctx context.Context, ip string) context.Context {
+	return context.WithValue(ctx, clientIPKey{}, ip)
+}
+
+// withSSESession returns a context carrying the ID of the SSE session a message arrived on
+func withSSESession(ctx context.Context, sessionID string) context.Context {
+	return context.WithValue(ctx, sseSessionKey{}, sessionID)
+}
+
+// rateLimitKey identifies the caller of a tool for rate limiting: the guardrail
+// session when the call carries a known session token, else the MCP (SSE) session,
+// else the client IP. Unknown tokens are ignored so that a caller cannot get a fresh
+// bucket by inventing one. Calls with none of these (e.g. stdio) share the
+// "anonymous" bucket.
+func (s *MCPServer) rateLimitKey(ctx context.Context, args map[string]interface{}) string {
+	if token, _ := args["session_token"].(string); token != "" {
+		known := s.sessionExists(token)
+		if known {
+			return "session:" + token
+		}
+	}
+	if id, _ := ctx.Value(sseSessionKey{}).(string); id != "" {
+		return "sse:" + id
+	}
+	if ip, _ := ctx.Value(clientIPKey{}).(string); ip != "" {
+		return "ip:" + ip
+	}
+	return "anonymous"
+}
+
+// withBoundSessionToken fills in session_token for a tool that takes one when the
+// call omits it but arrives on an SSE connection where guardrail_init_session
+// created a session. Clients can then call init_session once per connection instead
+// of passing the token to every tool; an explicit session_token always wins.
+func (s *MCPServer) withBoundSessionToken(ctx context.Context, name string, args map[string]interface{}) map[string]interface{} {
+	if token, _ := args["session_token"].(string); token != "" || s.sseSessions == nil {
+		return args
+	}
+	id, _ := ctx.Value(sseSessionKey{}).(string)
+	if id == "" {
+		return args
+	}
+	schema, ok := s.toolSchema(name)
+	if !ok {
+		return args
+	}
+	if _, takesToken := schema.Properties["session_token"]; !takesToken {
+		return args
+	}
+	token := s.sseSessions.guardrailSession(id)
+	if token == "" {
+		return args
+	}
+
+	bound := make(map[string]interface{}, len(args)+1)
+	for k, v := range args {
+		bound[k] = v
+	}
+	bound["session_token"] = token
+	return bound
+}
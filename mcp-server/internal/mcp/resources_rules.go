@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// activeRulesResourceURI lists enabled prevention rules. Query suffixes page and
+// filter it, e.g. guardrail://rules/active?category=git&limit=50&offset=0
+const activeRulesResourceURI = "guardrail://rules/active"
+
+const (
+	// defaultActiveRulesPageSize is the page size when a query gives no limit
+	defaultActiveRulesPageSize = 50
+	// maxActiveRulesPageSize is the largest page a query can ask for
+	maxActiveRulesPageSize = 200
+	// maxActiveRulesUnpaged caps the bare URI, which returns every rule in one blob
+	maxActiveRulesUnpaged = 1000
+)
+
+// ruleLister pages through prevention rules, as RuleStore does
+type ruleLister interface {
+	List(ctx context.Context, enabled *bool, category string, limit, offset int) ([]models.PreventionRule, error)
+	Count(ctx context.Context, enabled *bool, category string) (int, error)
+}
+
+// activeRulesPage is the body of a paged guardrail://rules/active read
+type activeRulesPage struct {
+	Rules    []models.PreventionRule `json:"rules"`
+	Category string                  `json:"category,omitempty"`
+	Total    int                     `json:"total"`
+	Limit    int                     `json:"limit"`
+	Offset   int                     `json:"offset"`
+	HasMore  bool                    `json:"has_more"`
+}
+
+// isActiveRulesResource reports whether uri is the active rules resource, with or
+// without a query
+func isActiveRulesResource(uri string) bool {
+	return uri == activeRulesResourceURI || strings.HasPrefix(uri, activeRulesResourceURI+"?")
+}
+
+// readActiveRulesResource returns enabled prevention rules. The bare URI keeps its
+// original shape, a JSON array of every rule, capped at maxActiveRulesUnpaged; a
+// query with category, limit or offset returns one page with the total count.
+func (s *MCPServer) readActiveRulesResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	if s.rules == nil {
+		return nil, fmt.Errorf("rule store not available")
+	}
+	enabled := true
+
+	var body interface{}
+	_, rawQuery, paged := strings.Cut(uri, "?")
+	if !paged {
+		rules, err := s.rules.List(ctx, &enabled, "", maxActiveRulesUnpaged+1, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list active rules: %w", err)
+		}
+		if len(rules) > maxActiveRulesUnpaged {
+			slog.Warn("Active rules resource truncated; use limit and offset to page", "max", maxActiveRulesUnpaged)
+			rules = rules[:maxActiveRulesUnpaged]
+		}
+		if rules == nil {
+			rules = []models.PreventionRule{}
+		}
+		body = rules
+	} else {
+		page, err := parseActiveRulesQuery(rawQuery)
+		if err != nil {
+			return nil, fmt.Errorf("invalid resource query %s: %w", uri, err)
+		}
+		rules, err := s.rules.List(ctx, &enabled, page.Category, page.Limit, page.Offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list active rules: %w", err)
+		}
+		total, err := s.rules.Count(ctx, &enabled, page.Category)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count active rules: %w", err)
+		}
+		if rules == nil {
+			rules = []models.PreventionRule{}
+		}
+		page.Rules = rules
+		page.Total = total
+		page.HasMore = page.Offset+len(rules) < total
+		body = page
+	}
+
+	content, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal rules: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []interface{}{
+			mcp.TextResourceContents{
+				Uri:      uri,
+				MimeType: "application/json",
+				Text:     string(content),
+			},
+		},
+	}, nil
+}
+
+// parseActiveRulesQuery reads the category, limit and offset of a paged read
+func parseActiveRulesQuery(rawQuery string) (activeRulesPage, error) {
+	page := activeRulesPage{Limit: defaultActiveRulesPageSize}
+
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return page, err
+	}
+	for key := range values {
+		switch key {
+		case "category", "limit", "offset":
+		default:
+			return page, fmt.Errorf("unknown parameter %q", key)
+		}
+	}
+
+	page.Category = values.Get("category")
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 1 {
+			return page, fmt.Errorf("limit must be a positive integer")
+		}
+		page.Limit = min(limit, maxActiveRulesPageSize)
+	}
+	if v := values.Get("offset"); v != "" {
+		offset, err := strconv.Atoi(v)
+		if err != nil || offset < 0 {
+			return page, fmt.Errorf("offset must be a non-negative integer")
+		}
+		page.Offset = offset
+	}
+	return page, nil
+}
@@ -0,0 +1,124 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// fakeRules serves enabled rules from memory in place of RuleStore
+type fakeRules struct {
+	rules []models.PreventionRule
+}
+
+func (f *fakeRules) matching(category string) []models.PreventionRule {
+	var matched []models.PreventionRule
+	for _, r := range f.rules {
+		if r.Enabled && (category == "" || r.Category == category) {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+func (f *fakeRules) List(ctx context.Context, enabled *bool, category string, limit, offset int) ([]models.PreventionRule, error) {
+	matched := f.matching(category)
+	if offset >= len(matched) {
+		return nil, nil
+	}
+	return matched[offset:min(offset+limit, len(matched))], nil
+}
+
+func (f *fakeRules) Count(ctx context.Context, enabled *bool, category string) (int, error) {
+	return len(f.matching(category)), nil
+}
+
+// newFakeRules creates count enabled rules, alternating between the git and bash categories
+func newFakeRules(count int) *fakeRules {
+	f := &fakeRules{}
+	for i := 0; i < count; i++ {
+		category := "git"
+		if i%2 == 1 {
+			category = "bash"
+		}
+		f.rules = append(f.rules, models.PreventionRule{RuleID: fmt.Sprintf("RULE-%04d", i), Enabled: true, Category: category})
+	}
+	f.rules = append(f.rules, models.PreventionRule{RuleID: "DISABLED", Category: "git"})
+	return f
+}
+
+// TestReadActiveRulesResource_Paged tests category filtering and paging through query suffixes
+func TestReadActiveRulesResource_Paged(t *testing.T) {
+	s := &MCPServer{rules: newFakeRules(10)}
+
+	tests := []struct {
+		name        string
+		uri         string
+		wantFirst   string
+		wantCount   int
+		wantTotal   int
+		wantHasMore bool
+	}{
+		{name: "first page", uri: activeRulesResourceURI + "?limit=4", wantFirst: "RULE-0000", wantCount: 4, wantTotal: 10, wantHasMore: true},
+		{name: "last page", uri: activeRulesResourceURI + "?limit=4&offset=8", wantFirst: "RULE-0008", wantCount: 2, wantTotal: 10},
+		{name: "category filter", uri: activeRulesResourceURI + "?category=bash&limit=2&offset=1", wantFirst: "RULE-0003", wantCount: 2, wantTotal: 5, wantHasMore: true},
+		{name: "default limit", uri: activeRulesResourceURI + "?category=git", wantFirst: "RULE-0000", wantCount: 5, wantTotal: 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.readActiveRulesResource(context.Background(), tt.uri)
+			if err != nil {
+				t.Fatalf("readActiveRulesResource() error = %v", err)
+			}
+			var page activeRulesPage
+			text := res.Contents[0].(mcp.TextResourceContents).Text
+			if err := json.Unmarshal([]byte(text), &page); err != nil {
+				t.Fatalf("invalid JSON %s: %v", text, err)
+			}
+			if len(page.Rules) != tt.wantCount || page.Rules[0].RuleID != tt.wantFirst {
+				t.Errorf("rules = %d starting %s, want %d starting %s", len(page.Rules), page.Rules[0].RuleID, tt.wantCount, tt.wantFirst)
+			}
+			if page.Total != tt.wantTotal || page.HasMore != tt.wantHasMore {
+				t.Errorf("total = %d, has_more = %v, want %d, %v", page.Total, page.HasMore, tt.wantTotal, tt.wantHasMore)
+			}
+		})
+	}
+}
+
+// TestReadActiveRulesResource_Bare tests that the bare URI still returns a plain array, capped
+func TestReadActiveRulesResource_Bare(t *testing.T) {
+	s := &MCPServer{rules: newFakeRules(maxActiveRulesUnpaged + 5)}
+
+	res, err := s.readActiveRulesResource(context.Background(), activeRulesResourceURI)
+	if err != nil {
+		t.Fatalf("readActiveRulesResource() error = %v", err)
+	}
+	var rules []models.PreventionRule
+	text := res.Contents[0].(mcp.TextResourceContents).Text
+	if err := json.Unmarshal([]byte(text), &rules); err != nil {
+		t.Fatalf("bare URI did not return an array: %v", err)
+	}
+	if len(rules) != maxActiveRulesUnpaged {
+		t.Errorf("rules = %d, want the cap of %d", len(rules), maxActiveRulesUnpaged)
+	}
+}
+
+// TestReadActiveRulesResource_InvalidQuery tests that bad query parameters are rejected
+func TestReadActiveRulesResource_InvalidQuery(t *testing.T) {
+	s := &MCPServer{rules: newFakeRules(3)}
+
+	for _, query := range []string{"limit=0", "limit=ten", "offset=-1", "sort=name"} {
+		t.Run(query, func(t *testing.T) {
+			_, err := s.readActiveRulesResource(context.Background(), activeRulesResourceURI+"?"+query)
+			if err == nil || !strings.Contains(err.Error(), "invalid resource query") {
+				t.Errorf("error = %v, want invalid resource query", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/database"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// sessionResourcePrefix begins the URIs of live session state resources:
+// guardrail://session/{token}/attempts and guardrail://session/{token}/halts
+const sessionResourcePrefix = "guardrail://session/"
+
+// sessionResourceToken matches the characters a session token can contain
+var sessionResourceToken = regexp.MustCompile(`^[A-Za-z0-9_-]{1,128}$`)
+
+// sessionAttemptsResource is the body of guardrail://session/{token}/attempts
+type sessionAttemptsResource struct {
+	SessionID    string                       `json:"session_id"`
+	ThreeStrikes *database.ThreeStrikesStatus `json:"three_strikes"`
+}
+
+// sessionHaltsResource is the body of guardrail://session/{token}/halts
+type sessionHaltsResource struct {
+	SessionID string              `json:"session_id"`
+	Pending   []*models.HaltEvent `json:"pending"`
+}
+
+// readSessionResource returns a session's three-strikes status or its pending
+// halt events, so that dashboards can poll session state without calling tools.
+// Malformed URIs and tokens of sessions that do not exist are unknown resources.
+func (s *MCPServer) readSessionResource(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+	token, kind, ok := strings.Cut(strings.TrimPrefix(uri, sessionResourcePrefix), "/")
+	if !ok || !sessionResourceToken.MatchString(token) || !s.sessionExists(token) {
+		return nil, fmt.Errorf("unknown resource: %s", uri)
+	}
+
+	var body interface{}
+	switch kind {
+	case "attempts":
+		if s.taskAttemptStore == nil {
+			return nil, fmt.Errorf("three strikes tracking not available")
+		}
+		status, err := s.taskAttemptStore.GetThreeStrikesStatus(ctx, token, "", "", s.threeStrikesLimits())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get three strikes status: %w", err)
+		}
+		body = sessionAttemptsResource{SessionID: token, ThreeStrikes: status}
+	case "halts":
+		if s.haltEvents == nil {
+			return nil, fmt.Errorf("halt event tracking not available")
+		}
+		events, err := s.haltEvents.GetUnacknowledgedBySession(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get halt events: %w", err)
+		}
+		if events == nil {
+			events = []*models.HaltEvent{}
+		}
+		body = sessionHaltsResource{SessionID: token, Pending: events}
+	default:
+		return nil, fmt.Errorf("unknown resource: %s", uri)
+	}
+
+	content, err := json.MarshalIndent(body, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session resource: %w", err)
+	}
+
+	return &mcp.ReadResourceResult{
+		Contents: []interface{}{
+			mcp.TextResourceContents{
+				Uri:      uri,
+				MimeType: "application/json",
+				Text:     string(content),
+			},
+		},
+	}, nil
+}
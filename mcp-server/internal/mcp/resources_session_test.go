@@ -0,0 +1,60 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// TestReadSessionResource_Halts tests that the halts resource lists the session's unacknowledged halt events
+func TestReadSessionResource_Halts(t *testing.T) {
+	s := mockMCPServer()
+	s.sessions.Put(context.Background(), &Session{ID: "sess-1", CreatedAt: time.Now(), LastActivity: time.Now()})
+	s.haltEvents = &fakeHaltEvents{events: []*models.HaltEvent{
+		{SessionID: "sess-1", HaltType: string(models.HaltTypeSecurity), Severity: string(models.HaltSeverityCritical), Description: "credential exposure", Resolution: "pending"},
+		{SessionID: "sess-1", HaltType: string(models.HaltTypeSecurity), Description: "already handled", Acknowledged: true},
+		{SessionID: "sess-2", HaltType: string(models.HaltTypeSecurity), Description: "someone else", Resolution: "pending"},
+	}}
+
+	res, err := s.readSessionResource(context.Background(), "guardrail://session/sess-1/halts")
+	if err != nil {
+		t.Fatalf("readSessionResource() error = %v", err)
+	}
+	text := res.Contents[0].(mcp.TextResourceContents).Text
+	var body sessionHaltsResource
+	if err := json.Unmarshal([]byte(text), &body); err != nil {
+		t.Fatalf("invalid JSON %s: %v", text, err)
+	}
+	if body.SessionID != "sess-1" || len(body.Pending) != 1 || body.Pending[0].Description != "credential exposure" {
+		t.Errorf("resource = %s, want the one pending halt of sess-1", text)
+	}
+}
+
+// TestReadSessionResource_Unknown tests that malformed URIs and tokens are unknown resources
+func TestReadSessionResource_Unknown(t *testing.T) {
+	s := mockMCPServer()
+	s.sessions.Put(context.Background(), &Session{ID: "sess-1", CreatedAt: time.Now(), LastActivity: time.Now()})
+	s.haltEvents = &fakeHaltEvents{}
+
+	for _, uri := range []string{
+		"guardrail://session/sess-1",
+		"guardrail://session//halts",
+		"guardrail://session/../halts",
+		"guardrail://session/sess 1/halts",
+		"guardrail://session/sess-1/halts/extra",
+		"guardrail://session/sess-1/budget",
+		"guardrail://session/no-such-session/attempts",
+	} {
+		t.Run(uri, func(t *testing.T) {
+			_, err := s.readSessionResource(context.Background(), uri)
+			if err == nil || !strings.HasPrefix(err.Error(), "unknown resource") {
+				t.Errorf("readSessionResource(%q) error = %v, want unknown resource", uri, err)
+			}
+		})
+	}
+}
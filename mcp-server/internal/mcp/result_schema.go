@@ -0,0 +1,121 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Tool result schema versions. Every JSON object result carries schema_version so
+// clients can tell which shape they were sent. A client built against an older
+// shape asks for it once, with result_schema_version in guardrail_init_session, and
+// every result in that session is converted back before it is returned.
+const (
+	// Version 1 results are the plain result objects, without schema_version
+	resultSchemaV1 = 1
+	// Version 2 results start with a schema_version field
+	resultSchemaV2 = 2
+
+	currentResultSchemaVersion = resultSchemaV2
+	oldestResultSchemaVersion  = resultSchemaV1
+)
+
+// supportedResultSchemaVersions lists the versions a client may request
+func supportedResultSchemaVersions() []int {
+	versions := make([]int, 0, currentResultSchemaVersion-oldestResultSchemaVersion+1)
+	for v := oldestResultSchemaVersion; v <= currentResultSchemaVersion; v++ {
+		versions = append(versions, v)
+	}
+	return versions
+}
+
+// parseResultSchemaVersion reads the result_schema_version argument; 0 means the
+// client did not ask for a version
+func parseResultSchemaVersion(args map[string]interface{}) (int, error) {
+	raw, ok := args["result_schema_version"]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+	n, ok := raw.(float64)
+	if !ok || n != float64(int(n)) || int(n) < oldestResultSchemaVersion || int(n) > currentResultSchemaVersion {
+		return 0, fmt.Errorf("result_schema_version must be one of %v", supportedResultSchemaVersions())
+	}
+	return int(n), nil
+}
+
+// stampSchemaVersion adds the current schema_version as the first field of a JSON
+// object result. Other results, JSON or plain text, are returned unchanged.
+func stampSchemaVersion(resultJSON []byte) []byte {
+	if len(resultJSON) < 2 || resultJSON[0] != '{' || !json.Valid(resultJSON) {
+		return resultJSON
+	}
+	stamped := []byte(`{"schema_version":` + strconv.Itoa(currentResultSchemaVersion))
+	if rest := bytes.TrimSpace(resultJSON[1:]); len(rest) > 0 && rest[0] != '}' {
+		stamped = append(stamped, ',')
+	}
+	return append(stamped, resultJSON[1:]...)
+}
+
+// downgradeResultText converts a current result to the shape of version
+func downgradeResultText(text string, version int) string {
+	prefix := `{"schema_version":` + strconv.Itoa(currentResultSchemaVersion)
+	if version >= currentResultSchemaVersion || len(text) < len(prefix) || text[:len(prefix)] != prefix {
+		return text
+	}
+	// Version 1: drop the schema_version field stampSchemaVersion added
+	rest := text[len(prefix):]
+	if len(rest) > 0 && rest[0] == ',' {
+		rest = rest[1:]
+	}
+	return "{" + rest
+}
+
+// resultSchemaVersion returns the result version the caller asked for: the one
+// requested by this init_session call, or the one stored on the caller's session
+func (s *MCPServer) resultSchemaVersion(ctx context.Context, name string, args map[string]interface{}) int {
+	if name == "guardrail_init_session" {
+		if v, err := parseResultSchemaVersion(args); err == nil && v != 0 {
+			return v
+		}
+		return currentResultSchemaVersion
+	}
+
+	token, _ := args["session_token"].(string)
+	if token == "" && s.sseSessions != nil {
+		if id, _ := ctx.Value(sseSessionKey{}).(string); id != "" {
+			token = s.sseSessions.guardrailSession(id)
+		}
+	}
+	if token == "" || s.sessions == nil {
+		return currentResultSchemaVersion
+	}
+	session, ok, err := s.sessions.Get(ctx, token)
+	if err != nil || !ok || session.ResultSchemaVersion == 0 {
+		return currentResultSchemaVersion
+	}
+	return session.ResultSchemaVersion
+}
+
+// applyResultSchemaVersion stamps every JSON object result with schema_version, however
+// its handler built it, and returns it in the shape the caller's session asked for
+func (s *MCPServer) applyResultSchemaVersion(ctx context.Context, name string, args map[string]interface{}, result *mcp.CallToolResult) *mcp.CallToolResult {
+	if result == nil {
+		return nil
+	}
+	version := s.resultSchemaVersion(ctx, name, args)
+	content := make([]interface{}, len(result.Content))
+	for i, c := range result.Content {
+		if text, ok := c.(mcp.TextContent); ok {
+			text.Text = downgradeResultText(string(stampSchemaVersion([]byte(text.Text))), version)
+			c = text
+		}
+		content[i] = c
+	}
+	versioned := *result
+	versioned.Content = content
+	return &versioned
+}
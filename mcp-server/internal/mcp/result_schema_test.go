@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/domain"
+)
+
+// TestStampSchemaVersion tests that object results gain schema_version and v1 drops it again
+func TestStampSchemaVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		result  string
+		stamped string
+	}{
+		{name: "object", result: `{"valid":true}`, stamped: `{"schema_version":2,"valid":true}`},
+		{name: "empty object", result: `{}`, stamped: `{"schema_version":2}`},
+		{name: "array untouched", result: `[1,2]`, stamped: `[1,2]`},
+		{name: "string untouched", result: `"ok"`, stamped: `"ok"`},
+		{name: "plain text untouched", result: `{not json}`, stamped: `{not json}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stamped := string(stampSchemaVersion([]byte(tt.result)))
+			if stamped != tt.stamped {
+				t.Errorf("stampSchemaVersion() = %s, want %s", stamped, tt.stamped)
+			}
+			if got := downgradeResultText(stamped, resultSchemaV1); got != tt.result {
+				t.Errorf("downgradeResultText(v1) = %s, want %s", got, tt.result)
+			}
+			if got := downgradeResultText(stamped, currentResultSchemaVersion); got != stamped {
+				t.Errorf("downgradeResultText(current) = %s, want %s", got, stamped)
+			}
+		})
+	}
+}
+
+// TestHandleToolCall_ResultSchemaVersion tests that a client asking for v1 at
+// init_session gets v1 results while a default client gets the current shape
+func TestHandleToolCall_ResultSchemaVersion(t *testing.T) {
+	t.Setenv("GUARDRAILS_REPO_PATH", t.TempDir())
+	s := mockMCPServer()
+	ctx := context.Background()
+
+	initSession := func(args map[string]interface{}) (map[string]interface{}, bool) {
+		t.Helper()
+		args["user_id"] = "agent-1"
+		res, err := s.handleToolCall(ctx, "guardrail_init_session", args)
+		if err != nil {
+			t.Fatalf("handleToolCall() error = %v", err)
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &body); err != nil {
+			t.Fatalf("failed to parse result: %v", err)
+		}
+		return body, res.IsError
+	}
+	toolResult := func(sessionID string) string {
+		t.Helper()
+		res, _ := buildToolResult(map[string]interface{}{"valid": true}, false)
+		res = s.applyResultSchemaVersion(ctx, "guardrail_validate_commit_revert", map[string]interface{}{"session_token": sessionID}, res)
+		return res.Content[0].(mcp.TextContent).Text
+	}
+
+	current, _ := initSession(map[string]interface{}{})
+	if current["schema_version"] != float64(currentResultSchemaVersion) || current["result_schema_version"] != float64(currentResultSchemaVersion) {
+		t.Errorf("default init_session = %v, want schema_version %d", current, currentResultSchemaVersion)
+	}
+	if got := toolResult(current["session_id"].(string)); got != `{"schema_version":2,"valid":true}` {
+		t.Errorf("default client result = %s, want the current shape", got)
+	}
+
+	v1, _ := initSession(map[string]interface{}{"result_schema_version": float64(1)})
+	if _, ok := v1["schema_version"]; ok || v1["result_schema_version"] != float64(1) {
+		t.Errorf("v1 init_session = %v, want no schema_version and result_schema_version 1", v1)
+	}
+	if got := toolResult(v1["session_id"].(string)); got != `{"valid":true}` {
+		t.Errorf("v1 client result = %s, want the v1 shape", got)
+	}
+
+	rejected, isError := initSession(map[string]interface{}{"result_schema_version": float64(3)})
+	if !isError || !strings.Contains(rejected["error"].(string), "result_schema_version must be one of [1 2]") {
+		t.Errorf("init_session(v3) = %v, want an unsupported version error", rejected)
+	}
+}
+
+// TestApplyResultSchemaVersion_HandlerFamilies tests that JSON object results are
+// stamped however their handler built them, and plain text results are left alone
+func TestApplyResultSchemaVersion_HandlerFamilies(t *testing.T) {
+	if _, err := os.Stat("../../../scripts/team_manager.py"); os.IsNotExist(err) {
+		t.Skip("team_manager.py not found, skipping integration test")
+	}
+
+	s := mockMCPServer()
+	ctx := context.Background()
+	projectName := "test-project-schema-version"
+	defer cleanupTestProject(t, projectName)
+
+	initResult, _ := s.handleTeamInit(ctx, map[string]interface{}{"project_name": projectName})
+	statusResult, _ := s.handleTeamStatus(ctx, map[string]interface{}{"project_name": projectName})
+	haltResult, _ := s.handleRecordHalt(ctx, map[string]interface{}{"session_token": "sess-0123456789"})
+	extendedResult, _ := buildToolResult(map[string]interface{}{"valid": true}, false)
+	validation := &mcp.CallToolResult{Content: []interface{}{mcp.TextContent{
+		Type: "text",
+		Text: formatValidationResult(domain.NewValidationResult(nil), "ls"),
+	}}}
+
+	tests := []struct {
+		name    string
+		tool    string
+		result  *mcp.CallToolResult
+		stamped bool
+	}{
+		{name: "rule validation", tool: "guardrail_validate_bash", result: validation, stamped: true},
+		{name: "halt", tool: "guardrail_record_halt", result: haltResult, stamped: true},
+		{name: "team status", tool: "guardrail_team_status", result: statusResult, stamped: true},
+		{name: "extended tool", tool: "guardrail_validate_commit_revert", result: extendedResult, stamped: true},
+		{name: "team text", tool: "guardrail_team_init", result: initResult, stamped: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := s.applyResultSchemaVersion(ctx, tt.tool, map[string]interface{}{}, tt.result).Content[0].(mcp.TextContent).Text
+			if !tt.stamped {
+				if text != getResultText(tt.result) {
+					t.Errorf("result = %q, want it unchanged", text)
+				}
+				return
+			}
+			var body map[string]interface{}
+			if err := json.Unmarshal([]byte(text), &body); err != nil {
+				t.Fatalf("stamped result is not JSON: %v\n%s", err, text)
+			}
+			if body["schema_version"] != float64(currentResultSchemaVersion) {
+				t.Errorf("schema_version = %v, want %d in %s", body["schema_version"], currentResultSchemaVersion, text)
+			}
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -32,9 +33,9 @@ import (
 type MCPServer struct {
 	mcpServer         *server.MCPServer
 	db                *database.DB
-	cache             *cache.Cache
+	cache             *cache.Client
 	metrics           *metrics.Metrics
-	audit             *audit.AuditLogger
+	audit             *audit.Logger
 	validator         *validation.Engine
 	config            *config.Config
 	visionTools       *VisionTools
@@ -43,6 +44,44 @@ type MCPServer struct {
 	budgetStore       *database.BudgetStore
 	budgetGovernor    *budget.Governor
 	agentStateStore   *database.AgentStateStore
+	haltEvents        haltEventStore
+	secretBaselines   secretBaselineStore
+	fileReads         fileReadStore
+	rules             ruleLister
+	ruleHits          ruleHitStore
+	sessions          *sessionStore
+	outcomes          *outcomeRecorder
+	version           string
+
+	// Per-caller rate limits for team tools (see newTeamRateLimiters)
+	teamRateLimiter     *rateLimiter
+	teamReadRateLimiter *rateLimiter
+
+	// Closed on Shutdown to stop background cleanup goroutines
+	stopCleanup     chan struct{}
+	stopCleanupOnce sync.Once
+
+	// HTTP server and in-flight tool call tracking for graceful shutdown
+	httpServer   *echo.Echo
+	inFlight     sync.WaitGroup
+	inFlightMu   sync.Mutex
+	shuttingDown bool
+
+	// SSE client sessions, resumable for a grace period after a dropped connection
+	sseSessions *sseSessionManager
+
+	// Tool input schemas indexed by name for argument validation at dispatch
+	toolSchemasOnce sync.Once
+	toolSchemas     map[string]mcp.ToolInputSchema
+}
+
+// haltEventStore records and acknowledges halt events and loads unresolved critical
+// ones for a session
+type haltEventStore interface {
+	Create(ctx context.Context, sessionID, haltType, description, severity string, contextData map[string]interface{}) (*models.HaltEvent, error)
+	Acknowledge(ctx context.Context, id uuid.UUID, resolution string) (*models.HaltEvent, error)
+	GetCriticalPending(ctx context.Context, sessionID string) ([]*models.HaltEvent, error)
+	GetUnacknowledgedBySession(ctx context.Context, sessionID string) ([]*models.HaltEvent, error)
 }
 
 // SetWebhookStore sets the webhook store for notification tools.
@@ -67,7 +106,7 @@ func (s *MCPServer) SetAgentStateStore(store *database.AgentStateStore) {
 }
 
 // NewServer creates a new MCP server instance
-func NewServer(db *database.DB, cache *cache.Cache, metrics *metrics.Metrics, audit *audit.AuditLogger, validator *validation.Engine, cfg *config.Config) *MCPServer {
+func NewServer(db *database.DB, cache *cache.Client, metrics *metrics.Metrics, audit *audit.Logger, validator *validation.Engine, cfg *config.Config) *MCPServer {
 	s := &MCPServer{
 		mcpServer: server.NewMCPServer(
 			"Guardrail Enforcement Server",
@@ -75,13 +114,37 @@ func NewServer(db *database.DB, cache *cache.Cache, metrics *metrics.Metrics, au
 			server.WithResourceCapabilities(true, true),
 			server.WithLogging(),
 		),
-		db:        db,
-		cache:     cache,
-		metrics:   metrics,
-		audit:     audit,
-		validator: validator,
-		config:    cfg,
+		db:              db,
+		cache:           cache,
+		metrics:         metrics,
+		audit:           audit,
+		validator:       validator,
+		config:          cfg,
+		haltEvents:      database.NewHaltEventStore(db),
+		secretBaselines: database.NewSecretBaselineStore(db),
+		fileReads:       database.NewFileReadStore(db),
+		rules:           database.NewRuleStore(db),
+		ruleHits:        database.NewRuleHitStore(db),
+		version:         cfg.Version,
+		sseSessions: newSSESessionManager(cfg.SSEResumeGracePeriod, cfg.SSEPingInterval, cfg.SSEIdleTimeout, sseQueueConfig{
+			size:           cfg.SSEQueueSize,
+			enqueueTimeout: cfg.SSEEnqueueTimeout,
+			policy:         cfg.SSEQueuePolicy,
+		}),
 	}
+	// Guard against a nil *cache.Client ending up in a non-nil interface
+	var backend sessionBackend
+	if cache != nil {
+		backend = cache
+	}
+	s.sessions = newSessionStore(backend, cfg.SessionTTL(), cfg.SessionCacheSize)
+	s.teamRateLimiter, s.teamReadRateLimiter = newTeamRateLimiters(cfg)
+	s.stopCleanup = make(chan struct{})
+	for _, limiter := range []*rateLimiter{s.teamRateLimiter, s.teamReadRateLimiter} {
+		go limiter.runCleanup(limiter.cleanupInterval(), s.stopCleanup)
+	}
+	s.outcomes = newOutcomeRecorder(database.NewValidationOutcomeStore(db), s.ruleHits)
+	go s.outcomes.run(s.stopCleanup)
 
 	// Initialize vision tools if configured
 	if cfg.Vision.Enabled {
@@ -92,655 +155,1862 @@ func NewServer(db *database.DB, cache *cache.Cache, metrics *metrics.Metrics, au
 	return s
 }
 
-func (s *MCPServer) setupHandlers() {
-	// Register tools
-	s.mcpServer.HandleListTools(func(ctx context.Context, cursor *string) (*mcp.ListToolsResult, error) {
-		tools := []mcp.Tool{
-			{
-				Name:        "guardrail_init_session",
-				Description: "Initialize a new session with security parameters and session ID",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"user_id": map[string]interface{}{
-							"type":        "string",
-							"description": "Unique identifier for the user",
-						},
-						"environment": map[string]interface{}{
-							"type":        "string",
-							"description": "Target environment (development, staging, production)",
-						},
+// toolList returns every tool exposed by the server, including optional tool
+// groups whose backing stores are configured
+func (s *MCPServer) toolList() []mcp.Tool {
+	tools := []mcp.Tool{
+		{
+			Name:        "guardrail_init_session",
+			Description: "Initialize a new session with security parameters and session ID",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"user_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Unique identifier for the user",
+					},
+					"environment": map[string]interface{}{
+						"type":        "string",
+						"description": "Target environment (development, staging, production)",
+					},
+					"project_slug": map[string]interface{}{
+						"type":        "string",
+						"description": "Project the session works on, used to scope rules and outcomes",
+					},
+					"result_schema_version": map[string]interface{}{
+						"type":        "number",
+						"description": "Tool result schema version to return for this session (1: results without schema_version; default: current)",
 					},
-					Required: []string{"user_id"},
 				},
+				Required: []string{"user_id"},
 			},
-			{
-				Name:        "guardrail_validate_bash",
-				Description: "Validate a bash command against security policies and prevention rules",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"command": map[string]interface{}{
-							"type":        "string",
-							"description": "The bash command to validate",
-						},
-						"working_dir": map[string]interface{}{
-							"type":        "string",
-							"description": "Current working directory",
-						},
+		},
+		{
+			Name:        "guardrail_validate_bash",
+			Description: "Validate a bash command against security policies and prevention rules",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"command": map[string]interface{}{
+						"type":        "string",
+						"description": "The bash command to validate",
+					},
+					"working_dir": map[string]interface{}{
+						"type":        "string",
+						"description": "Current working directory",
 					},
-					Required: []string{"command"},
 				},
+				Required: []string{"command"},
 			},
-			{
-				Name:        "guardrail_validate_file_edit",
-				Description: "Validate a file edit operation (search and replace) against safety rules",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "Path to the file being edited",
-						},
-						"old_string": map[string]interface{}{
-							"type":        "string",
-							"description": "Text to be replaced",
-						},
-						"new_string": map[string]interface{}{
-							"type":        "string",
-							"description": "Replacement text",
-						},
+		},
+		{
+			Name:        "guardrail_validate_file_edit",
+			Description: "Validate a file edit operation (search and replace) against safety rules",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the file being edited",
+					},
+					"old_string": map[string]interface{}{
+						"type":        "string",
+						"description": "Text to be replaced",
+					},
+					"new_string": map[string]interface{}{
+						"type":        "string",
+						"description": "Replacement text",
+					},
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token; required when the project's .guardrails/edit-policy.json sets require_read_before_edit",
 					},
-					Required: []string{"file_path", "old_string", "new_string"},
 				},
+				Required: []string{"file_path", "old_string", "new_string"},
 			},
-			{
-				Name:        "guardrail_validate_git_operation",
-				Description: "Validate a git operation (commit, push, branch) against policy",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"operation": map[string]interface{}{
-							"type":        "string",
-							"description": "Git command to validate (e.g., commit, push)",
-						},
-						"args": map[string]interface{}{
-							"type":        "array",
-							"items":       map[string]interface{}{"type": "string"},
-							"description": "Arguments to the git command",
-						},
+		},
+		{
+			Name:        "guardrail_validate_git_operation",
+			Description: "Validate a git operation (commit, push, branch) against policy",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"operation": map[string]interface{}{
+						"type":        "string",
+						"description": "Git command to validate (e.g., commit, push)",
+					},
+					"args": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Arguments to the git command",
 					},
-					Required: []string{"operation"},
 				},
+				Required: []string{"operation"},
 			},
-			{
-				Name:        "guardrail_pre_work_check",
-				Description: "Perform a mandatory pre-work safety check before starting a new task",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"task_description": map[string]interface{}{
-							"type":        "string",
-							"description": "Brief description of the planned task",
-						},
+		},
+		{
+			Name:        "guardrail_pre_work_check",
+			Description: "Perform a mandatory pre-work safety check before starting a new task",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"task_description": map[string]interface{}{
+						"type":        "string",
+						"description": "Brief description of the planned task",
 					},
-					Required: []string{"task_description"},
 				},
+				Required: []string{"task_description"},
 			},
-			{
-				Name:        "guardrail_get_context",
-				Description: "Get the current active guardrail context and applicable rules",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"path": map[string]interface{}{
-							"type":        "string",
-							"description": "Current working directory or file path",
-						},
+		},
+		{
+			Name:        "guardrail_get_context",
+			Description: "Get the current active guardrail context and applicable rules",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Current working directory or file path",
 					},
 				},
 			},
-			{
-				Name:        "guardrail_validate_scope",
-				Description: "Verify if a file path is within authorized project scope",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "Path to the file to validate",
-						},
-						"authorized_scope": map[string]interface{}{
-							"type":        "string",
-							"description": "Root directory of the authorized scope",
-						},
+		},
+		{
+			Name:        "guardrail_validate_scope",
+			Description: "Verify if a file path is within authorized project scope",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the file to validate",
+					},
+					"authorized_scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Authorized scope: a root directory, glob or regex depending on scope_mode",
+					},
+					"scope_mode": map[string]interface{}{
+						"type":        "string",
+						"description": "How authorized_scope is matched: prefix (directory boundary, default), glob (supports **) or regex",
+						"enum":        []string{"prefix", "glob", "regex"},
 					},
-					Required: []string{"file_path"},
 				},
+				Required: []string{"file_path"},
 			},
-			{
-				Name:        "guardrail_validate_commit",
-				Description: "Validate proposed commit message and changed files",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"message": map[string]interface{}{
-							"type":        "string",
-							"description": "Commit message to validate",
-						},
-						"files": map[string]interface{}{
-							"type":        "array",
-							"items":       map[string]interface{}{"type": "string"},
-							"description": "List of files to be committed",
-						},
+		},
+		{
+			Name:        "guardrail_validate_commit",
+			Description: "Validate proposed commit message and changed files",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "Commit message to validate",
+					},
+					"files": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "List of files to be committed",
+					},
+					"allowed_types": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Allowed conventional commit types (default: feat, fix, docs, style, refactor, perf, test, chore, build, ci, revert). An empty array accepts any type.",
+					},
+					"max_subject_length": map[string]interface{}{
+						"type":        "number",
+						"description": "Maximum subject line length (default: 72)",
 					},
-					Required: []string{"message", "files"},
 				},
+				Required: []string{"message", "files"},
 			},
-			{
-				Name:        "guardrail_prevent_regression",
-				Description: "Check if changes might reintroduce known bugs or violate strict patterns",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "File being modified",
-						},
-						"changes": map[string]interface{}{
-							"type":        "string",
-							"description": "Description or diff of planned changes",
-						},
+		},
+		{
+			Name:        "guardrail_prevent_regression",
+			Description: "Check if changes might reintroduce known bugs or violate strict patterns",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "File being modified",
+					},
+					"changes": map[string]interface{}{
+						"type":        "string",
+						"description": "Description or diff of planned changes",
 					},
-					Required: []string{"file_path", "changes"},
 				},
+				Required: []string{"file_path", "changes"},
 			},
-			{
-				Name:        "guardrail_check_test_prod_separation",
-				Description: "Enforce strict separation between test code and production code",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "Path to the file being checked",
-						},
+		},
+		{
+			Name:        "guardrail_check_test_prod_separation",
+			Description: "Enforce strict separation between test code and production code",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the file being checked",
+					},
+					"environment": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment the file belongs to",
+						"enum":        []string{"test", "prod"},
+					},
+					"patterns": map[string]interface{}{
+						"type":        "object",
+						"description": "Patterns keyed by environment (test, prod) as [{pattern, message}]; each environment given replaces the built-in patterns and those in .guardrails/test-prod-separation.json",
 					},
-					Required: []string{"file_path"},
 				},
+				Required: []string{"file_path"},
 			},
-			{
-				Name:        "guardrail_validate_push",
-				Description: "Pre-push validation of current branch status and health",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"branch": map[string]interface{}{
-							"type":        "string",
-							"description": "Branch to be pushed",
-						},
-						"remote": map[string]interface{}{
-							"type":        "string",
-							"description": "Remote name (e.g., origin)",
+		},
+		{
+			Name:        "guardrail_validate_test_prod_separation",
+			Description: "Check test/production separation for a whole changeset in one call and return aggregated violations",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"files": map[string]interface{}{
+						"type":        "array",
+						"description": "Files to check as {file_path, environment, content}; content is optional and read from disk when omitted",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"file_path":   map[string]interface{}{"type": "string"},
+								"environment": map[string]interface{}{"type": "string", "enum": []string{"test", "prod"}},
+								"content":     map[string]interface{}{"type": "string"},
+							},
+							"required": []string{"file_path", "environment"},
 						},
 					},
-					Required: []string{"branch"},
+					"patterns": map[string]interface{}{
+						"type":        "object",
+						"description": "Patterns keyed by environment (test, prod) as [{pattern, message}]; each environment given replaces the built-in patterns and those in .guardrails/test-prod-separation.json",
+					},
 				},
+				Required: []string{"files"},
 			},
-			{
-				Name:        "guardrail_record_file_read",
-				Description: "Record that a file has been read by the agent (Four Laws enforcement)",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "Path to the file that was read",
+		},
+		{
+			Name:        "guardrail_validate_push",
+			Description: "Pre-push validation of current branch status and health",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"branch": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch to be pushed",
+					},
+					"remote": map[string]interface{}{
+						"type":        "string",
+						"description": "Remote name (e.g., origin)",
+					},
+					"protected_branches": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Protected branch names, case-insensitive; a trailing /* protects sub-branches (e.g. env/*). Defaults to .guardrails/git-policy.json, else main, master, production, release",
+					},
+					"staged_files": map[string]interface{}{
+						"type":        "array",
+						"description": "Files being pushed as {path, size} objects (size in bytes); oversized files block the push; binary artifacts (.zip, .tar, .bin, .mp4) are warned about",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"path": map[string]interface{}{"type": "string"},
+								"size": map[string]interface{}{"type": "integer"},
+							},
 						},
 					},
-					Required: []string{"file_path"},
+					"max_file_bytes": map[string]interface{}{
+						"type":        "integer",
+						"description": "Maximum size of a single staged file in bytes (default 5242880)",
+					},
 				},
+				Required: []string{"branch"},
 			},
-			{
-				Name:        "guardrail_record_attempt",
-				Description: "Record a tool use attempt for tracking progress/failure rates",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"tool_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the tool being attempted",
-						},
-						"success": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Whether the attempt was successful",
-						},
-						"error_msg": map[string]interface{}{
-							"type":        "string",
-							"description": "Error message if failed",
-						},
+		},
+		{
+			Name:        "guardrail_record_file_read",
+			Description: "Record that a file has been read in the session, so later edits to it pass guardrail_verify_file_read (Four Laws enforcement)",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the file that was read",
 					},
-					Required: []string{"tool_name", "success"},
 				},
+				Required: []string{"session_token", "file_path"},
 			},
-			{
-				Name:        "guardrail_verify_file_read",
-				Description: "Verify a file has been read in current context before editing",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "Path to the file to verify",
-						},
+		},
+		{
+			Name:        "guardrail_record_attempt",
+			Description: "Record a failed attempt at a task for three strikes tracking",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"task_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Identifier of the task being attempted",
+					},
+					"error_message": map[string]interface{}{
+						"type":        "string",
+						"description": "Error message from the failed attempt",
+					},
+					"error_category": map[string]interface{}{
+						"type":        "string",
+						"description": "Kind of failure (default other)",
+						"enum":        []string{"syntax", "runtime", "logic", "timeout", "test_flake", "other"},
 					},
-					Required: []string{"file_path"},
 				},
+				Required: []string{"session_token"},
 			},
-			{
-				Name:        "guardrail_validate_three_strikes",
-				Description: "Check if current task has hit consecutive failure threshold",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"task_id": map[string]interface{}{
-							"type":        "string",
-							"description": "Unique identifier for the current task",
-						},
+		},
+		{
+			Name:        "guardrail_verify_file_read",
+			Description: "Verify a file has been read in the session before editing it",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the file to verify",
 					},
 				},
+				Required: []string{"session_token", "file_path"},
 			},
-			{
-				Name:        "guardrail_validate_exact_replacement",
-				Description: "Verify that strings for replacement exactly match target file content",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "File path to check",
-						},
-						"target_string": map[string]interface{}{
-							"type":        "string",
-							"description": "The string to find for replacement",
-						},
+		},
+		{
+			Name:        "guardrail_list_file_reads",
+			Description: "List the files recorded as read in the session, most recent first, with read timestamps",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"limit": map[string]interface{}{
+						"type":        "number",
+						"description": "Maximum number of files to return (default 100, max 1000)",
+					},
+					"offset": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of files to skip",
 					},
-					Required: []string{"file_path", "target_string"},
 				},
+				Required: []string{"session_token"},
 			},
-			{
-				Name:        "guardrail_reset_attempts",
-				Description: "Reset failure counters for a given task or tool",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"task_id": map[string]interface{}{
-							"type":        "string",
-							"description": "ID of task to reset",
-						},
+		},
+		{
+			Name:        "guardrail_clear_file_reads",
+			Description: "Clear the files recorded as read in the session to start a fresh read-before-edit verification cycle",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
 					},
 				},
+				Required: []string{"session_token"},
 			},
-			{
-				Name:        "guardrail_check_uncertainty",
-				Description: "Force self-reflection when confidence in next step is low",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"current_plan": map[string]interface{}{
-							"type":        "string",
-							"description": "Description of the current plan",
-						},
-						"uncertainty_reason": map[string]interface{}{
-							"type":        "string",
-							"description": "Reason for uncertainty",
-						},
+		},
+		{
+			Name:        "guardrail_validate_three_strikes",
+			Description: "Check whether a task has reached its failed attempt limit (three by default, configurable per error category) and must be escalated",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"task_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Identifier of the current task",
+					},
+					"error_category": map[string]interface{}{
+						"type":        "string",
+						"description": "Apply the limit for this kind of failure (default: the limit closest to being reached)",
+						"enum":        []string{"syntax", "runtime", "logic", "timeout", "test_flake", "other"},
 					},
-					Required: []string{"current_plan", "uncertainty_reason"},
 				},
+				Required: []string{"session_token"},
 			},
-			{
-				Name:        "guardrail_check_halt_conditions",
-				Description: "Evaluate if current state requires manual human escalation",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"status": map[string]interface{}{
-							"type":        "string",
-							"description": "Current system/task status",
-						},
+		},
+		{
+			Name:        "guardrail_validate_exact_replacement",
+			Description: "Verify that an edit changes only what was intended, comparing file content before and after",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "File being edited",
+					},
+					"original_content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content before the edit (empty for a new file)",
+					},
+					"modified_content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content after the edit (default: the current file content)",
+					},
+					"replacement_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Kind of replacement being made, e.g. line or block",
+					},
+					"language": map[string]interface{}{
+						"type":        "string",
+						"description": "Language of the code (go, python, javascript, typescript, rust, java); when omitted, patterns for all of them are used",
+						"enum":        []string{"go", "python", "javascript", "typescript", "rust", "java"},
 					},
 				},
+				Required: []string{"session_token", "file_path"},
 			},
-			{
-				Name:        "guardrail_record_halt",
-				Description: "Record a system-forced halt event",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"reason": map[string]interface{}{
-							"type":        "string",
-							"description": "Reason for the halt",
-						},
+		},
+		{
+			Name:        "guardrail_reset_attempts",
+			Description: "Reset the failed attempt counter for a task",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"task_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Identifier of the task to reset",
 					},
-					Required: []string{"reason"},
 				},
+				Required: []string{"session_token"},
 			},
-			{
-				Name:        "guardrail_acknowledge_halt",
-				Description: "Acknowledged a previously recorded halt to resume operation",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"halt_id": map[string]interface{}{
-							"type":        "string",
-							"description": "ID of the halt being acknowledged",
-						},
+		},
+		{
+			Name:        "guardrail_check_uncertainty",
+			Description: "Force self-reflection when confidence in next step is low",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"current_plan": map[string]interface{}{
+						"type":        "string",
+						"description": "Description of the current plan",
+					},
+					"uncertainty_reason": map[string]interface{}{
+						"type":        "string",
+						"description": "Reason for uncertainty",
 					},
-					Required: []string{"halt_id"},
 				},
+				Required: []string{"current_plan", "uncertainty_reason"},
 			},
-			{
-				Name:        "guardrail_validate_production_first",
-				Description: "Ensure production changes are prioritized or isolated correctly",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"path": map[string]interface{}{
-							"type":        "string",
-							"description": "Path being modified",
-						},
+		},
+		{
+			Name:        "guardrail_check_halt_conditions",
+			Description: "Evaluate if current state requires manual human escalation",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"task_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Task whose three strikes status is checked",
+					},
+					"context": map[string]interface{}{
+						"type":        "object",
+						"description": "Halt indicators: should_halt (bool), error_rate (0-1) and error_rate_threshold (0-1, default 0.5)",
 					},
 				},
+				Required: []string{"session_token"},
 			},
-			{
-				Name:        "guardrail_detect_feature_creep",
-				Description: "Analyze if changes exceed original task scope",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"task_id": map[string]interface{}{
-							"type":        "string",
-							"description": "Original task identifier",
-						},
-						"current_changes": map[string]interface{}{
-							"type":        "string",
-							"description": "Diff or summary of changes so far",
-						},
+		},
+		{
+			Name:        "guardrail_record_halt",
+			Description: "Record a system-forced halt event",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"halt_type": map[string]interface{}{
+						"type":        "string",
+						"description": "Kind of halt condition",
+						"enum":        []string{"code_safety", "scope", "environment", "execution", "security", "uncertainty"},
+					},
+					"description": map[string]interface{}{
+						"type":        "string",
+						"description": "Reason for the halt",
+					},
+					"severity": map[string]interface{}{
+						"type":        "string",
+						"description": "Halt severity (default medium)",
+						"enum":        []string{"low", "medium", "high", "critical"},
+					},
+					"context": map[string]interface{}{
+						"type":        "object",
+						"description": "Additional details stored with the halt event",
 					},
-					Required: []string{"task_id", "current_changes"},
 				},
+				Required: []string{"session_token", "halt_type"},
 			},
-			{
-				Name:        "guardrail_verify_fixes_intact",
-				Description: "Ensure recent bugfixes haven't been regressed by new edits",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"bug_id": map[string]interface{}{
-							"type":        "string",
-							"description": "Known bug ID or description",
-						},
-						"file_path": map[string]interface{}{
-							"type":        "string",
-							"description": "File to check",
-						},
+		},
+		{
+			Name:        "guardrail_acknowledge_halt",
+			Description: "Acknowledge a previously recorded halt to resume operation",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"halt_id": map[string]interface{}{
+						"type":        "string",
+						"description": "ID of the halt being acknowledged",
+					},
+					"resolution": map[string]interface{}{
+						"type":        "string",
+						"description": "How the halt was resolved (default pending)",
+						"enum":        []string{"pending", "resolved", "escalated", "dismissed", "abandoned"},
 					},
-					Required: []string{"bug_id", "file_path"},
 				},
+				Required: []string{"session_token", "halt_id"},
 			},
-			{
-				Name:        "guardrail_team_init",
-				Description: "Initialize a new project team with roles and rules",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the project",
-						},
-						"teams": map[string]interface{}{
-							"type":        "array",
-							"items":       map[string]interface{}{"type": "string"},
-							"description": "List of team names to initialize",
-						},
+		},
+		{
+			Name:        "guardrail_validate_production_first",
+			Description: "Ensure production changes are prioritized or isolated correctly",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path being modified",
 					},
-					Required: []string{"project_name", "teams"},
 				},
 			},
-			{
-				Name:        "guardrail_team_list",
-				Description: "List all active teams and their configurations",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Filter by project name",
+		},
+		{
+			Name:        "guardrail_detect_feature_creep",
+			Description: "Analyze if changes exceed original task scope",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "File the changes apply to",
+					},
+					"git_diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff of the changes",
+					},
+					"change_description": map[string]interface{}{
+						"type":        "string",
+						"description": "Description of the task the changes are for",
+					},
+					"is_new_file": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether the file is newly created",
+					},
+					"language": map[string]interface{}{
+						"type":        "string",
+						"description": "Language of the code (go, python, javascript, typescript, rust, java); when omitted, patterns for all of them are used",
+						"enum":        []string{"go", "python", "javascript", "typescript", "rust", "java"},
+					},
+					"thresholds": map[string]interface{}{
+						"type":        "object",
+						"description": "Limits merged over the defaults; a count must exceed its limit to be reported",
+						"properties": map[string]interface{}{
+							"max_additions":      map[string]interface{}{"type": "integer", "minimum": 0, "description": "Added lines allowed in the diff (default 100)"},
+							"new_file_additions": map[string]interface{}{"type": "integer", "minimum": 0, "description": "Added lines allowed in a new file (default 50)"},
+							"max_new_functions":  map[string]interface{}{"type": "integer", "minimum": 0, "description": "New functions allowed (default 1)"},
+							"max_new_imports":    map[string]interface{}{"type": "integer", "minimum": 0, "description": "New imports allowed (default 3)"},
 						},
 					},
 				},
+				Required: []string{"session_token", "file_path", "git_diff"},
 			},
-			{
-				Name:        "guardrail_team_config_get",
-				Description: "Get detailed configuration for a specific team",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the project",
-						},
-						"team_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the team",
-						},
+		},
+		{
+			Name:        "guardrail_verify_fixes_intact",
+			Description: "Ensure recent bugfixes haven't been regressed by new edits",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "File to check",
+					},
+					"original_content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content before the edit",
+					},
+					"modified_content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content after the edit",
 					},
-					Required: []string{"project_name", "team_name"},
 				},
+				Required: []string{"session_token", "file_path"},
 			},
-			{
-				Name:        "guardrail_team_config_update",
-				Description: "Update rules or roles for an existing team",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the project",
-						},
-						"team_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the team",
-						},
-						"config": map[string]interface{}{
-							"type":        "object",
-							"description": "New configuration data",
-						},
+		},
+		{
+			Name:        "guardrail_validate_api_stability",
+			Description: "Check that an edit to a Go file preserves exported function signatures, types and struct fields",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path of the Go file being edited (non-Go files are skipped)",
+					},
+					"original_content": map[string]interface{}{
+						"type":        "string",
+						"description": "File content before the edit",
+					},
+					"modified_content": map[string]interface{}{
+						"type":        "string",
+						"description": "File content after the edit",
 					},
-					Required: []string{"project_name", "team_name", "config"},
 				},
+				Required: []string{"original_content", "modified_content"},
 			},
-			{
-				Name:        "guardrail_advisor_list",
-				Description: "List all available AI advisors and their specialties",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
+		},
+		{
+			Name:        "guardrail_validate_comment_ratio",
+			Description: "Check that added code meets a minimum comment ratio and that added exported Go declarations have doc comments",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or new file content) to analyze",
+					},
+					"min_ratio": map[string]interface{}{
+						"type":        "number",
+						"description": "Minimum comment lines per code line for added code (default: 0.1)",
+					},
+					"min_code_lines": map[string]interface{}{
+						"type":        "number",
+						"description": "Only enforce the ratio when at least this many code lines are added (default: 20)",
+					},
 				},
+				Required: []string{"diff"},
 			},
-			{
-				Name:        "guardrail_advisor_query",
-				Description: "Ask a specialist AI advisor for guidance on a specific topic",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"advisor_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the specialist advisor",
-						},
-						"query": map[string]interface{}{
-							"type":        "string",
-							"description": "Your question or request",
-						},
+		},
+		{
+			Name:        "guardrail_validate_file_permissions",
+			Description: "Flag added code that sets world-writable file modes (e.g. 0777) or writes to predictable paths under /tmp",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or new file content) to scan",
 					},
-					Required: []string{"advisor_name", "query"},
 				},
+				Required: []string{"diff"},
 			},
-			{
-				Name:        "guardrail_team_assign",
-				Description: "Assign a specific team member (AI advisor) to a project",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Project name",
-						},
-						"advisor_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Advisor to assign",
-						},
-						"role": map[string]interface{}{
-							"type":        "string",
-							"description": "Specific project role",
-						},
+		},
+		{
+			Name:        "guardrail_detect_hardcoded_endpoints",
+			Description: "Flag hardcoded IP addresses and absolute URLs in added lines that should be config-driven (localhost and documented examples are ignored)",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or new file content) to scan",
 					},
-					Required: []string{"project_name", "advisor_name"},
 				},
+				Required: []string{"diff"},
 			},
-			{
-				Name:        "guardrail_team_remove",
-				Description: "Remove a team or advisor assignment from a project",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Project name",
-						},
-						"team_id": map[string]interface{}{
-							"type":        "number",
-							"description": "Team ID to delete (1-12)",
-						},
-						"confirmed": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Set to true to confirm deletion. First call without this to see confirmation prompt.",
-						},
+		},
+		{
+			Name:        "guardrail_validate_migration_order",
+			Description: "Validate that database migration versions are monotonically ordered and that pending migrations are newer than the latest applied one",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"migrations": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Migration filenames in the order they will run",
+					},
+					"new_migrations": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Migration filenames added by this change (optional; migrations after latest_applied are also treated as pending)",
+					},
+					"latest_applied": map[string]interface{}{
+						"type":        "string",
+						"description": "Filename or version of the latest migration applied to the target database",
 					},
 				},
+				Required: []string{"migrations"},
 			},
-			{
-				Name:        "guardrail_project_delete",
-				Description: "Delete an entire project and all its teams. Requires confirmation.",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Name of the project to delete",
-						},
-						"confirmed": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Set to true to confirm deletion. First call without this to see confirmation prompt.",
-						},
+		},
+		{
+			Name:        "guardrail_validate_integration_contract",
+			Description: "Validate a message/event schema change against its consumers: flags removed or retyped fields consumers read and renamed topics",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"original_schema": map[string]interface{}{
+						"type":        "string",
+						"description": "Message schema before the change (JSON Schema or sample JSON payload)",
+					},
+					"modified_schema": map[string]interface{}{
+						"type":        "string",
+						"description": "Message schema after the change (JSON Schema or sample JSON payload)",
+					},
+					"topic": map[string]interface{}{
+						"type":        "string",
+						"description": "Topic or queue the message is published to",
+					},
+					"new_topic": map[string]interface{}{
+						"type":        "string",
+						"description": "New topic or queue name if it is being renamed",
+					},
+					"consumers": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "object"},
+						"description": "Consumers as {name, topic, fields}; defaults to .guardrails/consumer-registry.json",
 					},
 				},
+				Required: []string{"original_schema", "modified_schema"},
 			},
-			{
-				Name:        "guardrail_team_health",
-				Description: "Check team_manager.py health status - validates Python backend and file system access",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"project_name": map[string]interface{}{
-							"type":        "string",
-							"description": "Optional: Project name for config directory check",
-						},
+		},
+		{
+			Name:        "guardrail_validate_timeout_usage",
+			Description: "Scan added Go code for external calls made with a root context and no deadline, HTTP clients without timeouts and time.Sleep in request paths",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
 					},
 				},
+				Required: []string{"diff"},
 			},
-			{
-				Name:        "guardrail_install_skills",
-				Description: "Install or clone guardrails skill configs. Use 'skill' for per-skill install/clone, 'platforms' for full platform install, or 'path' for single-file clone.",
-				InputSchema: mcp.ToolInputSchema{
-					Type: "object",
-					Properties: mcp.ToolInputSchemaProperties{
-						"target_path": map[string]interface{}{
-							"type":        "string",
-							"description": "Target project directory path (default: current directory)",
-						},
-						"platforms": map[string]interface{}{
-							"type":        "string",
-							"description": "Comma-separated list of platforms: claude, cursor, opencode, windsurf, copilot (default: all). Use with action=install.",
-						},
-						"skill": map[string]interface{}{
-							"type":        "string",
-							"description": "Install a single skill by name (e.g. 'guardrails-enforcer', 'commit-validator', 'four-laws'). Use action=install. Run list_skills=true to see all.",
-						},
-						"path": map[string]interface{}{
-							"type":        "string",
-							"description": "Clone a single file by repo path (e.g. '.claude/skills/guardrails-enforcer.json'). Downloads from GitHub raw. Use with action=clone.",
-						},
-						"action": map[string]interface{}{
-							"type":        "string",
-							"description": "Action to perform: 'install' (default), 'clone' (download from GitHub), 'list' (list skills/platforms)",
-							"enum":        []string{"install", "clone", "list"},
-						},
-						"list_skills": map[string]interface{}{
-							"type":        "boolean",
-							"description": "List all available skills and exit",
-						},
-						"list_platforms": map[string]interface{}{
-							"type":        "boolean",
-							"description": "List all available platforms and exit",
+		},
+		{
+			Name:        "guardrail_validate_commit_atomicity",
+			Description: "Score whether a commit is atomic (one concern) by combining feature-creep, file-type mixing and diff-size signals; returns the score and contributing factors",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff of the commit",
+					},
+					"commit_message": map[string]interface{}{
+						"type":        "string",
+						"description": "Commit message or change description (optional)",
+					},
+					"min_score": map[string]interface{}{
+						"type":        "number",
+						"description": "Minimum score (0-100) for the commit to count as atomic (default 70)",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_logging_level",
+			Description: "Flag added log statements at inappropriate levels: Debug/Info inside loops, Error for expected conditions, and messages without structured fields",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_config_drift",
+			Description: "Compare a desired (IaC) configuration against the observed configuration and report drift ranked by significance",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"desired": map[string]interface{}{
+						"type":        "object",
+						"description": "Desired configuration (object or JSON string)",
+					},
+					"observed": map[string]interface{}{
+						"type":        "object",
+						"description": "Observed/current configuration (object or JSON string)",
+					},
+					"ignore_keys": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Dotted key prefixes to skip (e.g. metadata.resourceVersion)",
+					},
+				},
+				Required: []string{"desired", "observed"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_idempotency",
+			Description: "Scan added handler code for POST/PUT/PATCH endpoints that do not support idempotency keys and for non-idempotent side effects (inserts, charges, publishes) without guards",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_delete",
+			Description: "Validate a file deletion: refuses protected paths (.git, go.mod, migrations), files outside the authorized scope and files not read in this session",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path to the file to delete",
+					},
+					"authorized_scope": map[string]interface{}{
+						"type":        "string",
+						"description": "Root directory the agent is allowed to modify (optional)",
+					},
+				},
+				Required: []string{"session_token", "file_path"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_endpoint_auth",
+			Description: "Scan added route registrations for POST/PUT/PATCH/DELETE endpoints with no auth middleware, authenticated route group or explicit auth check in the handler",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
+					},
+					"public_routes": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Route paths that are intentionally public, exact or glob (e.g. /webhooks/*)",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_pii_logging",
+			Description: "Flag added code that logs or serializes fields classified as sensitive or restricted, using a classification map and/or classification tags in the code",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
+					},
+					"classification": map[string]interface{}{
+						"type":        "object",
+						"description": "Field name to classification (public, internal, sensitive, restricted); merged with classification tags found in the diff",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_readme_sync",
+			Description: "Flag CLI flags, commands and config options added in a changeset without a matching README/docs update",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff of the changeset, including any documentation changes",
+					},
+					"changed_files": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Other files changed in the changeset whose content is not in the diff",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_env_parity",
+			Description: "Compare environment variables across environments (dev/staging/prod) and flag variables used in code but missing from an environment, defined in only some environments, or defined inconsistently",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"environments": map[string]interface{}{
+						"type":        "object",
+						"description": "Map of environment name to its variables, each given as an object of name to value or as dotenv file text",
+					},
+					"referenced_vars": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Environment variables the code reads; each must be defined in every environment",
+					},
+				},
+				Required: []string{"environments"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_graceful_shutdown",
+			Description: "Flag added service entry points that start a server without trapping SIGTERM/SIGINT, shutting the server down gracefully, or cancelling background work",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_metric_naming",
+			Description: "Check Prometheus metrics registered in a diff for snake_case names, _total on counters, base-unit suffixes (_seconds, _bytes) and high-cardinality labels such as user ids",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_check_failure_quality",
+			Description: "Score a failure registry entry's completeness (regression pattern, root cause, affected files) and flag entries regression checks cannot use",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "UUID of a stored failure entry to check; when omitted the entry is given inline",
+					},
+					"failure_id": map[string]interface{}{
+						"type":        "string",
+						"description": "Failure identifier of the inline entry",
+					},
+					"error_message": map[string]interface{}{
+						"type":        "string",
+						"description": "Error message of the failure",
+					},
+					"root_cause": map[string]interface{}{
+						"type":        "string",
+						"description": "Why the failure happened",
+					},
+					"affected_files": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Files the failure affects",
+					},
+					"regression_pattern": map[string]interface{}{
+						"type":        "string",
+						"description": "Regular expression matching code that reintroduces the failure",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_validate_commit_frequency",
+			Description: "Flag a file that changes too often (high churn) or a critical file that has gone stale, from its commit history",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "File the commit history belongs to",
+					},
+					"commits": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Commit timestamps for the file (RFC 3339, git %ci format or YYYY-MM-DD), e.g. from git log --format=%cI -- <file>",
+					},
+					"critical": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Whether the file is critical and should be flagged when stale",
+					},
+					"window_days": map[string]interface{}{
+						"type":        "integer",
+						"description": "Days over which recent commits are counted (default 30)",
+					},
+					"max_commits": map[string]interface{}{
+						"type":        "integer",
+						"description": "Commits allowed in the window before the file is high churn (default 10)",
+					},
+					"stale_days": map[string]interface{}{
+						"type":        "integer",
+						"description": "Days without a commit after which a critical file is stale (default 180)",
+					},
+					"as_of": map[string]interface{}{
+						"type":        "string",
+						"description": "Time to measure from (default now)",
+					},
+				},
+				Required: []string{"file_path", "commits"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_dependency_graph",
+			Description: "Detect import cycles in a package dependency graph, flagging cycles a change introduces with their path",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"graph": map[string]interface{}{
+						"type":        "object",
+						"description": "Adjacency list of package to the packages it imports (object or JSON string)",
+					},
+					"files": map[string]interface{}{
+						"type":        "object",
+						"description": "Go source files keyed by path, scanned for imports when graph is not given; may include go.mod",
+					},
+					"module": map[string]interface{}{
+						"type":        "string",
+						"description": "Module path of the scanned files (read from go.mod when omitted)",
+					},
+					"baseline_graph": map[string]interface{}{
+						"type":        "object",
+						"description": "Adjacency list before the change; when given, only cycles through imports it lacks fail the check",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_validate_commit_author",
+			Description: "Validate a commit's author against the allowed email domains and authors, and flag bot commits where a human is required (or vice versa)",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"author_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Commit author name",
+					},
+					"author_email": map[string]interface{}{
+						"type":        "string",
+						"description": "Commit author email",
+					},
+					"allowed_domains": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Email domains authors must belong to, subdomains included (default: allowed_author_domains in .guardrails/git-policy.json)",
+					},
+					"allowed_authors": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Individual emails allowed regardless of domain (default: allowed_authors in .guardrails/git-policy.json)",
+					},
+					"require": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"human", "bot", "any"},
+						"description": "Whether the commit must be authored by a human or a bot (default any)",
+					},
+				},
+				Required: []string{"author_email"},
+			},
+		},
+		{
+			Name:        "guardrail_scan_secrets",
+			Description: "Scan content before writing it for embedded secrets (API keys, tokens, private keys); returns each finding's type, line and a redacted snippet",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content to scan",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path the content is for; the file is read and scanned when content is omitted",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_validate_secret_scanning_baseline",
+			Description: "Compare secret scanning findings with the project's baseline of known findings and report only new ones; add or remove findings in the stored baseline",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project": map[string]interface{}{
+						"type":        "string",
+						"description": "Project whose stored baseline applies (required to add or remove entries)",
+					},
+					"findings": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"type":        map[string]interface{}{"type": "string"},
+								"file_path":   map[string]interface{}{"type": "string"},
+								"line":        map[string]interface{}{"type": "integer"},
+								"snippet":     map[string]interface{}{"type": "string"},
+								"fingerprint": map[string]interface{}{"type": "string"},
+							},
+						},
+						"description": "Current findings, e.g. from guardrail_scan_secrets with file_path set",
+					},
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "Content to scan for findings instead of passing them",
+					},
+					"file_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Path of the scanned content",
+					},
+					"baseline": map[string]interface{}{
+						"type":        "array",
+						"description": "Additional known findings, as fingerprints or findings, checked along with the stored baseline",
+					},
+					"action": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"check", "add", "remove"},
+						"description": "check reports new findings (default); add and remove update the stored baseline with the findings",
+					},
+					"reason": map[string]interface{}{
+						"type":        "string",
+						"description": "Why the findings are baselined, e.g. test fixture (for add)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_validate_goroutine_lifecycle",
+			Description: "Scan added Go code for go func() launches with no way to stop or wait for them (no context, WaitGroup or done channel), flagging potential goroutine leaks",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff (or added code) to scan",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_feature_toggle_cleanup",
+			Description: "Flag feature flags in a registry that are older than the allowed age as candidates for removal",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"flags": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"name":       map[string]interface{}{"type": "string"},
+								"created_at": map[string]interface{}{"type": "string", "description": "Creation date (RFC 3339 or YYYY-MM-DD)"},
+								"owner":      map[string]interface{}{"type": "string"},
+								"permanent":  map[string]interface{}{"type": "boolean", "description": "Long-lived flag, such as a kill switch, exempt from cleanup"},
+							},
+							"required": []string{"name", "created_at"},
+						},
+						"description": "Feature-flag registry entries",
+					},
+					"max_age_days": map[string]interface{}{
+						"type":        "integer",
+						"description": "Age in days after which a flag is stale (default 90)",
+					},
+					"as_of": map[string]interface{}{
+						"type":        "string",
+						"description": "Time to measure from (default now)",
+					},
+				},
+				Required: []string{"flags"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_deployment_window",
+			Description: "Check whether a deployment to an environment is allowed at a given time: not in a change freeze, not in incident mode and within a deploy window, from the project's .guardrails/deploy-policy.json",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"environment": map[string]interface{}{
+						"type":        "string",
+						"description": "Target environment, e.g. production",
+					},
+					"deploy_time": map[string]interface{}{
+						"type":        "string",
+						"description": "Intended deploy time (RFC 3339, default now)",
+					},
+				},
+				Required: []string{"environment"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_backup_policy",
+			Description: "Check a backup configuration against policy: a defined schedule, retention within bounds, offsite and redundant storage and a documented restore-test cadence",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"backup": map[string]interface{}{
+						"type":        "object",
+						"description": "Backup configuration: schedule (cron expression or hourly/daily/weekly/monthly/continuous), retention_days, storage [{location, offsite}] and restore_test_interval_days",
+					},
+					"min_retention_days": map[string]interface{}{
+						"type":        "number",
+						"description": "Shortest allowed retention in days (default 7)",
+					},
+					"max_retention_days": map[string]interface{}{
+						"type":        "number",
+						"description": "Longest allowed retention in days (default 365)",
+					},
+				},
+				Required: []string{"backup"},
+			},
+		},
+		{
+			Name:        "guardrail_close_session",
+			Description: "Close a guardrail session when the agent is done so its token stops working at once instead of expiring later. Closing an already closed session succeeds",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"session_token": map[string]interface{}{
+						"type":        "string",
+						"description": "Session token from guardrail_init_session",
+					},
+				},
+				Required: []string{"session_token"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_incident_runbook",
+			Description: "Check that an incident runbook (markdown) has detection, triage, mitigation, rollback, communications and postmortem sections, links the postmortem and lists on-call and escalation contacts",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"content": map[string]interface{}{
+						"type":        "string",
+						"description": "Markdown content of the runbook",
+					},
+				},
+				Required: []string{"content"},
+			},
+		},
+		{
+			Name:        "guardrail_rule_coverage",
+			Description: "Report which active prevention rules have matched in at least one validation and which have never fired, to find dead or untested rules",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+			},
+		},
+		{
+			Name:        "guardrail_validate_commit_size",
+			Description: "Flag commits that change more lines or files than a reviewer can take in, with separate limits for generated files such as lockfiles and generated code; recommends splitting oversized commits",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff of the commit",
+					},
+					"files": map[string]interface{}{
+						"type":        "array",
+						"description": "Changed files as {path, additions, deletions} objects, used when no diff is given",
+						"items": map[string]interface{}{
+							"type": "object",
 						},
-						"mode": map[string]interface{}{
-							"type":        "string",
-							"description": "Installation mode: 'copy' or 'symlink' (default: copy). Applies to action=install.",
-							"enum":        []string{"copy", "symlink"},
+					},
+					"max_lines": map[string]interface{}{
+						"type":        "number",
+						"description": "Most hand-written lines (added plus removed) a commit may change (default 400)",
+					},
+					"max_files": map[string]interface{}{
+						"type":        "number",
+						"description": "Most hand-written files a commit may change (default 15)",
+					},
+					"max_generated_lines": map[string]interface{}{
+						"type":        "number",
+						"description": "Most generated lines a commit may change (default 5000)",
+					},
+					"max_generated_files": map[string]interface{}{
+						"type":        "number",
+						"description": "Most generated files a commit may change (default 100)",
+					},
+					"generated_globs": map[string]interface{}{
+						"type":        "array",
+						"description": "Globs for generated files, replacing the defaults (lockfiles, *.pb.go, vendor/**, dist/** and similar)",
+						"items": map[string]interface{}{
+							"type": "string",
 						},
-						"dry_run": map[string]interface{}{
-							"type":        "boolean",
-							"description": "Preview what would be done without making changes (default: false)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_validate_dependency_freshness",
+			Description: "Flag a dependency that is too many major or minor versions behind its latest release, or affected by a known advisory (CVE), and recommend the version to upgrade to",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"name": map[string]interface{}{
+						"type":        "string",
+						"description": "Dependency name, e.g. github.com/lib/pq or lodash",
+					},
+					"current_version": map[string]interface{}{
+						"type":        "string",
+						"description": "Version in use, e.g. 1.10.2 or v1.10.2",
+					},
+					"latest_version": map[string]interface{}{
+						"type":        "string",
+						"description": "Latest available version",
+					},
+					"advisories": map[string]interface{}{
+						"type":        "array",
+						"description": "Known advisories as {id, package, severity, fixed_version, summary} objects; package defaults to name",
+						"items": map[string]interface{}{
+							"type": "object",
 						},
 					},
+					"max_majors_behind": map[string]interface{}{
+						"type":        "number",
+						"description": "Major versions the dependency may trail the latest before it is an error (default 1)",
+					},
+					"max_minors_behind": map[string]interface{}{
+						"type":        "number",
+						"description": "Minor versions the dependency may trail the latest of its major before it is a warning (default 5)",
+					},
 				},
+				Required: []string{"name", "current_version", "latest_version"},
 			},
-		}
+		},
+		{
+			Name:        "guardrail_validate_schema_evolution",
+			Description: "Check an Avro, Protobuf or JSON Schema change against a compatibility mode (backward, forward or full) and flag incompatible changes such as required fields added or removed, type changes and reused Protobuf field numbers",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"old_schema": map[string]interface{}{
+						"type":        "string",
+						"description": "Schema text currently registered",
+					},
+					"new_schema": map[string]interface{}{
+						"type":        "string",
+						"description": "Proposed schema text",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"avro", "protobuf", "json_schema"},
+						"description": "Schema format (detected from old_schema when omitted)",
+					},
+					"mode": map[string]interface{}{
+						"type":        "string",
+						"enum":        []string{"backward", "forward", "full"},
+						"description": "backward: new consumers read old data; forward: old consumers read new data; full: both (default backward)",
+					},
+				},
+				Required: []string{"old_schema", "new_schema"},
+			},
+		},
+		{
+			Name:        "guardrail_rate_limit_stats",
+			Description: "Show the team tool rate limits, how many callers hold a bucket and the callers that have used the most of their current window, to diagnose throttling",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"top_n": map[string]interface{}{
+						"type":        "number",
+						"description": "Number of top consumers to list per limiter (default 10, max 100)",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_validate_commit_revert",
+			Description: "Validate a revert commit: the message must reference the reverted commit (This reverts commit <sha>) and use the revert conventional type, and given the revert and original diffs the revert must undo every change of the original commit",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "Full revert commit message",
+					},
+					"reverted_sha": map[string]interface{}{
+						"type":        "string",
+						"description": "SHA of the commit being reverted, checked against the one the message references",
+					},
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff of the revert commit",
+					},
+					"original_diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff of the reverted commit; with diff, checks the revert is complete",
+					},
+				},
+				Required: []string{"message"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_readonly_filesystem",
+			Description: "Check a Kubernetes workload, Docker Compose file or Dockerfile for container filesystem hardening gaps: a writable root filesystem (missing readOnlyRootFilesystem: true or read_only: true), privileged containers, and writable host or volume mounts",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"spec": map[string]interface{}{
+						"type":        "string",
+						"description": "Kubernetes manifest(s), Docker Compose file or Dockerfile content",
+					},
+					"format": map[string]interface{}{
+						"type":        "string",
+						"description": "Spec format; detected from the content when omitted",
+						"enum":        []string{"kubernetes", "compose", "dockerfile"},
+					},
+				},
+				Required: []string{"spec"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_env_var_addition",
+			Description: "Check environment variables newly read in a diff (Go os.Getenv/LookupEnv and env struct tags, Python os.getenv/os.environ, JavaScript process.env): each must be documented in the env reference or in documentation changed by the diff, should have a default or be validated at startup, and must not fall back to a secret written in the code",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"diff": map[string]interface{}{
+						"type":        "string",
+						"description": "Unified diff introducing the environment variable reads",
+					},
+					"env_reference": map[string]interface{}{
+						"type":        "string",
+						"description": "Environment variable reference the new variables must appear in, e.g. the content of .env.example or the configuration docs",
+					},
+				},
+				Required: []string{"diff"},
+			},
+		},
+		{
+			Name:        "guardrail_validate_commit_wip",
+			Description: "Detect work-in-progress markers (wip, fixup!, squash!, amend!, DO NOT MERGE) in commit messages; blocked on protected branches, warned on feature branches",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"branch": map[string]interface{}{
+						"type":        "string",
+						"description": "Branch the commits are going to",
+					},
+					"message": map[string]interface{}{
+						"type":        "string",
+						"description": "Commit message to check",
+					},
+					"messages": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Several commit messages to check, e.g. every commit of a push",
+					},
+					"protected_branches": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "Protected branches, \"name/*\" covering sub-branches (default: protected_branches in .guardrails/git-policy.json, then main, master, production and release)",
+					},
+				},
+				Required: []string{"branch"},
+			},
+		},
+		{
+			Name:        "guardrail_team_init",
+			Description: "Initialize a new project team with roles and rules",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the project",
+					},
+					"teams": map[string]interface{}{
+						"type":        "array",
+						"items":       map[string]interface{}{"type": "string"},
+						"description": "List of team names to initialize",
+					},
+				},
+				Required: []string{"project_name", "teams"},
+			},
+		},
+		{
+			Name:        "guardrail_team_list",
+			Description: "List all active teams and their configurations",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Filter by project name",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_team_config_get",
+			Description: "Get detailed configuration for a specific team",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the project",
+					},
+					"team_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the team",
+					},
+				},
+				Required: []string{"project_name", "team_name"},
+			},
+		},
+		{
+			Name:        "guardrail_team_config_update",
+			Description: "Update rules or roles for an existing team",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the project",
+					},
+					"team_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the team",
+					},
+					"config": map[string]interface{}{
+						"type":        "object",
+						"description": "New configuration data",
+					},
+				},
+				Required: []string{"project_name", "team_name", "config"},
+			},
+		},
+		{
+			Name:        "guardrail_advisor_list",
+			Description: "List all available AI advisors and their specialties",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+			},
+		},
+		{
+			Name:        "guardrail_advisor_query",
+			Description: "Ask a specialist AI advisor for guidance on a specific topic",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"advisor_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the specialist advisor",
+					},
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Your question or request",
+					},
+				},
+				Required: []string{"advisor_name", "query"},
+			},
+		},
+		{
+			Name:        "guardrail_team_assign",
+			Description: "Assign a specific team member (AI advisor) to a project",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name",
+					},
+					"advisor_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Advisor to assign",
+					},
+					"role": map[string]interface{}{
+						"type":        "string",
+						"description": "Specific project role",
+					},
+					"replace": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Unassign the current holder of the role first (default false)",
+					},
+				},
+				Required: []string{"project_name", "advisor_name"},
+			},
+		},
+		{
+			Name:        "guardrail_team_remove",
+			Description: "Remove a team or advisor assignment from a project",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name",
+					},
+					"team_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Team ID to delete (1-12)",
+					},
+					"confirmed": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Set to true to confirm deletion. First call without this to see confirmation prompt.",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_project_delete",
+			Description: "Delete an entire project and all its teams. Requires confirmation.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Name of the project to delete",
+					},
+					"confirmed": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Set to true to confirm deletion. First call without this to see confirmation prompt.",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_team_health",
+			Description: "Check team_manager.py health status - validates Python backend and file system access",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional: Project name for config directory check",
+					},
+				},
+			},
+		},
+		{
+			Name:        "guardrail_team_unassign",
+			Description: "Remove the person assigned to a role in a team",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name",
+					},
+					"team_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Team ID (1-12)",
+					},
+					"role_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Role to unassign",
+					},
+				},
+				Required: []string{"project_name", "team_id", "role_name"},
+			},
+		},
+		{
+			Name:        "guardrail_team_start",
+			Description: "Start a team, checking that the previous phase gate passed unless overridden",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name",
+					},
+					"team_id": map[string]interface{}{
+						"type":        "number",
+						"description": "Team ID (1-12)",
+					},
+					"override": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Start even if the phase gate has not passed",
+					},
+					"reason": map[string]interface{}{
+						"type":        "string",
+						"description": "Why the phase gate is overridden (required with override)",
+					},
+				},
+				Required: []string{"project_name", "team_id"},
+			},
+		},
+		{
+			Name:        "guardrail_team_status",
+			Description: "Get the status of a project's teams, or of one phase",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name",
+					},
+					"phase": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional: phase to report on",
+					},
+				},
+				Required: []string{"project_name"},
+			},
+		},
+		{
+			Name:        "guardrail_phase_gate_check",
+			Description: "Check whether the teams and deliverables required to move between two phases are complete",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name",
+					},
+					"from_phase": map[string]interface{}{
+						"type":        "number",
+						"description": "Phase being left",
+					},
+					"to_phase": map[string]interface{}{
+						"type":        "number",
+						"description": "Phase being entered",
+					},
+				},
+				Required: []string{"project_name", "from_phase", "to_phase"},
+			},
+		},
+		{
+			Name:        "guardrail_team_size_validate",
+			Description: "Check that every team in a project has 4-6 members",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"project_name": map[string]interface{}{
+						"type":        "string",
+						"description": "Project name",
+					},
+				},
+				Required: []string{"project_name"},
+			},
+		},
+		{
+			Name:        "guardrail_install_skills",
+			Description: "Install or clone guardrails skill configs. Use 'skill' for per-skill install/clone, 'platforms' for full platform install, or 'path' for single-file clone.",
+			InputSchema: mcp.ToolInputSchema{
+				Type: "object",
+				Properties: mcp.ToolInputSchemaProperties{
+					"target_path": map[string]interface{}{
+						"type":        "string",
+						"description": "Target project directory path (default: current directory)",
+					},
+					"platforms": map[string]interface{}{
+						"type":        "string",
+						"description": "Comma-separated list of platforms: claude, cursor, opencode, windsurf, copilot (default: all). Use with action=install.",
+					},
+					"skill": map[string]interface{}{
+						"type":        "string",
+						"description": "Install a single skill by name (e.g. 'guardrails-enforcer', 'commit-validator', 'four-laws'). Use action=install. Run list_skills=true to see all.",
+					},
+					"path": map[string]interface{}{
+						"type":        "string",
+						"description": "Clone a single file by repo path (e.g. '.claude/skills/guardrails-enforcer.json'). Downloads from GitHub raw. Use with action=clone.",
+					},
+					"action": map[string]interface{}{
+						"type":        "string",
+						"description": "Action to perform: 'install' (default), 'clone' (download from GitHub), 'list' (list skills/platforms)",
+						"enum":        []string{"install", "clone", "list"},
+					},
+					"list_skills": map[string]interface{}{
+						"type":        "boolean",
+						"description": "List all available skills and exit",
+					},
+					"list_platforms": map[string]interface{}{
+						"type":        "boolean",
+						"description": "List all available platforms and exit",
+					},
+					"mode": map[string]interface{}{
+						"type":        "string",
+						"description": "Installation mode: 'copy' or 'symlink' (default: copy). Applies to action=install.",
+						"enum":        []string{"copy", "symlink"},
+					},
+					"dry_run": map[string]interface{}{
+						"type":        "boolean",
+						"description": "Preview what would be done without making changes (default: false)",
+					},
+				},
+			},
+		},
+	}
 
-		if s.visionTools != nil {
-			tools = append(tools, s.visionTools.visionToolList()...)
-		}
+	if s.visionTools != nil {
+		tools = append(tools, s.visionTools.visionToolList()...)
+	}
 
-		// Webhook notification tools
-		if s.webhookStore != nil {
-			tools = append(tools, s.notificationToolList()...)
-		}
+	// Webhook notification tools
+	if s.webhookStore != nil {
+		tools = append(tools, s.notificationToolList()...)
+	}
 
-		// Budget management tools
-		if s.budgetStore != nil {
-			tools = append(tools, s.budgetToolList()...)
-		}
+	// Budget management tools
+	if s.budgetStore != nil {
+		tools = append(tools, s.budgetToolList()...)
+	}
 
-		// Agent lifecycle tools
-		if s.agentStateStore != nil {
-			tools = append(tools, s.lifecycleToolList()...)
-		}
+	// Agent lifecycle tools
+	if s.agentStateStore != nil {
+		tools = append(tools, s.lifecycleToolList()...)
+	}
 
+	return tools
+}
+
+func (s *MCPServer) setupHandlers() {
+	// Register tools
+	s.mcpServer.HandleListTools(func(ctx context.Context, cursor *string) (*mcp.ListToolsResult, error) {
 		return &mcp.ListToolsResult{
-			Tools: tools,
+			Tools: s.enabledTools(),
 		}, nil
 	})
 
 	// Handle tool calls
 	s.mcpServer.HandleCallTool(func(ctx context.Context, name string, arguments map[string]interface{}) (*mcp.CallToolResult, error) {
-		// Try vision tools first if enabled
-		if s.visionTools != nil {
-			result, err := s.visionTools.dispatch(ctx, name, arguments)
-			if err == nil {
-				return result, nil
-			}
-		}
 		return s.handleToolCall(ctx, name, arguments)
 	})
 
@@ -756,12 +2026,22 @@ func (s *MCPServer) setupHandlers() {
 					URI:  "guardrail://stats",
 					Name: "Guardrail Usage Stats",
 				},
+				{
+					URI:  activeRulesResourceURI,
+					Name: "Active Prevention Rules",
+				},
 			},
 		}, nil
 	})
 
 	// Handle resource read requests
 	s.mcpServer.HandleReadResource(func(ctx context.Context, uri string) (*mcp.ReadResourceResult, error) {
+		if strings.HasPrefix(uri, sessionResourcePrefix) {
+			return s.readSessionResource(ctx, uri)
+		}
+		if isActiveRulesResource(uri) {
+			return s.readActiveRulesResource(ctx, uri)
+		}
 		if uri == "guardrail://config" {
 			configJSON, _ := json.MarshalIndent(s.config, "", "  ")
 			return &mcp.ReadResourceResult{
@@ -778,9 +2058,59 @@ func (s *MCPServer) setupHandlers() {
 	})
 }
 
-func (s *MCPServer) handleToolCall(ctx context.Context, name string, args map[string]interface{}) (*mcp.CallToolResult, error) {
+func (s *MCPServer) handleToolCall(ctx context.Context, name string, args map[string]interface{}) (result *mcp.CallToolResult, err error) {
 	slog.Info("Tool call received", "name", name, "args", args)
 
+	done, ok := s.trackToolCall()
+	if !ok {
+		return &mcp.CallToolResult{
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: "Server is shutting down, tool call rejected"}},
+			IsError: true,
+		}, nil
+	}
+	defer done()
+
+	if s.disabledTools()[name] {
+		slog.Warn("Tool call rejected: tool disabled", "name", name)
+		return &mcp.CallToolResult{
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: fmt.Sprintf("Tool %s is disabled", name)}},
+			IsError: true,
+		}, nil
+	}
+
+	// Reject arguments outside declared enums before any handler runs
+	if schema, ok := s.toolSchema(name); ok {
+		if err := validateEnumArgs(schema, args); err != nil {
+			slog.Warn("Tool call rejected: invalid params", "name", name, "error", err)
+			return invalidParamsResult(name, err), nil
+		}
+	}
+
+	args = s.withBoundSessionToken(ctx, name, args)
+
+	// Return results in the shape the caller's session asked for
+	defer func() { result = s.applyResultSchemaVersion(ctx, name, args, result) }()
+
+	// Keep each validation's outcome for the project's validation trend
+	defer func() { s.recordValidationOutcome(name, args, result) }()
+
+	// Audit every rule that denied a command, edit or git operation
+	defer func() { s.auditRuleViolations(ctx, name, args, result) }()
+
+	// Edits to files the session never read are denied before the rules run
+	if name == "guardrail_validate_file_edit" {
+		if denied := s.enforceReadBeforeEdit(ctx, args); denied != nil {
+			return denied, nil
+		}
+	}
+
+	// Vision tools are dispatched separately when enabled
+	if s.visionTools != nil {
+		if result, err := s.visionTools.dispatch(ctx, name, args); err == nil {
+			return result, nil
+		}
+	}
+
 	switch name {
 	case "guardrail_init_session":
 		return s.handleInitSession(ctx, args)
@@ -802,6 +2132,8 @@ func (s *MCPServer) handleToolCall(ctx context.Context, name string, args map[st
 		return s.handlePreventRegression(ctx, args)
 	case "guardrail_check_test_prod_separation":
 		return s.handleCheckTestProdSeparation(ctx, args)
+	case "guardrail_validate_test_prod_separation":
+		return s.handleValidateTestProdSeparation(ctx, args)
 	case "guardrail_validate_push":
 		return s.handleValidatePush(ctx, args)
 	case "guardrail_record_file_read":
@@ -810,6 +2142,10 @@ func (s *MCPServer) handleToolCall(ctx context.Context, name string, args map[st
 		return s.handleRecordAttempt(ctx, args)
 	case "guardrail_verify_file_read":
 		return s.handleVerifyFileRead(ctx, args)
+	case "guardrail_list_file_reads":
+		return s.handleListFileReads(ctx, args)
+	case "guardrail_clear_file_reads":
+		return s.handleClearFileReads(ctx, args)
 	case "guardrail_validate_three_strikes":
 		return s.handleValidateThreeStrikes(ctx, args)
 	case "guardrail_validate_exact_replacement":
@@ -830,6 +2166,84 @@ func (s *MCPServer) handleToolCall(ctx context.Context, name string, args map[st
 		return s.handleDetectFeatureCreep(ctx, args)
 	case "guardrail_verify_fixes_intact":
 		return s.handleVerifyFixesIntact(ctx, args)
+	case "guardrail_validate_api_stability":
+		return s.handleValidateAPIStability(ctx, args)
+	case "guardrail_validate_comment_ratio":
+		return s.handleValidateCommentRatio(ctx, args)
+	case "guardrail_validate_file_permissions":
+		return s.handleValidateFilePermissions(ctx, args)
+	case "guardrail_detect_hardcoded_endpoints":
+		return s.handleDetectHardcodedEndpoints(ctx, args)
+	case "guardrail_validate_migration_order":
+		return s.handleValidateMigrationOrder(ctx, args)
+	case "guardrail_validate_integration_contract":
+		return s.handleValidateIntegrationContract(ctx, args)
+	case "guardrail_validate_timeout_usage":
+		return s.handleValidateTimeoutUsage(ctx, args)
+	case "guardrail_validate_commit_atomicity":
+		return s.handleValidateCommitAtomicity(ctx, args)
+	case "guardrail_validate_logging_level":
+		return s.handleValidateLoggingLevel(ctx, args)
+	case "guardrail_validate_config_drift":
+		return s.handleValidateConfigDrift(ctx, args)
+	case "guardrail_validate_idempotency":
+		return s.handleValidateIdempotency(ctx, args)
+	case "guardrail_validate_delete":
+		return s.handleValidateDelete(ctx, args)
+	case "guardrail_validate_endpoint_auth":
+		return s.handleValidateEndpointAuth(ctx, args)
+	case "guardrail_validate_pii_logging":
+		return s.handleValidatePIILogging(ctx, args)
+	case "guardrail_validate_readme_sync":
+		return s.handleValidateReadmeSync(ctx, args)
+	case "guardrail_validate_env_parity":
+		return s.handleValidateEnvParity(ctx, args)
+	case "guardrail_validate_graceful_shutdown":
+		return s.handleValidateGracefulShutdown(ctx, args)
+	case "guardrail_validate_metric_naming":
+		return s.handleValidateMetricNaming(ctx, args)
+	case "guardrail_check_failure_quality":
+		return s.handleCheckFailureQuality(ctx, args)
+	case "guardrail_validate_commit_frequency":
+		return s.handleValidateCommitFrequency(ctx, args)
+	case "guardrail_validate_dependency_graph":
+		return s.handleValidateDependencyGraph(ctx, args)
+	case "guardrail_validate_commit_author":
+		return s.handleValidateCommitAuthor(ctx, args)
+	case "guardrail_scan_secrets":
+		return s.handleScanSecrets(ctx, args)
+	case "guardrail_validate_secret_scanning_baseline":
+		return s.handleValidateSecretScanningBaseline(ctx, args)
+	case "guardrail_validate_goroutine_lifecycle":
+		return s.handleValidateGoroutineLifecycle(ctx, args)
+	case "guardrail_validate_feature_toggle_cleanup":
+		return s.handleValidateFeatureToggleCleanup(ctx, args)
+	case "guardrail_validate_deployment_window":
+		return s.handleValidateDeploymentWindow(ctx, args)
+	case "guardrail_validate_backup_policy":
+		return s.handleValidateBackupPolicy(ctx, args)
+	case "guardrail_close_session":
+		return s.handleCloseSession(ctx, args)
+	case "guardrail_validate_incident_runbook":
+		return s.handleValidateIncidentRunbook(ctx, args)
+	case "guardrail_rule_coverage":
+		return s.handleRuleCoverage(ctx, args)
+	case "guardrail_validate_commit_size":
+		return s.handleValidateCommitSize(ctx, args)
+	case "guardrail_validate_dependency_freshness":
+		return s.handleValidateDependencyFreshness(ctx, args)
+	case "guardrail_validate_schema_evolution":
+		return s.handleValidateSchemaEvolution(ctx, args)
+	case "guardrail_rate_limit_stats":
+		return s.handleRateLimitStats(ctx, args)
+	case "guardrail_validate_commit_revert":
+		return s.handleValidateCommitRevert(ctx, args)
+	case "guardrail_validate_readonly_filesystem":
+		return s.handleValidateReadonlyFilesystem(ctx, args)
+	case "guardrail_validate_env_var_addition":
+		return s.handleValidateEnvVarAddition(ctx, args)
+	case "guardrail_validate_commit_wip":
+		return s.handleValidateCommitWIP(ctx, args)
 	case "guardrail_team_init":
 		return s.handleTeamInit(ctx, args)
 	case "guardrail_team_list":
@@ -850,6 +2264,16 @@ func (s *MCPServer) handleToolCall(ctx context.Context, name string, args map[st
 		return s.handleProjectDelete(ctx, args)
 	case "guardrail_team_health":
 		return s.handleTeamHealth(ctx, args)
+	case "guardrail_team_unassign":
+		return s.handleTeamUnassign(ctx, args)
+	case "guardrail_team_start":
+		return s.handleTeamStart(ctx, args)
+	case "guardrail_team_status":
+		return s.handleTeamStatus(ctx, args)
+	case "guardrail_phase_gate_check":
+		return s.handlePhaseGateCheck(ctx, args)
+	case "guardrail_team_size_validate":
+		return s.handleTeamSizeValidate(ctx, args)
 	case "guardrail_install_skills":
 		return s.handleInstallSkills(ctx, args)
 	// Webhook notification tools
@@ -896,6 +2320,13 @@ func (s *MCPServer) handleToolCall(ctx context.Context, name string, args map[st
 func (s *MCPServer) handleInitSession(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	userID, _ := args["user_id"].(string)
 	env, _ := args["environment"].(string)
+	projectSlug, _ := args["project_slug"].(string)
+	schemaVersion, err := parseResultSchemaVersion(args)
+	if err != nil {
+		return buildToolResult(map[string]interface{}{
+			"error": err.Error(),
+		}, true)
+	}
 
 	token := make([]byte, 24) // 192 bits — sufficient entropy for session tokens
 	if _, err := rand.Read(token); err != nil {
@@ -904,32 +2335,146 @@ func (s *MCPServer) handleInitSession(ctx context.Context, args map[string]inter
 		}, true)
 	}
 	sessionID := hex.EncodeToString(token)
+	now := time.Now()
 
-	result := models.SessionInfo{
-		SessionID:   sessionID,
-		UserID:      userID,
-		Environment: env,
-		StartTime:   time.Now(),
+	session := &Session{
+		ID:                  sessionID,
+		UserID:              userID,
+		Environment:         env,
+		ProjectSlug:         projectSlug,
+		ResultSchemaVersion: schemaVersion,
+		CreatedAt:           now,
+		LastActivity:        now,
+	}
+	if err := s.sessions.Put(ctx, session); err != nil {
+		slog.Error("Failed to store session", "error", err)
+		return buildToolResult(map[string]interface{}{
+			"error": "failed to store session",
+		}, true)
+	}
+
+	// Guardrail sessions expire in the shared store where no replica sees it, so
+	// they are counted when created but not tracked in the active sessions gauge
+	metrics.RecordSessionCreated()
+	if s.audit != nil {
+		s.audit.LogSession(ctx, audit.EventSessionCreated, sessionID, projectSlug)
+	}
+
+	// On an SSE connection, later tool calls default to this session
+	if sseID, _ := ctx.Value(sseSessionKey{}).(string); sseID != "" && s.sseSessions != nil {
+		s.sseSessions.bindGuardrailSession(sseID, sessionID)
+	}
+
+	result := sessionInitResult{
+		SessionInfo: models.SessionInfo{
+			SessionID:   sessionID,
+			UserID:      userID,
+			Environment: env,
+			StartTime:   now,
+		},
+		ExpiresAt:                     now.Add(s.sessions.ttl),
+		ServerVersion:                 s.version,
+		Capabilities:                  s.capabilities(),
+		ResultSchemaVersion:           currentResultSchemaVersion,
+		SupportedResultSchemaVersions: supportedResultSchemaVersions(),
+	}
+	if schemaVersion != 0 {
+		result.ResultSchemaVersion = schemaVersion
 	}
 
 	return buildToolResult(result, false)
 }
 
+// trackToolCall registers an in-flight tool call. It returns false once shutdown
+// has started; otherwise the returned func must be called when the call completes.
+func (s *MCPServer) trackToolCall() (func(), bool) {
+	s.inFlightMu.Lock()
+	defer s.inFlightMu.Unlock()
+	if s.shuttingDown {
+		return nil, false
+	}
+	s.inFlight.Add(1)
+	return s.inFlight.Done, true
+}
+
+// waitForInFlight blocks until all in-flight tool calls complete or ctx expires
+func (s *MCPServer) waitForInFlight(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for in-flight tool calls: %w", ctx.Err())
+	}
+}
+
+// Start starts the MCP HTTP server
+func (s *MCPServer) Start(addr string) error {
+	return s.Serve(addr)
+}
+
+// Shutdown stops accepting new tool calls, waits for in-flight tool calls to finish,
+// then closes open SSE streams and stops the HTTP server, all bounded by ctx, so
+// that callers can safely close the database and cache afterwards.
+func (s *MCPServer) Shutdown(ctx context.Context) error {
+	s.inFlightMu.Lock()
+	s.shuttingDown = true
+	e := s.httpServer
+	s.inFlightMu.Unlock()
+
+	if s.stopCleanup != nil {
+		s.stopCleanupOnce.Do(func() { close(s.stopCleanup) })
+	}
+
+	// Tool calls finish before the streams close, so that their responses are
+	// still delivered
+	waitErr := s.waitForInFlight(ctx)
+
+	if s.sseSessions != nil {
+		s.sseSessions.closeStreams()
+	}
+	var shutdownErr error
+	if e != nil {
+		shutdownErr = e.Shutdown(ctx)
+	}
+
+	if waitErr != nil {
+		return waitErr
+	}
+	return shutdownErr
+}
+
 // Serve HTTP requests (SSE for MCP)
 func (s *MCPServer) Serve(addr string) error {
-	e := echo.New()
+	e := newMCPEcho()
+	s.inFlightMu.Lock()
+	s.httpServer = e
+	s.inFlightMu.Unlock()
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
+	// SSE connections: optional API key auth plus a per-IP connection rate limit.
+	// With auth disabled, loopback clients are not rate limited (local development).
+	sseAuth := sseAuthMiddleware(s.config.MCPAPIKey, s.config.SSEAuthEnabled)
+	connectLimit := sseRateLimitMiddleware(
+		newSSEConnectLimiter(s.config.SSEConnectRateLimit, s.config.SSEConnectRateWindow),
+		!s.config.SSEAuthEnabled,
+	)
+
 	e.GET("/mcp", func(c echo.Context) error {
-		s.mcpServer.HandleSSE(c.Response().Writer, c.Request())
+		s.handleSSE(c.Response().Writer, c.Request())
 		return nil
-	})
+	}, connectLimit, sseAuth)
 
 	e.POST("/mcp", func(c echo.Context) error {
-		s.mcpServer.HandleSSE(c.Response().Writer, c.Request())
+		s.handleSSEMessage(c.Response().Writer, c.Request())
 		return nil
-	})
+	}, sseAuth, clientIPMiddleware)
 
 	return e.Start(addr)
 }
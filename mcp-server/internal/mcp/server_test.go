@@ -0,0 +1,108 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// TestShutdownWaitsForInFlightToolCalls tests that Shutdown blocks until running tool calls finish
+func TestShutdownWaitsForInFlightToolCalls(t *testing.T) {
+	s := mockMCPServer()
+
+	done, ok := s.trackToolCall()
+	if !ok {
+		t.Fatal("trackToolCall() rejected call before shutdown")
+	}
+
+	shutdownErr := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownErr <- s.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-shutdownErr:
+		t.Fatalf("Shutdown() returned before in-flight call completed: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// New calls are rejected while shutting down
+	if _, ok := s.trackToolCall(); ok {
+		t.Error("trackToolCall() accepted call during shutdown")
+	}
+
+	done()
+
+	select {
+	case err := <-shutdownErr:
+		if err != nil {
+			t.Errorf("Shutdown() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Shutdown() did not return after in-flight call completed")
+	}
+}
+
+// TestShutdownTimesOut tests that Shutdown gives up waiting when the context expires
+func TestShutdownTimesOut(t *testing.T) {
+	s := mockMCPServer()
+
+	done, _ := s.trackToolCall()
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := s.Shutdown(ctx); err == nil {
+		t.Error("Shutdown() expected timeout error with a stuck tool call")
+	}
+}
+
+// TestShutdownClosesSSEStreams tests that an open SSE stream does not hold up
+// Shutdown until its deadline
+func TestShutdownClosesSSEStreams(t *testing.T) {
+	s := mockMCPServer()
+	s.sseSessions = newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{})
+
+	e := echo.New()
+	e.HideBanner = true
+	e.GET("/mcp", func(c echo.Context) error {
+		s.handleSSE(c.Response().Writer, c.Request())
+		return nil
+	})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	e.Listener = listener
+	s.httpServer = e
+	go e.Start("")
+
+	resp, err := http.Get("http://" + listener.Addr().String() + "/mcp")
+	if err != nil {
+		t.Fatalf("GET /mcp: %v", err)
+	}
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	if line, _ := reader.ReadString('\n'); !strings.HasPrefix(line, "event: endpoint") {
+		t.Fatalf("first stream line = %q, want the endpoint event", line)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() with a stream open error = %v, want it to close the stream", err)
+	}
+	if _, err := io.ReadAll(reader); err != nil {
+		t.Errorf("stream did not end cleanly: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+package mcp
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/thearchitectit/guardrail-mcp/internal/cache"
+)
+
+const (
+	// sessionCacheFreshness is how long a replica serves a session from memory before
+	// reading it from Redis again, so that a session removed by another replica stops
+	// working within this time
+	sessionCacheFreshness = 30 * time.Second
+	// sessionStoreTimeout bounds each session lookup
+	sessionStoreTimeout = 2 * time.Second
+)
+
+// Session is a guardrail session created by guardrail_init_session
+type Session struct {
+	ID           string    `json:"id"`
+	UserID       string    `json:"user_id,omitempty"`
+	Environment  string    `json:"environment,omitempty"`
+	ProjectSlug  string    `json:"project_slug,omitempty"`
+	AgentType    string    `json:"agent_type,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastActivity time.Time `json:"last_activity"`
+	// ResultSchemaVersion is the tool result shape the client asked for, 0 for current
+	ResultSchemaVersion int `json:"result_schema_version,omitempty"`
+}
+
+// sessionBackend is the shared store sessions are kept in, implemented by cache.Client
+type sessionBackend interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	SetExisting(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// sessionStore keeps sessions in Redis, so that they survive restarts and are seen
+// by every replica, with an in-memory LRU in front for hot reads. A session expires
+// after ttl without activity: lookups push the expiry back once a quarter of the
+// ttl has passed since the last write. Without a backend, sessions live only in
+// memory and are lost on restart or when the LRU evicts them.
+type sessionStore struct {
+	backend  sessionBackend
+	ttl      time.Duration
+	capacity int
+
+	mu      sync.Mutex
+	order   *list.List // *sessionCacheEntry, most recently used first
+	entries map[string]*list.Element
+}
+
+// sessionCacheEntry is a session held in the LRU
+type sessionCacheEntry struct {
+	session  Session
+	cachedAt time.Time
+}
+
+// newSessionStore creates a session store; backend may be nil
+func newSessionStore(backend sessionBackend, ttl time.Duration, capacity int) *sessionStore {
+	return &sessionStore{
+		backend:  backend,
+		ttl:      ttl,
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Put stores a session and restarts its expiry
+func (st *sessionStore) Put(ctx context.Context, session *Session) error {
+	if session.LastActivity.IsZero() {
+		session.LastActivity = time.Now()
+	}
+	if st.backend != nil {
+		data, err := json.Marshal(session)
+		if err != nil {
+			return fmt.Errorf("failed to marshal session: %w", err)
+		}
+		if err := st.backend.Set(ctx, sessionKey(session.ID), data, st.ttl); err != nil {
+			return fmt.Errorf("failed to store session: %w", err)
+		}
+	}
+	st.remember(*session)
+	return nil
+}
+
+// Get returns the session with id, or false if it does not exist or has expired
+func (st *sessionStore) Get(ctx context.Context, id string) (*Session, bool, error) {
+	if session, ok := st.cached(id); ok {
+		return st.touch(ctx, session)
+	}
+	if st.backend == nil {
+		return nil, false, nil
+	}
+
+	data, err := st.backend.Get(ctx, sessionKey(id))
+	if errors.Is(err, redis.Nil) {
+		st.forget(id)
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load session: %w", err)
+	}
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal session: %w", err)
+	}
+	st.remember(session)
+	return st.touch(ctx, session)
+}
+
+// Delete removes a session; deleting a missing session is not an error
+func (st *sessionStore) Delete(ctx context.Context, id string) error {
+	st.forget(id)
+	if st.backend == nil {
+		return nil
+	}
+	if err := st.backend.Delete(ctx, sessionKey(id)); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// touch records activity on a session, writing it back once a quarter of the ttl
+// has passed since the last write so that active sessions do not expire. The write
+// only replaces an existing record, so a session deleted or expired on another
+// replica is reported missing rather than brought back.
+func (st *sessionStore) touch(ctx context.Context, session Session) (*Session, bool, error) {
+	if time.Since(session.LastActivity) < st.ttl/4 {
+		return &session, true, nil
+	}
+	session.LastActivity = time.Now()
+	if st.backend != nil {
+		data, err := json.Marshal(session)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to marshal session: %w", err)
+		}
+		exists, err := st.backend.SetExisting(ctx, sessionKey(session.ID), data, st.ttl)
+		if err != nil {
+			slog.Warn("Failed to extend session", "error", err)
+			return &session, true, nil
+		}
+		if !exists {
+			st.forget(session.ID)
+			return nil, false, nil
+		}
+	}
+	st.remember(session)
+	return &session, true, nil
+}
+
+// cached returns a session from the LRU if it is fresh: not expired, and, with a
+// backend, read from it within sessionCacheFreshness
+func (st *sessionStore) cached(id string) (Session, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	elem, ok := st.entries[id]
+	if !ok {
+		return Session{}, false
+	}
+	entry := elem.Value.(*sessionCacheEntry)
+	expired := time.Since(entry.session.LastActivity) >= st.ttl
+	stale := st.backend != nil && time.Since(entry.cachedAt) >= sessionCacheFreshness
+	if expired || stale {
+		st.order.Remove(elem)
+		delete(st.entries, id)
+		return Session{}, false
+	}
+	st.order.MoveToFront(elem)
+	return entry.session, true
+}
+
+// remember adds or refreshes a session in the LRU, evicting the least recently used
+func (st *sessionStore) remember(session Session) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	entry := &sessionCacheEntry{session: session, cachedAt: time.Now()}
+	if elem, ok := st.entries[session.ID]; ok {
+		elem.Value = entry
+		st.order.MoveToFront(elem)
+		return
+	}
+	st.entries[session.ID] = st.order.PushFront(entry)
+	for st.capacity > 0 && st.order.Len() > st.capacity {
+		oldest := st.order.Back()
+		st.order.Remove(oldest)
+		delete(st.entries, oldest.Value.(*sessionCacheEntry).session.ID)
+	}
+}
+
+// forget drops a session from the LRU
+func (st *sessionStore) forget(id string) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	if elem, ok := st.entries[id]; ok {
+		st.order.Remove(elem)
+		delete(st.entries, id)
+	}
+}
+
+// sessionKey is the Redis key a session is stored under
+func sessionKey(id string) string {
+	return fmt.Sprintf(cache.KeySession, id)
+}
+
+// getSession returns the session for token. Store errors are logged and treated as
+// an unknown session.
+func (s *MCPServer) getSession(token string) (*Session, bool) {
+	if token == "" || s.sessions == nil {
+		return nil, false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sessionStoreTimeout)
+	defer cancel()
+	session, ok, err := s.sessions.Get(ctx, token)
+	if err != nil {
+		slog.Error("Session lookup failed", "error", err)
+		return nil, false
+	}
+	return session, ok
+}
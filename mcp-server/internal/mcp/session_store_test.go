@@ -0,0 +1,234 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// fakeSessionBackend is an in-memory sessionBackend that honours TTLs
+type fakeSessionBackend struct {
+	mu      sync.Mutex
+	values  map[string][]byte
+	expires map[string]time.Time
+	gets    int
+	failGet error
+}
+
+func newFakeSessionBackend() *fakeSessionBackend {
+	return &fakeSessionBackend{values: map[string][]byte{}, expires: map[string]time.Time{}}
+}
+
+func (f *fakeSessionBackend) Get(ctx context.Context, key string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	if f.failGet != nil {
+		return nil, f.failGet
+	}
+	value, ok := f.values[key]
+	if !ok || time.Now().After(f.expires[key]) {
+		return nil, redis.Nil
+	}
+	return value, nil
+}
+
+func (f *fakeSessionBackend) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.values[key] = value
+	f.expires[key] = time.Now().Add(ttl)
+	return nil
+}
+
+func (f *fakeSessionBackend) SetExisting(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.values[key]; !ok || time.Now().After(f.expires[key]) {
+		return false, nil
+	}
+	f.values[key] = value
+	f.expires[key] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (f *fakeSessionBackend) Delete(ctx context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.values, key)
+	return nil
+}
+
+// TestSessionStore_SurvivesRestart tests that a session stored by one replica is
+// found by a fresh store sharing the backend, as after a restart or on another pod
+func TestSessionStore_SurvivesRestart(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeSessionBackend()
+
+	first := newSessionStore(backend, time.Hour, 10)
+	if err := first.Put(ctx, &Session{ID: "tok-1", UserID: "alice", ProjectSlug: "demo", CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	second := newSessionStore(backend, time.Hour, 10)
+	session, ok, err := second.Get(ctx, "tok-1")
+	if err != nil || !ok {
+		t.Fatalf("Get() = %v, %v, want the stored session", ok, err)
+	}
+	if session.UserID != "alice" || session.ProjectSlug != "demo" {
+		t.Errorf("session = %+v, want user alice in project demo", session)
+	}
+
+	// The second read is served from memory
+	gets := backend.gets
+	if _, ok, _ := second.Get(ctx, "tok-1"); !ok {
+		t.Fatal("second Get() missed")
+	}
+	if backend.gets != gets {
+		t.Errorf("backend reads = %d, want %d (cached)", backend.gets, gets)
+	}
+
+	if err := first.Delete(ctx, "tok-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, ok, _ := first.Get(ctx, "tok-1"); ok {
+		t.Error("Get() found a deleted session")
+	}
+}
+
+// TestSessionStore_MissAndError tests unknown sessions and backend failures
+func TestSessionStore_MissAndError(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeSessionBackend()
+	store := newSessionStore(backend, time.Hour, 10)
+
+	if _, ok, err := store.Get(ctx, "missing"); ok || err != nil {
+		t.Errorf("Get(missing) = %v, %v, want a miss without error", ok, err)
+	}
+
+	backend.failGet = errors.New("connection refused")
+	if _, ok, err := store.Get(ctx, "missing"); ok || err == nil {
+		t.Errorf("Get() with failing backend = %v, %v, want an error", ok, err)
+	}
+}
+
+// TestSessionStore_MemoryOnly tests the LRU without a backend: expiry after the
+// ttl without activity and eviction of the least recently used session
+func TestSessionStore_MemoryOnly(t *testing.T) {
+	ctx := context.Background()
+	store := newSessionStore(nil, time.Hour, 2)
+
+	store.Put(ctx, &Session{ID: "idle", LastActivity: time.Now().Add(-2 * time.Hour)})
+	if _, ok, _ := store.Get(ctx, "idle"); ok {
+		t.Error("Get() returned a session idle for longer than the ttl")
+	}
+
+	store.Put(ctx, &Session{ID: "a"})
+	store.Put(ctx, &Session{ID: "b"})
+	store.Get(ctx, "a") // a is now the most recently used
+	store.Put(ctx, &Session{ID: "c"})
+
+	if _, ok, _ := store.Get(ctx, "b"); ok {
+		t.Error("least recently used session b was not evicted")
+	}
+	for _, id := range []string{"a", "c"} {
+		if _, ok, _ := store.Get(ctx, id); !ok {
+			t.Errorf("session %s was evicted", id)
+		}
+	}
+}
+
+// TestSessionStore_ExtendsActiveSessions tests that a lookup late in a session's
+// life writes it back so that the backend ttl restarts
+func TestSessionStore_ExtendsActiveSessions(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeSessionBackend()
+	store := newSessionStore(backend, time.Hour, 10)
+
+	store.Put(ctx, &Session{ID: "tok-1", LastActivity: time.Now().Add(-30 * time.Minute)})
+	before := backend.expires[sessionKey("tok-1")]
+
+	session, ok, _ := store.Get(ctx, "tok-1")
+	if !ok {
+		t.Fatal("Get() missed")
+	}
+	if time.Since(session.LastActivity) > time.Minute {
+		t.Errorf("LastActivity = %v, want it refreshed", session.LastActivity)
+	}
+	if after := backend.expires[sessionKey("tok-1")]; !after.After(before) {
+		t.Errorf("backend expiry %v not pushed past %v", after, before)
+	}
+}
+
+// TestSessionStore_ExtendDoesNotRecreate tests that extending a session another
+// replica has deleted does not bring it back
+func TestSessionStore_ExtendDoesNotRecreate(t *testing.T) {
+	ctx := context.Background()
+	backend := newFakeSessionBackend()
+	first := newSessionStore(backend, time.Hour, 10)
+	second := newSessionStore(backend, time.Hour, 10)
+
+	first.Put(ctx, &Session{ID: "tok-1", LastActivity: time.Now().Add(-30 * time.Minute)})
+	if err := second.Delete(ctx, "tok-1"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	// first still holds the session in its LRU and is due to extend it
+	if _, ok, err := first.Get(ctx, "tok-1"); ok || err != nil {
+		t.Errorf("Get() = %v, %v, want the deleted session missing", ok, err)
+	}
+	if _, ok := backend.values[sessionKey("tok-1")]; ok {
+		t.Error("extending the session re-created the deleted key")
+	}
+	if _, ok, _ := second.Get(ctx, "tok-1"); ok {
+		t.Error("another replica found the deleted session")
+	}
+}
+
+// TestSessionStore_RetainedUntilIdleTimeout tests that a session is kept until just
+// before the configured idle timeout and evicted once it has passed
+func TestSessionStore_RetainedUntilIdleTimeout(t *testing.T) {
+	ctx := context.Background()
+	idleTimeout := 20 * time.Minute
+
+	tests := []struct {
+		name     string
+		idle     time.Duration
+		wantKept bool
+	}{
+		{name: "just before the timeout", idle: idleTimeout - time.Second, wantKept: true},
+		{name: "past the timeout", idle: idleTimeout + time.Second, wantKept: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newSessionStore(nil, idleTimeout, 10)
+			store.Put(ctx, &Session{ID: "tok-1", LastActivity: time.Now().Add(-tt.idle)})
+			if _, ok, _ := store.Get(ctx, "tok-1"); ok != tt.wantKept {
+				t.Errorf("Get() found = %v, want %v", ok, tt.wantKept)
+			}
+		})
+	}
+}
+
+// TestHandleInitSession_ExpiresAt tests that the advertised expiry is the idle
+// timeout sessions are evicted after
+func TestHandleInitSession_ExpiresAt(t *testing.T) {
+	t.Setenv("GUARDRAILS_REPO_PATH", t.TempDir())
+	s := mockMCPServer()
+	s.sessions = newSessionStore(nil, 20*time.Minute, 10)
+
+	before := time.Now()
+	result := initSessionCapabilities(t, s)
+	want := before.Add(20 * time.Minute)
+	if result.ExpiresAt.Before(want) || result.ExpiresAt.After(want.Add(time.Minute)) {
+		t.Errorf("expires_at = %v, want about %v", result.ExpiresAt, want)
+	}
+	if _, ok := s.getSession(result.SessionID); !ok {
+		t.Error("init_session did not store the session")
+	}
+}
@@ -0,0 +1,134 @@
+package mcp
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newMCPEcho creates the echo instance for the MCP transport. Client IPs are taken
+// from the connection: X-Forwarded-For and X-Real-IP are set by the client, so
+// trusting them would let it dodge the per-IP limits or claim a loopback address.
+func newMCPEcho() *echo.Echo {
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	return e
+}
+
+// clientIPMiddleware passes the client IP on to tool handlers through the request
+// context, for per-client rate limits. It comes from the echo IP extractor, so it is
+// the connection address set up by newMCPEcho rather than a forwarding header.
+func clientIPMiddleware(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		req := c.Request()
+		c.SetRequest(req.WithContext(withClientIP(req.Context(), c.Echo().IPExtractor(req))))
+		return next(c)
+	}
+}
+
+// sseConnectLimiter limits how many SSE connections a client IP may open per window
+type sseConnectLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	window  time.Duration
+	clients map[string]*sseConnectWindow
+	now     func() time.Time
+}
+
+type sseConnectWindow struct {
+	count int
+	start time.Time
+}
+
+// newSSEConnectLimiter creates a fixed-window connection limiter. A limit of 0 disables limiting.
+func newSSEConnectLimiter(limit int, window time.Duration) *sseConnectLimiter {
+	return &sseConnectLimiter{
+		limit:   limit,
+		window:  window,
+		clients: make(map[string]*sseConnectWindow),
+		now:     time.Now,
+	}
+}
+
+// allow records a connection attempt for ip and reports whether it is permitted.
+// When denied, it also returns how long until the window resets.
+func (l *sseConnectLimiter) allow(ip string) (bool, time.Duration) {
+	if l.limit <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	w, ok := l.clients[ip]
+	if !ok || now.Sub(w.start) >= l.window {
+		// Drop expired windows so idle clients do not accumulate
+		if len(l.clients) > 1000 {
+			for k, old := range l.clients {
+				if now.Sub(old.start) >= l.window {
+					delete(l.clients, k)
+				}
+			}
+		}
+		l.clients[ip] = &sseConnectWindow{count: 1, start: now}
+		return true, 0
+	}
+
+	if w.count >= l.limit {
+		return false, l.window - now.Sub(w.start)
+	}
+	w.count++
+	return true, 0
+}
+
+// sseAuthMiddleware requires a Bearer API key on SSE requests when enabled.
+// When disabled every request is passed through so local development stays open.
+func sseAuthMiddleware(apiKey string, enabled bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !enabled {
+				return next(c)
+			}
+
+			auth := c.Request().Header.Get("Authorization")
+			parts := strings.SplitN(auth, " ", 2)
+			if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" ||
+				subtle.ConstantTimeCompare([]byte(parts[1]), []byte(apiKey)) != 1 {
+				slog.Warn("Rejected unauthenticated SSE connection", "ip", c.RealIP())
+				return echo.NewHTTPError(http.StatusUnauthorized, "Missing or invalid API key")
+			}
+			return next(c)
+		}
+	}
+}
+
+// sseRateLimitMiddleware limits new SSE connections per client IP. Loopback clients
+// are exempt when exemptLoopback is set (auth disabled, local development).
+func sseRateLimitMiddleware(limiter *sseConnectLimiter, exemptLoopback bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ip := c.RealIP()
+			if exemptLoopback {
+				if parsed := net.ParseIP(ip); parsed != nil && parsed.IsLoopback() {
+					return next(c)
+				}
+			}
+
+			allowed, retryAfter := limiter.allow(ip)
+			if !allowed {
+				slog.Warn("SSE connection rate limit exceeded", "ip", ip)
+				c.Response().Header().Set("Retry-After", strconv.Itoa(int(retryAfter.Seconds())+1))
+				return echo.NewHTTPError(http.StatusTooManyRequests, "Too many SSE connections, retry later")
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func newSSETestEcho(authEnabled bool, limiter *sseConnectLimiter) *echo.Echo {
+	e := newMCPEcho()
+	e.GET("/mcp", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, sseRateLimitMiddleware(limiter, !authEnabled), sseAuthMiddleware("test-api-key", authEnabled))
+	return e
+}
+
+func sseConnect(e *echo.Echo, remoteAddr, auth string) int {
+	return sseConnectForwarded(e, remoteAddr, auth, "")
+}
+
+// sseConnectForwarded connects with X-Forwarded-For and X-Real-IP set to forwarded
+func sseConnectForwarded(e *echo.Echo, remoteAddr, auth, forwarded string) int {
+	req := httptest.NewRequest(http.MethodGet, "/mcp", nil)
+	req.RemoteAddr = remoteAddr
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	if forwarded != "" {
+		req.Header.Set(echo.HeaderXForwardedFor, forwarded)
+		req.Header.Set(echo.HeaderXRealIP, forwarded)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+// TestSSEAuthMiddleware tests API key enforcement on SSE connections
+func TestSSEAuthMiddleware(t *testing.T) {
+	limiter := newSSEConnectLimiter(0, time.Minute)
+
+	enabled := newSSETestEcho(true, limiter)
+	if code := sseConnect(enabled, "10.0.0.1:5000", ""); code != http.StatusUnauthorized {
+		t.Errorf("unauthenticated connect with auth enabled = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := sseConnect(enabled, "10.0.0.1:5000", "Bearer wrong-key"); code != http.StatusUnauthorized {
+		t.Errorf("wrong key = %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := sseConnect(enabled, "10.0.0.1:5000", "Bearer test-api-key"); code != http.StatusOK {
+		t.Errorf("valid key = %d, want %d", code, http.StatusOK)
+	}
+
+	disabled := newSSETestEcho(false, limiter)
+	if code := sseConnect(disabled, "127.0.0.1:5000", ""); code != http.StatusOK {
+		t.Errorf("unauthenticated connect with auth disabled = %d, want %d", code, http.StatusOK)
+	}
+}
+
+// TestSSERateLimitMiddleware tests per-IP connection limiting
+func TestSSERateLimitMiddleware(t *testing.T) {
+	limiter := newSSEConnectLimiter(2, time.Minute)
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	e := newSSETestEcho(false, limiter)
+
+	for i := 0; i < 2; i++ {
+		if code := sseConnect(e, "10.0.0.2:5000", ""); code != http.StatusOK {
+			t.Fatalf("connect %d = %d, want %d", i+1, code, http.StatusOK)
+		}
+	}
+	if code := sseConnect(e, "10.0.0.2:5000", ""); code != http.StatusTooManyRequests {
+		t.Errorf("repeated connect = %d, want %d", code, http.StatusTooManyRequests)
+	}
+
+	// Other clients are unaffected
+	if code := sseConnect(e, "10.0.0.3:5000", ""); code != http.StatusOK {
+		t.Errorf("other client connect = %d, want %d", code, http.StatusOK)
+	}
+
+	// Loopback stays open for local development when auth is disabled
+	for i := 0; i < 5; i++ {
+		if code := sseConnect(e, "127.0.0.1:5000", ""); code != http.StatusOK {
+			t.Fatalf("loopback connect %d = %d, want %d", i+1, code, http.StatusOK)
+		}
+	}
+
+	// The window resets
+	now = now.Add(time.Minute)
+	if code := sseConnect(e, "10.0.0.2:5000", ""); code != http.StatusOK {
+		t.Errorf("connect after window reset = %d, want %d", code, http.StatusOK)
+	}
+}
+
+// TestSSERateLimitMiddleware_SpoofedHeaders tests that forwarding headers set by the
+// client neither reset its limit nor earn the loopback exemption
+func TestSSERateLimitMiddleware_SpoofedHeaders(t *testing.T) {
+	limiter := newSSEConnectLimiter(2, time.Minute)
+	e := newSSETestEcho(false, limiter)
+
+	for i, forwarded := range []string{"203.0.113.1", "203.0.113.2"} {
+		if code := sseConnectForwarded(e, "10.0.0.4:5000", "", forwarded); code != http.StatusOK {
+			t.Fatalf("connect %d = %d, want %d", i+1, code, http.StatusOK)
+		}
+	}
+	if code := sseConnectForwarded(e, "10.0.0.4:5000", "", "203.0.113.3"); code != http.StatusTooManyRequests {
+		t.Errorf("connect with a new forwarded IP = %d, want %d", code, http.StatusTooManyRequests)
+	}
+	if code := sseConnectForwarded(e, "10.0.0.4:5000", "", "127.0.0.1"); code != http.StatusTooManyRequests {
+		t.Errorf("connect claiming loopback = %d, want %d", code, http.StatusTooManyRequests)
+	}
+}
+
+// TestClientIPMiddleware tests that tool handlers see the connection IP, not a spoofed one
+func TestClientIPMiddleware(t *testing.T) {
+	var got string
+	e := newMCPEcho()
+	e.POST("/mcp", func(c echo.Context) error {
+		got, _ = c.Request().Context().Value(clientIPKey{}).(string)
+		return c.NoContent(http.StatusAccepted)
+	}, clientIPMiddleware)
+
+	req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+	req.RemoteAddr = "10.0.0.5:5000"
+	req.Header.Set(echo.HeaderXForwardedFor, "127.0.0.1")
+	req.Header.Set(echo.HeaderXRealIP, "127.0.0.1")
+	e.ServeHTTP(httptest.NewRecorder(), req)
+
+	if got != "10.0.0.5" {
+		t.Errorf("client IP = %q, want the connection address 10.0.0.5", got)
+	}
+}
@@ -0,0 +1,457 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultSSEPingInterval is how often an open stream is pinged when not configured
+const defaultSSEPingInterval = 30 * time.Second
+
+// SSE queue policies for a session whose queue is full
+const (
+	sseQueueDropOldest   = "drop_oldest"   // discard the oldest queued event to make room
+	sseQueueRejectNewest = "reject_newest" // refuse the new event
+)
+
+// errSSEQueueFull is returned by send when the reject_newest policy refuses an event
+var errSSEQueueFull = errors.New("session queue full")
+
+// sseQueueConfig bounds the events buffered for a session and sets what happens
+// when the buffer is full
+type sseQueueConfig struct {
+	size           int           // events buffered, on the stream or while detached
+	enqueueTimeout time.Duration // how long a sender waits for room on an attached stream
+	policy         string        // sseQueueDropOldest or sseQueueRejectNewest
+}
+
+// defaultSSEQueueConfig matches the server defaults
+var defaultSSEQueueConfig = sseQueueConfig{size: 100, enqueueTimeout: time.Second, policy: sseQueueDropOldest}
+
+// sseSession is an MCP client connection over Server-Sent Events. A session
+// outlives its HTTP stream for a grace period so that a client that reconnects
+// with the session's resume token gets the same session and any responses that
+// were produced while it was away.
+type sseSession struct {
+	id          string
+	resumeToken string
+	queue       sseQueueConfig
+
+	// sendMu serializes senders with detach, so that an event is never sent to a
+	// stream after its remaining events were moved to pending
+	sendMu sync.Mutex
+
+	mu             sync.Mutex
+	stream         chan []byte // set while a client is attached
+	pending        [][]byte    // responses produced while detached
+	disconnectedAt time.Time
+	lastActivity   time.Time // last attach or message from the client
+	guardrailToken string    // guardrail session created on this connection by init_session
+}
+
+// send delivers an event to the attached stream, or queues it while detached.
+// When an attached stream's buffer is full, send waits up to the enqueue timeout
+// for the client to catch up. A full queue then either drops its oldest event or,
+// under reject_newest, refuses this one with errSSEQueueFull.
+func (ss *sseSession) send(data []byte) error {
+	ss.sendMu.Lock()
+	defer ss.sendMu.Unlock()
+
+	ss.mu.Lock()
+	stream := ss.stream
+	if stream == nil {
+		defer ss.mu.Unlock()
+		if len(ss.pending) >= ss.queue.size {
+			if ss.queue.policy == sseQueueRejectNewest {
+				return errSSEQueueFull
+			}
+			ss.pending = ss.pending[1:]
+		}
+		ss.pending = append(ss.pending, data)
+		return nil
+	}
+	ss.mu.Unlock()
+
+	select {
+	case stream <- data:
+		return nil
+	default:
+	}
+	if ss.queue.enqueueTimeout > 0 {
+		timer := time.NewTimer(ss.queue.enqueueTimeout)
+		defer timer.Stop()
+		select {
+		case stream <- data:
+			return nil
+		case <-timer.C:
+		}
+	}
+
+	if ss.queue.policy == sseQueueRejectNewest {
+		return errSSEQueueFull
+	}
+	// Senders are serialized and the reader only takes events out, so once the
+	// oldest is dropped there is room
+	select {
+	case <-stream:
+	default:
+	}
+	stream <- data
+	return nil
+}
+
+// sseSessionManager tracks SSE sessions and the resume tokens that re-attach them
+type sseSessionManager struct {
+	mu       sync.Mutex
+	sessions map[string]*sseSession // by session ID
+	tokens   map[string]string      // resume token -> session ID
+	grace    time.Duration
+	queue    sseQueueConfig
+	now      func() time.Time
+
+	// Open streams are pinged every pingInterval and, when idleTimeout is set,
+	// closed after that long without a message from the client
+	pingInterval time.Duration
+	idleTimeout  time.Duration
+	newTicker    func(d time.Duration) (<-chan time.Time, func())
+
+	// closing is closed on shutdown to end every open stream
+	closing   chan struct{}
+	closeOnce sync.Once
+}
+
+// newSSESessionManager creates a session manager whose detached sessions can be
+// resumed for the given grace period. A pingInterval of zero uses the default;
+// an idleTimeout of zero never closes idle streams. Zero queue settings take the
+// values of defaultSSEQueueConfig, except that a zero enqueue timeout is kept
+// when a size is set.
+func newSSESessionManager(grace, pingInterval, idleTimeout time.Duration, queue sseQueueConfig) *sseSessionManager {
+	if pingInterval <= 0 {
+		pingInterval = defaultSSEPingInterval
+	}
+	if queue.size <= 0 {
+		queue.size = defaultSSEQueueConfig.size
+		queue.enqueueTimeout = defaultSSEQueueConfig.enqueueTimeout
+	}
+	if queue.policy == "" {
+		queue.policy = defaultSSEQueueConfig.policy
+	}
+	return &sseSessionManager{
+		sessions:     make(map[string]*sseSession),
+		tokens:       make(map[string]string),
+		grace:        grace,
+		queue:        queue,
+		now:          time.Now,
+		pingInterval: pingInterval,
+		idleTimeout:  idleTimeout,
+		newTicker: func(d time.Duration) (<-chan time.Time, func()) {
+			ticker := time.NewTicker(d)
+			return ticker.C, ticker.Stop
+		},
+		closing: make(chan struct{}),
+	}
+}
+
+// closeStreams ends every open stream, and any opened afterwards, so that the HTTP
+// server can shut down: http.Server.Shutdown waits for handlers to return but never
+// cancels their request contexts
+func (m *sseSessionManager) closeStreams() {
+	m.closeOnce.Do(func() { close(m.closing) })
+}
+
+// checkInterval is how often an open stream is checked for a due ping or an idle
+// timeout: the ping interval, or the idle timeout when that is shorter
+func (m *sseSessionManager) checkInterval() time.Duration {
+	if m.idleTimeout > 0 && m.idleTimeout < m.pingInterval {
+		return m.idleTimeout
+	}
+	return m.pingInterval
+}
+
+// touch records client activity on a session, postponing its idle timeout
+func (m *sseSessionManager) touch(session *sseSession) {
+	now := m.now()
+	session.mu.Lock()
+	session.lastActivity = now
+	session.mu.Unlock()
+}
+
+// idle reports whether a session has had no client activity for the idle timeout
+func (m *sseSessionManager) idle(session *sseSession, now time.Time) bool {
+	if m.idleTimeout <= 0 {
+		return false
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return now.Sub(session.lastActivity) >= m.idleTimeout
+}
+
+// attach opens a stream for a connecting client. A valid resume token re-attaches
+// the detached session it was issued for; a missing, unknown or expired token
+// starts a fresh session. The token is rotated on every attach, so each token
+// resumes at most once.
+func (m *sseSessionManager) attach(resumeToken string) (*sseSession, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expireLocked()
+
+	var session *sseSession
+	resumed := false
+	if id, ok := m.tokens[resumeToken]; ok && resumeToken != "" {
+		if existing := m.sessions[id]; existing != nil && existing.stream == nil {
+			session, resumed = existing, true
+			delete(m.tokens, resumeToken)
+		}
+	}
+
+	if session == nil {
+		id, err := randomHex(16)
+		if err != nil {
+			return nil, false, err
+		}
+		session = &sseSession{id: id, queue: m.queue}
+		m.sessions[id] = session
+	}
+
+	token, err := randomHex(24)
+	if err != nil {
+		return nil, false, err
+	}
+	delete(m.tokens, session.resumeToken)
+	session.resumeToken = token
+	m.tokens[token] = session.id
+
+	session.mu.Lock()
+	session.stream = make(chan []byte, m.queue.size)
+	session.disconnectedAt = time.Time{}
+	session.lastActivity = m.now()
+	session.mu.Unlock()
+
+	return session, resumed, nil
+}
+
+// detach marks a session's stream as closed and starts its grace period
+func (m *sseSessionManager) detach(session *sseSession) {
+	session.sendMu.Lock()
+	defer session.sendMu.Unlock()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	// Events still buffered in the stream were never written; keep them for a resume
+	for {
+		select {
+		case data := <-session.stream:
+			session.pending = append(session.pending, data)
+			continue
+		default:
+		}
+		break
+	}
+	session.stream = nil
+	session.disconnectedAt = m.now()
+}
+
+// get returns the session with the given ID, if it is live or within its grace period
+func (m *sseSessionManager) get(id string) (*sseSession, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expireLocked()
+	session, ok := m.sessions[id]
+	return session, ok
+}
+
+// bindGuardrailSession records the guardrail session token that init_session created
+// on an SSE session, so that later calls on the connection use it by default. A
+// later init_session on the same connection replaces it.
+func (m *sseSessionManager) bindGuardrailSession(id, token string) bool {
+	session, ok := m.get(id)
+	if !ok {
+		return false
+	}
+	session.mu.Lock()
+	session.guardrailToken = token
+	session.mu.Unlock()
+	return true
+}
+
+// unbindGuardrailSession clears a closed guardrail session token from every SSE
+// session it is bound to
+func (m *sseSessionManager) unbindGuardrailSession(token string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, session := range m.sessions {
+		session.mu.Lock()
+		if session.guardrailToken == token {
+			session.guardrailToken = ""
+		}
+		session.mu.Unlock()
+	}
+}
+
+// guardrailSession returns the guardrail session token bound to an SSE session, if any
+func (m *sseSessionManager) guardrailSession(id string) string {
+	session, ok := m.get(id)
+	if !ok {
+		return ""
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return session.guardrailToken
+}
+
+// expireLocked drops sessions detached for longer than the grace period. Callers hold m.mu.
+func (m *sseSessionManager) expireLocked() {
+	now := m.now()
+	for id, session := range m.sessions {
+		session.mu.Lock()
+		expired := session.stream == nil && !session.disconnectedAt.IsZero() && now.Sub(session.disconnectedAt) > m.grace
+		session.mu.Unlock()
+		if expired {
+			delete(m.tokens, session.resumeToken)
+			delete(m.sessions, id)
+		}
+	}
+}
+
+// randomHex returns n random bytes hex-encoded
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// handleSSE serves the MCP event stream. Clients that lost their connection may
+// present the resume token from the previous "session" event, either as the
+// resume_token query parameter or the X-Resume-Token header, to re-attach to
+// their session within the grace period and receive responses queued meanwhile.
+// The stream carries a ": ping" comment every ping interval and, when an idle
+// timeout is configured, is closed once the client has sent nothing for that long.
+func (s *MCPServer) handleSSE(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	token := r.URL.Query().Get("resume_token")
+	if token == "" {
+		token = r.Header.Get("X-Resume-Token")
+	}
+
+	session, resumed, err := s.sseSessions.attach(token)
+	if err != nil {
+		slog.Error("Failed to create SSE session", "error", err)
+		http.Error(w, "Failed to create session", http.StatusInternalServerError)
+		return
+	}
+	defer s.sseSessions.detach(session)
+
+	if token != "" && !resumed {
+		slog.Info("SSE resume token invalid or expired, starting new session", "session_id", session.id)
+	} else if resumed {
+		slog.Info("SSE session resumed", "session_id", session.id)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	fmt.Fprintf(w, "event: endpoint\ndata: %s?sessionId=%s\n\n", r.URL.Path, session.id)
+	info, _ := json.Marshal(map[string]interface{}{
+		"session_id":         session.id,
+		"resume_token":       session.resumeToken,
+		"resumed":            resumed,
+		"resume_ttl_seconds": int(s.sseSessions.grace.Seconds()),
+	})
+	fmt.Fprintf(w, "event: session\ndata: %s\n\n", info)
+
+	session.mu.Lock()
+	pending := session.pending
+	session.pending = nil
+	stream := session.stream
+	session.mu.Unlock()
+	for _, data := range pending {
+		writeSSEMessage(w, data)
+	}
+	flusher.Flush()
+
+	ticks, stopTicker := s.sseSessions.newTicker(s.sseSessions.checkInterval())
+	defer stopTicker()
+	lastPing := s.sseSessions.now()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-s.sseSessions.closing:
+			return
+		case data := <-stream:
+			writeSSEMessage(w, data)
+			flusher.Flush()
+		case <-ticks:
+			now := s.sseSessions.now()
+			if s.sseSessions.idle(session, now) {
+				slog.Info("Closing idle SSE connection", "session_id", session.id, "idle_timeout", s.sseSessions.idleTimeout)
+				return
+			}
+			if now.Sub(lastPing) >= s.sseSessions.pingInterval {
+				fmt.Fprint(w, ": ping\n\n")
+				flusher.Flush()
+				lastPing = now
+			}
+		}
+	}
+}
+
+// handleSSEMessage accepts a JSON-RPC message for a session and delivers the
+// response on the session's event stream (or queues it while detached)
+func (s *MCPServer) handleSSEMessage(w http.ResponseWriter, r *http.Request) {
+	session, ok := s.sseSessions.get(r.URL.Query().Get("sessionId"))
+	if !ok {
+		http.Error(w, "Invalid or expired session", http.StatusNotFound)
+		return
+	}
+	s.sseSessions.touch(session)
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 10<<20))
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+		return
+	}
+
+	response := s.mcpServer.HandleMessage(withSSESession(r.Context(), session.id), body)
+	if response != nil {
+		data, err := json.Marshal(response)
+		if err != nil {
+			http.Error(w, "Failed to encode response", http.StatusInternalServerError)
+			return
+		}
+		if err := session.send(data); err != nil {
+			slog.Warn("SSE session queue full, response rejected", "session_id", session.id, "queue_size", session.queue.size)
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, "Session busy", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// writeSSEMessage writes a JSON-RPC payload as an SSE message event
+func writeSSEMessage(w io.Writer, data []byte) {
+	fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+}
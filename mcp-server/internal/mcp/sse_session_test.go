@@ -0,0 +1,370 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestSSESessionManager_Resume tests re-attaching a detached session with its resume token
+func TestSSESessionManager_Resume(t *testing.T) {
+	m := newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{})
+
+	first, resumed, err := m.attach("")
+	if err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+	if resumed {
+		t.Error("first attach reported resumed")
+	}
+	token := first.resumeToken
+	m.detach(first)
+
+	// A response produced while detached is queued for the resumed stream
+	first.send([]byte(`{"id":1}`))
+
+	second, resumed, err := m.attach(token)
+	if err != nil {
+		t.Fatalf("attach with token: %v", err)
+	}
+	if !resumed || second.id != first.id {
+		t.Fatalf("attach(token) = %s resumed=%v, want session %s resumed", second.id, resumed, first.id)
+	}
+	if second.resumeToken == token {
+		t.Error("resume token was not rotated")
+	}
+	if len(second.pending) != 1 || string(second.pending[0]) != `{"id":1}` {
+		t.Errorf("pending = %q, want the queued response", second.pending)
+	}
+
+	// The used token cannot resume again
+	m.detach(second)
+	third, resumed, _ := m.attach(token)
+	if resumed || third.id == first.id {
+		t.Error("reused resume token re-attached the session")
+	}
+}
+
+// TestSSESession_QueuePolicies tests both queue policies when a session's queue is saturated
+func TestSSESession_QueuePolicies(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		attached    bool
+		wantErr     error
+		wantPending []string
+	}{
+		{name: "detached drop oldest", policy: sseQueueDropOldest, wantPending: []string{"2", "3"}},
+		{name: "detached reject newest", policy: sseQueueRejectNewest, wantErr: errSSEQueueFull, wantPending: []string{"1", "2"}},
+		{name: "attached drop oldest", policy: sseQueueDropOldest, attached: true, wantPending: []string{"2", "3"}},
+		{name: "attached reject newest", policy: sseQueueRejectNewest, attached: true, wantErr: errSSEQueueFull, wantPending: []string{"1", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{size: 2, enqueueTimeout: 10 * time.Millisecond, policy: tt.policy})
+			session, _, err := m.attach("")
+			if err != nil {
+				t.Fatalf("attach: %v", err)
+			}
+			if !tt.attached {
+				m.detach(session)
+			}
+
+			for _, event := range []string{"1", "2"} {
+				if err := session.send([]byte(event)); err != nil {
+					t.Fatalf("send(%s) with room in the queue: %v", event, err)
+				}
+			}
+			start := time.Now()
+			if err := session.send([]byte("3")); err != tt.wantErr {
+				t.Errorf("send to a full queue = %v, want %v", err, tt.wantErr)
+			}
+			if waited := time.Since(start); tt.attached && waited < 10*time.Millisecond {
+				t.Errorf("send returned after %v, want it to wait for the enqueue timeout", waited)
+			}
+
+			// Whatever is left on the stream is what a resumed client would receive
+			m.detach(session)
+			got := []string{}
+			for _, data := range session.pending {
+				got = append(got, string(data))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantPending, ",") {
+				t.Errorf("queued events = %v, want %v", got, tt.wantPending)
+			}
+		})
+	}
+}
+
+// TestSSESessionManager_ExpiredToken tests that a token presented after the grace period starts a fresh session
+func TestSSESessionManager_ExpiredToken(t *testing.T) {
+	m := newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{})
+	now := time.Now()
+	m.now = func() time.Time { return now }
+
+	first, _, _ := m.attach("")
+	token := first.resumeToken
+	m.detach(first)
+
+	now = now.Add(2 * time.Minute)
+	second, resumed, err := m.attach(token)
+	if err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+	if resumed || second.id == first.id {
+		t.Errorf("expired token resumed session %s", second.id)
+	}
+	if _, ok := m.get(first.id); ok {
+		t.Error("expired session was not removed")
+	}
+}
+
+// TestSSESessionManager_AttachedSessionNotStolen tests that a token cannot take over a live stream
+func TestSSESessionManager_AttachedSessionNotStolen(t *testing.T) {
+	m := newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{})
+
+	live, _, _ := m.attach("")
+	other, resumed, _ := m.attach(live.resumeToken)
+	if resumed || other.id == live.id {
+		t.Error("resume token attached to a session that is still connected")
+	}
+}
+
+// sseSessionEvent connects to the SSE handler and returns the decoded "session" event
+func sseSessionEvent(t *testing.T, url string) map[string]interface{} {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	event := ""
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "event: ") {
+			event = strings.TrimPrefix(line, "event: ")
+		}
+		if event == "session" && strings.HasPrefix(line, "data: ") {
+			var info map[string]interface{}
+			if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &info); err != nil {
+				t.Fatalf("invalid session event: %v", err)
+			}
+			return info
+		}
+	}
+	t.Fatal("stream ended without a session event")
+	return nil
+}
+
+// TestHandleSSE_ResumeToken tests reconnecting to the SSE endpoint with valid and expired resume tokens
+func TestHandleSSE_ResumeToken(t *testing.T) {
+	s := mockMCPServer()
+	s.sseSessions = newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{})
+	now := time.Now()
+	s.sseSessions.now = func() time.Time { return now }
+
+	ts := httptest.NewServer(http.HandlerFunc(s.handleSSE))
+	defer ts.Close()
+
+	waitDetached := func(id string) {
+		t.Helper()
+		deadline := time.Now().Add(2 * time.Second)
+		for time.Now().Before(deadline) {
+			session, ok := s.sseSessions.get(id)
+			if ok {
+				session.mu.Lock()
+				detached := session.stream == nil
+				session.mu.Unlock()
+				if detached {
+					return
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		t.Fatalf("session %s was not detached", id)
+	}
+
+	first := sseSessionEvent(t, ts.URL)
+	if first["resumed"] != false {
+		t.Errorf("first connection resumed = %v, want false", first["resumed"])
+	}
+	waitDetached(first["session_id"].(string))
+
+	second := sseSessionEvent(t, ts.URL+"?resume_token="+first["resume_token"].(string))
+	if second["resumed"] != true || second["session_id"] != first["session_id"] {
+		t.Fatalf("reconnect with valid token = %v, want session %v resumed", second, first["session_id"])
+	}
+	waitDetached(second["session_id"].(string))
+
+	now = now.Add(2 * time.Minute)
+	third := sseSessionEvent(t, ts.URL+"?resume_token="+second["resume_token"].(string))
+	if third["resumed"] != false || third["session_id"] == first["session_id"] {
+		t.Errorf("reconnect with expired token = %v, want a fresh session", third)
+	}
+}
+
+// TestHandleSSE_PingAndIdleTimeout tests that a ping is sent at the configured interval
+// and that a connection without client messages is closed after the idle timeout
+func TestHandleSSE_PingAndIdleTimeout(t *testing.T) {
+	s := mockMCPServer()
+	s.sseSessions = newSSESessionManager(time.Minute, 10*time.Second, 25*time.Second, sseQueueConfig{})
+
+	var mu sync.Mutex
+	now := time.Now()
+	s.sseSessions.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+	ticks := make(chan time.Time)
+	var tickerInterval time.Duration
+	s.sseSessions.newTicker = func(d time.Duration) (<-chan time.Time, func()) {
+		tickerInterval = d
+		return ticks, func() {}
+	}
+
+	ts := httptest.NewServer(http.HandlerFunc(s.handleSSE))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	defer resp.Body.Close()
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if line := scanner.Text(); line != "" {
+				lines <- line
+			}
+		}
+	}()
+	next := func() (string, bool) {
+		t.Helper()
+		select {
+		case line, ok := <-lines:
+			return line, ok
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for the stream")
+			return "", false
+		}
+	}
+	for {
+		line, _ := next()
+		if strings.HasPrefix(line, "data: ") && strings.Contains(line, "resume_token") {
+			break
+		}
+	}
+
+	// A tick before the interval has passed sends nothing; one after it pings
+	advance(5 * time.Second)
+	ticks <- time.Time{}
+	if tickerInterval != 10*time.Second {
+		t.Errorf("ticker interval = %v, want the 10s ping interval", tickerInterval)
+	}
+	advance(5 * time.Second)
+	ticks <- time.Time{}
+	if line, _ := next(); line != ": ping" {
+		t.Fatalf("line after ping interval = %q, want \": ping\"", line)
+	}
+
+	// 30s after connecting with no client messages, the idle timeout closes the stream
+	advance(20 * time.Second)
+	ticks <- time.Time{}
+	if line, ok := next(); ok {
+		t.Fatalf("stream still open after idle timeout, got %q", line)
+	}
+}
+
+// TestWithBoundSessionToken tests that calls on an SSE connection default to the
+// guardrail session init_session created on it
+func TestWithBoundSessionToken(t *testing.T) {
+	s := mockMCPServer()
+	s.sseSessions = newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{})
+	bound, _, _ := s.sseSessions.attach("")
+	unbound, _, _ := s.sseSessions.attach("")
+	if !s.sseSessions.bindGuardrailSession(bound.id, "guard-1") {
+		t.Fatal("bindGuardrailSession() = false for a live session")
+	}
+	if s.sseSessions.bindGuardrailSession("missing", "guard-2") {
+		t.Error("bindGuardrailSession() = true for an unknown session")
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		tool      string
+		args      map[string]interface{}
+		wantToken interface{}
+	}{
+		{
+			name:      "token filled in from the SSE session",
+			ctx:       withSSESession(context.Background(), bound.id),
+			tool:      "guardrail_validate_file_edit",
+			args:      map[string]interface{}{"file_path": "main.go"},
+			wantToken: "guard-1",
+		},
+		{
+			name:      "explicit token wins",
+			ctx:       withSSESession(context.Background(), bound.id),
+			tool:      "guardrail_validate_file_edit",
+			args:      map[string]interface{}{"session_token": "explicit"},
+			wantToken: "explicit",
+		},
+		{
+			name:      "tool without a session_token parameter untouched",
+			ctx:       withSSESession(context.Background(), bound.id),
+			tool:      "guardrail_init_session",
+			args:      map[string]interface{}{"user_id": "alice"},
+			wantToken: nil,
+		},
+		{
+			name:      "SSE session without init_session untouched",
+			ctx:       withSSESession(context.Background(), unbound.id),
+			tool:      "guardrail_validate_file_edit",
+			args:      map[string]interface{}{},
+			wantToken: nil,
+		},
+		{
+			name:      "call outside SSE untouched",
+			ctx:       context.Background(),
+			tool:      "guardrail_validate_file_edit",
+			args:      map[string]interface{}{},
+			wantToken: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := len(tt.args)
+			got := s.withBoundSessionToken(tt.ctx, tt.tool, tt.args)
+			if got["session_token"] != tt.wantToken {
+				t.Errorf("session_token = %v, want %v", got["session_token"], tt.wantToken)
+			}
+			if len(tt.args) != before {
+				t.Error("caller's arguments were modified")
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thearchitectit/guardrail-mcp/internal/team"
+)
+
+// teamLayoutRulesFile is the project's .guardrails/team-layout-rules.json, the phase
+// gates and agent-to-team mapping used by the phase gate and agent map tools
+const teamLayoutRulesFile = "team-layout-rules.json"
+
+// phaseGateKeyRegex matches phase gate keys such as "1_to_2"
+var phaseGateKeyRegex = regexp.MustCompile(`^[1-9][0-9]*_to_[1-9][0-9]*$`)
+
+// TeamLayoutRules is the structure of .guardrails/team-layout-rules.json
+type TeamLayoutRules struct {
+	Name         string               `json:"name"`
+	Version      string               `json:"version"`
+	Description  string               `json:"description"`
+	AppliesTo    []string             `json:"applies_to"`
+	Rules        []TeamRule           `json:"rules"`
+	PhaseGates   map[string]PhaseGate `json:"phase_gates"`
+	AgentMapping map[string]AgentTeam `json:"agent_mapping"`
+}
+
+type TeamRule struct {
+	ID       string   `json:"id"`
+	Name     string   `json:"name"`
+	Severity string   `json:"severity"`
+	Check    string   `json:"check"`
+	Command  string   `json:"command"`
+	Message  string   `json:"message"`
+	Trigger  string   `json:"trigger,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+type PhaseGate struct {
+	Name             string   `json:"name"`
+	RequiredTeams    []int    `json:"required_teams"`
+	ApprovalRequired []int    `json:"approval_required"`
+	Deliverables     []string `json:"deliverables"`
+}
+
+type AgentTeam struct {
+	Team  int      `json:"team"`
+	Roles []string `json:"roles"`
+	Phase string   `json:"phase"`
+}
+
+// teamLayoutRulesCache holds the last parsed rules file. The file is re-read when
+// its modification time or size changes, so edits apply without a restart.
+var teamLayoutRulesCache struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	size    int64
+	rules   *TeamLayoutRules
+}
+
+// loadTeamLayoutRules returns the project's team layout rules. A missing file gives
+// the built-in defaults; an unreadable or invalid one is an error so that a broken
+// file never silently replaces the configured phase gates.
+func (s *MCPServer) loadTeamLayoutRules() (*TeamLayoutRules, error) {
+	return loadTeamLayoutRulesFile(filepath.Join(s.getRepoPath(), ".guardrails", teamLayoutRulesFile))
+}
+
+// loadTeamLayoutRulesFile reads and validates the rules at path, reusing the cached
+// result while the file is unchanged
+func loadTeamLayoutRulesFile(path string) (*TeamLayoutRules, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return defaultTeamLayoutRules(), nil
+		}
+		return nil, err
+	}
+
+	cache := &teamLayoutRulesCache
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+	if cache.rules != nil && cache.path == path && cache.modTime.Equal(info.ModTime()) && cache.size == info.Size() {
+		return cache.rules, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var rules TeamLayoutRules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", teamLayoutRulesFile, err)
+	}
+	if err := validateTeamLayoutRules(&rules); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", teamLayoutRulesFile, err)
+	}
+
+	cache.path, cache.modTime, cache.size, cache.rules = path, info.ModTime(), info.Size(), &rules
+	return &rules, nil
+}
+
+// validateTeamLayoutRules checks the fields the team tools rely on: named gates
+// keyed "<from>_to_<to>" with valid team IDs, and agent mappings to a valid team
+// with a phase and at least one role
+func validateTeamLayoutRules(rules *TeamLayoutRules) error {
+	if rules.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if rules.Version == "" {
+		return fmt.Errorf("version is required")
+	}
+	for i, rule := range rules.Rules {
+		if rule.ID == "" {
+			return fmt.Errorf("rules[%d]: id is required", i)
+		}
+	}
+	for key, gate := range rules.PhaseGates {
+		if !phaseGateKeyRegex.MatchString(key) {
+			return fmt.Errorf("phase_gates: key %q must look like \"1_to_2\"", key)
+		}
+		if gate.Name == "" {
+			return fmt.Errorf("phase_gates.%s: name is required", key)
+		}
+		if len(gate.RequiredTeams) == 0 {
+			return fmt.Errorf("phase_gates.%s: required_teams must not be empty", key)
+		}
+		for _, ids := range [][]int{gate.RequiredTeams, gate.ApprovalRequired} {
+			for _, id := range ids {
+				if err := team.ValidateTeamID(id); err != nil {
+					return fmt.Errorf("phase_gates.%s: %w", key, err)
+				}
+			}
+		}
+	}
+	for agent, mapping := range rules.AgentMapping {
+		if err := team.ValidateTeamID(mapping.Team); err != nil {
+			return fmt.Errorf("agent_mapping.%s: %w", agent, err)
+		}
+		if mapping.Phase == "" {
+			return fmt.Errorf("agent_mapping.%s: phase is required", agent)
+		}
+		if len(mapping.Roles) == 0 {
+			return fmt.Errorf("agent_mapping.%s: roles must not be empty", agent)
+		}
+	}
+	return nil
+}
+
+// describePhaseGates lists the defined gates in phase order, for a request naming a
+// gate that does not exist. When fromPhase is at or past the last gate's target
+// phase, it also says that no gate leads out of the final phase.
+func describePhaseGates(rules *TeamLayoutRules, fromPhase int) string {
+	type gateRef struct {
+		from, to int
+		name     string
+	}
+	gates := make([]gateRef, 0, len(rules.PhaseGates))
+	lastPhase := 0
+	for key, gate := range rules.PhaseGates {
+		var ref gateRef
+		if _, err := fmt.Sscanf(key, "%d_to_%d", &ref.from, &ref.to); err != nil {
+			continue
+		}
+		ref.name = gate.Name
+		gates = append(gates, ref)
+		lastPhase = max(lastPhase, ref.to)
+	}
+	if len(gates) == 0 {
+		return "No phase gates are defined in " + teamLayoutRulesFile
+	}
+	sort.Slice(gates, func(i, j int) bool {
+		if gates[i].from != gates[j].from {
+			return gates[i].from < gates[j].from
+		}
+		return gates[i].to < gates[j].to
+	})
+
+	var b strings.Builder
+	if fromPhase >= lastPhase {
+		fmt.Fprintf(&b, "Phase %d is the final phase; no gate leads out of it.\n\n", lastPhase)
+	}
+	b.WriteString("**Defined Phase Gates:**\n")
+	for _, g := range gates {
+		fmt.Fprintf(&b, "- from_phase %d → to_phase %d: %s\n", g.from, g.to, g.name)
+	}
+	return b.String()
+}
+
+// defaultTeamLayoutRules returns the built-in rules, matching the template's
+// .guardrails/team-layout-rules.json, used when a project has no rules file
+func defaultTeamLayoutRules() *TeamLayoutRules {
+	return &TeamLayoutRules{
+		Name:        "Team Layout Compliance",
+		Version:     "1.0",
+		Description: "Enforces standardized team structure",
+		PhaseGates: map[string]PhaseGate{
+			"1_to_2": {
+				Name:             "Architecture Review Board",
+				RequiredTeams:    []int{1, 2, 3},
+				ApprovalRequired: []int{2},
+				Deliverables:     []string{"Architecture Decision Records", "Approved Tech List", "Compliance Checklist"},
+			},
+			"2_to_3": {
+				Name:             "Environment Readiness",
+				RequiredTeams:    []int{4, 5, 6},
+				ApprovalRequired: []int{4, 5},
+				Deliverables:     []string{"Infrastructure Provisioned", "CI/CD Pipelines", "Data Models"},
+			},
+			"3_to_4": {
+				Name:             "Feature Complete + Code Review",
+				RequiredTeams:    []int{7, 8},
+				ApprovalRequired: []int{7},
+				Deliverables:     []string{"Features Implemented", "Code Reviewed", "Documentation Complete"},
+			},
+			"4_to_5": {
+				Name:             "Security + QA Sign-off",
+				RequiredTeams:    []int{9, 10},
+				ApprovalRequired: []int{9, 10},
+				Deliverables:     []string{"Security Review Passed", "Test Coverage Met", "UAT Sign-off"},
+			},
+		},
+		AgentMapping: map[string]AgentTeam{
+			"planner":              {Team: 2, Roles: []string{"Solution Architect"}, Phase: "Phase 1"},
+			"architect":            {Team: 2, Roles: []string{"Chief Architect", "Domain Architect"}, Phase: "Phase 1"},
+			"infrastructure":       {Team: 4, Roles: []string{"Cloud Architect", "IaC Engineer"}, Phase: "Phase 2"},
+			"platform":             {Team: 5, Roles: []string{"CI/CD Architect", "Kubernetes Administrator"}, Phase: "Phase 2"},
+			"backend":              {Team: 7, Roles: []string{"Senior Backend Engineer"}, Phase: "Phase 3"},
+			"frontend":             {Team: 7, Roles: []string{"Senior Frontend Engineer", "Accessibility Expert"}, Phase: "Phase 3"},
+			"security":             {Team: 9, Roles: []string{"Security Architect"}, Phase: "Phase 4"},
+			"security-engineer":    {Team: 9, Roles: []string{"DevSecOps Engineer", "Vulnerability Researcher"}, Phase: "Phase 4"},
+			"qa":                   {Team: 10, Roles: []string{"QA Architect", "SDET"}, Phase: "Phase 4"},
+			"performance-tester":   {Team: 10, Roles: []string{"Performance/Load Engineer"}, Phase: "Phase 4"},
+			"accessibility-tester": {Team: 7, Roles: []string{"Accessibility (A11y) Expert"}, Phase: "Phase 3"},
+			"ux-researcher":        {Team: 1, Roles: []string{"Business Systems Analyst", "Lead Product Manager"}, Phase: "Phase 1"},
+			"sre":                  {Team: 11, Roles: []string{"SRE Lead", "Observability Engineer"}, Phase: "Phase 5"},
+			"ops":                  {Team: 12, Roles: []string{"Release Manager", "NOC Analyst"}, Phase: "Phase 5"},
+		},
+	}
+}
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// writeTeamLayoutRules writes a rules file into repo/.guardrails and points the
+// server's repo path at repo
+func writeTeamLayoutRules(t *testing.T, repo, content string) string {
+	t.Helper()
+	path := filepath.Join(repo, ".guardrails", teamLayoutRulesFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GUARDRAILS_REPO_PATH", repo)
+	return path
+}
+
+const customTeamLayoutRules = `{
+  "name": "Custom Layout",
+  "version": "2.0",
+  "phase_gates": {
+    "1_to_2": {"name": "Design Sign-off", "required_teams": [1, 2], "approval_required": [2], "deliverables": ["Design Doc"]}
+  },
+  "agent_mapping": {
+    "backend": {"team": 8, "roles": ["API Engineer"], "phase": "Phase 3"}
+  }
+}`
+
+// TestLoadTeamLayoutRulesFile tests reading, falling back and rejecting rules files
+func TestLoadTeamLayoutRulesFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string // empty means no file
+		wantErr  string
+		wantName string
+	}{
+		{name: "missing file uses defaults", wantName: "Team Layout Compliance"},
+		{name: "custom file", content: customTeamLayoutRules, wantName: "Custom Layout"},
+		{name: "malformed json", content: `{"name": `, wantErr: "invalid team-layout-rules.json"},
+		{name: "missing version", content: `{"name": "X"}`, wantErr: "version is required"},
+		{name: "bad gate key", content: `{"name": "X", "version": "1", "phase_gates": {"one_to_two": {"name": "G", "required_teams": [1]}}}`, wantErr: `key "one_to_two"`},
+		{name: "gate without teams", content: `{"name": "X", "version": "1", "phase_gates": {"1_to_2": {"name": "G"}}}`, wantErr: "required_teams must not be empty"},
+		{name: "gate team out of range", content: `{"name": "X", "version": "1", "phase_gates": {"1_to_2": {"name": "G", "required_teams": [1, 13]}}}`, wantErr: "team_id must be between 1 and 12"},
+		{name: "agent without roles", content: `{"name": "X", "version": "1", "agent_mapping": {"qa": {"team": 10, "phase": "Phase 4"}}}`, wantErr: "agent_mapping.qa: roles must not be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), teamLayoutRulesFile)
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			rules, err := loadTeamLayoutRulesFile(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("loadTeamLayoutRulesFile() error = %v", err)
+			}
+			if rules.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", rules.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+// TestLoadTeamLayoutRulesFile_Reload tests that the cached rules are replaced when
+// the file changes
+func TestLoadTeamLayoutRulesFile_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), teamLayoutRulesFile)
+	if err := os.WriteFile(path, []byte(customTeamLayoutRules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	first, err := loadTeamLayoutRulesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := loadTeamLayoutRulesFile(path)
+	if again != first {
+		t.Error("unchanged file was parsed again, want the cached rules")
+	}
+
+	edited := strings.Replace(customTeamLayoutRules, "Design Sign-off", "Design Review", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := loadTeamLayoutRulesFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.PhaseGates["1_to_2"].Name; got != "Design Review" {
+		t.Errorf("gate name after edit = %q, want %q", got, "Design Review")
+	}
+}
+
+// TestTeamLayoutRules_RepoFile tests that the template's own rules file is valid
+func TestTeamLayoutRules_RepoFile(t *testing.T) {
+	rules, err := loadTeamLayoutRulesFile(filepath.Join("..", "..", "..", ".guardrails", teamLayoutRulesFile))
+	if err != nil {
+		t.Fatalf("repository rules file: %v", err)
+	}
+	for _, gate := range []string{"1_to_2", "2_to_3", "3_to_4", "4_to_5"} {
+		if _, ok := rules.PhaseGates[gate]; !ok {
+			t.Errorf("phase gate %s missing from repository rules file", gate)
+		}
+	}
+}
+
+// TestTeamLayoutRules_Handlers tests that the phase gate and agent map tools use
+// the project's rules file
+func TestTeamLayoutRules_Handlers(t *testing.T) {
+	writeTeamLayoutRules(t, t.TempDir(), customTeamLayoutRules)
+	s := mockMCPServer()
+	ctx := context.Background()
+
+	res, err := s.handlePhaseGateCheck(ctx, map[string]interface{}{"project_name": "demo", "from_phase": float64(1), "to_phase": float64(2)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := res.Content[0].(mcp.TextContent).Text; res.IsError || !strings.Contains(text, "Design Sign-off") || !strings.Contains(text, "Design Doc") {
+		t.Errorf("phase gate result = %q, want the gate from the rules file", text)
+	}
+
+	res, err = s.handleAgentTeamMap(ctx, map[string]interface{}{"agent_type": "backend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text := res.Content[0].(mcp.TextContent).Text; res.IsError || !strings.Contains(text, "Team 8") || !strings.Contains(text, "API Engineer") {
+		t.Errorf("agent map result = %q, want the mapping from the rules file", text)
+	}
+
+	res, _ = s.handleAgentTeamMap(ctx, map[string]interface{}{"agent_type": "planner"})
+	if !res.IsError {
+		t.Error("planner is not in the rules file, want no mapping")
+	}
+}
+
+// TestDescribePhaseGates tests the gate list returned for an undefined gate
+func TestDescribePhaseGates(t *testing.T) {
+	tests := []struct {
+		name      string
+		rules     *TeamLayoutRules
+		fromPhase int
+		want      []string
+		wantNot   []string
+	}{
+		{
+			name:      "defaults in phase order",
+			rules:     defaultTeamLayoutRules(),
+			fromPhase: 2,
+			want: []string{
+				"- from_phase 1 → to_phase 2: Architecture Review Board\n- from_phase 2 → to_phase 3: Environment Readiness\n" +
+					"- from_phase 3 → to_phase 4: Feature Complete + Code Review\n- from_phase 4 → to_phase 5: Security + QA Sign-off",
+			},
+			wantNot: []string{"final phase"},
+		},
+		{
+			name:      "out of the final phase",
+			rules:     defaultTeamLayoutRules(),
+			fromPhase: 5,
+			want:      []string{"Phase 5 is the final phase; no gate leads out of it.", "to_phase 5: Security + QA Sign-off"},
+		},
+		{
+			name:      "no gates",
+			rules:     &TeamLayoutRules{Name: "Empty", Version: "1"},
+			fromPhase: 1,
+			want:      []string{"No phase gates are defined"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := describePhaseGates(tt.rules, tt.fromPhase)
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("describePhaseGates() = %q, want it to contain %q", got, want)
+				}
+			}
+			for _, notWant := range tt.wantNot {
+				if strings.Contains(got, notWant) {
+					t.Errorf("describePhaseGates() = %q, should not contain %q", got, notWant)
+				}
+			}
+		})
+	}
+}
@@ -4,16 +4,19 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"path/filepath"
-	"regexp"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/config"
 	"github.com/thearchitectit/guardrail-mcp/internal/metrics"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
 	"github.com/thearchitectit/guardrail-mcp/internal/team"
 )
 
@@ -39,8 +42,9 @@ func getRepoRoot() string {
 
 // SEC-005: Rate limiting configuration
 const (
-	defaultRateLimitRequests = 100
-	defaultRateLimitWindow   = 60 // seconds
+	defaultRateLimitRequests     = 100
+	defaultReadRateLimitRequests = 600
+	defaultRateLimitWindow       = 60 // seconds
 )
 
 // rateBucket represents a token bucket for rate limiting
@@ -55,22 +59,58 @@ type rateLimiter struct {
 	buckets         map[string]*rateBucket
 	requestsLimit   int
 	windowSeconds   int
+	now             func() time.Time
 }
 
-// globalRateLimiter is the singleton rate limiter instance
-var globalRateLimiter = &rateLimiter{
-	buckets:       make(map[string]*rateBucket),
-	requestsLimit: defaultRateLimitRequests,
-	windowSeconds: defaultRateLimitWindow,
+// newRateLimiter creates a limiter allowing requestsLimit calls per caller per window
+func newRateLimiter(requestsLimit, windowSeconds int) *rateLimiter {
+	return &rateLimiter{
+		buckets:       make(map[string]*rateBucket),
+		requestsLimit: requestsLimit,
+		windowSeconds: windowSeconds,
+		now:           time.Now,
+	}
+}
+
+// newTeamRateLimiters creates the limiter for team tools that change state and a
+// separate one, with a higher limit, for read-only team tools so that polling status
+// does not use up the budget for changes. Limits come from RATE_LIMIT_TEAM_REQUESTS
+// and RATE_LIMIT_TEAM_WINDOW_SECONDS; without a config the defaults apply.
+func newTeamRateLimiters(cfg *config.Config) (mutating, readOnly *rateLimiter) {
+	requests, window := defaultRateLimitRequests, defaultRateLimitWindow
+	if cfg != nil && cfg.RateLimitRequests > 0 && cfg.RateLimitWindowSeconds > 0 {
+		requests, window = cfg.RateLimitRequests, cfg.RateLimitWindowSeconds
+	}
+	return newRateLimiter(requests, window), newRateLimiter(max(requests, defaultReadRateLimitRequests), window)
 }
 
-// checkRateLimit checks if a request is allowed for the given user
+// checkTeamRateLimit applies limiter to a call of the named team tool, with one bucket
+// per caller (see rateLimitKey). It returns nil when the call may proceed; otherwise it
+// records the rate_limit_exceeded metric and returns the error result for the handler
+// to return.
+func (s *MCPServer) checkTeamRateLimit(ctx context.Context, args map[string]interface{}, tool string, limiter *rateLimiter) *mcp.CallToolResult {
+	allowed, rateHeaders := limiter.checkRateLimit(s.rateLimitKey(ctx, args))
+	if allowed {
+		return nil
+	}
+
+	metrics.RecordTeamToolError(tool, "rate_limit_exceeded")
+	return &mcp.CallToolResult{
+		Content: []interface{}{mcp.TextContent{
+			Type: "text",
+			Text: fmt.Sprintf("Error: Rate limit exceeded. Retry after %s", rateHeaders["X-RateLimit-Reset"]),
+		}},
+		IsError: true,
+	}
+}
+
+// checkRateLimit checks if a request is allowed for the given caller key
 // Returns (allowed, rateLimitHeaders)
 func (rl *rateLimiter) checkRateLimit(userID string) (bool, map[string]string) {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
+	now := rl.now()
 	bucket, exists := rl.buckets[userID]
 
 	if !exists || now.Sub(bucket.lastReset) >= time.Duration(rl.windowSeconds)*time.Second {
@@ -114,7 +154,7 @@ func (rl *rateLimiter) cleanupOldBuckets() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	now := time.Now()
+	now := rl.now()
 	window := time.Duration(rl.windowSeconds) * time.Second
 
 	for userID, bucket := range rl.buckets {
@@ -124,6 +164,91 @@ func (rl *rateLimiter) cleanupOldBuckets() {
 	}
 }
 
+// cleanupInterval is how often runCleanup evicts expired buckets: every two windows,
+// the age after which cleanupOldBuckets drops a bucket
+func (rl *rateLimiter) cleanupInterval() time.Duration {
+	return 2 * time.Duration(rl.windowSeconds) * time.Second
+}
+
+// runCleanup calls cleanupOldBuckets every interval until stop is closed, so that
+// buckets of callers that have gone away do not accumulate for the life of the
+// process. A panic during one pass is logged and the loop carries on.
+func (rl *rateLimiter) runCleanup(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						slog.Error("Panic in rate limiter cleanup", "recover", r)
+					}
+				}()
+				rl.cleanupOldBuckets()
+			}()
+		}
+	}
+}
+
+// snapshot returns the limiter's configuration and the topN callers that have used
+// the most tokens in their current window, most first. Expired buckets count toward
+// Buckets only.
+func (rl *rateLimiter) snapshot(topN int) models.RateLimiterSnapshot {
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	now := rl.now()
+	window := time.Duration(rl.windowSeconds) * time.Second
+	snap := models.RateLimiterSnapshot{
+		Limit:         rl.requestsLimit,
+		WindowSeconds: rl.windowSeconds,
+		Buckets:       len(rl.buckets),
+		TopConsumers:  []models.RateLimitConsumer{},
+	}
+	for key, bucket := range rl.buckets {
+		if now.Sub(bucket.lastReset) >= window {
+			continue
+		}
+		snap.ActiveBuckets++
+		if bucket.tokens <= 0 {
+			snap.Throttled++
+		}
+		snap.TopConsumers = append(snap.TopConsumers, models.RateLimitConsumer{
+			Key:       redactRateLimitKey(key),
+			Used:      rl.requestsLimit - bucket.tokens,
+			Remaining: bucket.tokens,
+			ResetAt:   bucket.lastReset.Add(window),
+		})
+	}
+
+	sort.Slice(snap.TopConsumers, func(i, j int) bool {
+		a, b := snap.TopConsumers[i], snap.TopConsumers[j]
+		if a.Used != b.Used {
+			return a.Used > b.Used
+		}
+		return a.Key < b.Key
+	})
+	if len(snap.TopConsumers) > topN {
+		snap.TopConsumers = snap.TopConsumers[:topN]
+	}
+	return snap
+}
+
+// redactRateLimitKey shortens the session token or SSE session id in a rate limit
+// key (see rateLimitKey), since either lets the holder act as that caller
+func redactRateLimitKey(key string) string {
+	for _, prefix := range []string{"session:", "sse:"} {
+		if id, ok := strings.CutPrefix(key, prefix); ok && len(id) > 8 {
+			return prefix + id[:8] + "..."
+		}
+	}
+	return key
+}
+
 // validateProjectName validates project name to prevent command injection
 func validateProjectName(name string) error {
 	if name == "" {
@@ -278,18 +403,9 @@ func validatePersonName(name string) error {
 }
 
 // validatePhase validates phase filter value (SEC-010: Phase injection hardening)
-// Whitelist: Phase 1, Phase 2, Phase 3 (strict regex validation)
+// Whitelist: Phase 1, Phase 2, Phase 3; shared with the team CLI
 func validatePhase(phase string) error {
-	if phase == "" {
-		return nil // Phase is optional
-	}
-	// SEC-010: Strict regex validation - only allow "Phase 1", "Phase 2", "Phase 3"
-	// This prevents injection attacks through the phase parameter
-	validPhaseRegex := regexp.MustCompile(`^Phase [1-3]$`)
-	if !validPhaseRegex.MatchString(phase) {
-		return fmt.Errorf("invalid phase: must be 'Phase 1', 'Phase 2', or 'Phase 3'")
-	}
-	return nil
+	return team.ValidatePhaseFilter(phase)
 }
 
 // sanitizePhase sanitizes phase string for safe command execution (SEC-010)
@@ -339,6 +455,11 @@ func (s *MCPServer) handleTeamInit(ctx context.Context, args map[string]interfac
 		}, nil
 	}
 
+	// SEC-005: Check rate limit
+	if limited := s.checkTeamRateLimit(ctx, args, "team_init", s.teamRateLimiter); limited != nil {
+		return limited, nil
+	}
+
 	// Use Go implementation instead of Python
 	mgr, err := team.NewManager(projectName, team.WithTestMode(true))
 	if err != nil {
@@ -395,6 +516,11 @@ func (s *MCPServer) handleTeamList(ctx context.Context, args map[string]interfac
 		}, nil
 	}
 
+	// SEC-005: Check rate limit
+	if limited := s.checkTeamRateLimit(ctx, args, "team_list", s.teamReadRateLimiter); limited != nil {
+		return limited, nil
+	}
+
 	// Use Go implementation
 	mgr, err := team.NewManager(projectName, team.WithTestMode(true))
 	if err != nil {
@@ -485,18 +611,8 @@ func (s *MCPServer) handleTeamAssign(ctx context.Context, args map[string]interf
 	}
 
 	// SEC-005: Check rate limit
-	userID := "default" // Could extract from context/auth if available
-	allowed, rateHeaders := globalRateLimiter.checkRateLimit(userID)
-	if !allowed {
-		metrics.RecordTeamToolError("team_assign", "rate_limit_exceeded")
-		retryAfter := rateHeaders["X-RateLimit-Reset"]
-		return &mcp.CallToolResult{
-			Content: []interface{}{mcp.TextContent{
-				Type: "text",
-				Text: fmt.Sprintf("Error: Rate limit exceeded. Retry after %s", retryAfter),
-			}},
-			IsError: true,
-		}, nil
+	if limited := s.checkTeamRateLimit(ctx, args, "team_assign", s.teamRateLimiter); limited != nil {
+		return limited, nil
 	}
 
 	teamID, ok := args["team_id"].(float64)
@@ -510,10 +626,10 @@ func (s *MCPServer) handleTeamAssign(ctx context.Context, args map[string]interf
 
 	// Validate team_id range (1-12)
 	teamIDInt := int(teamID)
-	if teamIDInt < 1 || teamIDInt > 12 {
+	if err := team.ValidateTeamID(teamIDInt); err != nil {
 		metrics.RecordTeamToolError("team_assign", "validation_error")
 		return &mcp.CallToolResult{
-			Content: []interface{}{mcp.TextContent{Type: "text", Text: "Error: team_id must be between 1 and 12"}},
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error: %v", err)}},
 			IsError: true,
 		}, nil
 	}
@@ -573,6 +689,16 @@ func (s *MCPServer) handleTeamAssign(ctx context.Context, args map[string]interf
 		}, nil
 	}
 
+	// With replace, a role held by someone else is reported as unassigned from them;
+	// AssignRole overwrites the holder in the same save
+	replaced := ""
+	if replace, _ := args["replace"].(bool); replace {
+		replaced = roleHolder(mgr, teamIDInt, roleName)
+		if replaced == person {
+			replaced = ""
+		}
+	}
+
 	if err := mgr.AssignRole(teamIDInt, roleName, person); err != nil {
 		metrics.RecordTeamToolDuration("team_assign", time.Since(goStart))
 		metrics.RecordTeamToolError("team_assign", "go_error")
@@ -586,12 +712,34 @@ func (s *MCPServer) handleTeamAssign(ctx context.Context, args map[string]interf
 
 	resultText := fmt.Sprintf("✅ Assigned '%s' to '%s' in Team %d (%s)",
 		person, roleName, teamIDInt, team.StandardTeams[teamIDInt].Name)
+	if replaced != "" {
+		slog.Info("Role assignee replaced", "project", projectName, "team_id", teamIDInt, "role", roleName, "previous", replaced, "assignee", person)
+		if s.audit != nil {
+			s.audit.LogRoleReplaced(ctx, projectName, teamIDInt, roleName, replaced, person)
+		}
+		resultText = fmt.Sprintf("✅ Unassigned '%s' from '%s' in Team %d (%s)\n",
+			replaced, roleName, teamIDInt, team.StandardTeams[teamIDInt].Name) + resultText
+	}
 	metrics.RecordTeamToolCall("team_assign", true)
 	return &mcp.CallToolResult{
 		Content: []interface{}{mcp.TextContent{Type: "text", Text: resultText}},
 	}, nil
 }
 
+// roleHolder returns the person assigned to a role in a team, or "" if it is vacant
+func roleHolder(mgr *team.Manager, teamID int, roleName string) string {
+	assignments, err := mgr.GetTeamAssignments(teamID)
+	if err != nil {
+		return ""
+	}
+	for _, a := range assignments {
+		if a.RoleName == roleName {
+			return a.Person
+		}
+	}
+	return ""
+}
+
 // handleTeamUnassign removes a person from a role in a team
 func (s *MCPServer) handleTeamUnassign(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	start := time.Now()
@@ -618,6 +766,11 @@ func (s *MCPServer) handleTeamUnassign(ctx context.Context, args map[string]inte
 		}, nil
 	}
 
+	// SEC-005: Check rate limit
+	if limited := s.checkTeamRateLimit(ctx, args, "team_unassign", s.teamRateLimiter); limited != nil {
+		return limited, nil
+	}
+
 	teamID, ok := args["team_id"].(float64)
 	if !ok {
 		metrics.RecordTeamToolError("team_unassign", "validation_error")
@@ -629,10 +782,10 @@ func (s *MCPServer) handleTeamUnassign(ctx context.Context, args map[string]inte
 
 	// Validate team_id range (1-12)
 	teamIDInt := int(teamID)
-	if teamIDInt < 1 || teamIDInt > 12 {
+	if err := team.ValidateTeamID(teamIDInt); err != nil {
 		metrics.RecordTeamToolError("team_unassign", "validation_error")
 		return &mcp.CallToolResult{
-			Content: []interface{}{mcp.TextContent{Type: "text", Text: "Error: team_id must be between 1 and 12"}},
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error: %v", err)}},
 			IsError: true,
 		}, nil
 	}
@@ -721,6 +874,11 @@ func (s *MCPServer) handleTeamStart(ctx context.Context, args map[string]interfa
 		}, nil
 	}
 
+	// SEC-005: Check rate limit
+	if limited := s.checkTeamRateLimit(ctx, args, "team_start", s.teamRateLimiter); limited != nil {
+		return limited, nil
+	}
+
 	teamID, ok := args["team_id"].(float64)
 	if !ok {
 		metrics.RecordTeamToolError("team_start", "validation_error")
@@ -732,10 +890,10 @@ func (s *MCPServer) handleTeamStart(ctx context.Context, args map[string]interfa
 
 	// Validate team_id range (1-12)
 	teamIDInt := int(teamID)
-	if teamIDInt < 1 || teamIDInt > 12 {
+	if err := team.ValidateTeamID(teamIDInt); err != nil {
 		metrics.RecordTeamToolError("team_start", "validation_error")
 		return &mcp.CallToolResult{
-			Content: []interface{}{mcp.TextContent{Type: "text", Text: "Error: team_id must be between 1 and 12"}},
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error: %v", err)}},
 			IsError: true,
 		}, nil
 	}
@@ -826,6 +984,11 @@ func (s *MCPServer) handleTeamStatus(ctx context.Context, args map[string]interf
 		}, nil
 	}
 
+	// SEC-005: Check rate limit
+	if limited := s.checkTeamRateLimit(ctx, args, "team_status", s.teamReadRateLimiter); limited != nil {
+		return limited, nil
+	}
+
 	// Use Go implementation
 	mgr, err := team.NewManager(projectName, team.WithTestMode(true))
 	if err != nil {
@@ -910,7 +1073,7 @@ func (s *MCPServer) handlePhaseGateCheck(ctx context.Context, args map[string]in
 	}
 
 	// Load team layout rules
-	rules, err := loadTeamLayoutRules()
+	rules, err := s.loadTeamLayoutRules()
 	if err != nil {
 		metrics.RecordTeamToolError("phase_gate_check", "rules_error")
 		metrics.RecordTeamToolCall("phase_gate_check", false)
@@ -932,7 +1095,7 @@ func (s *MCPServer) handlePhaseGateCheck(ctx context.Context, args map[string]in
 		return &mcp.CallToolResult{
 			Content: []interface{}{mcp.TextContent{
 				Type: "text",
-				Text: fmt.Sprintf("No phase gate defined from phase %d to phase %d", int(fromPhase), int(toPhase)),
+				Text: fmt.Sprintf("No phase gate defined from phase %d to phase %d\n\n%s", int(fromPhase), int(toPhase), describePhaseGates(rules, int(fromPhase))),
 			}},
 			IsError: true,
 		}, nil
@@ -976,7 +1139,7 @@ func (s *MCPServer) handleAgentTeamMap(ctx context.Context, args map[string]inte
 	}
 
 	// Load team layout rules
-	rules, err := loadTeamLayoutRules()
+	rules, err := s.loadTeamLayoutRules()
 	if err != nil {
 		metrics.RecordTeamToolError("agent_team_map", "rules_error")
 		metrics.RecordTeamToolCall("agent_team_map", false)
@@ -1094,42 +1257,6 @@ func (s *MCPServer) handleTeamSizeValidate(ctx context.Context, args map[string]
 	}, nil
 }
 
-// Helper types and functions
-
-type TeamLayoutRules struct {
-	Name         string               `json:"name"`
-	Version      string               `json:"version"`
-	Description  string               `json:"description"`
-	AppliesTo    []string             `json:"applies_to"`
-	Rules        []TeamRule           `json:"rules"`
-	PhaseGates   map[string]PhaseGate `json:"phase_gates"`
-	AgentMapping map[string]AgentTeam `json:"agent_mapping"`
-}
-
-type TeamRule struct {
-	ID       string   `json:"id"`
-	Name     string   `json:"name"`
-	Severity string   `json:"severity"`
-	Check    string   `json:"check"`
-	Command  string   `json:"command"`
-	Message  string   `json:"message"`
-	Trigger  string   `json:"trigger,omitempty"`
-	Patterns []string `json:"patterns,omitempty"`
-}
-
-type PhaseGate struct {
-	Name             string   `json:"name"`
-	RequiredTeams    []int    `json:"required_teams"`
-	ApprovalRequired []int    `json:"approval_required"`
-	Deliverables     []string `json:"deliverables"`
-}
-
-type AgentTeam struct {
-	Team  int      `json:"team"`
-	Roles []string `json:"roles"`
-	Phase string   `json:"phase"`
-}
-
 // handleTeamDelete deletes a specific team from a project
 func (s *MCPServer) handleTeamDelete(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	start := time.Now()
@@ -1156,6 +1283,11 @@ func (s *MCPServer) handleTeamDelete(ctx context.Context, args map[string]interf
 		}, nil
 	}
 
+	// SEC-005: Check rate limit
+	if limited := s.checkTeamRateLimit(ctx, args, "team_delete", s.teamRateLimiter); limited != nil {
+		return limited, nil
+	}
+
 	teamID, ok := args["team_id"].(float64)
 	if !ok {
 		metrics.RecordTeamToolError("team_delete", "validation_error")
@@ -1167,10 +1299,10 @@ func (s *MCPServer) handleTeamDelete(ctx context.Context, args map[string]interf
 
 	// Validate team_id range (1-12)
 	teamIDInt := int(teamID)
-	if teamIDInt < 1 || teamIDInt > 12 {
+	if err := team.ValidateTeamID(teamIDInt); err != nil {
 		metrics.RecordTeamToolError("team_delete", "validation_error")
 		return &mcp.CallToolResult{
-			Content: []interface{}{mcp.TextContent{Type: "text", Text: "Error: team_id must be between 1 and 12"}},
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: fmt.Sprintf("Error: %v", err)}},
 			IsError: true,
 		}, nil
 	}
@@ -1244,6 +1376,11 @@ func (s *MCPServer) handleProjectDelete(ctx context.Context, args map[string]int
 		}, nil
 	}
 
+	// SEC-005: Check rate limit
+	if limited := s.checkTeamRateLimit(ctx, args, "project_delete", s.teamRateLimiter); limited != nil {
+		return limited, nil
+	}
+
 	// Check for confirmation
 	confirmed := false
 	if conf, ok := args["confirmed"].(bool); ok {
@@ -1346,54 +1483,3 @@ func (s *MCPServer) handleTeamHealth(ctx context.Context, args map[string]interf
 		Content: []interface{}{mcp.TextContent{Type: "text", Text: resultText}},
 	}, nil
 }
-
-func loadTeamLayoutRules() (*TeamLayoutRules, error) {
-	// Return hardcoded rules matching .guardrails/team-layout-rules.json
-	return &TeamLayoutRules{
-		Name:        "Team Layout Compliance",
-		Version:     "1.0",
-		Description: "Enforces standardized team structure",
-		PhaseGates: map[string]PhaseGate{
-			"1_to_2": {
-				Name:             "Architecture Review Board",
-				RequiredTeams:    []int{1, 2, 3},
-				ApprovalRequired: []int{2},
-				Deliverables:     []string{"Architecture Decision Records", "Approved Tech List", "Compliance Checklist"},
-			},
-			"2_to_3": {
-				Name:             "Environment Readiness",
-				RequiredTeams:    []int{4, 5, 6},
-				ApprovalRequired: []int{4, 5},
-				Deliverables:     []string{"Infrastructure Provisioned", "CI/CD Pipelines", "Data Models"},
-			},
-			"3_to_4": {
-				Name:             "Feature Complete + Code Review",
-				RequiredTeams:    []int{7, 8},
-				ApprovalRequired: []int{7},
-				Deliverables:     []string{"Features Implemented", "Code Reviewed", "Documentation Complete"},
-			},
-			"4_to_5": {
-				Name:             "Security + QA Sign-off",
-				RequiredTeams:    []int{9, 10},
-				ApprovalRequired: []int{9, 10},
-				Deliverables:     []string{"Security Review Passed", "Test Coverage Met", "UAT Sign-off"},
-			},
-		},
-		AgentMapping: map[string]AgentTeam{
-			"planner":             {Team: 2, Roles: []string{"Solution Architect"}, Phase: "Phase 1"},
-			"architect":           {Team: 2, Roles: []string{"Chief Architect", "Domain Architect"}, Phase: "Phase 1"},
-			"infrastructure":        {Team: 4, Roles: []string{"Cloud Architect", "IaC Engineer"}, Phase: "Phase 2"},
-			"platform":            {Team: 5, Roles: []string{"CI/CD Architect", "Kubernetes Administrator"}, Phase: "Phase 2"},
-			"backend":             {Team: 7, Roles: []string{"Senior Backend Engineer"}, Phase: "Phase 3"},
-			"frontend":            {Team: 7, Roles: []string{"Senior Frontend Engineer", "Accessibility Expert"}, Phase: "Phase 3"},
-			"security":            {Team: 9, Roles: []string{"Security Architect"}, Phase: "Phase 4"},
-			"security-engineer":   {Team: 9, Roles: []string{"DevSecOps Engineer", "Vulnerability Researcher"}, Phase: "Phase 4"},
-			"qa":                  {Team: 10, Roles: []string{"QA Architect", "SDET"}, Phase: "Phase 4"},
-			"performance-tester":  {Team: 10, Roles: []string{"Performance/Load Engineer"}, Phase: "Phase 4"},
-			"accessibility-tester": {Team: 7, Roles: []string{"Accessibility (A11y) Expert"}, Phase: "Phase 3"},
-			"ux-researcher":       {Team: 1, Roles: []string{"Business Systems Analyst", "Lead Product Manager"}, Phase: "Phase 1"},
-			"sre":                 {Team: 11, Roles: []string{"SRE Lead", "Observability Engineer"}, Phase: "Phase 5"},
-			"ops":                 {Team: 12, Roles: []string{"Release Manager", "NOC Analyst"}, Phase: "Phase 5"},
-		},
-	}, nil
-}
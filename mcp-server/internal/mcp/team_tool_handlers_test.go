@@ -5,16 +5,22 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/audit"
+	"github.com/thearchitectit/guardrail-mcp/internal/config"
 )
 
 // mockMCPServer creates a minimal MCPServer for testing
 func mockMCPServer() *MCPServer {
-	return &MCPServer{
-		sessions: make(map[string]*Session),
+	s := &MCPServer{
+		sessions: newSessionStore(nil, time.Hour, 0),
 	}
+	s.teamRateLimiter, s.teamReadRateLimiter = newTeamRateLimiters(nil)
+	return s
 }
 
 // TestValidateProjectName tests the project name validation function
@@ -360,6 +366,62 @@ func TestHandleTeamAssign_Valid(t *testing.T) {
 	cleanupTestProject(t, projectName)
 }
 
+// TestHandleTeamAssign_Replace tests that replace reports and audits the previous
+// holder as unassigned and that a plain assign keeps overwriting silently
+func TestHandleTeamAssign_Replace(t *testing.T) {
+	s := mockMCPServer()
+	s.audit = audit.NewLogger(10)
+	events, unsubscribe := s.audit.Subscribe(10)
+	defer unsubscribe()
+	ctx := context.Background()
+	projectName := "test-project-assign-replace"
+	defer cleanupTestProject(t, projectName)
+
+	s.handleTeamInit(ctx, map[string]interface{}{"project_name": projectName})
+
+	assign := func(person string, replace bool) string {
+		t.Helper()
+		args := map[string]interface{}{
+			"project_name": projectName,
+			"team_id":      float64(1),
+			"role_name":    "Business Relationship Manager",
+			"person":       person,
+		}
+		if replace {
+			args["replace"] = true
+		}
+		result, err := s.handleTeamAssign(ctx, args)
+		if err != nil {
+			t.Fatalf("handleTeamAssign returned error: %v", err)
+		}
+		if result.IsError {
+			t.Fatalf("handleTeamAssign returned error result: %v", getResultText(result))
+		}
+		return getResultText(result)
+	}
+
+	if text := assign("John Doe", true); strings.Contains(text, "Unassigned") {
+		t.Errorf("replace on a vacant role = %q, want no unassignment", text)
+	}
+
+	text := assign("Jane Roe", true)
+	if !strings.Contains(text, "Unassigned 'John Doe'") || !strings.Contains(text, "Assigned 'Jane Roe'") {
+		t.Errorf("replace = %q, want John Doe unassigned and Jane Roe assigned", text)
+	}
+
+	if text := assign("Sam Lee", false); strings.Contains(text, "Unassigned") || !strings.Contains(text, "Assigned 'Sam Lee'") {
+		t.Errorf("assign without replace = %q, want a plain assignment", text)
+	}
+
+	got := collectAuditEvents(t, events, 1)[0]
+	if got.Type != audit.EventRoleReplaced || got.Resource != projectName {
+		t.Errorf("event = %+v, want a role replacement in %s", got, projectName)
+	}
+	if got.Details["previous"] != "John Doe" || got.Details["assignee"] != "Jane Roe" || got.Details["role"] != "Business Relationship Manager" {
+		t.Errorf("event details = %v, want John Doe replaced by Jane Roe", got.Details)
+	}
+}
+
 // TestHandleTeamAssign_MissingFields tests handleTeamAssign with missing required fields
 func TestHandleTeamAssign_MissingFields(t *testing.T) {
 	s := mockMCPServer()
@@ -647,6 +709,9 @@ func TestHandlePhaseGateCheck_InvalidGate(t *testing.T) {
 	if !strings.Contains(text, "No phase gate defined") {
 		t.Errorf("Expected error message about undefined gate, got: %s", text)
 	}
+	if !strings.Contains(text, "Phase 5 is the final phase") || !strings.Contains(text, "from_phase 4 → to_phase 5") {
+		t.Errorf("Expected the defined phase gates to be listed, got: %s", text)
+	}
 }
 
 // TestHandleAgentTeamMap_Valid tests handleAgentTeamMap with valid agent types
@@ -823,7 +888,7 @@ func TestHandleTeamSizeValidate_MissingProjectName(t *testing.T) {
 
 // TestLoadTeamLayoutRules tests the loadTeamLayoutRules function
 func TestLoadTeamLayoutRules(t *testing.T) {
-	rules, err := loadTeamLayoutRules()
+	rules, err := mockMCPServer().loadTeamLayoutRules()
 	if err != nil {
 		t.Fatalf("loadTeamLayoutRules returned error: %v", err)
 	}
@@ -870,7 +935,7 @@ func TestLoadTeamLayoutRules(t *testing.T) {
 
 // TestTeamLayoutRulesPhaseGateStructure tests the structure of phase gates
 func TestTeamLayoutRulesPhaseGateStructure(t *testing.T) {
-	rules, err := loadTeamLayoutRules()
+	rules, err := mockMCPServer().loadTeamLayoutRules()
 	if err != nil {
 		t.Fatalf("loadTeamLayoutRules returned error: %v", err)
 	}
@@ -890,7 +955,7 @@ func TestTeamLayoutRulesPhaseGateStructure(t *testing.T) {
 
 // TestTeamLayoutRulesAgentMappingStructure tests the structure of agent mappings
 func TestTeamLayoutRulesAgentMappingStructure(t *testing.T) {
-	rules, err := loadTeamLayoutRules()
+	rules, err := mockMCPServer().loadTeamLayoutRules()
 	if err != nil {
 		t.Fatalf("loadTeamLayoutRules returned error: %v", err)
 	}
@@ -1466,3 +1531,169 @@ func TestHandleTeamList_InvalidPhase(t *testing.T) {
 		})
 	}
 }
+
+// TestTeamToolRateLimit tests that every mutating team tool enforces the shared rate
+// limit while read-only tools use their own limiter
+func TestTeamToolRateLimit(t *testing.T) {
+	s := mockMCPServer()
+	s.teamRateLimiter = newRateLimiter(1, 60)
+	// Exhaust the mutating limit
+	if limited := s.checkTeamRateLimit(context.Background(), map[string]interface{}{}, "team_assign", s.teamRateLimiter); limited != nil {
+		t.Fatal("first call was rate limited")
+	}
+
+	args := map[string]interface{}{"project_name": "rate-limit-project", "team_id": float64(7), "role_name": "Technical Lead", "person": "Jane"}
+	mutating := map[string]func(context.Context, map[string]interface{}) (*mcp.CallToolResult, error){
+		"team_init":      s.handleTeamInit,
+		"team_assign":    s.handleTeamAssign,
+		"team_unassign":  s.handleTeamUnassign,
+		"team_start":     s.handleTeamStart,
+		"team_delete":    s.handleTeamDelete,
+		"project_delete": s.handleProjectDelete,
+	}
+	for name, handler := range mutating {
+		result, err := handler(context.Background(), args)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "Rate limit exceeded") {
+			t.Errorf("%s was not rate limited: %+v", name, result)
+		}
+	}
+
+	// Read-only tools are limited separately, so they still get through once
+	for name, handler := range map[string]func(context.Context, map[string]interface{}) (*mcp.CallToolResult, error){
+		"team_list":   s.handleTeamList,
+		"team_status": s.handleTeamStatus,
+	} {
+		s.teamReadRateLimiter = newRateLimiter(1, 60)
+		result, _ := handler(context.Background(), args)
+		if strings.Contains(result.Content[0].(mcp.TextContent).Text, "Rate limit exceeded") {
+			t.Errorf("%s was limited by the mutating tool limit", name)
+		}
+		result, _ = handler(context.Background(), args)
+		if !strings.Contains(result.Content[0].(mcp.TextContent).Text, "Rate limit exceeded") {
+			t.Errorf("%s was not limited once its own limit was reached", name)
+		}
+	}
+}
+
+// TestRateLimitKey tests how the rate limit bucket is chosen for a caller
+func TestRateLimitKey(t *testing.T) {
+	s := mockMCPServer()
+	s.sessions.Put(context.Background(), &Session{ID: "known-token"})
+
+	ipCtx := withClientIP(context.Background(), "203.0.113.7")
+	sseCtx := withSSESession(ipCtx, "sse-1")
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		args map[string]interface{}
+		want string
+	}{
+		{"known session token", sseCtx, map[string]interface{}{"session_token": "known-token"}, "session:known-token"},
+		{"unknown session token ignored", sseCtx, map[string]interface{}{"session_token": "made-up"}, "sse:sse-1"},
+		{"sse session", sseCtx, map[string]interface{}{}, "sse:sse-1"},
+		{"client ip", ipCtx, map[string]interface{}{}, "ip:203.0.113.7"},
+		{"no identity", context.Background(), map[string]interface{}{}, "anonymous"},
+	}
+	for _, tt := range tests {
+		if got := s.rateLimitKey(tt.ctx, tt.args); got != tt.want {
+			t.Errorf("%s: rateLimitKey = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+// TestTeamToolRateLimit_PerClient tests that one client exhausting its limit does not throttle another
+func TestTeamToolRateLimit_PerClient(t *testing.T) {
+	s := mockMCPServer()
+	s.teamRateLimiter = newRateLimiter(1, 60)
+	args := map[string]interface{}{}
+	noisy := withClientIP(context.Background(), "203.0.113.7")
+	quiet := withClientIP(context.Background(), "198.51.100.2")
+
+	if s.checkTeamRateLimit(noisy, args, "team_assign", s.teamRateLimiter) != nil {
+		t.Fatal("first call from noisy client was rate limited")
+	}
+	if s.checkTeamRateLimit(noisy, args, "team_assign", s.teamRateLimiter) == nil {
+		t.Error("second call from noisy client was not rate limited")
+	}
+	if s.checkTeamRateLimit(quiet, args, "team_assign", s.teamRateLimiter) != nil {
+		t.Error("quiet client was throttled by the noisy client's bucket")
+	}
+}
+
+// TestNewTeamRateLimiters tests that team rate limits come from the configuration
+func TestNewTeamRateLimiters(t *testing.T) {
+	mutating, readOnly := newTeamRateLimiters(nil)
+	if mutating.requestsLimit != defaultRateLimitRequests || mutating.windowSeconds != defaultRateLimitWindow {
+		t.Errorf("default limiter = %d/%ds, want %d/%ds", mutating.requestsLimit, mutating.windowSeconds, defaultRateLimitRequests, defaultRateLimitWindow)
+	}
+	if readOnly.requestsLimit != defaultReadRateLimitRequests {
+		t.Errorf("default read limit = %d, want %d", readOnly.requestsLimit, defaultReadRateLimitRequests)
+	}
+
+	mutating, readOnly = newTeamRateLimiters(&config.Config{RateLimitRequests: 1000, RateLimitWindowSeconds: 10})
+	if mutating.requestsLimit != 1000 || mutating.windowSeconds != 10 {
+		t.Errorf("configured limiter = %d/%ds, want 1000/10s", mutating.requestsLimit, mutating.windowSeconds)
+	}
+	if readOnly.requestsLimit != 1000 || readOnly.windowSeconds != 10 {
+		t.Errorf("read limiter = %d/%ds, want at least the mutating limit per the same window", readOnly.requestsLimit, readOnly.windowSeconds)
+	}
+}
+
+// TestRateLimiterCleanup tests that buckets idle for more than two windows are evicted
+func TestRateLimiterCleanup(t *testing.T) {
+	var mu sync.Mutex
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+
+	rl := newRateLimiter(10, 60)
+	rl.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	bucketCount := func() int {
+		rl.mu.RLock()
+		defer rl.mu.RUnlock()
+		return len(rl.buckets)
+	}
+
+	rl.checkRateLimit("session:stale")
+	advance(90 * time.Second)
+	rl.checkRateLimit("session:recent")
+
+	// stale is 90s old, within two windows
+	rl.cleanupOldBuckets()
+	if got := bucketCount(); got != 2 {
+		t.Fatalf("buckets after 90s = %d, want 2", got)
+	}
+
+	// stale is now 150s old, recent 60s
+	advance(60 * time.Second)
+	stop := make(chan struct{})
+	defer close(stop)
+	go rl.runCleanup(5*time.Millisecond, stop)
+
+	deadline := time.Now().Add(time.Second)
+	for bucketCount() != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	rl.mu.RLock()
+	_, staleKept := rl.buckets["session:stale"]
+	_, recentKept := rl.buckets["session:recent"]
+	rl.mu.RUnlock()
+	if staleKept || !recentKept {
+		t.Errorf("after cleanup stale kept = %v, recent kept = %v, want only recent kept", staleKept, recentKept)
+	}
+
+	if got := rl.cleanupInterval(); got != 2*time.Minute {
+		t.Errorf("cleanupInterval() = %v, want 2m", got)
+	}
+}
@@ -0,0 +1,77 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// toolSchema returns the input schema declared for a tool. Schemas are indexed on
+// first use; the tool set is fixed once the server is constructed.
+func (s *MCPServer) toolSchema(name string) (mcp.ToolInputSchema, bool) {
+	s.toolSchemasOnce.Do(func() {
+		s.toolSchemas = make(map[string]mcp.ToolInputSchema)
+		for _, tool := range s.toolList() {
+			s.toolSchemas[tool.Name] = tool.InputSchema
+		}
+	})
+	schema, ok := s.toolSchemas[name]
+	return schema, ok
+}
+
+// validateEnumArgs checks every argument whose schema property declares an enum
+// against the allowed values. Absent and null arguments are left to the handler.
+func validateEnumArgs(schema mcp.ToolInputSchema, args map[string]interface{}) error {
+	for param, prop := range schema.Properties {
+		allowed := enumValues(prop["enum"])
+		if allowed == nil {
+			continue
+		}
+		value, present := args[param]
+		if !present || value == nil {
+			continue
+		}
+		got := fmt.Sprint(value)
+		match := false
+		for _, v := range allowed {
+			if got == v {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return fmt.Errorf("invalid value %q for %s: must be one of %s", got, param, strings.Join(allowed, ", "))
+		}
+	}
+	return nil
+}
+
+// enumValues normalizes a schema "enum" declaration to strings
+func enumValues(raw interface{}) []string {
+	switch v := raw.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return nil
+}
+
+// invalidParamsResult builds the error result returned when arguments fail schema validation
+func invalidParamsResult(name string, err error) *mcp.CallToolResult {
+	payload, _ := json.Marshal(map[string]string{
+		"error":   "invalid_params",
+		"tool":    name,
+		"message": err.Error(),
+	})
+	return &mcp.CallToolResult{
+		Content: []interface{}{mcp.TextContent{Type: "text", Text: string(payload)}},
+		IsError: true,
+	}
+}
@@ -0,0 +1,146 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestValidateEnumArgs tests enum enforcement against a tool input schema
+func TestValidateEnumArgs(t *testing.T) {
+	schema := mcp.ToolInputSchema{
+		Type: "object",
+		Properties: mcp.ToolInputSchemaProperties{
+			"environment": map[string]interface{}{
+				"type": "string",
+				"enum": []string{"test", "prod"},
+			},
+			"period": map[string]interface{}{
+				"type": "string",
+				"enum": []interface{}{"daily", "weekly"},
+			},
+			"file_path": map[string]interface{}{
+				"type": "string",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		args    map[string]interface{}
+		wantErr string
+	}{
+		{name: "allowed values", args: map[string]interface{}{"environment": "prod", "period": "weekly"}},
+		{name: "enum args absent", args: map[string]interface{}{"file_path": "main.go"}},
+		{name: "null enum arg", args: map[string]interface{}{"environment": nil}},
+		{name: "out of enum string", args: map[string]interface{}{"environment": "staging"}, wantErr: "environment"},
+		{name: "out of interface enum", args: map[string]interface{}{"period": "yearly"}, wantErr: "period"},
+		{name: "wrong type", args: map[string]interface{}{"environment": true}, wantErr: "environment"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateEnumArgs(schema, tt.args)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("validateEnumArgs() error = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("validateEnumArgs() error = %v, want error mentioning %s", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestHandleToolCall_RejectsInvalidEnum tests that every enum-constrained argument is
+// rejected with invalid_params before the tool handler runs
+func TestHandleToolCall_RejectsInvalidEnum(t *testing.T) {
+	s := mockMCPServer()
+
+	checked := 0
+	for _, tool := range s.toolList() {
+		for param, prop := range tool.InputSchema.Properties {
+			if enumValues(prop["enum"]) == nil {
+				continue
+			}
+			checked++
+			t.Run(tool.Name+"/"+param, func(t *testing.T) {
+				result, err := s.handleToolCall(context.Background(), tool.Name, map[string]interface{}{
+					param: "not-a-declared-value",
+				})
+				if err != nil {
+					t.Fatalf("handleToolCall() error = %v", err)
+				}
+				if !result.IsError {
+					t.Fatal("expected error result for out-of-enum value")
+				}
+
+				text := result.Content[0].(mcp.TextContent).Text
+				var payload map[string]string
+				if err := json.Unmarshal([]byte(text), &payload); err != nil {
+					t.Fatalf("invalid result payload %q: %v", text, err)
+				}
+				if payload["error"] != "invalid_params" || payload["tool"] != tool.Name {
+					t.Errorf("payload = %v, want invalid_params for %s", payload, tool.Name)
+				}
+			})
+		}
+	}
+
+	if checked == 0 {
+		t.Fatal("no enum-constrained tool arguments found")
+	}
+}
+
+// TestExtendedToolSchemas tests that the session-scoped extended tools are listed,
+// dispatched and declare the arguments their handlers read
+func TestExtendedToolSchemas(t *testing.T) {
+	s := mockMCPServer()
+
+	wantArgs := map[string][]string{
+		"guardrail_record_file_read":           {"session_token", "file_path"},
+		"guardrail_verify_file_read":           {"session_token", "file_path"},
+		"guardrail_record_attempt":             {"session_token", "task_id", "error_message", "error_category"},
+		"guardrail_validate_three_strikes":     {"session_token", "task_id"},
+		"guardrail_reset_attempts":             {"session_token", "task_id"},
+		"guardrail_check_halt_conditions":      {"session_token", "task_id", "context"},
+		"guardrail_record_halt":                {"session_token", "halt_type", "description", "severity", "context"},
+		"guardrail_acknowledge_halt":           {"session_token", "halt_id", "resolution"},
+		"guardrail_detect_feature_creep":       {"session_token", "file_path", "git_diff", "change_description", "is_new_file"},
+		"guardrail_verify_fixes_intact":        {"session_token", "file_path", "original_content", "modified_content"},
+		"guardrail_validate_exact_replacement": {"session_token", "file_path", "original_content", "modified_content", "replacement_type"},
+	}
+
+	for name, args := range wantArgs {
+		t.Run(name, func(t *testing.T) {
+			schema, ok := s.toolSchema(name)
+			if !ok {
+				t.Fatal("tool not listed")
+			}
+			for _, arg := range args {
+				if _, ok := schema.Properties[arg]; !ok {
+					t.Errorf("schema missing argument %s", arg)
+				}
+			}
+			for _, arg := range schema.Required {
+				if _, ok := schema.Properties[arg]; !ok {
+					t.Errorf("required argument %s is not a declared property", arg)
+				}
+			}
+
+			// Without a session token every handler rejects the call itself
+			result, err := s.handleToolCall(context.Background(), name, map[string]interface{}{})
+			if err != nil {
+				t.Fatalf("handleToolCall() error = %v", err)
+			}
+			if !result.IsError || !strings.Contains(result.Content[0].(mcp.TextContent).Text, "session_token") {
+				t.Errorf("result = %+v, want session_token error from the handler", result)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package mcp
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// toolPolicyFile is the project's .guardrails/tools.json
+type toolPolicyFile struct {
+	DisabledTools []string `json:"disabled_tools"`
+}
+
+// sessionInitResult is the guardrail_init_session response: the session plus what
+// this server offers, so clients can feature-detect instead of assuming a tool set
+type sessionInitResult struct {
+	models.SessionInfo
+	ExpiresAt     time.Time `json:"expires_at"` // extended by activity, see sessionStore
+	ServerVersion string    `json:"server_version"`
+	Capabilities  []string  `json:"capabilities"`
+	// ResultSchemaVersion is the shape tool results in this session are returned in
+	ResultSchemaVersion           int   `json:"result_schema_version"`
+	SupportedResultSchemaVersions []int `json:"supported_result_schema_versions"`
+}
+
+// disabledTools returns the tools switched off by the DISABLED_TOOLS setting or the
+// project's .guardrails/tools.json. The project file is read on every call so that
+// edits take effect without a restart.
+func (s *MCPServer) disabledTools() map[string]bool {
+	disabled := make(map[string]bool)
+	if s.config != nil {
+		for _, name := range s.config.DisabledTools {
+			disabled[name] = true
+		}
+	}
+
+	data, err := os.ReadFile(filepath.Join(s.getRepoPath(), ".guardrails", "tools.json"))
+	if err != nil {
+		return disabled
+	}
+	var policy toolPolicyFile
+	if err := json.Unmarshal(data, &policy); err != nil {
+		slog.Warn("Ignoring invalid tool policy", "error", err)
+		return disabled
+	}
+	for _, name := range policy.DisabledTools {
+		disabled[name] = true
+	}
+	return disabled
+}
+
+// enabledTools returns the registered tools that are not disabled
+func (s *MCPServer) enabledTools() []mcp.Tool {
+	disabled := s.disabledTools()
+	tools := []mcp.Tool{}
+	for _, tool := range s.toolList() {
+		if !disabled[tool.Name] {
+			tools = append(tools, tool)
+		}
+	}
+	return tools
+}
+
+// capabilities returns the sorted names of the enabled tools
+func (s *MCPServer) capabilities() []string {
+	tools := s.enabledTools()
+	names := make([]string, 0, len(tools))
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,82 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/config"
+)
+
+// initSessionCapabilities calls guardrail_init_session and returns the decoded response
+func initSessionCapabilities(t *testing.T, s *MCPServer) sessionInitResult {
+	t.Helper()
+	res, err := s.handleInitSession(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var result sessionInitResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	return result
+}
+
+// TestHandleInitSession_Capabilities tests that init_session advertises the enabled tools
+func TestHandleInitSession_Capabilities(t *testing.T) {
+	t.Setenv("GUARDRAILS_REPO_PATH", t.TempDir())
+	s := mockMCPServer()
+	s.version = "v2.6.0"
+
+	result := initSessionCapabilities(t, s)
+	if result.ServerVersion != "v2.6.0" {
+		t.Errorf("server_version = %q, want v2.6.0", result.ServerVersion)
+	}
+	if len(result.Capabilities) != len(s.toolList()) {
+		t.Errorf("got %d capabilities, want one per registered tool (%d)", len(result.Capabilities), len(s.toolList()))
+	}
+	for _, name := range []string{"guardrail_init_session", "guardrail_validate_bash"} {
+		if !contains(result.Capabilities, name) {
+			t.Errorf("capabilities missing %s", name)
+		}
+	}
+}
+
+// TestHandleInitSession_DisabledTools tests that disabled tools are removed from the
+// capabilities and the tool list, whether disabled by config or by the project
+func TestHandleInitSession_DisabledTools(t *testing.T) {
+	repo := t.TempDir()
+	t.Setenv("GUARDRAILS_REPO_PATH", repo)
+	if err := os.MkdirAll(filepath.Join(repo, ".guardrails"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	policy := `{"disabled_tools": ["guardrail_validate_push"]}`
+	if err := os.WriteFile(filepath.Join(repo, ".guardrails", "tools.json"), []byte(policy), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := mockMCPServer()
+	s.config = &config.Config{DisabledTools: []string{"guardrail_validate_bash"}}
+
+	result := initSessionCapabilities(t, s)
+	for _, name := range []string{"guardrail_validate_bash", "guardrail_validate_push"} {
+		if contains(result.Capabilities, name) {
+			t.Errorf("capabilities include disabled tool %s", name)
+		}
+	}
+	if !contains(result.Capabilities, "guardrail_validate_file_edit") {
+		t.Error("capabilities missing enabled tool guardrail_validate_file_edit")
+	}
+	if len(result.Capabilities) != len(s.toolList())-2 {
+		t.Errorf("got %d capabilities, want %d", len(result.Capabilities), len(s.toolList())-2)
+	}
+
+	for _, tool := range s.enabledTools() {
+		if tool.Name == "guardrail_validate_bash" || tool.Name == "guardrail_validate_push" {
+			t.Errorf("tool list includes disabled tool %s", tool.Name)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// handleValidateAPIStability checks that an edit to a Go file does not remove or
+// change exported function signatures, types or struct fields
+func (s *MCPServer) handleValidateAPIStability(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	filePath, _ := args["file_path"].(string)
+	originalContent, _ := args["original_content"].(string)
+	modifiedContent, _ := args["modified_content"].(string)
+
+	if originalContent == "" {
+		result := models.APIStabilityResult{
+			Valid:    false,
+			Message:  "original_content is required",
+			FilePath: filePath,
+		}
+		return buildToolResult(result, true)
+	}
+
+	if filePath != "" && filepath.Ext(filePath) != ".go" {
+		result := models.APIStabilityResult{
+			Valid:    true,
+			Message:  fmt.Sprintf("File %s is not a Go source file - API stability check skipped", filePath),
+			FilePath: filePath,
+		}
+		return buildToolResult(result, false)
+	}
+
+	result, err := checkAPIStability(originalContent, modifiedContent)
+	if err != nil {
+		result := models.APIStabilityResult{
+			Valid:    false,
+			Message:  err.Error(),
+			FilePath: filePath,
+		}
+		return buildToolResult(result, true)
+	}
+	result.FilePath = filePath
+
+	return buildToolResult(result, result.Breaking)
+}
+
+// checkAPIStability compares the exported declarations of two versions of a Go file.
+// Removed or changed exported symbols are breaking; added symbols are reported but allowed.
+func checkAPIStability(originalContent, modifiedContent string) (models.APIStabilityResult, error) {
+	before, err := exportedAPI(originalContent)
+	if err != nil {
+		return models.APIStabilityResult{}, fmt.Errorf("failed to parse original_content: %w", err)
+	}
+	after, err := exportedAPI(modifiedContent)
+	if err != nil {
+		return models.APIStabilityResult{}, fmt.Errorf("failed to parse modified_content: %w", err)
+	}
+
+	changes := []models.APIChange{}
+	for _, symbol := range sortedKeys(before) {
+		oldSig := before[symbol]
+		newSig, exists := after[symbol]
+		switch {
+		case !exists:
+			changes = append(changes, models.APIChange{
+				Kind:     "removed",
+				Symbol:   symbol,
+				Before:   oldSig,
+				Breaking: true,
+				Message:  fmt.Sprintf("Exported %s was removed", symbol),
+			})
+		case oldSig != newSig:
+			changes = append(changes, models.APIChange{
+				Kind:     "changed",
+				Symbol:   symbol,
+				Before:   oldSig,
+				After:    newSig,
+				Breaking: true,
+				Message:  fmt.Sprintf("Exported %s changed from %q to %q", symbol, oldSig, newSig),
+			})
+		}
+	}
+	for _, symbol := range sortedKeys(after) {
+		if _, exists := before[symbol]; !exists {
+			changes = append(changes, models.APIChange{
+				Kind:    "added",
+				Symbol:  symbol,
+				After:   after[symbol],
+				Message: fmt.Sprintf("Exported %s was added", symbol),
+			})
+		}
+	}
+
+	breaking := 0
+	for _, c := range changes {
+		if c.Breaking {
+			breaking++
+		}
+	}
+
+	result := models.APIStabilityResult{
+		Valid:          breaking == 0,
+		Breaking:       breaking > 0,
+		Changes:        changes,
+		ExportedBefore: len(before),
+		ExportedAfter:  len(after),
+	}
+	if breaking > 0 {
+		result.Message = fmt.Sprintf("%d breaking change(s) to the exported API", breaking)
+	} else {
+		result.Message = "Exported API preserved"
+	}
+	return result, nil
+}
+
+// exportedAPI parses Go source and returns a map of exported symbol to its normalized signature.
+// Methods are keyed as Type.Method and struct fields as Type.Field.
+func exportedAPI(src string) (map[string]string, error) {
+	api := make(map[string]string)
+	if strings.TrimSpace(src) == "" {
+		return api, nil
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if !d.Name.IsExported() {
+				continue
+			}
+			name := d.Name.Name
+			if d.Recv != nil && len(d.Recv.List) > 0 {
+				recv := receiverTypeName(d.Recv.List[0].Type)
+				if !ast.IsExported(recv) {
+					continue
+				}
+				name = recv + "." + name
+			}
+			api[name] = funcSignature(fset, d.Type)
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || !ts.Name.IsExported() {
+					continue
+				}
+				st, isStruct := ts.Type.(*ast.StructType)
+				if !isStruct {
+					api[ts.Name.Name] = "type " + exprString(fset, ts.Type)
+					continue
+				}
+				api[ts.Name.Name] = "struct"
+				for _, field := range st.Fields.List {
+					fieldType := exprString(fset, field.Type)
+					if len(field.Names) == 0 {
+						// Embedded field: exported if the embedded type name is exported
+						embedded := receiverTypeName(field.Type)
+						if ast.IsExported(embedded) {
+							api[ts.Name.Name+"."+embedded] = fieldType
+						}
+						continue
+					}
+					for _, fieldName := range field.Names {
+						if fieldName.IsExported() {
+							api[ts.Name.Name+"."+fieldName.Name] = fieldType
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return api, nil
+}
+
+// funcSignature renders a function type using parameter and result types only,
+// so renaming a parameter is not reported as a signature change
+func funcSignature(fset *token.FileSet, ft *ast.FuncType) string {
+	var sb strings.Builder
+	sb.WriteString("func(")
+	sb.WriteString(strings.Join(fieldTypes(fset, ft.Params), ", "))
+	sb.WriteString(")")
+	results := fieldTypes(fset, ft.Results)
+	switch len(results) {
+	case 0:
+	case 1:
+		sb.WriteString(" " + results[0])
+	default:
+		sb.WriteString(" (" + strings.Join(results, ", ") + ")")
+	}
+	return sb.String()
+}
+
+// fieldTypes expands a field list into one type string per declared name
+func fieldTypes(fset *token.FileSet, fields *ast.FieldList) []string {
+	types := []string{}
+	if fields == nil {
+		return types
+	}
+	for _, field := range fields.List {
+		t := exprString(fset, field.Type)
+		n := len(field.Names)
+		if n == 0 {
+			n = 1
+		}
+		for i := 0; i < n; i++ {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// receiverTypeName strips pointers and type parameters from a receiver or embedded type
+func receiverTypeName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexExpr:
+		return receiverTypeName(t.X)
+	case *ast.IndexListExpr:
+		return receiverTypeName(t.X)
+	case *ast.SelectorExpr:
+		return t.Sel.Name
+	case *ast.Ident:
+		return t.Name
+	}
+	return ""
+}
+
+// exprString prints an AST expression back to Go source
+func exprString(fset *token.FileSet, expr ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, fset, expr); err != nil {
+		return ""
+	}
+	return buf.String()
+}
+
+// sortedKeys returns the keys of a string map in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,154 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const apiStabilityOriginal = `package lib
+
+// Client talks to the backend
+type Client struct {
+	Endpoint string
+	Timeout  int
+	retries  int
+}
+
+// Fetch retrieves a resource by id
+func (c *Client) Fetch(id string) ([]byte, error) {
+	return nil, nil
+}
+
+// New creates a client
+func New(endpoint string) *Client {
+	return &Client{Endpoint: endpoint}
+}
+`
+
+// TestCheckAPIStability tests detection of breaking changes to exported declarations
+func TestCheckAPIStability(t *testing.T) {
+	tests := []struct {
+		name         string
+		modified     string
+		wantBreaking bool
+		wantSymbol   string
+	}{
+		{
+			name:         "unchanged file",
+			modified:     apiStabilityOriginal,
+			wantBreaking: false,
+		},
+		{
+			name: "added unexported helper passes",
+			modified: apiStabilityOriginal + `
+func normalize(id string) string {
+	return id
+}
+`,
+			wantBreaking: false,
+		},
+		{
+			name:         "renamed parameter passes",
+			modified:     strings.Replace(apiStabilityOriginal, "func New(endpoint string)", "func New(url string)", 1),
+			wantBreaking: false,
+		},
+		{
+			name:         "changed exported signature flagged",
+			modified:     strings.Replace(apiStabilityOriginal, "func New(endpoint string) *Client", "func New(endpoint string, timeout int) *Client", 1),
+			wantBreaking: true,
+			wantSymbol:   "New",
+		},
+		{
+			name:         "changed method result flagged",
+			modified:     strings.Replace(apiStabilityOriginal, "Fetch(id string) ([]byte, error)", "Fetch(id string) error", 1),
+			wantBreaking: true,
+			wantSymbol:   "Client.Fetch",
+		},
+		{
+			name:         "removed struct field flagged",
+			modified:     strings.Replace(apiStabilityOriginal, "\tTimeout  int\n", "", 1),
+			wantBreaking: true,
+			wantSymbol:   "Client.Timeout",
+		},
+		{
+			name:         "removed unexported field passes",
+			modified:     strings.Replace(apiStabilityOriginal, "\tretries  int\n", "", 1),
+			wantBreaking: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkAPIStability(apiStabilityOriginal, tt.modified)
+			if err != nil {
+				t.Fatalf("checkAPIStability() error = %v", err)
+			}
+			if result.Breaking != tt.wantBreaking {
+				t.Errorf("checkAPIStability() breaking = %v, want %v (changes: %+v)", result.Breaking, tt.wantBreaking, result.Changes)
+			}
+			if tt.wantSymbol == "" {
+				return
+			}
+			found := false
+			for _, c := range result.Changes {
+				if c.Symbol == tt.wantSymbol && c.Breaking {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected breaking change for %s, got %+v", tt.wantSymbol, result.Changes)
+			}
+		})
+	}
+}
+
+// TestHandleValidateAPIStability tests the tool handler input handling
+func TestHandleValidateAPIStability(t *testing.T) {
+	s := mockMCPServer()
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+	}{
+		{
+			name:      "missing original content",
+			args:      map[string]interface{}{"modified_content": apiStabilityOriginal},
+			wantError: true,
+		},
+		{
+			name:      "invalid go source",
+			args:      map[string]interface{}{"original_content": "package lib\nfunc {", "modified_content": apiStabilityOriginal},
+			wantError: true,
+		},
+		{
+			name:      "non go file skipped",
+			args:      map[string]interface{}{"file_path": "README.md", "original_content": "# Title", "modified_content": ""},
+			wantError: false,
+		},
+		{
+			name:      "breaking change",
+			args:      map[string]interface{}{"file_path": "lib.go", "original_content": apiStabilityOriginal, "modified_content": "package lib\n"},
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.handleValidateAPIStability(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateAPIStability() error = %v", err)
+			}
+			if result.IsError != tt.wantError {
+				text := ""
+				if len(result.Content) > 0 {
+					text = result.Content[0].(mcp.TextContent).Text
+				}
+				t.Errorf("IsError = %v, want %v: %s", result.IsError, tt.wantError, text)
+			}
+		})
+	}
+}
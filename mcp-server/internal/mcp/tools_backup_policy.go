@@ -0,0 +1,172 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+const (
+	// defaultMinBackupRetentionDays is the shortest retention that still covers a
+	// problem noticed a week late
+	defaultMinBackupRetentionDays = 7
+	// defaultMaxBackupRetentionDays bounds retention so that deleted personal data
+	// does not linger in backups indefinitely
+	defaultMaxBackupRetentionDays = 365
+	// maxRestoreTestIntervalDays is the longest gap between restore tests before the
+	// cadence is a warning
+	maxRestoreTestIntervalDays = 90
+)
+
+// backupSchedulePresets are the named schedules accepted besides cron expressions
+var backupSchedulePresets = map[string]bool{
+	"hourly": true, "daily": true, "weekly": true, "monthly": true,
+	"continuous": true, // point-in-time recovery, e.g. WAL archiving
+}
+
+// backupConfig is the backup configuration being checked
+type backupConfig struct {
+	Schedule                string
+	RetentionDays           int // 0 when not set
+	Storage                 []backupStorage
+	RestoreTestIntervalDays int // 0 when not documented
+}
+
+// backupStorage is a location backups are copied to
+type backupStorage struct {
+	Location string
+	Offsite  bool
+}
+
+// handleValidateBackupPolicy checks a backup configuration for a schedule, retention
+// within bounds, offsite and redundant storage and a restore-test cadence
+func (s *MCPServer) handleValidateBackupPolicy(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	raw, _ := args["backup"].(map[string]interface{})
+
+	minDays := defaultMinBackupRetentionDays
+	if v, ok := args["min_retention_days"].(float64); ok {
+		minDays = int(v)
+	}
+	maxDays := defaultMaxBackupRetentionDays
+	if v, ok := args["max_retention_days"].(float64); ok {
+		maxDays = int(v)
+	}
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.BackupPolicyResult{
+			Valid:            false,
+			Message:          message,
+			MinRetentionDays: minDays,
+			MaxRetentionDays: maxDays,
+			Issues:           []models.BackupPolicyIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if raw == nil {
+		return invalid("backup is required")
+	}
+	if minDays < 1 || maxDays < minDays {
+		return invalid("min_retention_days must be positive and no more than max_retention_days")
+	}
+
+	config := backupConfig{}
+	config.Schedule, _ = raw["schedule"].(string)
+	if v, ok := raw["retention_days"].(float64); ok {
+		config.RetentionDays = int(v)
+	}
+	if v, ok := raw["restore_test_interval_days"].(float64); ok {
+		config.RestoreTestIntervalDays = int(v)
+	}
+	rawStorage, _ := raw["storage"].([]interface{})
+	for i, item := range rawStorage {
+		entry, ok := item.(map[string]interface{})
+		if !ok {
+			return invalid(fmt.Sprintf("backup.storage[%d] must be an object", i))
+		}
+		storage := backupStorage{}
+		storage.Location, _ = entry["location"].(string)
+		storage.Offsite, _ = entry["offsite"].(bool)
+		if storage.Location == "" {
+			return invalid(fmt.Sprintf("backup.storage[%d].location is required", i))
+		}
+		config.Storage = append(config.Storage, storage)
+	}
+
+	result := checkBackupPolicy(config, minDays, maxDays)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkBackupPolicy flags the elements of a backup configuration that a restore would
+// depend on. A missing schedule, retention outside [minDays, maxDays], no storage or
+// no offsite copy and no restore-test cadence are errors; a single storage location
+// and restore tests less often than every maxRestoreTestIntervalDays are warnings.
+func checkBackupPolicy(config backupConfig, minDays, maxDays int) models.BackupPolicyResult {
+	issues := []models.BackupPolicyIssue{}
+	issue := func(field, severity, message string) {
+		issues = append(issues, models.BackupPolicyIssue{Field: field, Severity: severity, Message: message})
+	}
+
+	schedule := strings.TrimSpace(config.Schedule)
+	switch {
+	case schedule == "":
+		issue("schedule", "error", "No backup schedule defined")
+	case backupSchedulePresets[strings.ToLower(schedule)]:
+	case len(strings.Fields(schedule)) != 5:
+		issue("schedule", "error", fmt.Sprintf("Schedule %q is neither a cron expression nor one of hourly, daily, weekly, monthly, continuous", schedule))
+	}
+
+	switch {
+	case config.RetentionDays <= 0:
+		issue("retention_days", "error", "No retention period defined")
+	case config.RetentionDays < minDays:
+		issue("retention_days", "error", fmt.Sprintf("Retention of %d day(s) is below the minimum of %d", config.RetentionDays, minDays))
+	case config.RetentionDays > maxDays:
+		issue("retention_days", "error", fmt.Sprintf("Retention of %d days exceeds the maximum of %d", config.RetentionDays, maxDays))
+	}
+
+	offsite := false
+	for _, storage := range config.Storage {
+		offsite = offsite || storage.Offsite
+	}
+	switch {
+	case len(config.Storage) == 0:
+		issue("storage", "error", "No backup storage defined")
+	case !offsite:
+		issue("storage", "error", "No offsite copy; a site outage would take the backups with it")
+	case len(config.Storage) == 1:
+		issue("storage", "warning", "Backups are kept in a single location; add a second copy for redundancy")
+	}
+
+	switch {
+	case config.RestoreTestIntervalDays <= 0:
+		issue("restore_test_interval_days", "error", "No restore-test cadence documented; untested backups may not restore")
+	case config.RestoreTestIntervalDays > maxRestoreTestIntervalDays:
+		issue("restore_test_interval_days", "warning", fmt.Sprintf("Restores are tested every %d days; test at least every %d", config.RestoreTestIntervalDays, maxRestoreTestIntervalDays))
+	}
+
+	errorCount := 0
+	for _, i := range issues {
+		if i.Severity == "error" {
+			errorCount++
+		}
+	}
+
+	message := "Backup configuration meets policy"
+	if errorCount > 0 {
+		message = fmt.Sprintf("Backup configuration does not meet policy: %d error(s)", errorCount)
+	} else if len(issues) > 0 {
+		message = fmt.Sprintf("Backup configuration meets policy with %d warning(s)", len(issues))
+	}
+
+	return models.BackupPolicyResult{
+		Valid:            errorCount == 0,
+		Message:          message,
+		MinRetentionDays: minDays,
+		MaxRetentionDays: maxDays,
+		Issues:           issues,
+	}
+}
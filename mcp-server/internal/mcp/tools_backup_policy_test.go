@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestCheckBackupPolicy tests that missing or out-of-policy backup elements are flagged
+func TestCheckBackupPolicy(t *testing.T) {
+	compliant := func() backupConfig {
+		return backupConfig{
+			Schedule:      "0 2 * * *",
+			RetentionDays: 30,
+			Storage: []backupStorage{
+				{Location: "local-nas"},
+				{Location: "s3://backups-eu-west", Offsite: true},
+			},
+			RestoreTestIntervalDays: 30,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		modify     func(*backupConfig)
+		wantValid  bool
+		wantIssues []string // field/severity
+	}{
+		{
+			name:      "compliant config passes",
+			modify:    func(c *backupConfig) {},
+			wantValid: true,
+		},
+		{
+			name:       "missing retention flagged",
+			modify:     func(c *backupConfig) { c.RetentionDays = 0 },
+			wantValid:  false,
+			wantIssues: []string{"retention_days/error"},
+		},
+		{
+			name:       "retention above the maximum flagged",
+			modify:     func(c *backupConfig) { c.RetentionDays = 3650 },
+			wantValid:  false,
+			wantIssues: []string{"retention_days/error"},
+		},
+		{
+			name:       "unrecognised schedule flagged",
+			modify:     func(c *backupConfig) { c.Schedule = "sometimes" },
+			wantValid:  false,
+			wantIssues: []string{"schedule/error"},
+		},
+		{
+			name:       "no offsite copy flagged",
+			modify:     func(c *backupConfig) { c.Storage = []backupStorage{{Location: "local-nas"}, {Location: "second-rack"}} },
+			wantValid:  false,
+			wantIssues: []string{"storage/error"},
+		},
+		{
+			name:       "single offsite location is a warning",
+			modify:     func(c *backupConfig) { c.Storage = c.Storage[1:] },
+			wantValid:  true,
+			wantIssues: []string{"storage/warning"},
+		},
+		{
+			name: "no restore-test cadence flagged",
+			modify: func(c *backupConfig) {
+				c.Schedule = "daily"
+				c.RestoreTestIntervalDays = 0
+			},
+			wantValid:  false,
+			wantIssues: []string{"restore_test_interval_days/error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := compliant()
+			tt.modify(&config)
+			result := checkBackupPolicy(config, defaultMinBackupRetentionDays, defaultMaxBackupRetentionDays)
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if len(result.Issues) != len(tt.wantIssues) {
+				t.Fatalf("issues = %+v, want %v", result.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := result.Issues[i].Field + "/" + result.Issues[i].Severity; got != want {
+					t.Errorf("issue[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleValidateBackupPolicy tests argument parsing and errors
+func TestHandleValidateBackupPolicy(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name: "missing retention reported",
+			args: map[string]interface{}{
+				"backup": map[string]interface{}{
+					"schedule":                   "daily",
+					"storage":                    []interface{}{map[string]interface{}{"location": "s3://b", "offsite": true}},
+					"restore_test_interval_days": float64(30),
+				},
+			},
+			wantError: true,
+			wantText:  "No retention period defined",
+		},
+		{
+			name:      "missing config rejected",
+			args:      map[string]interface{}{},
+			wantError: true,
+			wantText:  "backup is required",
+		},
+		{
+			name: "storage without location rejected",
+			args: map[string]interface{}{
+				"backup": map[string]interface{}{"storage": []interface{}{map[string]interface{}{"offsite": true}}},
+			},
+			wantError: true,
+			wantText:  "backup.storage[0].location",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateBackupPolicy(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateBackupPolicy() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/audit"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// handleCloseSession ends a guardrail session when an agent finishes, instead of
+// leaving it to expire. The session is deleted from the shared store and the token
+// stops working on this replica at once; other replicas may still accept it from
+// their in-memory cache for up to sessionCacheFreshness. The token is unbound from
+// the SSE connection it was created on, which stays open for further calls.
+// Closing a session that is already closed or has expired succeeds with
+// closed=false.
+func (s *MCPServer) handleCloseSession(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	sessionToken, _ := args["session_token"].(string)
+
+	if !sessionResourceToken.MatchString(sessionToken) {
+		result := models.SessionCloseResult{
+			Valid:     false,
+			Message:   "session_token is required and must be a session token",
+			SessionID: sessionToken,
+		}
+		return buildToolResult(result, true)
+	}
+
+	session, exists := s.getSession(sessionToken)
+	if !exists {
+		result := models.SessionCloseResult{
+			Valid:     true,
+			Message:   "Session already closed or expired",
+			SessionID: sessionToken,
+		}
+		return buildToolResult(result, false)
+	}
+
+	if err := s.sessions.Delete(ctx, sessionToken); err != nil {
+		slog.Error("Failed to close session", "error", err)
+		result := models.SessionCloseResult{
+			Valid:     false,
+			Message:   "Failed to close session",
+			SessionID: sessionToken,
+		}
+		return buildToolResult(result, true)
+	}
+	if s.sseSessions != nil {
+		s.sseSessions.unbindGuardrailSession(sessionToken)
+	}
+	if s.audit != nil {
+		s.audit.LogSession(ctx, audit.EventSessionClosed, sessionToken, session.ProjectSlug)
+	}
+
+	result := models.SessionCloseResult{
+		Valid:     true,
+		Message:   "Session closed",
+		SessionID: sessionToken,
+		Closed:    true,
+	}
+	return buildToolResult(result, false)
+}
@@ -0,0 +1,59 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// TestHandleCloseSession tests closing a session, closing it again and bad tokens
+func TestHandleCloseSession(t *testing.T) {
+	ctx := context.Background()
+	s := mockMCPServer()
+	s.sseSessions = newSSESessionManager(time.Minute, 0, 0, sseQueueConfig{})
+	sse, _, _ := s.sseSessions.attach("")
+
+	token := "0123456789abcdef0123456789abcdef0123456789abcdef"
+	s.sessions.Put(ctx, &Session{ID: token, ProjectSlug: "demo"})
+	s.sseSessions.bindGuardrailSession(sse.id, token)
+
+	closeSession := func(token string) (models.SessionCloseResult, bool) {
+		t.Helper()
+		res, err := s.handleCloseSession(ctx, map[string]interface{}{"session_token": token})
+		if err != nil {
+			t.Fatalf("handleCloseSession() error = %v", err)
+		}
+		var result models.SessionCloseResult
+		if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+			t.Fatalf("invalid JSON result: %v", err)
+		}
+		return result, res.IsError
+	}
+
+	result, isError := closeSession(token)
+	if isError || !result.Closed {
+		t.Fatalf("first close = %+v (error %v), want closed", result, isError)
+	}
+	if s.sessionExists(token) {
+		t.Error("session still exists after close")
+	}
+	if got := s.sseSessions.guardrailSession(sse.id); got != "" {
+		t.Errorf("SSE session still bound to %q", got)
+	}
+
+	// Closing again is not an error
+	result, isError = closeSession(token)
+	if isError || result.Closed || !result.Valid {
+		t.Errorf("second close = %+v (error %v), want valid and not closed", result, isError)
+	}
+
+	for _, bad := range []string{"", "not a token!"} {
+		if _, isError := closeSession(bad); !isError {
+			t.Errorf("close(%q) succeeded, want an error", bad)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+const (
+	// defaultMinCommentRatio is the minimum comment-to-code ratio for added code
+	defaultMinCommentRatio = 0.1
+	// defaultMinCodeLines is the number of added code lines below which the ratio is not enforced
+	defaultMinCodeLines = 20
+)
+
+// exportedDeclPattern matches top-level exported Go declarations
+var exportedDeclPattern = regexp.MustCompile(`^(?:func\s+(?:\([^)]*\)\s*)?|type\s+|var\s+|const\s+)([A-Z]\w*)`)
+
+// handleValidateCommentRatio checks that added code carries enough comments and
+// that every added exported Go declaration has a doc comment
+func (s *MCPServer) handleValidateCommentRatio(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	minRatio := defaultMinCommentRatio
+	if v, ok := args["min_ratio"].(float64); ok {
+		minRatio = v
+	}
+	minCodeLines := defaultMinCodeLines
+	if v, ok := args["min_code_lines"].(float64); ok {
+		minCodeLines = int(v)
+	}
+
+	if diff == "" {
+		result := models.CommentRatioResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	if minRatio < 0 || minRatio > 1 {
+		result := models.CommentRatioResult{
+			Valid:   false,
+			Message: "min_ratio must be between 0 and 1",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkCommentRatio(diff, minRatio, minCodeLines)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkCommentRatio analyzes the added lines of a diff for comment density and
+// undocumented exported declarations
+func checkCommentRatio(diff string, minRatio float64, minCodeLines int) models.CommentRatioResult {
+	lines := parseDiffLines(diff)
+
+	result := models.CommentRatioResult{
+		MinRatio:     minRatio,
+		Undocumented: []models.UndocumentedSymbol{},
+		Issues:       []string{},
+	}
+
+	for i, line := range lines {
+		if !line.Added {
+			continue
+		}
+		trimmed := strings.TrimSpace(line.Text)
+		switch {
+		case trimmed == "":
+			continue
+		case isCommentLine(trimmed):
+			result.CommentLines++
+			continue
+		}
+		result.CodeLines++
+
+		m := exportedDeclPattern.FindStringSubmatch(line.Text)
+		if m == nil {
+			continue
+		}
+		// The doc comment must be on the line directly above the declaration.
+		// If that line is not part of the diff we cannot tell, so skip it.
+		if line.Number > 1 {
+			if i == 0 || lines[i-1].Number != line.Number-1 || lines[i-1].File != line.File {
+				continue
+			}
+			if isCommentLine(strings.TrimSpace(lines[i-1].Text)) {
+				continue
+			}
+		}
+		result.Undocumented = append(result.Undocumented, models.UndocumentedSymbol{
+			Symbol:     m[1],
+			LineNumber: line.Number,
+			Line:       trimmed,
+		})
+		result.Issues = append(result.Issues, fmt.Sprintf("Exported %s at line %d has no doc comment", m[1], line.Number))
+	}
+
+	if result.CodeLines > 0 {
+		result.Ratio = float64(result.CommentLines) / float64(result.CodeLines)
+	}
+
+	ratioOK := true
+	if result.CodeLines >= minCodeLines {
+		result.RatioChecked = true
+		if result.Ratio < minRatio {
+			ratioOK = false
+			result.Issues = append(result.Issues, fmt.Sprintf("Comment ratio %.2f is below minimum %.2f (%d comment lines for %d code lines)",
+				result.Ratio, minRatio, result.CommentLines, result.CodeLines))
+		}
+	}
+
+	result.Valid = ratioOK && len(result.Undocumented) == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("Comment coverage OK (%d comment lines, %d code lines)", result.CommentLines, result.CodeLines)
+	} else {
+		result.Message = fmt.Sprintf("%d comment issue(s) found in added code", len(result.Issues))
+	}
+	return result
+}
+
+// isCommentLine reports whether a trimmed source line is a comment in common languages
+func isCommentLine(trimmed string) bool {
+	for _, prefix := range []string{"//", "/*", "* ", "#", `"""`, "'''", "-- "} {
+		if strings.HasPrefix(trimmed, prefix) {
+			return true
+		}
+	}
+	// Bare "*" continuation lines and block comment terminators
+	return trimmed == "*" || strings.HasSuffix(trimmed, "*/")
+}
@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckCommentRatio tests doc comment and comment ratio detection on added lines
+func TestCheckCommentRatio(t *testing.T) {
+	tests := []struct {
+		name             string
+		diff             string
+		minRatio         float64
+		minCodeLines     int
+		wantValid        bool
+		wantUndocumented []string
+	}{
+		{
+			name: "undocumented exported function flagged",
+			diff: `--- a/lib.go
++++ b/lib.go
+@@ -1,3 +1,7 @@
+ package lib
+
+ import "fmt"
++
++func Greet(name string) string {
++	return fmt.Sprintf("hello %s", name)
++}
+`,
+			minRatio:         defaultMinCommentRatio,
+			minCodeLines:     defaultMinCodeLines,
+			wantValid:        false,
+			wantUndocumented: []string{"Greet"},
+		},
+		{
+			name: "documented exported function passes",
+			diff: `--- a/lib.go
++++ b/lib.go
+@@ -1,3 +1,8 @@
+ package lib
+
+ import "fmt"
++
++// Greet returns a greeting for name
++func Greet(name string) string {
++	return fmt.Sprintf("hello %s", name)
++}
+`,
+			minRatio:     defaultMinCommentRatio,
+			minCodeLines: defaultMinCodeLines,
+			wantValid:    true,
+		},
+		{
+			name: "doc comment on context line passes",
+			diff: `--- a/lib.go
++++ b/lib.go
+@@ -4,2 +4,4 @@
+ // Client talks to the backend
+-type client struct{}
++type Client struct {
++}
+`,
+			minRatio:     defaultMinCommentRatio,
+			minCodeLines: defaultMinCodeLines,
+			wantValid:    true,
+		},
+		{
+			name: "unexported function without comment passes",
+			diff: `+++ b/lib.go
+@@ -1,1 +1,4 @@
+ package lib
++func greet() string {
++	return "hello"
++}
+`,
+			minRatio:     defaultMinCommentRatio,
+			minCodeLines: defaultMinCodeLines,
+			wantValid:    true,
+		},
+		{
+			name: "ratio below minimum for substantial code",
+			diff: `+++ b/lib.go
+@@ -1,1 +1,5 @@
+ package lib
++func greet() string {
++	msg := "hello"
++	return msg
++}
+`,
+			minRatio:     0.5,
+			minCodeLines: 4,
+			wantValid:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCommentRatio(tt.diff, tt.minRatio, tt.minCodeLines)
+			if result.Valid != tt.wantValid {
+				t.Errorf("checkCommentRatio() valid = %v, want %v (issues: %v)", result.Valid, tt.wantValid, result.Issues)
+			}
+			if len(result.Undocumented) != len(tt.wantUndocumented) {
+				t.Fatalf("undocumented = %+v, want %v", result.Undocumented, tt.wantUndocumented)
+			}
+			for i, sym := range tt.wantUndocumented {
+				if result.Undocumented[i].Symbol != sym {
+					t.Errorf("undocumented[%d] = %s, want %s", i, result.Undocumented[i].Symbol, sym)
+				}
+			}
+		})
+	}
+}
+
+// TestParseDiffLines tests new-file line numbering from unified diff hunks
+func TestParseDiffLines(t *testing.T) {
+	diff := `--- a/main.go
++++ b/main.go
+@@ -10,3 +10,3 @@
+ keep
+-old
++new
+ tail
+`
+	lines := parseDiffLines(diff)
+	if len(lines) != 3 {
+		t.Fatalf("parseDiffLines() returned %d lines, want 3: %+v", len(lines), lines)
+	}
+	if lines[1].Text != "new" || lines[1].Number != 11 || !lines[1].Added || lines[1].File != "main.go" {
+		t.Errorf("added line = %+v, want new at 11 in main.go", lines[1])
+	}
+	if lines[2].Number != 12 || lines[2].Added {
+		t.Errorf("context line = %+v, want line 12 not added", lines[2])
+	}
+
+	plain := parseDiffLines("package lib\nfunc A() {}")
+	if len(plain) != 2 || !plain[1].Added || plain[1].Number != 2 {
+		t.Errorf("plain content = %+v, want every line added", plain)
+	}
+}
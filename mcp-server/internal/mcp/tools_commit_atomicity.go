@@ -0,0 +1,184 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+const (
+	// defaultAtomicityMinScore is the score at or above which a commit is considered atomic
+	defaultAtomicityMinScore = 70
+	// atomicityFileBudget is the number of non-test files a focused commit may touch without penalty
+	atomicityFileBudget = 5
+)
+
+// dependencyManifests are files whose changes indicate a dependency update
+var dependencyManifests = map[string]bool{
+	"go.mod": true, "go.sum": true,
+	"package.json": true, "package-lock.json": true, "yarn.lock": true, "pnpm-lock.yaml": true,
+	"requirements.txt": true, "pyproject.toml": true, "poetry.lock": true, "Pipfile": true, "Pipfile.lock": true,
+	"Cargo.toml": true, "Cargo.lock": true, "Gemfile": true, "Gemfile.lock": true, "pom.xml": true, "build.gradle": true,
+}
+
+// handleValidateCommitAtomicity combines feature-creep, file-type mixing and diff-size
+// signals into a single score for whether a commit addresses one concern
+func (s *MCPServer) handleValidateCommitAtomicity(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+	description, _ := args["commit_message"].(string)
+
+	minScore := defaultAtomicityMinScore
+	if v, ok := args["min_score"].(float64); ok {
+		minScore = int(v)
+	}
+
+	if diff == "" {
+		result := models.CommitAtomicityResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	if minScore < 0 || minScore > 100 {
+		result := models.CommitAtomicityResult{
+			Valid:   false,
+			Message: "min_score must be between 0 and 100",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkCommitAtomicity(diff, description, minScore)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkCommitAtomicity scores a diff from 100 down, subtracting a capped penalty for
+// each signal of a multi-concern commit
+func checkCommitAtomicity(diff, description string, minScore int) models.CommitAtomicityResult {
+	creep := detectFeatureCreep(diff, description, false, "", defaultFeatureCreepThresholds, defaultLanguagePatterns)
+	files := changedFiles(diff)
+
+	result := models.CommitAtomicityResult{
+		FilesChanged: len(files),
+		Concerns:     []string{},
+		Additions:    creep.TotalChanges.Additions,
+		Deletions:    creep.TotalChanges.Deletions,
+		Factors:      []models.AtomicityFactor{},
+	}
+
+	// Feature creep: large_addition is covered by the diff size factor
+	creepSignals := []string{}
+	for _, v := range creep.Violations {
+		if v.Type != "large_addition" {
+			creepSignals = append(creepSignals, v.Message)
+		}
+	}
+	if len(creepSignals) > 0 {
+		result.Factors = append(result.Factors, models.AtomicityFactor{
+			Name:    "feature_creep",
+			Penalty: min(10*len(creepSignals), 40),
+			Detail:  strings.Join(creepSignals, "; "),
+		})
+	}
+
+	// File type mixing: tests and docs accompany a change and do not count as concerns
+	seen := make(map[string]bool)
+	primary := []string{}
+	nonTestFiles := 0
+	for _, f := range files {
+		concern := fileConcern(f)
+		if concern != "test" {
+			nonTestFiles++
+		}
+		if seen[concern] {
+			continue
+		}
+		seen[concern] = true
+		result.Concerns = append(result.Concerns, concern)
+		if concern != "test" && concern != "docs" {
+			primary = append(primary, concern)
+		}
+	}
+	if len(primary) > 1 {
+		result.Factors = append(result.Factors, models.AtomicityFactor{
+			Name:    "mixed_concerns",
+			Penalty: min(15*(len(primary)-1), 45),
+			Detail:  fmt.Sprintf("Touches %d kinds of files: %s", len(primary), strings.Join(primary, ", ")),
+		})
+	}
+
+	if nonTestFiles > atomicityFileBudget {
+		result.Factors = append(result.Factors, models.AtomicityFactor{
+			Name:    "file_count",
+			Penalty: min(5*(nonTestFiles-atomicityFileBudget), 20),
+			Detail:  fmt.Sprintf("%d non-test files changed (budget %d)", nonTestFiles, atomicityFileBudget),
+		})
+	}
+
+	changed := result.Additions + result.Deletions
+	sizePenalty := 0
+	switch {
+	case changed > 800:
+		sizePenalty = 30
+	case changed > 400:
+		sizePenalty = 20
+	case changed > 200:
+		sizePenalty = 10
+	}
+	if sizePenalty > 0 {
+		result.Factors = append(result.Factors, models.AtomicityFactor{
+			Name:    "diff_size",
+			Penalty: sizePenalty,
+			Detail:  fmt.Sprintf("%d lines changed (+%d/-%d)", changed, result.Additions, result.Deletions),
+		})
+	}
+
+	result.Score = 100
+	for _, f := range result.Factors {
+		result.Score -= f.Penalty
+	}
+	if result.Score < 0 {
+		result.Score = 0
+	}
+
+	result.Atomic = result.Score >= minScore
+	result.Valid = result.Atomic
+	if result.Atomic {
+		result.Message = fmt.Sprintf("Commit is atomic (score %d/100)", result.Score)
+	} else {
+		result.Message = fmt.Sprintf("Commit is not atomic (score %d/100, minimum %d) - consider splitting it", result.Score, minScore)
+	}
+	return result
+}
+
+// fileConcern classifies a changed file by the kind of change it represents
+func fileConcern(path string) string {
+	lower := strings.ToLower(path)
+	base := filepath.Base(path)
+	ext := filepath.Ext(lower)
+
+	switch {
+	case strings.HasSuffix(lower, "_test.go") || strings.Contains(lower, ".test.") || strings.Contains(lower, ".spec.") ||
+		strings.HasPrefix(base, "test_") || strings.HasPrefix(lower, "test/") || strings.HasPrefix(lower, "tests/") ||
+		strings.Contains(lower, "/test/") || strings.Contains(lower, "/tests/"):
+		return "test"
+	case dependencyManifests[base]:
+		return "dependencies"
+	case strings.Contains(lower, "migration") || ext == ".sql":
+		return "migration"
+	case strings.HasPrefix(lower, ".github/") || strings.HasPrefix(base, "Dockerfile") || base == "Makefile" ||
+		base == ".gitlab-ci.yml" || strings.HasPrefix(base, "docker-compose"):
+		return "ci"
+	case ext == ".md" || ext == ".rst" || ext == ".txt" || strings.HasPrefix(lower, "docs/"):
+		return "docs"
+	case ext == ".yaml" || ext == ".yml" || ext == ".json" || ext == ".toml" || ext == ".ini" ||
+		strings.HasPrefix(base, ".env"):
+		return "config"
+	default:
+		return "source"
+	}
+}
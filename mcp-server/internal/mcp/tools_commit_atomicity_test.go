@@ -0,0 +1,96 @@
+package mcp
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestCheckCommitAtomicity tests that focused commits score high and sprawling ones low
+func TestCheckCommitAtomicity(t *testing.T) {
+	focusedFix := `diff --git a/internal/cache/client.go b/internal/cache/client.go
+--- a/internal/cache/client.go
++++ b/internal/cache/client.go
+@@ -40,7 +40,7 @@ func (c *Client) Get(key string) (string, error) {
+ 	val, err := c.rdb.Get(ctx, key).Result()
+-	if err != nil {
++	if err != nil && err != redis.Nil {
+ 		return "", err
+ 	}
+diff --git a/internal/cache/client_test.go b/internal/cache/client_test.go
+--- a/internal/cache/client_test.go
++++ b/internal/cache/client_test.go
+@@ -10,3 +10,6 @@
+ func TestGet(t *testing.T) {
++	if _, err := c.Get("missing"); err != nil {
++		t.Fatal(err)
++	}
+ }
+`
+
+	var sprawling strings.Builder
+	for i, file := range []string{"internal/web/handlers.go", "internal/web/server.go", "internal/auth/token.go",
+		"internal/cache/client.go", "internal/ingest/service.go", "internal/updates/checker.go", "internal/team/manager.go"} {
+		fmt.Fprintf(&sprawling, "--- a/%s\n+++ b/%s\n@@ -1,1 +1,40 @@\n", file, file)
+		fmt.Fprintf(&sprawling, "+type Helper%d struct{}\n+func NewHelper%d() *Helper%d { return nil }\n+func (h *Helper%d) Run() {}\n", i, i, i, i)
+		for j := 0; j < 60; j++ {
+			fmt.Fprintf(&sprawling, "+\tvalue%d := compute(%d)\n", j, j)
+		}
+	}
+	sprawling.WriteString("--- a/go.mod\n+++ b/go.mod\n@@ -3,1 +3,2 @@\n+require github.com/some/lib v1.2.3\n")
+	sprawling.WriteString("--- /dev/null\n+++ b/migrations/005_add_index.sql\n@@ -0,0 +1,1 @@\n+CREATE INDEX idx ON t(c);\n")
+	sprawling.WriteString("--- a/.github/workflows/ci.yml\n+++ b/.github/workflows/ci.yml\n@@ -1,1 +1,2 @@\n+  - run: make lint\n")
+
+	tests := []struct {
+		name       string
+		diff       string
+		message    string
+		wantAtomic bool
+		minScore   int
+		maxScore   int
+	}{
+		{name: "focused single-file fix scores high", diff: focusedFix, message: "Handle redis.Nil in cache Get", wantAtomic: true, minScore: 90, maxScore: 100},
+		{name: "sprawling multi-concern diff scores low", diff: sprawling.String(), message: "Improve helpers, bump deps and CI", wantAtomic: false, minScore: 0, maxScore: 40},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCommitAtomicity(tt.diff, tt.message, defaultAtomicityMinScore)
+			if result.Atomic != tt.wantAtomic || result.Valid != tt.wantAtomic {
+				t.Errorf("atomic = %v, valid = %v, want %v (%+v)", result.Atomic, result.Valid, tt.wantAtomic, result.Factors)
+			}
+			if result.Score < tt.minScore || result.Score > tt.maxScore {
+				t.Errorf("score = %d, want between %d and %d (%+v)", result.Score, tt.minScore, tt.maxScore, result.Factors)
+			}
+		})
+	}
+
+	sprawl := checkCommitAtomicity(sprawling.String(), "", defaultAtomicityMinScore)
+	factors := map[string]bool{}
+	for _, f := range sprawl.Factors {
+		factors[f.Name] = true
+	}
+	for _, want := range []string{"feature_creep", "mixed_concerns", "file_count", "diff_size"} {
+		if !factors[want] {
+			t.Errorf("sprawling diff missing factor %s (%+v)", want, sprawl.Factors)
+		}
+	}
+}
+
+// TestFileConcern tests classification of changed files by concern
+func TestFileConcern(t *testing.T) {
+	tests := map[string]string{
+		"internal/web/handlers.go":      "source",
+		"internal/web/handlers_test.go": "test",
+		"go.sum":                        "dependencies",
+		"migrations/001_init.sql":       "migration",
+		".github/workflows/ci.yml":      "ci",
+		"docs/openapi.yaml":             "docs",
+		"config/settings.yaml":          "config",
+	}
+	for path, want := range tests {
+		if got := fileConcern(path); got != want {
+			t.Errorf("fileConcern(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// Commit author kinds accepted by the require argument
+const (
+	authorKindHuman = "human"
+	authorKindBot   = "bot"
+	authorKindAny   = "any"
+)
+
+// botAuthorPattern matches the names and emails of automated committers: GitHub
+// App accounts ("dependabot[bot]"), well-known bots and names ending in "bot"
+var botAuthorPattern = regexp.MustCompile(`(?i)\[bot\]|\b(?:dependabot|renovate|greenkeeper|snyk-bot|github-actions|gitlab-bot)\b|[-_.]bot\b|^bot\b`)
+
+// commitAuthorPolicy is the allowlist an author is checked against. With neither
+// domains nor authors set, any well-formed email is allowed.
+type commitAuthorPolicy struct {
+	domains []string // email domains, each also covering its subdomains
+	authors []string // individual emails allowed regardless of domain
+	require string   // human, bot or any
+}
+
+// handleValidateCommitAuthor checks a commit's author against the allowed email
+// domains and authors and whether the commit must come from a human or a bot. The
+// allowlist comes from the arguments or, when they name none, the project's
+// .guardrails/git-policy.json.
+func (s *MCPServer) handleValidateCommitAuthor(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, _ := args["author_name"].(string)
+	email, _ := args["author_email"].(string)
+
+	policy := commitAuthorPolicy{
+		domains: stringSliceArg(args, "allowed_domains"),
+		authors: stringSliceArg(args, "allowed_authors"),
+		require: authorKindAny,
+	}
+	if require, _ := args["require"].(string); require != "" {
+		policy.require = require
+	}
+	if len(policy.domains) == 0 && len(policy.authors) == 0 {
+		file := s.loadGitPolicy()
+		policy.domains = file.AllowedAuthorDomains
+		policy.authors = file.AllowedAuthors
+	}
+
+	if strings.TrimSpace(email) == "" {
+		result := models.CommitAuthorResult{
+			Valid:      false,
+			Message:    "author_email is required",
+			AuthorName: name,
+			Issues:     []models.CommitAuthorIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkCommitAuthor(name, email, policy)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkCommitAuthor validates an author's email against the policy's allowlist and
+// the author's kind, human or bot, against what the policy requires
+func checkCommitAuthor(name, email string, policy commitAuthorPolicy) models.CommitAuthorResult {
+	email = strings.TrimSpace(email)
+	result := models.CommitAuthorResult{
+		AuthorName:  name,
+		AuthorEmail: email,
+		IsBot:       botAuthorPattern.MatchString(name) || botAuthorPattern.MatchString(email),
+		Issues:      []models.CommitAuthorIssue{},
+	}
+
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok || local == "" || domain == "" || strings.ContainsAny(domain, "@ ") {
+		result.Issues = append(result.Issues, models.CommitAuthorIssue{
+			Type:     "invalid_email",
+			Severity: "error",
+			Message:  fmt.Sprintf("Author email %q is not a valid address", email),
+		})
+	} else {
+		result.Domain = strings.ToLower(domain)
+		if !authorAllowed(email, result.Domain, policy) {
+			message := fmt.Sprintf("Author %s is not an allowed author", email)
+			if len(policy.domains) > 0 {
+				message = fmt.Sprintf("Author %s is not in an allowed domain (%s)", email, strings.Join(policy.domains, ", "))
+			}
+			result.Issues = append(result.Issues, models.CommitAuthorIssue{
+				Type:     "unauthorized_author",
+				Severity: "error",
+				Message:  message,
+			})
+		}
+	}
+
+	switch {
+	case policy.require == authorKindHuman && result.IsBot:
+		result.Issues = append(result.Issues, models.CommitAuthorIssue{
+			Type:     "bot_author",
+			Severity: "error",
+			Message:  fmt.Sprintf("Commit by bot %s where a human author is required", authorLabel(name, email)),
+		})
+	case policy.require == authorKindBot && !result.IsBot:
+		result.Issues = append(result.Issues, models.CommitAuthorIssue{
+			Type:     "human_author",
+			Severity: "error",
+			Message:  fmt.Sprintf("Commit by %s where a bot author is required", authorLabel(name, email)),
+		})
+	}
+
+	result.Valid = len(result.Issues) == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("Author %s is allowed", authorLabel(name, email))
+	} else {
+		result.Message = fmt.Sprintf("Author %s violates commit author policy (%d issues)", authorLabel(name, email), len(result.Issues))
+	}
+	return result
+}
+
+// authorAllowed reports whether the email is listed in the policy's authors or
+// belongs to one of its domains or their subdomains. An empty allowlist allows all.
+func authorAllowed(email, domain string, policy commitAuthorPolicy) bool {
+	if len(policy.domains) == 0 && len(policy.authors) == 0 {
+		return true
+	}
+	for _, author := range policy.authors {
+		if strings.EqualFold(strings.TrimSpace(author), email) {
+			return true
+		}
+	}
+	for _, allowed := range policy.domains {
+		allowed = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(allowed), "@"))
+		if allowed != "" && (domain == allowed || strings.HasSuffix(domain, "."+allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+// authorLabel formats an author as "Name <email>", or the email alone without a name
+func authorLabel(name, email string) string {
+	if name == "" {
+		return email
+	}
+	return fmt.Sprintf("%s <%s>", name, email)
+}
@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestCheckCommitAuthor tests domain allowlisting and human/bot author requirements
+func TestCheckCommitAuthor(t *testing.T) {
+	orgPolicy := commitAuthorPolicy{
+		domains: []string{"example.com"},
+		authors: []string{"contractor@partner.io"},
+		require: authorKindHuman,
+	}
+
+	tests := []struct {
+		name       string
+		authorName string
+		email      string
+		policy     commitAuthorPolicy
+		wantValid  bool
+		wantBot    bool
+		wantIssues []string
+	}{
+		{name: "allowed domain passes", authorName: "Jane Doe", email: "jane@example.com", policy: orgPolicy, wantValid: true},
+		{name: "subdomain passes", authorName: "Jane Doe", email: "jane@eng.Example.com", policy: orgPolicy, wantValid: true},
+		{name: "allowed author outside domain passes", email: "Contractor@partner.io", policy: orgPolicy, wantValid: true},
+		{name: "out of domain author flagged", authorName: "Jane Doe", email: "jane@gmail.com", policy: orgPolicy, wantIssues: []string{"unauthorized_author"}},
+		{name: "lookalike domain flagged", email: "jane@notexample.com", policy: orgPolicy, wantIssues: []string{"unauthorized_author"}},
+		{
+			name:       "bot where human required",
+			authorName: "dependabot[bot]",
+			email:      "49699333+dependabot[bot]@users.noreply.github.com",
+			policy:     commitAuthorPolicy{require: authorKindHuman},
+			wantBot:    true,
+			wantIssues: []string{"bot_author"},
+		},
+		{
+			name:       "human where bot required",
+			authorName: "Jane Doe",
+			email:      "jane@example.com",
+			policy:     commitAuthorPolicy{domains: []string{"example.com"}, require: authorKindBot},
+			wantIssues: []string{"human_author"},
+		},
+		{name: "release bot allowed", authorName: "release-bot", email: "release-bot@example.com", policy: commitAuthorPolicy{domains: []string{"example.com"}, require: authorKindBot}, wantValid: true, wantBot: true},
+		{name: "invalid email", authorName: "Jane Doe", email: "jane", policy: commitAuthorPolicy{require: authorKindAny}, wantIssues: []string{"invalid_email"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCommitAuthor(tt.authorName, tt.email, tt.policy)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if result.IsBot != tt.wantBot {
+				t.Errorf("IsBot = %v, want %v", result.IsBot, tt.wantBot)
+			}
+			got := []string{}
+			for _, issue := range result.Issues {
+				got = append(got, issue.Type)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantIssues, ",") {
+				t.Errorf("issues = %v, want %v", got, tt.wantIssues)
+			}
+		})
+	}
+}
+
+// TestHandleValidateCommitAuthor tests that the allowlist falls back to the project's git policy
+func TestHandleValidateCommitAuthor(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".guardrails"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	policy := `{"allowed_author_domains": ["example.com"]}`
+	if err := os.WriteFile(filepath.Join(repo, ".guardrails", "git-policy.json"), []byte(policy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GUARDRAILS_REPO_PATH", repo)
+	s := mockMCPServer()
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{name: "project policy allows org email", args: map[string]interface{}{"author_email": "jane@example.com"}, wantText: `"domain":"example.com"`},
+		{name: "project policy flags other domain", args: map[string]interface{}{"author_email": "jane@gmail.com"}, wantError: true, wantText: "unauthorized_author"},
+		{
+			name:     "arguments override project policy",
+			args:     map[string]interface{}{"author_email": "jane@gmail.com", "allowed_domains": []interface{}{"gmail.com"}},
+			wantText: `"valid":true`,
+		},
+		{name: "missing email", args: map[string]interface{}{"author_name": "Jane Doe"}, wantError: true, wantText: "author_email is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateCommitAuthor(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateCommitAuthor() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result = %s, want it to contain %q", text, tt.wantText)
+			}
+		})
+	}
+}
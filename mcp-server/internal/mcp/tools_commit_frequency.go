@@ -0,0 +1,161 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+const (
+	// defaultChurnWindowDays is the period over which recent commits are counted
+	defaultChurnWindowDays = 30
+	// defaultMaxCommitsPerWindow is the number of commits in the window above which a file is high churn
+	defaultMaxCommitsPerWindow = 10
+	// defaultStaleDays is the age of the last commit after which a critical file is stale
+	defaultStaleDays = 180
+)
+
+// commitTimeLayouts are the accepted commit timestamp formats: RFC 3339, git's
+// %ci format and a plain date
+var commitTimeLayouts = []string{time.RFC3339, "2006-01-02 15:04:05 -0700", "2006-01-02"}
+
+// handleValidateCommitFrequency flags a file that changes too often (high churn) or,
+// when it is marked critical, one that has not changed for a long time
+func (s *MCPServer) handleValidateCommitFrequency(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	filePath, _ := args["file_path"].(string)
+	rawCommits, _ := args["commits"].([]interface{})
+	critical, _ := args["critical"].(bool)
+
+	windowDays := defaultChurnWindowDays
+	if v, ok := args["window_days"].(float64); ok {
+		windowDays = int(v)
+	}
+	maxCommits := defaultMaxCommitsPerWindow
+	if v, ok := args["max_commits"].(float64); ok {
+		maxCommits = int(v)
+	}
+	staleDays := defaultStaleDays
+	if v, ok := args["stale_days"].(float64); ok {
+		staleDays = int(v)
+	}
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.CommitFrequencyResult{
+			Valid:    false,
+			Message:  message,
+			FilePath: filePath,
+			Risks:    []models.CommitFrequencyRisk{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if filePath == "" {
+		return invalid("file_path is required")
+	}
+	if len(rawCommits) == 0 {
+		return invalid("commits is required")
+	}
+	if windowDays < 1 || maxCommits < 1 || staleDays < 1 {
+		return invalid("window_days, max_commits and stale_days must be positive")
+	}
+
+	commits := make([]time.Time, 0, len(rawCommits))
+	for i, raw := range rawCommits {
+		text, _ := raw.(string)
+		commit, err := parseCommitTime(text)
+		if err != nil {
+			return invalid(fmt.Sprintf("commits[%d]: %v", i, err))
+		}
+		commits = append(commits, commit)
+	}
+
+	now := time.Now()
+	if asOf, _ := args["as_of"].(string); asOf != "" {
+		t, err := parseCommitTime(asOf)
+		if err != nil {
+			return invalid(fmt.Sprintf("as_of: %v", err))
+		}
+		now = t
+	}
+
+	result := checkCommitFrequency(filePath, commits, critical, windowDays, maxCommits, staleDays, now)
+	return buildToolResult(result, !result.Valid)
+}
+
+// parseCommitTime parses a commit timestamp in one of commitTimeLayouts
+func parseCommitTime(text string) (time.Time, error) {
+	text = strings.TrimSpace(text)
+	for _, layout := range commitTimeLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q (use RFC 3339, e.g. 2024-05-01T12:00:00Z)", text)
+}
+
+// checkCommitFrequency counts the commits within windowDays of now. More than
+// maxCommits is high churn, and more than twice that is an error: the file is
+// unsettled and changes to it deserve closer review. A critical file whose last
+// commit is more than staleDays old is flagged as stale, since nobody has looked
+// at it against the code that has moved on around it.
+func checkCommitFrequency(filePath string, commits []time.Time, critical bool, windowDays, maxCommits, staleDays int, now time.Time) models.CommitFrequencyResult {
+	sorted := append([]time.Time(nil), commits...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	result := models.CommitFrequencyResult{
+		FilePath:     filePath,
+		TotalCommits: len(sorted),
+		WindowDays:   windowDays,
+		MaxCommits:   maxCommits,
+		Critical:     critical,
+		Risks:        []models.CommitFrequencyRisk{},
+	}
+
+	windowStart := now.AddDate(0, 0, -windowDays)
+	for _, commit := range sorted {
+		if commit.After(windowStart) && !commit.After(now) {
+			result.RecentCommits++
+		}
+	}
+
+	if len(sorted) > 0 {
+		last := sorted[len(sorted)-1]
+		result.LastCommit = last.Format(time.RFC3339)
+		if now.After(last) {
+			result.DaysSinceLastCommit = int(now.Sub(last).Hours() / 24)
+		}
+	}
+
+	if result.RecentCommits > maxCommits {
+		severity := "warning"
+		if result.RecentCommits > 2*maxCommits {
+			severity = "error"
+		}
+		result.Risks = append(result.Risks, models.CommitFrequencyRisk{
+			Type:     "high_churn",
+			Severity: severity,
+			Message:  fmt.Sprintf("%s changed %d times in the last %d days (limit %d); review changes to it closely", filePath, result.RecentCommits, windowDays, maxCommits),
+		})
+	}
+
+	if critical && result.DaysSinceLastCommit > staleDays {
+		result.Risks = append(result.Risks, models.CommitFrequencyRisk{
+			Type:     "stale_critical_file",
+			Severity: "warning",
+			Message:  fmt.Sprintf("Critical file %s has not changed in %d days (limit %d); check it still matches the code around it", filePath, result.DaysSinceLastCommit, staleDays),
+		})
+	}
+
+	result.Valid = len(result.Risks) == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("%s: %d commits in the last %d days", filePath, result.RecentCommits, windowDays)
+	} else {
+		result.Message = fmt.Sprintf("%s: %d risk indicator(s) found", filePath, len(result.Risks))
+	}
+	return result
+}
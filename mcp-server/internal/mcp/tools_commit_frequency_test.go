@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// commitsEvery returns count commit times spaced interval apart, the latest at last
+func commitsEvery(last time.Time, interval time.Duration, count int) []time.Time {
+	commits := make([]time.Time, count)
+	for i := range commits {
+		commits[i] = last.Add(-time.Duration(i) * interval)
+	}
+	return commits
+}
+
+// TestCheckCommitFrequency tests churn and staleness detection over a file's commit history
+func TestCheckCommitFrequency(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		commits      []time.Time
+		critical     bool
+		wantValid    bool
+		wantRecent   int
+		wantRiskKeys []string // type/severity
+	}{
+		{
+			name:       "stable file passes",
+			commits:    commitsEvery(now.AddDate(0, 0, -3), 14*24*time.Hour, 8),
+			wantValid:  true,
+			wantRecent: 2,
+		},
+		{
+			name:         "high churn file flagged",
+			commits:      commitsEvery(now.Add(-time.Hour), 2*24*time.Hour, 14),
+			wantRecent:   14,
+			wantRiskKeys: []string{"high_churn/warning"},
+		},
+		{
+			name:         "very high churn is an error",
+			commits:      commitsEvery(now.Add(-time.Hour), 12*time.Hour, 25),
+			wantRecent:   25,
+			wantRiskKeys: []string{"high_churn/error"},
+		},
+		{
+			name:         "stale critical file flagged",
+			commits:      commitsEvery(now.AddDate(-1, 0, 0), 30*24*time.Hour, 3),
+			critical:     true,
+			wantRiskKeys: []string{"stale_critical_file/warning"},
+		},
+		{
+			name:      "old non-critical file passes",
+			commits:   commitsEvery(now.AddDate(-1, 0, 0), 30*24*time.Hour, 3),
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCommitFrequency("internal/auth/session.go", tt.commits, tt.critical, defaultChurnWindowDays, defaultMaxCommitsPerWindow, defaultStaleDays, now)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (risks %+v)", result.Valid, tt.wantValid, result.Risks)
+			}
+			if result.RecentCommits != tt.wantRecent {
+				t.Errorf("RecentCommits = %d, want %d", result.RecentCommits, tt.wantRecent)
+			}
+			got := []string{}
+			for _, risk := range result.Risks {
+				got = append(got, risk.Type+"/"+risk.Severity)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantRiskKeys, ",") {
+				t.Errorf("risks = %v, want %v", got, tt.wantRiskKeys)
+			}
+		})
+	}
+}
+
+// TestHandleValidateCommitFrequency tests argument parsing and validation of the tool
+func TestHandleValidateCommitFrequency(t *testing.T) {
+	s := mockMCPServer()
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name: "git log timestamps",
+			args: map[string]interface{}{
+				"file_path": "main.go",
+				"commits":   []interface{}{"2024-05-30T09:15:00+02:00", "2024-05-20 10:00:00 +0000", "2024-01-02"},
+				"as_of":     "2024-06-01T00:00:00Z",
+			},
+			wantText: `"recent_commits":2`,
+		},
+		{
+			name: "custom limit",
+			args: map[string]interface{}{
+				"file_path":   "main.go",
+				"commits":     []interface{}{"2024-05-30", "2024-05-29"},
+				"max_commits": float64(1),
+				"as_of":       "2024-06-01",
+			},
+			wantError: true,
+			wantText:  "high_churn",
+		},
+		{name: "missing file path", args: map[string]interface{}{"commits": []interface{}{"2024-05-30"}}, wantError: true, wantText: "file_path is required"},
+		{name: "missing commits", args: map[string]interface{}{"file_path": "main.go"}, wantError: true, wantText: "commits is required"},
+		{name: "bad timestamp", args: map[string]interface{}{"file_path": "main.go", "commits": []interface{}{"yesterday"}}, wantError: true, wantText: "commits[0]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateCommitFrequency(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateCommitFrequency() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result = %s, want it to contain %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// maxRevertChangesListed caps the missing and extra changes listed in a result
+const maxRevertChangesListed = 20
+
+var (
+	revertReferencePattern  = regexp.MustCompile(`(?m)^This reverts commit ([0-9a-fA-F]{7,40})\.?\s*$`)
+	revertConventionalType  = regexp.MustCompile(`^revert(\([^)]+\))?!?: \S`)
+	revertGitDefaultSubject = regexp.MustCompile(`^Revert "(.+)"$`)
+	conventionalTypePrefix  = regexp.MustCompile(`^\w+(\([^)]+\))?!?: `)
+)
+
+// revertFileChanges counts the added and removed lines of one file in a diff
+type revertFileChanges struct {
+	added, removed map[string]int
+}
+
+// handleValidateCommitRevert checks that a revert commit names the commit it
+// reverts, uses the revert conventional type and, given both diffs, undoes every
+// change of the original commit
+func (s *MCPServer) handleValidateCommitRevert(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	message, _ := args["message"].(string)
+	diff, _ := args["diff"].(string)
+	originalDiff, _ := args["original_diff"].(string)
+	revertedSHA, _ := args["reverted_sha"].(string)
+
+	if strings.TrimSpace(message) == "" {
+		result := models.CommitRevertResult{
+			Valid:          false,
+			Message:        "message is required",
+			MissingChanges: []string{},
+			ExtraChanges:   []string{},
+			Issues:         []models.CommitRevertIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkCommitRevert(message, diff, originalDiff, revertedSHA)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkCommitRevert validates the revert message and, when the revert and the
+// original diff are both given, that the revert is their exact inverse. Changes the
+// revert leaves in place are errors; unrelated changes it adds are warnings.
+func checkCommitRevert(message, diff, originalDiff, revertedSHA string) models.CommitRevertResult {
+	result := models.CommitRevertResult{
+		MissingChanges: []string{},
+		ExtraChanges:   []string{},
+		Issues:         []models.CommitRevertIssue{},
+	}
+	issue := func(field, severity, message string) {
+		result.Issues = append(result.Issues, models.CommitRevertIssue{Field: field, Severity: severity, Message: message})
+	}
+
+	message = strings.ReplaceAll(strings.TrimSpace(message), "\r\n", "\n")
+	subject, body, _ := strings.Cut(message, "\n")
+	result.Subject = subject
+
+	switch {
+	case revertConventionalType.MatchString(subject):
+	case revertGitDefaultSubject.MatchString(subject):
+		original := conventionalTypePrefix.ReplaceAllString(revertGitDefaultSubject.FindStringSubmatch(subject)[1], "")
+		issue("subject", "error", "Subject uses git's default Revert \"...\" form instead of the revert conventional type")
+		result.Suggestion = "revert: " + original
+	default:
+		issue("subject", "error", "Subject must use the revert conventional type: revert: <subject of the reverted commit>")
+	}
+
+	if m := revertReferencePattern.FindStringSubmatch(body); m != nil {
+		result.RevertedSHA = strings.ToLower(m[1])
+		if revertedSHA != "" && !shaMatches(result.RevertedSHA, strings.ToLower(revertedSHA)) {
+			issue("reference", "error", fmt.Sprintf("Message reverts commit %s but the reverted commit is %s", result.RevertedSHA, revertedSHA))
+		}
+	} else {
+		issue("reference", "error", "Body must reference the reverted commit with a line \"This reverts commit <sha>.\"")
+	}
+
+	if strings.TrimSpace(revertReferencePattern.ReplaceAllString(body, "")) == "" {
+		issue("body", "warning", "Body should explain why the commit is being reverted")
+	}
+
+	if diff != "" && originalDiff != "" {
+		result.CompletenessChecked = true
+		missing, extra := compareRevertDiff(diff, originalDiff)
+		if len(missing) > 0 {
+			issue("diff", "error", fmt.Sprintf("Revert is incomplete: %d change(s) of the original commit are not undone", len(missing)))
+		}
+		if len(extra) > 0 {
+			issue("diff", "warning", fmt.Sprintf("Revert also makes %d change(s) that are not in the original commit", len(extra)))
+		}
+		result.MissingChanges = missing[:min(len(missing), maxRevertChangesListed)]
+		result.ExtraChanges = extra[:min(len(extra), maxRevertChangesListed)]
+	}
+
+	errorCount := 0
+	for _, i := range result.Issues {
+		if i.Severity == "error" {
+			errorCount++
+		}
+	}
+	result.Valid = errorCount == 0
+	switch {
+	case !result.Valid:
+		result.Message = fmt.Sprintf("Revert commit has %d problem(s)", errorCount)
+	case result.CompletenessChecked:
+		result.Message = fmt.Sprintf("Revert of %s is well-formed and undoes the whole commit", result.RevertedSHA)
+	default:
+		result.Message = fmt.Sprintf("Revert of %s is well-formed (pass diff and original_diff to check it is complete)", result.RevertedSHA)
+	}
+	return result
+}
+
+// compareRevertDiff matches the revert diff against the inverse of the original:
+// every line the original added must be removed and every line it removed must be
+// added back. Blank lines are ignored.
+func compareRevertDiff(diff, originalDiff string) (missing, extra []string) {
+	original := revertChangesByFile(originalDiff)
+	revert := revertChangesByFile(diff)
+
+	files := make([]string, 0, len(original)+len(revert))
+	for path := range original {
+		files = append(files, path)
+	}
+	for path := range revert {
+		if _, ok := original[path]; !ok {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+
+	for _, path := range files {
+		o, r := original[path], revert[path]
+		if o == nil {
+			o = &revertFileChanges{}
+		}
+		if r == nil {
+			r = &revertFileChanges{}
+		}
+		missing = append(missing, unmatchedLines(path, "-", o.added, r.removed)...)
+		missing = append(missing, unmatchedLines(path, "+", o.removed, r.added)...)
+		extra = append(extra, unmatchedLines(path, "-", r.removed, o.added)...)
+		extra = append(extra, unmatchedLines(path, "+", r.added, o.removed)...)
+	}
+	return missing, extra
+}
+
+// unmatchedLines lists the lines of want not covered by got, as "path: <sign>line"
+func unmatchedLines(path, sign string, want, got map[string]int) []string {
+	var lines []string
+	for _, line := range sortedLineKeys(want) {
+		for n := want[line] - got[line]; n > 0; n-- {
+			lines = append(lines, fmt.Sprintf("%s: %s%s", path, sign, line))
+		}
+	}
+	return lines
+}
+
+// revertChangesByFile counts the added and removed lines of each file in a unified
+// diff. Created and deleted files are keyed by their real path, not /dev/null.
+func revertChangesByFile(diff string) map[string]*revertFileChanges {
+	files := make(map[string]*revertFileChanges)
+	for _, file := range parseDiffFiles(diff) {
+		changes := files[file.Path]
+		if changes == nil {
+			changes = &revertFileChanges{added: map[string]int{}, removed: map[string]int{}}
+			files[file.Path] = changes
+		}
+		countRevertLines(changes.added, file.Added)
+		countRevertLines(changes.removed, file.Removed)
+	}
+	return files
+}
+
+// countRevertLines counts lines by their text without trailing whitespace, skipping blank lines
+func countRevertLines(counts map[string]int, lines []string) {
+	for _, line := range lines {
+		if text := strings.TrimRight(line, " \t"); strings.TrimSpace(text) != "" {
+			counts[text]++
+		}
+	}
+}
+
+// shaMatches reports whether two commit hashes, either possibly abbreviated, name
+// the same commit
+func shaMatches(a, b string) bool {
+	return strings.HasPrefix(a, b) || strings.HasPrefix(b, a)
+}
+
+func sortedLineKeys(m map[string]int) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
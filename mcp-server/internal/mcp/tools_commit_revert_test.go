@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const revertOriginalDiff = `diff --git a/config.go b/config.go
+--- a/config.go
++++ b/config.go
+@@ -10,3 +10,4 @@
+ 	Port int
+-	Timeout int
++	Timeout time.Duration
++	Retries int
+ }
+diff --git a/retry.go b/retry.go
+new file mode 100644
+--- /dev/null
++++ b/retry.go
+@@ -0,0 +1,2 @@
++package config
++
++const defaultRetries = 3
+`
+
+const revertFullDiff = `diff --git a/config.go b/config.go
+--- a/config.go
++++ b/config.go
+@@ -10,4 +10,3 @@
+ 	Port int
+-	Timeout time.Duration
+-	Retries int
++	Timeout int
+ }
+diff --git a/retry.go b/retry.go
+deleted file mode 100644
+--- a/retry.go
++++ /dev/null
+@@ -1,2 +0,0 @@
+-package config
+-
+-const defaultRetries = 3
+`
+
+const wellFormedRevert = "revert: add retry support to config\n\nRetries broke the health check timeout.\n\nThis reverts commit 3f9c2a1b7e.\n"
+
+// TestCheckCommitRevert tests the message checks and the completeness check
+func TestCheckCommitRevert(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string
+		diff         string
+		originalDiff string
+		revertedSHA  string
+		wantValid    bool
+		wantIssues   []string // field/severity
+		wantMissing  int
+	}{
+		{
+			name:         "well-formed complete revert",
+			message:      wellFormedRevert,
+			diff:         revertFullDiff,
+			originalDiff: revertOriginalDiff,
+			revertedSHA:  "3f9c2a1b7e4d",
+			wantValid:    true,
+		},
+		{
+			name:      "scoped revert without diffs",
+			message:   "revert(config): add retry support\n\nRetries broke startup.\n\nThis reverts commit 3F9C2A1.",
+			wantValid: true,
+		},
+		{
+			name:       "git default subject without a reason",
+			message:    "Revert \"feat: add retry support\"\n\nThis reverts commit 3f9c2a1b7e.",
+			wantValid:  false,
+			wantIssues: []string{"subject/error", "body/warning"},
+		},
+		{
+			name:       "missing reference and wrong type",
+			message:    "fix: undo retry support\n\nIt broke the health check.",
+			wantValid:  false,
+			wantIssues: []string{"subject/error", "reference/error"},
+		},
+		{
+			name:        "reference to another commit",
+			message:     wellFormedRevert,
+			revertedSHA: "9a8b7c6",
+			wantValid:   false,
+			wantIssues:  []string{"reference/error"},
+		},
+		{
+			name:         "partial manual undo",
+			message:      wellFormedRevert,
+			diff:         strings.Split(revertFullDiff, "diff --git a/retry.go")[0],
+			originalDiff: revertOriginalDiff,
+			wantValid:    false,
+			wantIssues:   []string{"diff/error"},
+			wantMissing:  2,
+		},
+		{
+			name:         "removed lines that look like file headers",
+			message:      wellFormedRevert,
+			diff:         "--- a/schema.sql\n+++ b/schema.sql\n@@ -1,3 +1 @@\n--- retries per job\n-ALTER TABLE jobs ADD retries int;\n CREATE TABLE jobs (id int);\n",
+			originalDiff: "--- a/schema.sql\n+++ b/schema.sql\n@@ -1 +1,3 @@\n+-- retries per job\n+ALTER TABLE jobs ADD retries int;\n CREATE TABLE jobs (id int);\n",
+			wantValid:    true,
+		},
+		{
+			name:         "revert with unrelated changes",
+			message:      wellFormedRevert,
+			diff:         revertFullDiff + "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-var debug = false\n+var debug = true\n",
+			originalDiff: revertOriginalDiff,
+			wantValid:    true,
+			wantIssues:   []string{"diff/warning"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCommitRevert(tt.message, tt.diff, tt.originalDiff, tt.revertedSHA)
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if len(result.Issues) != len(tt.wantIssues) {
+				t.Fatalf("issues = %+v, want %v", result.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := result.Issues[i].Field + "/" + result.Issues[i].Severity; got != want {
+					t.Errorf("issue[%d] = %s, want %s", i, got, want)
+				}
+			}
+			if len(result.MissingChanges) != tt.wantMissing {
+				t.Errorf("missing changes = %v, want %d", result.MissingChanges, tt.wantMissing)
+			}
+		})
+	}
+}
+
+// TestHandleValidateCommitRevert tests the tool result for good, bad and empty input
+func TestHandleValidateCommitRevert(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name:     "well-formed revert passes",
+			args:     map[string]interface{}{"message": wellFormedRevert, "diff": revertFullDiff, "original_diff": revertOriginalDiff},
+			wantText: "undoes the whole commit",
+		},
+		{
+			name:      "git default subject flagged with suggestion",
+			args:      map[string]interface{}{"message": "Revert \"feat: add retry support\"\n\nThis reverts commit 3f9c2a1b7e."},
+			wantError: true,
+			wantText:  `"suggestion":"revert: add retry support"`,
+		},
+		{name: "missing message", args: map[string]interface{}{}, wantError: true, wantText: "message is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateCommitRevert(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateCommitRevert() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// commitSizeLimits are the largest commit, in changed lines and files, that is
+// reviewable in one sitting. Generated files get their own, much larger, limits.
+type commitSizeLimits struct {
+	MaxLines          int
+	MaxFiles          int
+	MaxGeneratedLines int
+	MaxGeneratedFiles int
+}
+
+// defaultCommitSizeLimits apply when the call does not override them
+var defaultCommitSizeLimits = commitSizeLimits{
+	MaxLines:          400,
+	MaxFiles:          15,
+	MaxGeneratedLines: 5000,
+	MaxGeneratedFiles: 100,
+}
+
+// defaultGeneratedGlobs match files written by tools rather than people: lockfiles,
+// generated code, vendored dependencies and build output
+var defaultGeneratedGlobs = []string{
+	"**/go.sum", "**/package-lock.json", "**/yarn.lock", "**/pnpm-lock.yaml",
+	"**/poetry.lock", "**/Pipfile.lock", "**/Cargo.lock", "**/Gemfile.lock",
+	"**/*.pb.go", "**/*_generated.go", "**/*.gen.go", "**/zz_generated*",
+	"**/*.min.js", "**/*.min.css", "**/*.bundle.js", "**/__generated__/**",
+	"**/vendor/**", "**/node_modules/**", "**/dist/**", "**/build/**",
+}
+
+// commitFileStat is the lines one file changes in a commit
+type commitFileStat struct {
+	Path      string
+	Additions int
+	Deletions int
+}
+
+// handleValidateCommitSize flags commits over the line and file limits, counting
+// generated files separately so a lockfile update does not fail a small change
+func (s *MCPServer) handleValidateCommitSize(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.CommitSizeResult{
+			Valid:          false,
+			Message:        message,
+			GeneratedFiles: []string{},
+			Issues:         []models.CommitSizeIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	var files []commitFileStat
+	switch {
+	case diff != "":
+		files = diffFileStats(diff)
+	case args["files"] != nil:
+		files = commitFilesArg(args)
+	default:
+		return invalid("diff or files is required")
+	}
+
+	limits := defaultCommitSizeLimits
+	for key, limit := range map[string]*int{
+		"max_lines":           &limits.MaxLines,
+		"max_files":           &limits.MaxFiles,
+		"max_generated_lines": &limits.MaxGeneratedLines,
+		"max_generated_files": &limits.MaxGeneratedFiles,
+	} {
+		if v, ok := args[key].(float64); ok {
+			if v < 1 {
+				return invalid(fmt.Sprintf("%s must be at least 1", key))
+			}
+			*limit = int(v)
+		}
+	}
+
+	generatedGlobs := stringSliceArg(args, "generated_globs")
+	if len(generatedGlobs) == 0 {
+		generatedGlobs = defaultGeneratedGlobs
+	}
+	for _, glob := range generatedGlobs {
+		if _, err := globMatch(glob, ""); err != nil {
+			return invalid(err.Error())
+		}
+	}
+
+	result := checkCommitSize(files, limits, generatedGlobs)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkCommitSize totals hand-written and generated files separately and compares
+// each total against its own limits
+func checkCommitSize(files []commitFileStat, limits commitSizeLimits, generatedGlobs []string) models.CommitSizeResult {
+	result := models.CommitSizeResult{
+		GeneratedFiles: []string{},
+		Issues:         []models.CommitSizeIssue{},
+	}
+
+	for _, f := range files {
+		stats := &result.HandWritten
+		if isGeneratedFile(f.Path, generatedGlobs) {
+			stats = &result.Generated
+			result.GeneratedFiles = append(result.GeneratedFiles, f.Path)
+		}
+		stats.Files++
+		stats.Additions += f.Additions
+		stats.Deletions += f.Deletions
+	}
+
+	over := func(field string, got, limit int, what string) {
+		if got > limit {
+			result.Issues = append(result.Issues, models.CommitSizeIssue{
+				Field:    field,
+				Severity: "error",
+				Message:  fmt.Sprintf("%d %s (limit %d)", got, what, limit),
+			})
+		}
+	}
+	over("lines", result.HandWritten.Additions+result.HandWritten.Deletions, limits.MaxLines, "hand-written lines changed")
+	over("files", result.HandWritten.Files, limits.MaxFiles, "hand-written files changed")
+	over("generated_lines", result.Generated.Additions+result.Generated.Deletions, limits.MaxGeneratedLines, "generated lines changed")
+	over("generated_files", result.Generated.Files, limits.MaxGeneratedFiles, "generated files changed")
+
+	result.Valid = len(result.Issues) == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("Commit is within size limits (%d hand-written lines in %d files)",
+			result.HandWritten.Additions+result.HandWritten.Deletions, result.HandWritten.Files)
+	} else {
+		result.Message = fmt.Sprintf("Commit is too large: %d limit(s) exceeded - consider splitting it into smaller commits", len(result.Issues))
+	}
+	return result
+}
+
+// isGeneratedFile reports whether a path matches one of the generated file globs
+func isGeneratedFile(path string, generatedGlobs []string) bool {
+	for _, glob := range generatedGlobs {
+		if ok, _ := globMatch(glob, path); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// diffFileStats counts the added and removed lines of each file in a unified diff
+func diffFileStats(diff string) []commitFileStat {
+	var files []commitFileStat
+	for _, file := range parseDiffFiles(diff) {
+		files = append(files, commitFileStat{Path: file.Path, Additions: len(file.Added), Deletions: len(file.Removed)})
+	}
+	return files
+}
+
+// commitFilesArg reads files given as {path, additions, deletions} objects or plain paths
+func commitFilesArg(args map[string]interface{}) []commitFileStat {
+	raw, _ := args["files"].([]interface{})
+	files := make([]commitFileStat, 0, len(raw))
+	for _, item := range raw {
+		switch v := item.(type) {
+		case string:
+			files = append(files, commitFileStat{Path: v})
+		case map[string]interface{}:
+			path, _ := v["path"].(string)
+			additions, _ := v["additions"].(float64)
+			deletions, _ := v["deletions"].(float64)
+			if path != "" {
+				files = append(files, commitFileStat{Path: path, Additions: int(additions), Deletions: int(deletions)})
+			}
+		}
+	}
+	return files
+}
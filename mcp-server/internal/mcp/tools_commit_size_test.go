@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// sizedDiff builds a diff adding lines lines to each of the paths
+func sizedDiff(lines int, paths ...string) string {
+	var b strings.Builder
+	for _, path := range paths {
+		fmt.Fprintf(&b, "--- a/%s\n+++ b/%s\n@@ -0,0 +1,%d @@\n", path, path, lines)
+		for i := 0; i < lines; i++ {
+			fmt.Fprintf(&b, "+line %d\n", i)
+		}
+	}
+	return b.String()
+}
+
+// TestCheckCommitSize tests the hand-written and generated limits
+func TestCheckCommitSize(t *testing.T) {
+	tests := []struct {
+		name          string
+		diff          string
+		wantValid     bool
+		wantFields    []string
+		wantGenerated int
+	}{
+		{
+			name:      "small commit passes",
+			diff:      sizedDiff(20, "internal/api/handler.go", "internal/api/handler_test.go"),
+			wantValid: true,
+		},
+		{
+			name:       "too many lines flagged",
+			diff:       sizedDiff(250, "internal/api/handler.go", "internal/api/routes.go"),
+			wantValid:  false,
+			wantFields: []string{"lines"},
+		},
+		{
+			name:       "too many files flagged",
+			diff:       sizedDiff(1, "a.go", "b.go", "c.go", "d.go", "e.go", "f.go", "g.go", "h.go", "i.go", "j.go", "k.go", "l.go", "m.go", "n.go", "o.go", "p.go"),
+			wantValid:  false,
+			wantFields: []string{"files"},
+		},
+		{
+			name:          "generated files do not count toward the hand-written limit",
+			diff:          sizedDiff(10, "cmd/main.go") + sizedDiff(1500, "go.sum", "web/package-lock.json", "api/v1/service.pb.go"),
+			wantValid:     true,
+			wantGenerated: 3,
+		},
+		{
+			name:          "generated limit still applies",
+			diff:          sizedDiff(6000, "vendor/github.com/lib/pq/conn.go"),
+			wantValid:     false,
+			wantFields:    []string{"generated_lines"},
+			wantGenerated: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCommitSize(diffFileStats(tt.diff), defaultCommitSizeLimits, defaultGeneratedGlobs)
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if len(result.Issues) != len(tt.wantFields) {
+				t.Fatalf("issues = %+v, want %v", result.Issues, tt.wantFields)
+			}
+			for i, field := range tt.wantFields {
+				if result.Issues[i].Field != field {
+					t.Errorf("issue[%d] = %s, want %s", i, result.Issues[i].Field, field)
+				}
+			}
+			if len(result.GeneratedFiles) != tt.wantGenerated {
+				t.Errorf("generated files = %v, want %d", result.GeneratedFiles, tt.wantGenerated)
+			}
+		})
+	}
+}
+
+// TestDiffFileStats tests per-file counts, including changed lines that look like file headers
+func TestDiffFileStats(t *testing.T) {
+	tests := []struct {
+		name string
+		diff string
+		want []commitFileStat
+	}{
+		{
+			name: "two files",
+			diff: sizedDiff(3, "a.go", "b.go"),
+			want: []commitFileStat{{Path: "a.go", Additions: 3}, {Path: "b.go", Additions: 3}},
+		},
+		{
+			name: "removed SQL comment and added increment",
+			diff: "--- a/schema.sql\n+++ b/schema.sql\n@@ -1,3 +1,2 @@\n--- drop the legacy table\n-DROP TABLE legacy;\n+++ counter\n CREATE TABLE t (id int);\n" +
+				"--- a/main.go\n+++ b/main.go\n@@ -1 +1 @@\n-x := 1\n+x := 2\n",
+			want: []commitFileStat{{Path: "schema.sql", Additions: 1, Deletions: 2}, {Path: "main.go", Additions: 1, Deletions: 1}},
+		},
+		{
+			name: "deleted file",
+			diff: "--- a/old.go\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-package old\n-\n",
+			want: []commitFileStat{{Path: "old.go", Deletions: 2}},
+		},
+		{
+			name: "no hunk headers",
+			diff: "--- a/notes.txt\n+++ b/notes.txt\n+first\n-second\n",
+			want: []commitFileStat{{Path: "notes.txt", Additions: 1, Deletions: 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffFileStats(tt.diff)
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("diffFileStats() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHandleValidateCommitSize tests the tool result for diffs, file lists and bad arguments
+func TestHandleValidateCommitSize(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name:     "small diff",
+			args:     map[string]interface{}{"diff": sizedDiff(5, "main.go")},
+			wantText: "within size limits",
+		},
+		{
+			name: "oversized file list",
+			args: map[string]interface{}{"files": []interface{}{
+				map[string]interface{}{"path": "service.go", "additions": float64(300), "deletions": float64(200)},
+			}},
+			wantError: true,
+			wantText:  "consider splitting",
+		},
+		{
+			name:     "custom generated globs",
+			args:     map[string]interface{}{"diff": sizedDiff(500, "schema/types.graphql.ts"), "generated_globs": []interface{}{"schema/**"}},
+			wantText: "within size limits",
+		},
+		{
+			name:      "custom line limit",
+			args:      map[string]interface{}{"diff": sizedDiff(30, "main.go"), "max_lines": float64(10)},
+			wantError: true,
+			wantText:  "limit 10",
+		},
+		{name: "no diff", args: map[string]interface{}{}, wantError: true, wantText: "diff or files is required"},
+		{name: "bad limit", args: map[string]interface{}{"diff": "+x", "max_files": float64(0)}, wantError: true, wantText: "max_files must be at least 1"},
+		{name: "bad glob", args: map[string]interface{}{"diff": "+x", "generated_globs": []interface{}{"["}}, wantError: true, wantText: "invalid glob"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateCommitSize(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateCommitSize() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// wipMarker is a work-in-progress marker and where in a commit message it counts
+type wipMarker struct {
+	name        string
+	pattern     *regexp.Regexp
+	wholeCommit bool // match the body too, not just the subject
+	reason      string
+}
+
+// wipMarkers are checked in order; a commit is reported for its first marker only
+var wipMarkers = []wipMarker{
+	{name: "fixup!", pattern: regexp.MustCompile(`^fixup! `), reason: "is a fixup! commit meant to be autosquashed into an earlier one"},
+	{name: "squash!", pattern: regexp.MustCompile(`^squash! `), reason: "is a squash! commit meant to be autosquashed into an earlier one"},
+	{name: "amend!", pattern: regexp.MustCompile(`^amend! `), reason: "is an amend! commit meant to be autosquashed into an earlier one"},
+	{name: "do_not_merge", pattern: regexp.MustCompile(`(?i)\bdo[ _-]?not[ _-]?merge\b`), wholeCommit: true, reason: "is marked DO NOT MERGE"},
+	{name: "wip", pattern: regexp.MustCompile(`(?i)(^|[^\w-])wip([^\w-]|$)`), reason: "is marked as work in progress"},
+}
+
+// handleValidateCommitWIP checks commit messages for work-in-progress markers and
+// blocks them when the target branch is protected. Protected branches come from
+// the arguments, then the project's .guardrails/git-policy.json, then the defaults
+// used by guardrail_validate_git_push.
+func (s *MCPServer) handleValidateCommitWIP(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	branch, _ := args["branch"].(string)
+	messages := stringSliceArg(args, "messages")
+	if message, _ := args["message"].(string); message != "" {
+		messages = append([]string{message}, messages...)
+	}
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.CommitWIPResult{
+			Valid:    false,
+			Message:  message,
+			Branch:   branch,
+			Findings: []models.CommitWIPFinding{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if strings.TrimSpace(branch) == "" {
+		return invalid("branch is required")
+	}
+	if len(messages) == 0 {
+		return invalid("message or messages is required")
+	}
+
+	protectedBranches := stringSliceArg(args, "protected_branches")
+	if len(protectedBranches) == 0 {
+		protectedBranches = s.loadProtectedBranches()
+	}
+
+	result := checkCommitWIP(branch, messages, protectedBranches)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkCommitWIP reports the commits carrying a work-in-progress marker. They are
+// errors when branch is protected and warnings on any other branch, where WIP
+// commits are expected until the branch is cleaned up before merging.
+func checkCommitWIP(branch string, messages, protectedBranches []string) models.CommitWIPResult {
+	result := models.CommitWIPResult{
+		Branch:         branch,
+		ProtectedBy:    matchProtectedBranch(branch, protectedBranches),
+		CommitsChecked: len(messages),
+		Findings:       []models.CommitWIPFinding{},
+	}
+	result.Protected = result.ProtectedBy != ""
+
+	severity := "warning"
+	if result.Protected {
+		severity = "error"
+	}
+
+	for i, message := range messages {
+		message = strings.TrimSpace(strings.ReplaceAll(message, "\r\n", "\n"))
+		subject, _, _ := strings.Cut(message, "\n")
+		for _, marker := range wipMarkers {
+			text := subject
+			if marker.wholeCommit {
+				text = message
+			}
+			if !marker.pattern.MatchString(text) {
+				continue
+			}
+			finding := models.CommitWIPFinding{
+				Commit:   i,
+				Subject:  subject,
+				Marker:   marker.name,
+				Severity: severity,
+				Message:  fmt.Sprintf("Commit %q %s", subject, marker.reason),
+			}
+			if result.Protected {
+				finding.Message += fmt.Sprintf(" and cannot go to '%s' (protected by '%s')", branch, result.ProtectedBy)
+			} else {
+				finding.Message += "; squash or reword it before merging"
+			}
+			result.Findings = append(result.Findings, finding)
+			break
+		}
+	}
+
+	result.Valid = !result.Protected || len(result.Findings) == 0
+	switch {
+	case len(result.Findings) == 0:
+		result.Message = fmt.Sprintf("No work-in-progress markers in %d commit(s)", len(messages))
+	case result.Protected:
+		result.Message = fmt.Sprintf("%d work-in-progress commit(s) blocked on protected branch '%s'", len(result.Findings), branch)
+	default:
+		result.Message = fmt.Sprintf("%d work-in-progress commit(s) on '%s' - clean them up before merging", len(result.Findings), branch)
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// TestCheckCommitWIP tests marker detection and blocking on protected branches
+func TestCheckCommitWIP(t *testing.T) {
+	tests := []struct {
+		name          string
+		branch        string
+		messages      []string
+		wantValid     bool
+		wantProtected bool
+		wantMarkers   []string // marker/severity
+	}{
+		{
+			name:          "fixup to main is blocked",
+			branch:        "main",
+			messages:      []string{"fixup! feat: add retry support"},
+			wantValid:     false,
+			wantProtected: true,
+			wantMarkers:   []string{"fixup!/error"},
+		},
+		{
+			name:        "fixup on feature branch is warned only",
+			branch:      "feature/retries",
+			messages:    []string{"fixup! feat: add retry support"},
+			wantValid:   true,
+			wantMarkers: []string{"fixup!/warning"},
+		},
+		{
+			name:          "wip prefixes",
+			branch:        "release/2.1",
+			messages:      []string{"WIP: new parser", "[wip] tokenizer", "wip"},
+			wantValid:     false,
+			wantProtected: true,
+			wantMarkers:   []string{"wip/error", "wip/error", "wip/error"},
+		},
+		{
+			name:          "do not merge in body",
+			branch:        "master",
+			messages:      []string{"feat: toggle beta flag\n\nDO NOT MERGE until the launch is approved"},
+			wantValid:     false,
+			wantProtected: true,
+			wantMarkers:   []string{"do_not_merge/error"},
+		},
+		{
+			name:        "squash and clean commits",
+			branch:      "bugfix/login",
+			messages:    []string{"squash! fix: handle empty password", "fix: handle empty password"},
+			wantValid:   true,
+			wantMarkers: []string{"squash!/warning"},
+		},
+		{
+			name:          "words containing wip are not markers",
+			branch:        "main",
+			messages:      []string{"fix: wipe cache on logout", "docs: explain wip-limit setting", "fix: fixup!ed config loader"},
+			wantValid:     true,
+			wantProtected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkCommitWIP(tt.branch, tt.messages, defaultProtectedBranches)
+
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if result.Protected != tt.wantProtected {
+				t.Errorf("Protected = %v, want %v", result.Protected, tt.wantProtected)
+			}
+			var markers []string
+			for _, f := range result.Findings {
+				markers = append(markers, f.Marker+"/"+f.Severity)
+			}
+			if strings.Join(markers, ",") != strings.Join(tt.wantMarkers, ",") {
+				t.Errorf("findings = %v, want %v", markers, tt.wantMarkers)
+			}
+		})
+	}
+}
+
+// TestHandleValidateCommitWIP tests the handler arguments and protected branch
+// configuration
+func TestHandleValidateCommitWIP(t *testing.T) {
+	t.Setenv("GUARDRAILS_REPO_PATH", t.TempDir())
+	s := &MCPServer{}
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		wantError   bool
+		wantMessage string
+	}{
+		{
+			name:        "missing branch",
+			args:        map[string]interface{}{"message": "wip"},
+			wantError:   true,
+			wantMessage: "branch is required",
+		},
+		{
+			name:        "missing message",
+			args:        map[string]interface{}{"branch": "main"},
+			wantError:   true,
+			wantMessage: "message or messages is required",
+		},
+		{
+			name:        "fixup to main",
+			args:        map[string]interface{}{"branch": "main", "message": "fixup! feat: add retries"},
+			wantError:   true,
+			wantMessage: "blocked on protected branch 'main'",
+		},
+		{
+			name:        "fixup on feature branch",
+			args:        map[string]interface{}{"branch": "feature/retries", "message": "fixup! feat: add retries"},
+			wantMessage: "clean them up before merging",
+		},
+		{
+			name: "custom protected branches",
+			args: map[string]interface{}{
+				"branch":             "develop",
+				"messages":           []interface{}{"feat: add retries", "squash! feat: add retries"},
+				"protected_branches": []interface{}{"develop"},
+			},
+			wantError:   true,
+			wantMessage: "1 work-in-progress commit(s) blocked",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateCommitWIP(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateCommitWIP() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			var result models.CommitWIPResult
+			if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+				t.Fatalf("invalid result JSON: %v", err)
+			}
+			if !strings.Contains(result.Message, tt.wantMessage) {
+				t.Errorf("Message = %q, want it to contain %q", result.Message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// highDriftKeyPattern matches keys whose drift affects security or exposure
+	highDriftKeyPattern = regexp.MustCompile(`(?i)(^|[._-])(security\w*|tls|ssl|encrypt\w*|kms\w*|password|secrets?|iam|roles?|polic(y|ies)|acls?|public\w*|ingress|egress|firewall\w*|cidr\w*|ports?|auth\w*|cert\w*)([._-]|$)`)
+	// lowDriftKeyPattern matches descriptive keys whose drift is cosmetic
+	lowDriftKeyPattern = regexp.MustCompile(`(?i)(^|\.)(tags?|labels?|annotations?|description|comment|name_prefix)(\.|$)`)
+)
+
+// significanceRank orders drift significance from most to least important
+var significanceRank = map[string]int{"high": 0, "medium": 1, "low": 2}
+
+// handleValidateConfigDrift compares a desired (IaC) configuration against the
+// observed configuration and reports drift ranked by significance
+func (s *MCPServer) handleValidateConfigDrift(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	desired, err := configArg(args, "desired")
+	if err != nil {
+		result := models.ConfigDriftResult{
+			Valid:   false,
+			Message: err.Error(),
+		}
+		return buildToolResult(result, true)
+	}
+	observed, err := configArg(args, "observed")
+	if err != nil {
+		result := models.ConfigDriftResult{
+			Valid:   false,
+			Message: err.Error(),
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := detectConfigDrift(desired, observed, stringSliceArg(args, "ignore_keys"))
+	return buildToolResult(result, !result.Valid)
+}
+
+// configArg reads a configuration argument given either as an object or as a JSON string
+func configArg(args map[string]interface{}, key string) (map[string]interface{}, error) {
+	switch v := args[key].(type) {
+	case map[string]interface{}:
+		return v, nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return nil, fmt.Errorf("%s is required", key)
+		}
+		var parsed map[string]interface{}
+		if err := json.Unmarshal([]byte(v), &parsed); err != nil {
+			return nil, fmt.Errorf("%s must be a JSON object: %v", key, err)
+		}
+		return parsed, nil
+	case nil:
+		return nil, fmt.Errorf("%s is required", key)
+	default:
+		return nil, fmt.Errorf("%s must be an object or JSON string", key)
+	}
+}
+
+// detectConfigDrift flattens both configurations to dotted keys and reports keys
+// missing from either side and keys whose values differ. Keys under any ignore
+// prefix are skipped.
+func detectConfigDrift(desired, observed map[string]interface{}, ignoreKeys []string) models.ConfigDriftResult {
+	want := make(map[string]interface{})
+	flattenConfig("", desired, want)
+	got := make(map[string]interface{})
+	flattenConfig("", observed, got)
+
+	ignored := func(key string) bool {
+		for _, prefix := range ignoreKeys {
+			if key == prefix || strings.HasPrefix(key, prefix+".") {
+				return true
+			}
+		}
+		return false
+	}
+
+	drift := []models.ConfigDrift{}
+	compared := 0
+	for key, wantVal := range want {
+		if ignored(key) {
+			continue
+		}
+		compared++
+		gotVal, exists := got[key]
+		switch {
+		case !exists:
+			drift = append(drift, models.ConfigDrift{
+				Key:          key,
+				Type:         "missing_in_observed",
+				Significance: driftSignificance(key, "medium"),
+				Desired:      wantVal,
+				Message:      fmt.Sprintf("%s is declared but not present in the observed config", key),
+			})
+		case !reflect.DeepEqual(wantVal, gotVal):
+			drift = append(drift, models.ConfigDrift{
+				Key:          key,
+				Type:         "value_changed",
+				Significance: driftSignificance(key, "medium"),
+				Desired:      wantVal,
+				Observed:     gotVal,
+				Message:      fmt.Sprintf("%s is %v, expected %v", key, gotVal, wantVal),
+			})
+		}
+	}
+	for key, gotVal := range got {
+		if _, declared := want[key]; declared || ignored(key) {
+			continue
+		}
+		drift = append(drift, models.ConfigDrift{
+			Key:          key,
+			Type:         "unexpected_in_observed",
+			Significance: driftSignificance(key, "low"),
+			Observed:     gotVal,
+			Message:      fmt.Sprintf("%s exists in the observed config but is not declared", key),
+		})
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		ri, rj := significanceRank[drift[i].Significance], significanceRank[drift[j].Significance]
+		if ri != rj {
+			return ri < rj
+		}
+		return drift[i].Key < drift[j].Key
+	})
+
+	result := models.ConfigDriftResult{
+		Valid:        len(drift) == 0,
+		Drift:        drift,
+		KeysCompared: compared,
+	}
+	if result.Valid {
+		result.Message = fmt.Sprintf("No drift detected across %d keys", compared)
+	} else {
+		high := 0
+		for _, d := range drift {
+			if d.Significance == "high" {
+				high++
+			}
+		}
+		result.Message = fmt.Sprintf("%d drifted key(s) found (%d high significance)", len(drift), high)
+	}
+	return result
+}
+
+// driftSignificance ranks a drifted key: security/exposure keys are high, descriptive
+// metadata is low, everything else takes the supplied default
+func driftSignificance(key, fallback string) string {
+	switch {
+	case lowDriftKeyPattern.MatchString(key):
+		return "low"
+	case highDriftKeyPattern.MatchString(key):
+		return "high"
+	}
+	return fallback
+}
+
+// flattenConfig writes leaf values of a nested configuration into out keyed by dotted
+// path. Arrays are compared as whole values.
+func flattenConfig(prefix string, value interface{}, out map[string]interface{}) {
+	obj, ok := value.(map[string]interface{})
+	if !ok {
+		out[prefix] = value
+		return
+	}
+	if len(obj) == 0 && prefix != "" {
+		out[prefix] = obj
+		return
+	}
+	for k, v := range obj {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		flattenConfig(key, v, out)
+	}
+}
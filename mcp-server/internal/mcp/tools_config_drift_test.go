@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestDetectConfigDrift tests drift detection between desired and observed configuration
+func TestDetectConfigDrift(t *testing.T) {
+	desired := map[string]interface{}{
+		"instance_type": "t3.medium",
+		"storage": map[string]interface{}{
+			"encrypted": true,
+			"size_gb":   float64(100),
+		},
+		"security_groups": []interface{}{"sg-app"},
+		"tags": map[string]interface{}{
+			"owner": "platform",
+		},
+	}
+
+	t.Run("identical configs report no drift", func(t *testing.T) {
+		result := detectConfigDrift(desired, desired, nil)
+		if !result.Valid || len(result.Drift) != 0 {
+			t.Errorf("expected no drift, got %+v", result.Drift)
+		}
+		if result.KeysCompared != 5 {
+			t.Errorf("KeysCompared = %d, want 5", result.KeysCompared)
+		}
+	})
+
+	t.Run("drift detected and ranked", func(t *testing.T) {
+		observed := map[string]interface{}{
+			"instance_type": "t3.large",
+			"storage": map[string]interface{}{
+				"encrypted": false,
+				"size_gb":   float64(100),
+			},
+			"security_groups": []interface{}{"sg-app", "sg-debug"},
+			"tags": map[string]interface{}{
+				"owner":      "platform",
+				"created_by": "console",
+			},
+		}
+
+		result := detectConfigDrift(desired, observed, nil)
+		if result.Valid {
+			t.Fatal("expected drift to be reported")
+		}
+
+		want := []struct{ key, typ, significance string }{
+			{"security_groups", "value_changed", "high"},
+			{"storage.encrypted", "value_changed", "high"},
+			{"instance_type", "value_changed", "medium"},
+			{"tags.created_by", "unexpected_in_observed", "low"},
+		}
+		if len(result.Drift) != len(want) {
+			t.Fatalf("drift = %+v, want %d entries", result.Drift, len(want))
+		}
+		for i, w := range want {
+			d := result.Drift[i]
+			if d.Key != w.key || d.Type != w.typ || d.Significance != w.significance {
+				t.Errorf("drift[%d] = %s/%s/%s, want %s/%s/%s", i, d.Key, d.Type, d.Significance, w.key, w.typ, w.significance)
+			}
+		}
+	})
+
+	t.Run("missing key and ignore prefixes", func(t *testing.T) {
+		observed := map[string]interface{}{
+			"instance_type":   "t3.medium",
+			"security_groups": []interface{}{"sg-app"},
+			"tags":            map[string]interface{}{"owner": "someone-else"},
+		}
+
+		result := detectConfigDrift(desired, observed, []string{"tags"})
+		if len(result.Drift) != 2 {
+			t.Fatalf("drift = %+v, want 2 missing storage keys", result.Drift)
+		}
+		for _, d := range result.Drift {
+			if d.Type != "missing_in_observed" {
+				t.Errorf("drift %s type = %s, want missing_in_observed", d.Key, d.Type)
+			}
+		}
+	})
+}
+
+// TestConfigArg tests parsing configuration arguments given as objects or JSON strings
+func TestConfigArg(t *testing.T) {
+	args := map[string]interface{}{
+		"desired":  `{"replicas": 3}`,
+		"observed": map[string]interface{}{"replicas": float64(2)},
+		"bad":      "not json",
+	}
+	if cfg, err := configArg(args, "desired"); err != nil || cfg["replicas"] != float64(3) {
+		t.Errorf("configArg(desired) = %v, %v", cfg, err)
+	}
+	if _, err := configArg(args, "observed"); err != nil {
+		t.Errorf("configArg(observed) error = %v", err)
+	}
+	if _, err := configArg(args, "bad"); err == nil {
+		t.Error("configArg(bad) expected error")
+	}
+	if _, err := configArg(args, "missing"); err == nil {
+		t.Error("configArg(missing) expected error")
+	}
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/database"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// protectedDeletePaths lists paths an agent may never delete, with the reason reported
+var protectedDeletePaths = []struct {
+	pattern *regexp.Regexp
+	reason  string
+}{
+	{regexp.MustCompile(`(^|/)\.git(/|$)`), "git metadata is protected"},
+	{regexp.MustCompile(`(^|/)go\.(mod|sum)$`), "Go module files are protected"},
+	{regexp.MustCompile(`(^|/)(migrations?|db/migrate)/`), "database migrations are protected; applied migrations must not be removed"},
+	{regexp.MustCompile(`(^|/)\d+_[^/]+\.sql$`), "database migrations are protected; applied migrations must not be removed"},
+}
+
+// handleValidateDelete checks a proposed file deletion against protected paths, the
+// authorized scope and the session's read history
+func (s *MCPServer) handleValidateDelete(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	sessionToken, _ := args["session_token"].(string)
+	filePath, _ := args["file_path"].(string)
+	scope, _ := args["authorized_scope"].(string)
+
+	if sessionToken == "" {
+		result := models.DeleteValidationResult{
+			Valid:   false,
+			Message: "session_token is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	if filePath == "" {
+		result := models.DeleteValidationResult{
+			Valid:   false,
+			Message: "file_path is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	session, exists := s.getSession(sessionToken)
+
+	if !exists {
+		result := models.DeleteValidationResult{
+			Valid:     false,
+			CanDelete: false,
+			Message:   "Session not found or expired",
+			FilePath:  filePath,
+			Scope:     scope,
+			Reasons:   []string{"session not found or expired"},
+		}
+		return buildToolResult(result, true)
+	}
+
+	// Any lookup error is treated as "not read", matching guardrail_verify_file_read
+	fileReadStore := database.NewFileReadStore(s.db)
+	_, err := fileReadStore.GetBySessionAndPath(ctx, sessionToken, filePath)
+	wasRead := err == nil
+
+	reasons := deleteViolations(filePath, scope, wasRead)
+	result := models.DeleteValidationResult{
+		Valid:     len(reasons) == 0,
+		CanDelete: len(reasons) == 0,
+		SessionID: session.ID,
+		FilePath:  filePath,
+		Scope:     scope,
+		WasRead:   wasRead,
+		Reasons:   reasons,
+	}
+	if result.CanDelete {
+		result.Message = fmt.Sprintf("Deletion of %s is allowed", filePath)
+	} else {
+		result.Message = fmt.Sprintf("Deletion of %s is blocked: %s", filePath, strings.Join(reasons, "; "))
+	}
+	return buildToolResult(result, !result.Valid)
+}
+
+// deleteViolations returns every reason a deletion must be refused; an empty slice
+// means the file may be deleted
+func deleteViolations(filePath, scope string, wasRead bool) []string {
+	reasons := []string{}
+	cleanPath := filepath.ToSlash(filepath.Clean(filePath))
+
+	if cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+		reasons = append(reasons, "path escapes the project root")
+	}
+	for _, p := range protectedDeletePaths {
+		if p.pattern.MatchString(cleanPath) {
+			reasons = append(reasons, p.reason)
+			break
+		}
+	}
+	if scope != "" {
+		if inScope, _ := matchScope(filePath, scope, "prefix"); !inScope {
+			reasons = append(reasons, fmt.Sprintf("file is outside authorized scope %s", scope))
+		}
+	}
+	if !wasRead {
+		reasons = append(reasons, "file has not been read in this session")
+	}
+	return reasons
+}
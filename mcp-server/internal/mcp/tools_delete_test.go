@@ -0,0 +1,73 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// TestDeleteViolations tests protected paths, scope and read-before-delete checks
+func TestDeleteViolations(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		scope      string
+		wasRead    bool
+		wantReason string // empty means the delete is allowed
+	}{
+		{name: "read file in scope", path: "src/api/old_handler.go", scope: "src/api", wasRead: true},
+		{name: "no scope restriction", path: "docs/obsolete.md", wasRead: true},
+		{name: "git metadata", path: ".git/config", wasRead: true, wantReason: "git metadata"},
+		{name: "nested git directory", path: "vendor/lib/.git", wasRead: true, wantReason: "git metadata"},
+		{name: "go.mod", path: "mcp-server/go.mod", wasRead: true, wantReason: "Go module files"},
+		{name: "migration directory", path: "internal/database/migrations/004_add_index.up.sql", wasRead: true, wantReason: "migrations are protected"},
+		{name: "numbered sql file", path: "db/0003_users.sql", wasRead: true, wantReason: "migrations are protected"},
+		{name: "outside scope", path: "src/web/app.ts", scope: "src/api", wasRead: true, wantReason: "outside authorized scope"},
+		{name: "sibling of scope", path: "src/api-internal/x.go", scope: "src/api", wasRead: true, wantReason: "outside authorized scope"},
+		{name: "not read", path: "src/api/handler.go", scope: "src/api", wantReason: "has not been read"},
+		{name: "path traversal", path: "../other/main.go", wasRead: true, wantReason: "escapes the project root"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := deleteViolations(tt.path, tt.scope, tt.wasRead)
+			if tt.wantReason == "" {
+				if len(reasons) != 0 {
+					t.Errorf("deleteViolations(%q) = %v, want none", tt.path, reasons)
+				}
+				return
+			}
+			if !strings.Contains(strings.Join(reasons, "; "), tt.wantReason) {
+				t.Errorf("deleteViolations(%q) = %v, want reason containing %q", tt.path, reasons, tt.wantReason)
+			}
+		})
+	}
+}
+
+// TestHandleValidateDelete_RequiresSession tests that deletions are refused without a live session
+func TestHandleValidateDelete_RequiresSession(t *testing.T) {
+	s := mockMCPServer()
+
+	res, err := s.handleValidateDelete(context.Background(), map[string]interface{}{
+		"session_token": "unknown",
+		"file_path":     "src/api/handler.go",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.IsError {
+		t.Error("expected IsError for an unknown session")
+	}
+
+	var result models.DeleteValidationResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if result.CanDelete {
+		t.Errorf("can_delete = true for unknown session: %+v", result)
+	}
+}
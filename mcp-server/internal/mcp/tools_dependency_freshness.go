@@ -0,0 +1,222 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+const (
+	// defaultMaxMajorsBehind is how many major versions a dependency may trail the
+	// latest release before it is flagged
+	defaultMaxMajorsBehind = 1
+	// defaultMaxMinorsBehind is how many minor versions a dependency may trail the
+	// latest release of its major version before it is a warning
+	defaultMaxMinorsBehind = 5
+)
+
+// dependencyVersion is a parsed major.minor.patch version; pre-release and build
+// suffixes are ignored
+type dependencyVersion struct {
+	Major, Minor, Patch int
+}
+
+// dependencyAdvisory is a vulnerability report for a package, fixed in a version
+type dependencyAdvisory struct {
+	ID           string
+	Package      string // empty matches the checked dependency
+	Severity     string
+	FixedVersion string
+	Summary      string
+}
+
+// handleValidateDependencyFreshness flags a dependency that trails its latest
+// release by too many major or minor versions, or that a known advisory affects,
+// and recommends the version to upgrade to
+func (s *MCPServer) handleValidateDependencyFreshness(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	name, _ := args["name"].(string)
+	current, _ := args["current_version"].(string)
+	latest, _ := args["latest_version"].(string)
+
+	maxMajors := defaultMaxMajorsBehind
+	if v, ok := args["max_majors_behind"].(float64); ok {
+		maxMajors = int(v)
+	}
+	maxMinors := defaultMaxMinorsBehind
+	if v, ok := args["max_minors_behind"].(float64); ok {
+		maxMinors = int(v)
+	}
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.DependencyFreshnessResult{
+			Valid:           false,
+			Message:         message,
+			Name:            name,
+			CurrentVersion:  current,
+			LatestVersion:   latest,
+			Vulnerabilities: []models.DependencyAdvisory{},
+			Issues:          []models.DependencyFreshnessIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if name == "" || current == "" || latest == "" {
+		return invalid("name, current_version and latest_version are required")
+	}
+	if maxMajors < 0 || maxMinors < 0 {
+		return invalid("max_majors_behind and max_minors_behind must not be negative")
+	}
+
+	var advisories []dependencyAdvisory
+	raw, _ := args["advisories"].([]interface{})
+	for i, item := range raw {
+		fields, _ := item.(map[string]interface{})
+		advisory := dependencyAdvisory{}
+		advisory.ID, _ = fields["id"].(string)
+		advisory.Package, _ = fields["package"].(string)
+		advisory.Severity, _ = fields["severity"].(string)
+		advisory.FixedVersion, _ = fields["fixed_version"].(string)
+		advisory.Summary, _ = fields["summary"].(string)
+		if advisory.ID == "" || advisory.FixedVersion == "" {
+			return invalid(fmt.Sprintf("advisories[%d] needs an id and a fixed_version", i))
+		}
+		advisories = append(advisories, advisory)
+	}
+
+	result, err := checkDependencyFreshness(name, current, latest, maxMajors, maxMinors, advisories)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkDependencyFreshness compares the current version with the latest one and
+// the advisories. More than maxMajors majors behind or an advisory fixed in a later
+// version is an error; more than maxMinors minors behind within the same major is a
+// warning.
+func checkDependencyFreshness(name, current, latest string, maxMajors, maxMinors int, advisories []dependencyAdvisory) (models.DependencyFreshnessResult, error) {
+	result := models.DependencyFreshnessResult{
+		Name:            name,
+		CurrentVersion:  current,
+		LatestVersion:   latest,
+		Vulnerabilities: []models.DependencyAdvisory{},
+		Issues:          []models.DependencyFreshnessIssue{},
+	}
+
+	cur, err := parseDependencyVersion(current)
+	if err != nil {
+		return result, fmt.Errorf("current_version: %w", err)
+	}
+	lat, err := parseDependencyVersion(latest)
+	if err != nil {
+		return result, fmt.Errorf("latest_version: %w", err)
+	}
+
+	issue := func(field, severity, message string) {
+		result.Issues = append(result.Issues, models.DependencyFreshnessIssue{Field: field, Severity: severity, Message: message})
+	}
+
+	if lat.Major > cur.Major {
+		result.MajorsBehind = lat.Major - cur.Major
+	} else if lat.Major == cur.Major && lat.Minor > cur.Minor {
+		result.MinorsBehind = lat.Minor - cur.Minor
+	}
+	if result.MajorsBehind > maxMajors {
+		issue("major", "error", fmt.Sprintf("%s %s is %d major version(s) behind %s (limit %d)", name, current, result.MajorsBehind, latest, maxMajors))
+	}
+	if result.MinorsBehind > maxMinors {
+		issue("minor", "warning", fmt.Sprintf("%s %s is %d minor version(s) behind %s (limit %d)", name, current, result.MinorsBehind, latest, maxMinors))
+	}
+
+	// The upgrade must reach the highest fixed version among the advisories that apply
+	minimum := ""
+	var minimumVersion dependencyVersion
+	for _, advisory := range advisories {
+		if advisory.Package != "" && !strings.EqualFold(advisory.Package, name) {
+			continue
+		}
+		fixed, err := parseDependencyVersion(advisory.FixedVersion)
+		if err != nil {
+			return result, fmt.Errorf("advisory %s fixed_version: %w", advisory.ID, err)
+		}
+		if !cur.less(fixed) {
+			continue
+		}
+		result.Vulnerabilities = append(result.Vulnerabilities, models.DependencyAdvisory{
+			ID:           advisory.ID,
+			Severity:     advisory.Severity,
+			FixedVersion: advisory.FixedVersion,
+			Summary:      advisory.Summary,
+		})
+		issue("advisory", "error", fmt.Sprintf("%s %s is affected by %s, fixed in %s", name, current, advisory.ID, advisory.FixedVersion))
+		if minimum == "" || minimumVersion.less(fixed) {
+			minimum, minimumVersion = advisory.FixedVersion, fixed
+		}
+	}
+
+	errorCount := 0
+	for _, i := range result.Issues {
+		if i.Severity == "error" {
+			errorCount++
+		}
+	}
+
+	ids := make([]string, 0, len(result.Vulnerabilities))
+	for _, v := range result.Vulnerabilities {
+		ids = append(ids, v.ID)
+	}
+	switch {
+	case minimum != "" && result.MajorsBehind > maxMajors:
+		result.Recommendation = fmt.Sprintf("Upgrade %s to %s now to fix %s, then plan the upgrade to %s", name, minimum, strings.Join(ids, ", "), latest)
+	case minimum != "":
+		result.Recommendation = fmt.Sprintf("Upgrade %s to at least %s to fix %s; %s is the latest", name, minimum, strings.Join(ids, ", "), latest)
+	case len(result.Issues) > 0:
+		result.Recommendation = fmt.Sprintf("Upgrade %s from %s to %s, reviewing the changelog for breaking changes", name, current, latest)
+	}
+
+	result.Valid = errorCount == 0
+	if len(result.Issues) == 0 {
+		result.Message = fmt.Sprintf("%s %s is current enough (latest %s)", name, current, latest)
+	} else if result.Valid {
+		result.Message = fmt.Sprintf("%s %s is behind with %d warning(s)", name, current, len(result.Issues))
+	} else {
+		result.Message = fmt.Sprintf("%s %s needs an upgrade: %d problem(s)", name, current, errorCount)
+	}
+	return result, nil
+}
+
+// parseDependencyVersion parses versions such as "1.4", "v2.0.3" and "3.1.0-rc.1"
+func parseDependencyVersion(version string) (dependencyVersion, error) {
+	core := strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.IndexAny(core, "-+"); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.Split(core, ".")
+	if core == "" || len(parts) > 3 {
+		return dependencyVersion{}, fmt.Errorf("%q is not a major.minor.patch version", version)
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return dependencyVersion{}, fmt.Errorf("%q is not a major.minor.patch version", version)
+		}
+		numbers[i] = n
+	}
+	return dependencyVersion{Major: numbers[0], Minor: numbers[1], Patch: numbers[2]}, nil
+}
+
+// less reports whether v is an earlier version than other
+func (v dependencyVersion) less(other dependencyVersion) bool {
+	if v.Major != other.Major {
+		return v.Major < other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor < other.Minor
+	}
+	return v.Patch < other.Patch
+}
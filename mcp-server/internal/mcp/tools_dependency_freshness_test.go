@@ -0,0 +1,150 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestCheckDependencyFreshness tests version lag and advisory checks
+func TestCheckDependencyFreshness(t *testing.T) {
+	advisories := []dependencyAdvisory{
+		{ID: "CVE-2024-0001", Severity: "high", FixedVersion: "1.10.9"},
+		{ID: "CVE-2024-0002", Package: "other-lib", Severity: "critical", FixedVersion: "9.0.0"},
+	}
+
+	tests := []struct {
+		name               string
+		current            string
+		latest             string
+		advisories         []dependencyAdvisory
+		wantValid          bool
+		wantIssues         []string // field/severity
+		wantRecommendation string
+	}{
+		{
+			name:      "current version passes",
+			current:   "v2.3.1",
+			latest:    "v2.3.1",
+			wantValid: true,
+		},
+		{
+			name:       "one major behind is within the default limit",
+			current:    "1.12.0",
+			latest:     "2.0.0",
+			advisories: advisories,
+			wantValid:  true,
+		},
+		{
+			name:               "severely outdated dependency flagged",
+			current:            "1.2.0",
+			latest:             "4.1.0",
+			wantValid:          false,
+			wantIssues:         []string{"major/error"},
+			wantRecommendation: "Upgrade pq from 1.2.0 to 4.1.0",
+		},
+		{
+			name:       "many minors behind is a warning",
+			current:    "2.1.0",
+			latest:     "2.9.3",
+			wantValid:  true,
+			wantIssues: []string{"minor/warning"},
+		},
+		{
+			name:               "advisory fixed in a later version flagged",
+			current:            "1.10.2",
+			latest:             "1.10.9",
+			advisories:         advisories,
+			wantValid:          false,
+			wantIssues:         []string{"advisory/error"},
+			wantRecommendation: "at least 1.10.9 to fix CVE-2024-0001",
+		},
+		{
+			name:       "pre-release suffix ignored",
+			current:    "3.0.0-rc.1",
+			latest:     "3.0.0",
+			advisories: advisories,
+			wantValid:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkDependencyFreshness("pq", tt.current, tt.latest, defaultMaxMajorsBehind, defaultMaxMinorsBehind, tt.advisories)
+			if err != nil {
+				t.Fatalf("checkDependencyFreshness() error = %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if len(result.Issues) != len(tt.wantIssues) {
+				t.Fatalf("issues = %+v, want %v", result.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := result.Issues[i].Field + "/" + result.Issues[i].Severity; got != want {
+					t.Errorf("issue[%d] = %s, want %s", i, got, want)
+				}
+			}
+			if !strings.Contains(result.Recommendation, tt.wantRecommendation) {
+				t.Errorf("recommendation = %q, want it to contain %q", result.Recommendation, tt.wantRecommendation)
+			}
+		})
+	}
+}
+
+// TestHandleValidateDependencyFreshness tests the tool result for outdated, current and bad input
+func TestHandleValidateDependencyFreshness(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name:     "current",
+			args:     map[string]interface{}{"name": "lodash", "current_version": "4.17.21", "latest_version": "4.17.21"},
+			wantText: "current enough",
+		},
+		{
+			name:      "outdated",
+			args:      map[string]interface{}{"name": "lodash", "current_version": "2.4.2", "latest_version": "4.17.21"},
+			wantError: true,
+			wantText:  "2 major version(s) behind",
+		},
+		{
+			name: "advisory",
+			args: map[string]interface{}{"name": "lodash", "current_version": "4.17.15", "latest_version": "4.17.21", "advisories": []interface{}{
+				map[string]interface{}{"id": "CVE-2020-8203", "severity": "high", "fixed_version": "4.17.19"},
+			}},
+			wantError: true,
+			wantText:  "CVE-2020-8203",
+		},
+		{name: "missing version", args: map[string]interface{}{"name": "lodash", "current_version": "4.17.21"}, wantError: true, wantText: "are required"},
+		{name: "bad version", args: map[string]interface{}{"name": "lodash", "current_version": "latest", "latest_version": "4.17.21"}, wantError: true, wantText: "not a major.minor.patch version"},
+		{
+			name:      "advisory without fixed version",
+			args:      map[string]interface{}{"name": "lodash", "current_version": "4.17.21", "latest_version": "4.17.21", "advisories": []interface{}{map[string]interface{}{"id": "CVE-1"}}},
+			wantError: true,
+			wantText:  "advisories[0] needs an id and a fixed_version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateDependencyFreshness(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateDependencyFreshness() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
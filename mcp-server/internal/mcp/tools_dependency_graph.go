@@ -0,0 +1,327 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// goModModulePattern extracts the module path from a go.mod file
+var goModModulePattern = regexp.MustCompile(`(?m)^\s*module\s+"?([^"\s]+)"?`)
+
+// importGraph maps each package to the set of packages it imports
+type importGraph map[string]map[string]bool
+
+// addImport records that from imports to, adding both packages to the graph
+func (g importGraph) addImport(from, to string) {
+	g.addPackage(from)
+	g.addPackage(to)
+	g[from][to] = true
+}
+
+// addPackage adds a package with no imports unless it is already present
+func (g importGraph) addPackage(pkg string) {
+	if g[pkg] == nil {
+		g[pkg] = make(map[string]bool)
+	}
+}
+
+// imports returns the packages pkg imports, sorted
+func (g importGraph) imports(pkg string) []string {
+	deps := make([]string, 0, len(g[pkg]))
+	for dep := range g[pkg] {
+		deps = append(deps, dep)
+	}
+	sort.Strings(deps)
+	return deps
+}
+
+// handleValidateDependencyGraph checks a package import graph, given as an adjacency
+// list or scanned from Go files, for import cycles. With a baseline graph only cycles
+// the change introduces fail the check.
+func (s *MCPServer) handleValidateDependencyGraph(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.DependencyGraphResult{
+			Valid:   false,
+			Message: message,
+			Cycles:  []models.DependencyCycle{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	var graph importGraph
+	var err error
+	switch {
+	case args["graph"] != nil:
+		graph, err = importGraphArg(args, "graph")
+	case args["files"] != nil:
+		var files map[string]interface{}
+		if files, err = configArg(args, "files"); err == nil {
+			module, _ := args["module"].(string)
+			graph, err = scanGoImports(files, module)
+		}
+	default:
+		err = fmt.Errorf("graph or files is required")
+	}
+	if err != nil {
+		return invalid(err.Error())
+	}
+
+	var baseline importGraph
+	if args["baseline_graph"] != nil {
+		if baseline, err = importGraphArg(args, "baseline_graph"); err != nil {
+			return invalid(err.Error())
+		}
+	}
+
+	result := checkDependencyGraph(graph, baseline)
+	return buildToolResult(result, !result.Valid)
+}
+
+// importGraphArg reads an adjacency list of package to imported packages, given as
+// an object or JSON string
+func importGraphArg(args map[string]interface{}, key string) (importGraph, error) {
+	raw, err := configArg(args, key)
+	if err != nil {
+		return nil, err
+	}
+	graph := make(importGraph, len(raw))
+	for pkg, value := range raw {
+		deps, ok := value.([]interface{})
+		if !ok && value != nil {
+			return nil, fmt.Errorf("%s.%s must be an array of package names", key, pkg)
+		}
+		graph.addPackage(pkg)
+		for _, dep := range deps {
+			name, ok := dep.(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("%s.%s must be an array of package names", key, pkg)
+			}
+			graph.addImport(pkg, name)
+		}
+	}
+	return graph, nil
+}
+
+// scanGoImports builds the import graph of the module's own packages from Go source
+// files keyed by path. Test files are skipped. The module path comes from the module
+// argument or a go.mod among the files; imports outside the module cannot form a
+// cycle with it and are left out.
+func scanGoImports(files map[string]interface{}, module string) (importGraph, error) {
+	if module == "" {
+		if gomod, ok := files["go.mod"].(string); ok {
+			if m := goModModulePattern.FindStringSubmatch(gomod); m != nil {
+				module = m[1]
+			}
+		}
+	}
+	if module == "" {
+		return nil, fmt.Errorf("module is required when scanning files (or include go.mod)")
+	}
+
+	graph := make(importGraph)
+	fset := token.NewFileSet()
+	for name, raw := range files {
+		name = strings.ReplaceAll(name, "\\", "/")
+		if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		content, ok := raw.(string)
+		if !ok {
+			return nil, fmt.Errorf("files.%s must be the file content", name)
+		}
+		file, err := parser.ParseFile(fset, name, content, parser.ImportsOnly)
+		if err != nil {
+			return nil, fmt.Errorf("files.%s: %v", name, err)
+		}
+
+		pkg := module
+		if dir := path.Dir(name); dir != "." {
+			pkg = module + "/" + dir
+		}
+		graph.addPackage(pkg)
+		for _, spec := range file.Imports {
+			imported, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			if imported == module || strings.HasPrefix(imported, module+"/") {
+				graph.addImport(pkg, imported)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// checkDependencyGraph reports a cycle for each strongly connected group of packages.
+// When a baseline is given, a cycle is new if the group has an import the baseline
+// lacks, and its path is the shortest cycle through that import; groups made only of
+// baseline imports are reported as warnings. Without a baseline every cycle is new.
+func checkDependencyGraph(graph, baseline importGraph) models.DependencyGraphResult {
+	result := models.DependencyGraphResult{
+		Packages: len(graph),
+		Cycles:   []models.DependencyCycle{},
+	}
+	for pkg := range graph {
+		result.Imports += len(graph[pkg])
+	}
+
+	newCycles := 0
+	for _, group := range stronglyConnectedPackages(graph) {
+		members := make(map[string]bool, len(group))
+		for _, pkg := range group {
+			members[pkg] = true
+		}
+		if len(group) == 1 && !graph[group[0]][group[0]] {
+			continue
+		}
+
+		// The first import within the group that the baseline does not have
+		from, to, introduced := "", "", false
+		for _, pkg := range group {
+			for _, dep := range graph.imports(pkg) {
+				if members[dep] && (baseline == nil || !baseline[pkg][dep]) {
+					from, to, introduced = pkg, dep, true
+					break
+				}
+			}
+			if introduced {
+				break
+			}
+		}
+		if !introduced {
+			from = group[0]
+			for _, dep := range graph.imports(from) {
+				if members[dep] {
+					to = dep
+					break
+				}
+			}
+		}
+
+		cycle := models.DependencyCycle{
+			Path:     append([]string{from}, shortestImportPath(graph, members, to, from)...),
+			New:      introduced,
+			Severity: "warning",
+		}
+		chain := strings.Join(cycle.Path, " -> ")
+		switch {
+		case !introduced:
+			cycle.Message = "Existing import cycle: " + chain
+		case baseline != nil:
+			cycle.Severity = "error"
+			cycle.IntroducedBy = from + " -> " + to
+			cycle.Message = fmt.Sprintf("Import of %s by %s introduces a cycle: %s", to, from, chain)
+		default:
+			cycle.Severity = "error"
+			cycle.Message = "Import cycle: " + chain
+		}
+		if introduced {
+			newCycles++
+		}
+		result.Cycles = append(result.Cycles, cycle)
+	}
+
+	result.Valid = newCycles == 0
+	switch {
+	case newCycles > 0:
+		result.Message = fmt.Sprintf("%d import cycle(s) introduced", newCycles)
+	case len(result.Cycles) > 0:
+		result.Message = fmt.Sprintf("No new import cycles (%d existing)", len(result.Cycles))
+	default:
+		result.Message = fmt.Sprintf("No import cycles among %d packages", result.Packages)
+	}
+	return result
+}
+
+// stronglyConnectedPackages returns the strongly connected components of the graph
+// (Tarjan's algorithm), each sorted, in order of their first package
+func stronglyConnectedPackages(graph importGraph) [][]string {
+	pkgs := make([]string, 0, len(graph))
+	for pkg := range graph {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Strings(pkgs)
+
+	index := make(map[string]int, len(pkgs))
+	lowlink := make(map[string]int, len(pkgs))
+	onStack := make(map[string]bool, len(pkgs))
+	stack := []string{}
+	groups := [][]string{}
+
+	var visit func(pkg string)
+	visit = func(pkg string) {
+		index[pkg] = len(index)
+		lowlink[pkg] = index[pkg]
+		stack = append(stack, pkg)
+		onStack[pkg] = true
+
+		for _, dep := range graph.imports(pkg) {
+			if _, seen := index[dep]; !seen {
+				visit(dep)
+				lowlink[pkg] = min(lowlink[pkg], lowlink[dep])
+			} else if onStack[dep] {
+				lowlink[pkg] = min(lowlink[pkg], index[dep])
+			}
+		}
+
+		if lowlink[pkg] == index[pkg] {
+			group := []string{}
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				group = append(group, top)
+				if top == pkg {
+					break
+				}
+			}
+			sort.Strings(group)
+			groups = append(groups, group)
+		}
+	}
+	for _, pkg := range pkgs {
+		if _, seen := index[pkg]; !seen {
+			visit(pkg)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool { return groups[i][0] < groups[j][0] })
+	return groups
+}
+
+// shortestImportPath returns the packages on a shortest import path from one package
+// to another, both included, using only packages in members
+func shortestImportPath(graph importGraph, members map[string]bool, from, to string) []string {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if pkg == to {
+			break
+		}
+		for _, dep := range graph.imports(pkg) {
+			if _, seen := prev[dep]; !seen && members[dep] {
+				prev[dep] = pkg
+				queue = append(queue, dep)
+			}
+		}
+	}
+
+	route := []string{to}
+	for pkg := to; pkg != from; {
+		pkg = prev[pkg]
+		route = append([]string{pkg}, route...)
+	}
+	return route
+}
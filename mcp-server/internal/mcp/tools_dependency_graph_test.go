@@ -0,0 +1,126 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// graphOf builds an import graph from package: imports pairs
+func graphOf(adjacency map[string][]string) importGraph {
+	graph := make(importGraph)
+	for pkg, deps := range adjacency {
+		graph.addPackage(pkg)
+		for _, dep := range deps {
+			graph.addImport(pkg, dep)
+		}
+	}
+	return graph
+}
+
+// TestCheckDependencyGraph tests cycle detection and baseline comparison
+func TestCheckDependencyGraph(t *testing.T) {
+	layered := map[string][]string{
+		"cmd/server":        {"internal/mcp", "internal/config"},
+		"internal/mcp":      {"internal/models", "internal/database"},
+		"internal/database": {"internal/models"},
+		"internal/config":   nil,
+	}
+
+	tests := []struct {
+		name      string
+		graph     map[string][]string
+		baseline  map[string][]string
+		wantValid bool
+		wantPaths []string
+		wantBy    string
+	}{
+		{name: "acyclic graph passes", graph: layered, baseline: layered, wantValid: true},
+		{
+			name: "introduced cycle flagged with its path",
+			graph: map[string][]string{
+				"cmd/server":        {"internal/mcp", "internal/config"},
+				"internal/mcp":      {"internal/models", "internal/database"},
+				"internal/database": {"internal/models"},
+				"internal/models":   {"internal/mcp"},
+				"internal/config":   nil,
+			},
+			baseline:  layered,
+			wantPaths: []string{"internal/models -> internal/mcp -> internal/models"},
+			wantBy:    "internal/models -> internal/mcp",
+		},
+		{
+			name:      "existing cycle is not new",
+			graph:     map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+			baseline:  map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a"}},
+			wantValid: true,
+			wantPaths: []string{"a -> b -> c -> a"},
+		},
+		{
+			name:      "without baseline every cycle fails",
+			graph:     map[string][]string{"a": {"b"}, "b": {"a"}, "c": {"c"}},
+			wantPaths: []string{"a -> b -> a", "c -> c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var baseline importGraph
+			if tt.baseline != nil {
+				baseline = graphOf(tt.baseline)
+			}
+			result := checkDependencyGraph(graphOf(tt.graph), baseline)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			paths := []string{}
+			for _, cycle := range result.Cycles {
+				paths = append(paths, strings.Join(cycle.Path, " -> "))
+			}
+			if strings.Join(paths, "|") != strings.Join(tt.wantPaths, "|") {
+				t.Errorf("cycles = %v, want %v", paths, tt.wantPaths)
+			}
+			if tt.wantBy != "" && (len(result.Cycles) == 0 || result.Cycles[0].IntroducedBy != tt.wantBy) {
+				t.Errorf("cycles = %+v, want introduced by %q", result.Cycles, tt.wantBy)
+			}
+		})
+	}
+}
+
+// TestHandleValidateDependencyGraph_Files tests that imports scanned from Go files form the graph
+func TestHandleValidateDependencyGraph_Files(t *testing.T) {
+	s := mockMCPServer()
+	files := map[string]interface{}{
+		"go.mod":          "module example.com/app\n\ngo 1.23\n",
+		"store/store.go":  "package store\n\nimport (\n\t\"fmt\"\n\t\"example.com/app/api\"\n)\n",
+		"api/api.go":      "package api\n\nimport \"example.com/app/store\"\n",
+		"api/api_test.go": "package api\n\nimport \"example.com/app/cmd\"\n",
+		"cmd/main.go":     "package main\n\nimport _ \"example.com/app/api\"\n",
+		"docs/README.md":  "not go",
+	}
+
+	res, err := s.handleValidateDependencyGraph(context.Background(), map[string]interface{}{"files": files})
+	if err != nil {
+		t.Fatalf("handleValidateDependencyGraph() error = %v", err)
+	}
+	var result models.DependencyGraphResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatal(err)
+	}
+	want := "example.com/app/api -> example.com/app/store -> example.com/app/api"
+	if !res.IsError || len(result.Cycles) != 1 || strings.Join(result.Cycles[0].Path, " -> ") != want {
+		t.Errorf("cycles = %+v, want %s", result.Cycles, want)
+	}
+	if result.Packages != 3 {
+		t.Errorf("Packages = %d, want 3 (test files and non-Go files skipped)", result.Packages)
+	}
+
+	res, _ = s.handleValidateDependencyGraph(context.Background(), map[string]interface{}{})
+	if !res.IsError || !strings.Contains(res.Content[0].(mcp.TextContent).Text, "graph or files is required") {
+		t.Errorf("empty args result = %+v, want required error", res)
+	}
+}
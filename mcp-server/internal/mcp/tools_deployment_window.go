@@ -0,0 +1,261 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// deployPolicyFile is the project's .guardrails/deploy-policy.json: scheduled change
+// freezes, the current incident mode and the windows deployments are allowed in.
+// Entries without environments apply to every environment.
+type deployPolicyFile struct {
+	FreezeWindows []freezeWindow `json:"freeze_windows"`
+	IncidentMode  incidentMode   `json:"incident_mode"`
+	DeployWindows []deployWindow `json:"deploy_windows"`
+}
+
+// freezeWindow is a period, e.g. around a release or holiday, with no deployments
+type freezeWindow struct {
+	Start        string   `json:"start"` // RFC 3339 or YYYY-MM-DD, inclusive
+	End          string   `json:"end"`   // exclusive
+	Reason       string   `json:"reason"`
+	Environments []string `json:"environments"`
+}
+
+// incidentMode stops deployments while an incident is being handled
+type incidentMode struct {
+	Active       bool     `json:"active"`
+	Reason       string   `json:"reason"`
+	Environments []string `json:"environments"`
+}
+
+// deployWindow is a recurring time of the week deployments are allowed in
+type deployWindow struct {
+	Days         []string `json:"days"`     // mon..sun; empty means every day
+	Start        string   `json:"start"`    // HH:MM, inclusive
+	End          string   `json:"end"`      // HH:MM, exclusive
+	Timezone     string   `json:"timezone"` // IANA name, default UTC
+	Environments []string `json:"environments"`
+}
+
+// loadDeployPolicy reads the project's .guardrails/deploy-policy.json. A missing file
+// gives an empty policy, which allows every deployment; an invalid one is an error
+// so that a broken policy never silently lifts a freeze.
+func (s *MCPServer) loadDeployPolicy() (deployPolicyFile, error) {
+	var policy deployPolicyFile
+	data, err := os.ReadFile(filepath.Join(s.getRepoPath(), ".guardrails", "deploy-policy.json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return policy, nil
+		}
+		return policy, err
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		return policy, fmt.Errorf("invalid deploy policy: %w", err)
+	}
+	return policy, nil
+}
+
+// handleValidateDeploymentWindow reports whether a deployment to an environment is
+// allowed at the intended time, with the reasons when it is not
+func (s *MCPServer) handleValidateDeploymentWindow(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	environment, _ := args["environment"].(string)
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.DeploymentWindowResult{
+			Valid:       false,
+			Message:     message,
+			Environment: environment,
+			Reasons:     []models.DeploymentBlock{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if environment == "" {
+		return invalid("environment is required")
+	}
+
+	deployTime := time.Now()
+	if text, _ := args["deploy_time"].(string); text != "" {
+		t, err := parseCommitTime(text)
+		if err != nil {
+			return invalid(fmt.Sprintf("deploy_time: %v", err))
+		}
+		deployTime = t
+	}
+
+	policy, err := s.loadDeployPolicy()
+	if err != nil {
+		slog.Error("Failed to load deploy policy", "error", err)
+		return invalid(fmt.Sprintf("Cannot check deployment window: %v", err))
+	}
+
+	result, err := checkDeploymentWindow(policy, environment, deployTime)
+	if err != nil {
+		return invalid(fmt.Sprintf("Cannot check deployment window: %v", err))
+	}
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkDeploymentWindow applies the deploy policy to a deployment to environment at
+// deployTime. The deployment is blocked during a change freeze, while incident mode
+// is active, and outside the environment's deploy windows if it has any; every
+// reason that applies is listed.
+func checkDeploymentWindow(policy deployPolicyFile, environment string, deployTime time.Time) (models.DeploymentWindowResult, error) {
+	result := models.DeploymentWindowResult{
+		Environment: environment,
+		DeployTime:  deployTime.Format(time.RFC3339),
+		Reasons:     []models.DeploymentBlock{},
+	}
+
+	for i, freeze := range policy.FreezeWindows {
+		if !appliesToEnvironment(freeze.Environments, environment) {
+			continue
+		}
+		start, err := parseCommitTime(freeze.Start)
+		if err != nil {
+			return result, fmt.Errorf("freeze_windows[%d].start: %w", i, err)
+		}
+		end, err := parseCommitTime(freeze.End)
+		if err != nil {
+			return result, fmt.Errorf("freeze_windows[%d].end: %w", i, err)
+		}
+		if deployTime.Before(start) || !deployTime.Before(end) {
+			continue
+		}
+		message := fmt.Sprintf("Change freeze until %s", end.Format(time.RFC3339))
+		if freeze.Reason != "" {
+			message += ": " + freeze.Reason
+		}
+		result.Reasons = append(result.Reasons, models.DeploymentBlock{
+			Type:    "change_freeze",
+			Message: message,
+			Until:   end.Format(time.RFC3339),
+		})
+	}
+
+	if policy.IncidentMode.Active && appliesToEnvironment(policy.IncidentMode.Environments, environment) {
+		message := "Incident mode is active; only incident fixes may be deployed"
+		if policy.IncidentMode.Reason != "" {
+			message += ": " + policy.IncidentMode.Reason
+		}
+		result.Reasons = append(result.Reasons, models.DeploymentBlock{Type: "incident_mode", Message: message})
+	}
+
+	var windows []deployWindow
+	for _, window := range policy.DeployWindows {
+		if appliesToEnvironment(window.Environments, environment) {
+			windows = append(windows, window)
+		}
+	}
+	if len(windows) > 0 {
+		inWindow := false
+		descriptions := make([]string, 0, len(windows))
+		for i, window := range windows {
+			in, err := window.contains(deployTime)
+			if err != nil {
+				return result, fmt.Errorf("deploy_windows[%d]: %w", i, err)
+			}
+			inWindow = inWindow || in
+			descriptions = append(descriptions, window.String())
+		}
+		if !inWindow {
+			result.Reasons = append(result.Reasons, models.DeploymentBlock{
+				Type:    "outside_deploy_window",
+				Message: fmt.Sprintf("%s deployments are allowed only %s", environment, strings.Join(descriptions, " or ")),
+			})
+		}
+	}
+
+	result.Allowed = len(result.Reasons) == 0
+	result.Valid = result.Allowed
+	if result.Allowed {
+		result.Message = fmt.Sprintf("Deploying to %s at %s is allowed", environment, result.DeployTime)
+	} else {
+		result.Message = fmt.Sprintf("Deploying to %s at %s is blocked: %d reason(s)", environment, result.DeployTime, len(result.Reasons))
+	}
+	return result, nil
+}
+
+// appliesToEnvironment reports whether a policy entry scoped to environments covers
+// environment; an unscoped entry covers all of them
+func appliesToEnvironment(environments []string, environment string) bool {
+	if len(environments) == 0 {
+		return true
+	}
+	for _, env := range environments {
+		if strings.EqualFold(env, environment) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains reports whether t falls on one of the window's days between its start
+// and end, in the window's time zone
+func (w deployWindow) contains(t time.Time) (bool, error) {
+	loc := time.UTC
+	if w.Timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(w.Timezone); err != nil {
+			return false, fmt.Errorf("invalid timezone %q", w.Timezone)
+		}
+	}
+	start, err := time.Parse("15:04", w.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid start %q (use HH:MM)", w.Start)
+	}
+	end, err := time.Parse("15:04", w.End)
+	if err != nil {
+		return false, fmt.Errorf("invalid end %q (use HH:MM)", w.End)
+	}
+
+	local := t.In(loc)
+	if len(w.Days) > 0 {
+		day := weekdayAbbrev(local.Weekday().String())
+		onDay := false
+		for _, d := range w.Days {
+			if weekdayAbbrev(d) == day {
+				onDay = true
+				break
+			}
+		}
+		if !onDay {
+			return false, nil
+		}
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	return minute >= start.Hour()*60+start.Minute() && minute < end.Hour()*60+end.Minute(), nil
+}
+
+// String describes the window, e.g. "mon,tue 09:00-16:00 UTC"
+func (w deployWindow) String() string {
+	days := "daily"
+	if len(w.Days) > 0 {
+		days = strings.Join(w.Days, ",")
+	}
+	timezone := w.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	return fmt.Sprintf("%s %s-%s %s", days, w.Start, w.End, timezone)
+}
+
+// weekdayAbbrev reduces a day name to its lower-case three-letter abbreviation
+func weekdayAbbrev(day string) string {
+	day = strings.ToLower(strings.TrimSpace(day))
+	if len(day) > 3 {
+		day = day[:3]
+	}
+	return day
+}
@@ -0,0 +1,144 @@
+package mcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestCheckDeploymentWindow tests combining change freezes, incident mode and deploy windows
+func TestCheckDeploymentWindow(t *testing.T) {
+	businessHours := deployWindow{Days: []string{"mon", "tue", "wed", "thu"}, Start: "09:00", End: "16:00", Environments: []string{"production"}}
+	holidayFreeze := freezeWindow{Start: "2024-12-20", End: "2025-01-02", Reason: "holiday freeze", Environments: []string{"production"}}
+	// 2024-06-04 is a Tuesday
+	tuesdayMorning := time.Date(2024, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		policy      deployPolicyFile
+		environment string
+		deployTime  time.Time
+		wantAllowed bool
+		wantReasons []string
+	}{
+		{
+			name:        "inside deploy window allowed",
+			policy:      deployPolicyFile{DeployWindows: []deployWindow{businessHours}, FreezeWindows: []freezeWindow{holidayFreeze}},
+			environment: "production",
+			deployTime:  tuesdayMorning,
+			wantAllowed: true,
+		},
+		{
+			name:        "no policy allows deployment",
+			environment: "production",
+			deployTime:  tuesdayMorning,
+			wantAllowed: true,
+		},
+		{
+			name:        "change freeze blocks",
+			policy:      deployPolicyFile{FreezeWindows: []freezeWindow{holidayFreeze}},
+			environment: "production",
+			deployTime:  time.Date(2024, 12, 23, 10, 0, 0, 0, time.UTC),
+			wantReasons: []string{"change_freeze"},
+		},
+		{
+			name:        "freeze scoped to another environment",
+			policy:      deployPolicyFile{FreezeWindows: []freezeWindow{holidayFreeze}},
+			environment: "staging",
+			deployTime:  time.Date(2024, 12, 23, 10, 0, 0, 0, time.UTC),
+			wantAllowed: true,
+		},
+		{
+			name:        "incident mode blocks",
+			policy:      deployPolicyFile{IncidentMode: incidentMode{Active: true, Reason: "INC-42 checkout outage"}},
+			environment: "staging",
+			deployTime:  tuesdayMorning,
+			wantReasons: []string{"incident_mode"},
+		},
+		{
+			name:        "friday evening is outside the deploy window",
+			policy:      deployPolicyFile{DeployWindows: []deployWindow{businessHours}},
+			environment: "production",
+			deployTime:  time.Date(2024, 6, 7, 17, 30, 0, 0, time.UTC),
+			wantReasons: []string{"outside_deploy_window"},
+		},
+		{
+			name: "every reason is listed",
+			policy: deployPolicyFile{
+				FreezeWindows: []freezeWindow{holidayFreeze},
+				IncidentMode:  incidentMode{Active: true},
+				DeployWindows: []deployWindow{businessHours},
+			},
+			environment: "production",
+			deployTime:  time.Date(2024, 12, 21, 10, 0, 0, 0, time.UTC), // Saturday
+			wantReasons: []string{"change_freeze", "incident_mode", "outside_deploy_window"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkDeploymentWindow(tt.policy, tt.environment, tt.deployTime)
+			if err != nil {
+				t.Fatalf("checkDeploymentWindow() error = %v", err)
+			}
+			if result.Allowed != tt.wantAllowed || result.Valid != tt.wantAllowed {
+				t.Errorf("allowed = %v, want %v (%+v)", result.Allowed, tt.wantAllowed, result.Reasons)
+			}
+			got := []string{}
+			for _, reason := range result.Reasons {
+				got = append(got, reason.Type)
+				if reason.Message == "" {
+					t.Errorf("reason %s has no message", reason.Type)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tt.wantReasons, ",") {
+				t.Errorf("reasons = %v, want %v", got, tt.wantReasons)
+			}
+		})
+	}
+}
+
+// TestHandleValidateDeploymentWindow tests reading the project's deploy policy
+func TestHandleValidateDeploymentWindow(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".guardrails"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	policy := `{"freeze_windows": [{"start": "2024-12-20", "end": "2025-01-02", "reason": "holiday freeze"}]}`
+	if err := os.WriteFile(filepath.Join(repo, ".guardrails", "deploy-policy.json"), []byte(policy), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GUARDRAILS_REPO_PATH", repo)
+	s := mockMCPServer()
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{name: "allowed outside the freeze", args: map[string]interface{}{"environment": "production", "deploy_time": "2024-06-04T10:00:00Z"}, wantText: `"allowed":true`},
+		{name: "blocked in the freeze", args: map[string]interface{}{"environment": "production", "deploy_time": "2024-12-24T10:00:00Z"}, wantError: true, wantText: "holiday freeze"},
+		{name: "missing environment", args: map[string]interface{}{}, wantError: true, wantText: "environment is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateDeploymentWindow(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateDeploymentWindow() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
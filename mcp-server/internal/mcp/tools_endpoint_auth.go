@@ -0,0 +1,143 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// routeRegistrationPattern matches a route registration and captures the receiver,
+	// HTTP method and path, e.g. api.POST("/users", ...) or router.delete('/x', ...)
+	routeRegistrationPattern = regexp.MustCompile(`(\w+)\.(?i:(get|post|put|patch|delete))\(\s*["'` + "`" + `](/[^"'` + "`" + `]*)["'` + "`" + `]`)
+	// annotatedRoutePattern matches decorator/annotation style routes such as
+	// @router.post("/users") or @DeleteMapping("/users/{id}")
+	annotatedRoutePattern = regexp.MustCompile(`@(?:\w+\.)?(?i:(post|put|patch|delete))(?:Mapping)?\(\s*(?:value\s*=\s*|path\s*=\s*)?["'](/[^"']*)["']`)
+	// authMiddlewarePattern matches identifiers that indicate authentication or authorization
+	authMiddlewarePattern = regexp.MustCompile(`(?i)auth|jwt|bearer|api_?key|require_?(?:user|role|login|admin|scope)|login_required|permission|protected|session_?required|rbac|acl`)
+	// authGroupPattern matches a route group or middleware chain built with auth, capturing the variable
+	authGroupPattern = regexp.MustCompile(`(\w+)\s*:?=\s*\w+\.(?:Group|With|Route)\(.*`)
+	// useMiddlewarePattern matches middleware attached to an existing router variable
+	useMiddlewarePattern = regexp.MustCompile(`(\w+)\.(?:Use|use)\((.*)`)
+	// handlerFuncPattern matches the start of a Go, JS or Python handler definition, capturing its name
+	handlerFuncPattern = regexp.MustCompile(`^\s*(?:func\s+(?:\([^)]*\)\s*)?|(?:async\s+)?function\s+|(?:async\s+)?def\s+)(\w+)\s*\(`)
+	// trailingHandlerPattern captures the final identifier argument of a route registration
+	trailingHandlerPattern = regexp.MustCompile(`(\w+)\s*\)\s*;?\s*$`)
+)
+
+// handleValidateEndpointAuth flags added state-changing routes that have neither auth
+// middleware nor an explicit auth check in their handler
+func (s *MCPServer) handleValidateEndpointAuth(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkEndpointAuth(diff, stringSliceArg(args, "public_routes"))
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkEndpointAuth scans the added lines of a diff for POST/PUT/PATCH/DELETE route
+// registrations. A route counts as protected when auth middleware appears on the
+// registration line, on the group or router it is registered on, or inside the
+// handler body when that handler is part of the diff. Routes listed in publicRoutes
+// (exact paths or globs) are intentionally public and skipped.
+func checkEndpointAuth(diff string, publicRoutes []string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+
+	protectedRouters := make(map[string]bool)
+	protectedHandlers := make(map[string]bool)
+	currentHandler := ""
+	for _, line := range lines {
+		if m := authGroupPattern.FindStringSubmatch(line.Text); m != nil && authMiddlewarePattern.MatchString(m[0][len(m[1]):]) {
+			protectedRouters[m[1]] = true
+		}
+		if m := useMiddlewarePattern.FindStringSubmatch(line.Text); m != nil && authMiddlewarePattern.MatchString(m[2]) {
+			protectedRouters[m[1]] = true
+		}
+		if m := handlerFuncPattern.FindStringSubmatch(line.Text); m != nil {
+			currentHandler = m[1]
+			continue
+		}
+		if currentHandler != "" && authMiddlewarePattern.MatchString(line.Text) && !isCommentLine(strings.TrimSpace(line.Text)) {
+			protectedHandlers[currentHandler] = true
+		}
+	}
+
+	scanned := 0
+	for _, line := range lines {
+		if !line.Added {
+			continue
+		}
+		scanned++
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || isCommentLine(trimmed) {
+			continue
+		}
+
+		method, path, protected := "", "", false
+		if m := annotatedRoutePattern.FindStringSubmatch(line.Text); m != nil {
+			method, path = strings.ToUpper(m[1]), m[2]
+			protected = authMiddlewarePattern.MatchString(line.Text[strings.Index(line.Text, m[0])+len(m[0]):])
+			// Decorators stack above the handler; the next definition in the diff is the handler
+			for _, next := range lines {
+				if next.File == line.File && next.Number > line.Number {
+					if authMiddlewarePattern.MatchString(next.Text) && strings.HasPrefix(strings.TrimSpace(next.Text), "@") {
+						protected = true
+						continue
+					}
+					if h := handlerFuncPattern.FindStringSubmatch(next.Text); h != nil {
+						protected = protected || protectedHandlers[h[1]]
+						break
+					}
+				}
+			}
+		} else if m := routeRegistrationPattern.FindStringSubmatch(line.Text); m != nil {
+			method, path = strings.ToUpper(m[2]), m[3]
+			rest := line.Text[strings.Index(line.Text, m[0])+len(m[0]):]
+			protected = protectedRouters[m[1]] || authMiddlewarePattern.MatchString(rest)
+			if h := trailingHandlerPattern.FindStringSubmatch(rest); h != nil && protectedHandlers[h[1]] {
+				protected = true
+			}
+		}
+		if method == "" || method == "GET" || protected || isPublicRoute(path, publicRoutes) {
+			continue
+		}
+
+		violations = append(violations, models.DiffViolation{
+			Type:       "unauthenticated_mutating_route",
+			Severity:   "error",
+			File:       line.File,
+			LineNumber: line.Number,
+			Line:       trimmed,
+			Message:    fmt.Sprintf("%s %s changes state but has no auth middleware or auth check", method, path),
+			Suggestion: "Register the route on an authenticated group or add auth middleware; list it in public_routes if it is intentionally public",
+		})
+	}
+
+	return newDiffScanResult("endpoint auth", violations, scanned)
+}
+
+// isPublicRoute reports whether a route path is listed as intentionally public,
+// either exactly or by glob pattern
+func isPublicRoute(path string, publicRoutes []string) bool {
+	for _, public := range publicRoutes {
+		if path == public {
+			return true
+		}
+		if ok, err := globMatch(public, path); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
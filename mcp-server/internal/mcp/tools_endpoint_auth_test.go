@@ -0,0 +1,91 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckEndpointAuth tests detection of state-changing routes without authentication
+func TestCheckEndpointAuth(t *testing.T) {
+	tests := []struct {
+		name         string
+		diff         string
+		publicRoutes []string
+		wantValid    bool
+		wantTypes    []string
+	}{
+		{
+			name:      "unprotected mutating route flagged",
+			diff:      "+++ b/internal/web/server.go\n@@ -10,1 +10,2 @@\n \tapi := e.Group(\"/api\")\n+\tapi.POST(\"/users\", s.createUser)\n",
+			wantValid: false,
+			wantTypes: []string{"unauthenticated_mutating_route"},
+		},
+		{
+			name:      "route with auth middleware passes",
+			diff:      "+++ b/internal/web/server.go\n+\te.DELETE(\"/users/:id\", s.deleteUser, authMiddleware)\n",
+			wantValid: true,
+		},
+		{
+			name:      "route on authenticated group passes",
+			diff:      "+++ b/internal/web/server.go\n@@ -10,1 +10,2 @@\n \tapi := e.Group(\"/api\", s.requireAPIKey)\n+\tapi.PUT(\"/rules/:id\", s.updateRule)\n",
+			wantValid: true,
+		},
+		{
+			name:      "router protected with Use passes",
+			diff:      "+++ b/routes.js\n+router.use(authenticate)\n+router.post('/orders', createOrder)\n",
+			wantValid: true,
+		},
+		{
+			name: "explicit auth check in handler passes",
+			diff: "+++ b/internal/web/server.go\n" +
+				"+\te.PATCH(\"/profile\", s.updateProfile)\n" +
+				"+func (s *Server) updateProfile(c echo.Context) error {\n" +
+				"+\tuser, err := s.authenticate(c)\n" +
+				"+}\n",
+			wantValid: true,
+		},
+		{
+			name:         "intentionally public route skipped",
+			diff:         "+\te.POST(\"/webhooks/github\", s.githubWebhook)\n",
+			publicRoutes: []string{"/webhooks/*"},
+			wantValid:    true,
+		},
+		{
+			name:      "decorated route without auth flagged",
+			diff:      "+++ b/api/users.py\n+@router.delete(\"/users/{user_id}\")\n+async def delete_user(user_id: int):\n+    db.delete(user_id)\n",
+			wantValid: false,
+			wantTypes: []string{"unauthenticated_mutating_route"},
+		},
+		{
+			name:      "decorated route with auth dependency passes",
+			diff:      "+++ b/api/users.py\n+@router.post(\"/users\")\n+@login_required\n+async def create_user(body: UserIn):\n",
+			wantValid: true,
+		},
+		{
+			name:      "read-only route ignored",
+			diff:      "+\te.GET(\"/status\", s.status)\n",
+			wantValid: true,
+		},
+		{
+			name:      "HTTP client call ignored",
+			diff:      "+\tresp, err := http.Post(\"https://example.com/hook\", \"application/json\", body)\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkEndpointAuth(tt.diff, tt.publicRoutes)
+			if result.Valid != tt.wantValid {
+				t.Errorf("checkEndpointAuth() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("violations = %+v, want types %v", result.Violations, tt.wantTypes)
+			}
+			for i, typ := range tt.wantTypes {
+				if result.Violations[i].Type != typ {
+					t.Errorf("violation[%d] type = %s, want %s", i, result.Violations[i].Type, typ)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,217 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// handleValidateEnvParity compares the environment variables defined for each
+// environment against each other and against the variables the code references
+func (s *MCPServer) handleValidateEnvParity(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	environments, err := configArg(args, "environments")
+	if err != nil {
+		result := models.EnvParityResult{
+			Valid:   false,
+			Message: err.Error(),
+		}
+		return buildToolResult(result, true)
+	}
+
+	envVars := make(map[string]map[string]string, len(environments))
+	for name, raw := range environments {
+		vars, err := envVarsArg(raw)
+		if err != nil {
+			result := models.EnvParityResult{
+				Valid:   false,
+				Message: fmt.Sprintf("environments.%s: %v", name, err),
+			}
+			return buildToolResult(result, true)
+		}
+		envVars[name] = vars
+	}
+
+	referencedVars := stringSliceArg(args, "referenced_vars")
+	if len(envVars) == 0 || (len(envVars) < 2 && len(referencedVars) == 0) {
+		result := models.EnvParityResult{
+			Valid:   false,
+			Message: "at least two environments, or referenced_vars, are required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkEnvParity(referencedVars, envVars)
+	return buildToolResult(result, !result.Valid)
+}
+
+// envVarsArg reads one environment's variables, given as an object of name to value
+// or as the text of a dotenv file
+func envVarsArg(raw interface{}) (map[string]string, error) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		vars := make(map[string]string, len(v))
+		for name, value := range v {
+			if value == nil {
+				vars[name] = ""
+				continue
+			}
+			vars[name] = fmt.Sprint(value)
+		}
+		return vars, nil
+	case string:
+		return parseDotenv(v), nil
+	default:
+		return nil, fmt.Errorf("must be an object or dotenv text")
+	}
+}
+
+// parseDotenv parses KEY=value lines, skipping blanks and comments and accepting an
+// "export " prefix and quoted values
+func parseDotenv(content string) map[string]string {
+	vars := make(map[string]string)
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		vars[strings.TrimSpace(name)] = value
+	}
+	return vars
+}
+
+// checkEnvParity reports variables the code references that an environment does not
+// define (errors), variables defined in only some environments, and variables whose
+// values are of a different kind across environments, e.g. empty in prod but set in
+// staging, or a number in one and a URL in another (warnings).
+func checkEnvParity(referencedVars []string, envVars map[string]map[string]string) models.EnvParityResult {
+	envNames := make([]string, 0, len(envVars))
+	for name := range envVars {
+		envNames = append(envNames, name)
+	}
+	sort.Strings(envNames)
+
+	referenced := make(map[string]bool, len(referencedVars))
+	allVars := make(map[string]bool)
+	for _, name := range referencedVars {
+		referenced[name] = true
+		allVars[name] = true
+	}
+	for _, vars := range envVars {
+		for name := range vars {
+			allVars[name] = true
+		}
+	}
+	varNames := make([]string, 0, len(allVars))
+	for name := range allVars {
+		varNames = append(varNames, name)
+	}
+	sort.Strings(varNames)
+
+	issues := []models.EnvParityIssue{}
+	for _, name := range varNames {
+		missing := []string{}
+		kinds := make(map[string][]string)
+		for _, env := range envNames {
+			value, ok := envVars[env][name]
+			if !ok {
+				missing = append(missing, env)
+				continue
+			}
+			kind := envValueKind(value)
+			kinds[kind] = append(kinds[kind], env)
+		}
+
+		switch {
+		case len(missing) > 0 && referenced[name]:
+			issues = append(issues, models.EnvParityIssue{
+				Variable:     name,
+				Type:         "missing_in_environment",
+				Severity:     "error",
+				Environments: missing,
+				Message:      fmt.Sprintf("%s is used in code but not defined in %s", name, strings.Join(missing, ", ")),
+			})
+			continue
+		case len(missing) > 0:
+			issues = append(issues, models.EnvParityIssue{
+				Variable:     name,
+				Type:         "partially_defined",
+				Severity:     "warning",
+				Environments: missing,
+				Message:      fmt.Sprintf("%s is defined in some environments but not in %s", name, strings.Join(missing, ", ")),
+			})
+			continue
+		}
+
+		if len(kinds) > 1 {
+			described := make([]string, 0, len(kinds))
+			for kind, envs := range kinds {
+				described = append(described, fmt.Sprintf("%s in %s", kind, strings.Join(envs, ", ")))
+			}
+			sort.Strings(described)
+			issues = append(issues, models.EnvParityIssue{
+				Variable:     name,
+				Type:         "inconsistent_value",
+				Severity:     "warning",
+				Environments: envNames,
+				Message:      fmt.Sprintf("%s is defined inconsistently: %s", name, strings.Join(described, "; ")),
+			})
+		}
+	}
+
+	errors := 0
+	for _, issue := range issues {
+		if issue.Severity == "error" {
+			errors++
+		}
+	}
+
+	result := models.EnvParityResult{
+		Valid:            errors == 0,
+		Environments:     envNames,
+		VariablesChecked: len(varNames),
+		Issues:           issues,
+	}
+	switch {
+	case len(issues) == 0:
+		result.Message = fmt.Sprintf("%d variable(s) consistent across %d environment(s)", len(varNames), len(envNames))
+	case errors == 0:
+		result.Message = fmt.Sprintf("%d parity warning(s) across %d environment(s)", len(issues), len(envNames))
+	default:
+		result.Message = fmt.Sprintf("%d variable(s) used in code are missing from an environment (%d issue(s) total)", errors, len(issues))
+	}
+	return result
+}
+
+// envValueKind classifies a value so that the same variable can be compared across
+// environments whose actual values legitimately differ
+func envValueKind(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "empty"
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return "number"
+	}
+	if _, err := strconv.ParseBool(value); err == nil {
+		return "boolean"
+	}
+	if u, err := url.Parse(value); err == nil && u.Scheme != "" && u.Host != "" {
+		return "url"
+	}
+	return "string"
+}
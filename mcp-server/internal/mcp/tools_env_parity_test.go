@@ -0,0 +1,120 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckEnvParity tests detection of environment variable drift across environments
+func TestCheckEnvParity(t *testing.T) {
+	tests := []struct {
+		name       string
+		referenced []string
+		envs       map[string]map[string]string
+		wantValid  bool
+		wantTypes  []string
+	}{
+		{
+			name:       "full parity passes",
+			referenced: []string{"DATABASE_URL", "LOG_LEVEL"},
+			envs: map[string]map[string]string{
+				"dev":     {"DATABASE_URL": "postgres://localhost/app", "LOG_LEVEL": "debug"},
+				"staging": {"DATABASE_URL": "postgres://staging-db/app", "LOG_LEVEL": "info"},
+				"prod":    {"DATABASE_URL": "postgres://prod-db/app", "LOG_LEVEL": "warn"},
+			},
+			wantValid: true,
+		},
+		{
+			name:       "var used in code missing in prod flagged",
+			referenced: []string{"DATABASE_URL", "STRIPE_API_KEY"},
+			envs: map[string]map[string]string{
+				"dev":  {"DATABASE_URL": "postgres://localhost/app", "STRIPE_API_KEY": "sk_test_x"},
+				"prod": {"DATABASE_URL": "postgres://prod-db/app"},
+			},
+			wantValid: false,
+			wantTypes: []string{"missing_in_environment"},
+		},
+		{
+			name: "var defined only in some environments warns",
+			envs: map[string]map[string]string{
+				"dev":  {"DEBUG_TOOLBAR": "true", "PORT": "8080"},
+				"prod": {"PORT": "8080"},
+			},
+			wantValid: true,
+			wantTypes: []string{"partially_defined"},
+		},
+		{
+			name: "empty in prod but set elsewhere warns",
+			envs: map[string]map[string]string{
+				"staging": {"SENTRY_DSN": "https://key@sentry.io/1"},
+				"prod":    {"SENTRY_DSN": ""},
+			},
+			wantValid: true,
+			wantTypes: []string{"inconsistent_value"},
+		},
+		{
+			name: "different value kinds warn",
+			envs: map[string]map[string]string{
+				"dev":  {"CACHE_TTL": "300"},
+				"prod": {"CACHE_TTL": "five minutes"},
+			},
+			wantValid: true,
+			wantTypes: []string{"inconsistent_value"},
+		},
+		{
+			name:       "single environment checked against referenced vars",
+			referenced: []string{"REDIS_HOST"},
+			envs: map[string]map[string]string{
+				"prod": {"REDIS_PORT": "6379"},
+			},
+			wantValid: false,
+			wantTypes: []string{"missing_in_environment"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkEnvParity(tt.referenced, tt.envs)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (issues: %+v)", result.Valid, tt.wantValid, result.Issues)
+			}
+			if len(result.Issues) != len(tt.wantTypes) {
+				t.Fatalf("got %d issues, want %d: %+v", len(result.Issues), len(tt.wantTypes), result.Issues)
+			}
+			for i, want := range tt.wantTypes {
+				if result.Issues[i].Type != want {
+					t.Errorf("issue %d type = %s, want %s", i, result.Issues[i].Type, want)
+				}
+			}
+		})
+	}
+}
+
+// TestCheckEnvParity_MissingEnvironments tests that the issue names every environment lacking the variable
+func TestCheckEnvParity_MissingEnvironments(t *testing.T) {
+	result := checkEnvParity([]string{"API_URL"}, map[string]map[string]string{
+		"dev":     {"API_URL": "http://localhost:8080"},
+		"staging": {},
+		"prod":    {},
+	})
+	if len(result.Issues) != 1 {
+		t.Fatalf("got %d issues, want 1: %+v", len(result.Issues), result.Issues)
+	}
+	got := result.Issues[0].Environments
+	if len(got) != 2 || got[0] != "prod" || got[1] != "staging" {
+		t.Errorf("environments = %v, want [prod staging]", got)
+	}
+}
+
+// TestParseDotenv tests parsing of dotenv file contents
+func TestParseDotenv(t *testing.T) {
+	vars := parseDotenv("# comment\nexport DB_HOST=localhost\nDB_NAME=\"guardrails\"\nAPI_KEY='abc=123'\n\nEMPTY=\nnot a var\n")
+	want := map[string]string{"DB_HOST": "localhost", "DB_NAME": "guardrails", "API_KEY": "abc=123", "EMPTY": ""}
+	if len(vars) != len(want) {
+		t.Fatalf("got %v, want %v", vars, want)
+	}
+	for k, v := range want {
+		if vars[k] != v {
+			t.Errorf("%s = %q, want %q", k, vars[k], v)
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// envReadPattern matches one way of reading an environment variable. The name
+// capture group is the variable; the optional def group is an inline default.
+type envReadPattern struct {
+	pattern *regexp.Regexp
+	// failsFast reads abort at startup when the variable is unset (os.environ["X"])
+	failsFast bool
+	// literalDefault defaults are plain values rather than expressions (struct tags)
+	literalDefault bool
+}
+
+// envReadPatterns cover environment reads in Go, Python and JavaScript/TypeScript
+var envReadPatterns = []envReadPattern{
+	// Go: os.Getenv("NAME"), os.LookupEnv("NAME")
+	{pattern: regexp.MustCompile(`\bos\.(?:Getenv|LookupEnv)\(\s*"(?P<name>[A-Z][A-Z0-9_]*)"\s*\)`)},
+	// Go struct tags (caarlos0/env, envconfig): env:"NAME" envDefault:"x", env:"NAME,required"
+	{pattern: regexp.MustCompile(`\benv(?:config)?:"(?P<name>[A-Z][A-Z0-9_]*)[^"]*"(?:.*?\b(?:envDefault|default):"(?P<def>[^"]*)")?`), literalDefault: true},
+	// Python: os.getenv("NAME", default), os.environ.get("NAME", default)
+	{pattern: regexp.MustCompile(`\bos\.(?:getenv|environ\.get)\(\s*["'](?P<name>[A-Z][A-Z0-9_]*)["']\s*(?:,\s*(?P<def>[^)]+?)\s*)?\)`)},
+	// Python: os.environ["NAME"] raises KeyError when unset
+	{pattern: regexp.MustCompile(`\bos\.environ\[\s*["'](?P<name>[A-Z][A-Z0-9_]*)["']\s*\]`), failsFast: true},
+	// JavaScript: process.env.NAME || default, process.env["NAME"] ?? default
+	{pattern: regexp.MustCompile(`\bprocess\.env\.(?P<name>[A-Z][A-Z0-9_]*)(?:\s*(?:\|\||\?\?)\s*(?P<def>[^;,)]+))?`)},
+	{pattern: regexp.MustCompile(`\bprocess\.env\[\s*["'](?P<name>[A-Z][A-Z0-9_]*)["']\s*\](?:\s*(?:\|\||\?\?)\s*(?P<def>[^;,)]+))?`)},
+}
+
+var (
+	// secretEnvNamePattern matches variable names that hold credentials
+	secretEnvNamePattern = regexp.MustCompile(`(?i)(SECRET|TOKEN|PASSWORD|PASSWD|API_?KEY|PRIVATE_KEY|CREDENTIAL|ACCESS_KEY)`)
+	// envAssignPattern captures the identifiers a read is assigned to, from the text before it
+	envAssignPattern = regexp.MustCompile(`(?:^|[\s(])(?:const\s+|let\s+|var\s+)?([A-Za-z_][\w.]*)(?:\s*,\s*([A-Za-z_]\w*))?\s*:?=\s*$`)
+	// envCheckPattern matches a startup check of a value read from the environment
+	envCheckPattern = regexp.MustCompile(`(?:==|!=|===|!==)\s*(?:""|''|nil|None|undefined|null)|\bis\s+(?:not\s+)?None\b|\bif\s+not\s+|\bif\s*\(?\s*!|\bMust\w*\(|\brequire\w*\(`)
+	// envValidatorCallPattern matches a read wrapped in a validating helper: mustEnv(os.Getenv("X"))
+	envValidatorCallPattern = regexp.MustCompile(`(?i)\b(?:must|require)\w*\(`)
+	// envRequiredTagPattern matches struct tags that make a variable required
+	envRequiredTagPattern = regexp.MustCompile(`\benv:"[^"]*,required|\brequired:"true"`)
+	// quotedLiteralPattern matches a non-empty string literal default
+	quotedLiteralPattern = regexp.MustCompile(`^(?:"[^"]+"|'[^']+'|` + "`[^`]+`" + `)$`)
+)
+
+// envValidationWindow is how many following lines may hold the startup check or
+// fallback for a value read from the environment
+const envValidationWindow = 10
+
+// handleValidateEnvVarAddition checks environment variables newly read in a diff for
+// documentation, a default or startup validation, and hardcoded secret defaults
+func (s *MCPServer) handleValidateEnvVarAddition(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+	envReference, _ := args["env_reference"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkEnvVarAddition(diff, envReference)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkEnvVarAddition scans the added source lines of a diff for environment reads.
+// Each new variable must be documented in envReference or in documentation added by
+// the diff (error), must be a secret without an inline literal default (error), and
+// should have a default or be checked within a few lines of the read (warning, since
+// an empty value can be a deliberate default).
+func checkEnvVarAddition(diff, envReference string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+
+	// Documentation text: the provided reference plus documentation added in the diff
+	var docsText strings.Builder
+	docsText.WriteString(envReference)
+	for _, line := range lines {
+		if line.Added && isDocumentationFile(line.File) {
+			docsText.WriteString("\n")
+			docsText.WriteString(line.Text)
+		}
+	}
+	docs := docsText.String()
+
+	// Variables already read by unchanged code are not new
+	existing := make(map[string]bool)
+	for _, line := range lines {
+		if !line.Added {
+			for _, read := range findEnvReads(line.Text) {
+				existing[read.name] = true
+			}
+		}
+	}
+
+	scanned := 0
+	seen := make(map[string]bool)
+	for i, line := range lines {
+		if !line.Added || isDocumentationFile(line.File) || fileConcern(line.File) == "test" {
+			continue
+		}
+		scanned++
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || isCommentLine(trimmed) {
+			continue
+		}
+
+		for _, read := range findEnvReads(line.Text) {
+			if seen[read.name] || existing[read.name] {
+				continue
+			}
+			seen[read.name] = true
+
+			violation := func(violationType, severity, message, suggestion string) {
+				violations = append(violations, models.DiffViolation{
+					Type:       violationType,
+					Severity:   severity,
+					File:       line.File,
+					LineNumber: line.Number,
+					Line:       trimmed,
+					Message:    message,
+					Suggestion: suggestion,
+				})
+			}
+
+			if !regexp.MustCompile(`\b` + read.name + `\b`).MatchString(docs) {
+				violation("undocumented_env_var", "error",
+					fmt.Sprintf("New environment variable %s is not documented", read.name),
+					fmt.Sprintf("Add %s to the env reference (.env.example or the configuration docs) with its purpose and default", read.name))
+			}
+
+			secret := secretEnvNamePattern.MatchString(read.name)
+			following := followingLines(lines, i, envValidationWindow)
+			fallback := hardcodedFallback(following, read.idents)
+			switch {
+			case secret && (read.literalDefault || quotedLiteralPattern.MatchString(read.def) || fallback):
+				violation("hardcoded_secret", "error",
+					fmt.Sprintf("%s holds a secret but falls back to a value written in the code", read.name),
+					"Remove the inline value and fail at startup when the secret is not set")
+			case read.def != "" || read.required || fallback || envValueChecked(following, read.idents):
+				// Defaulted or validated at startup
+			default:
+				violation("env_var_without_default", "warning",
+					fmt.Sprintf("%s has no default and is not validated at startup; an unset variable is silently empty", read.name),
+					"Give it a default or fail at startup with a clear error when it is missing")
+			}
+		}
+	}
+
+	return newDiffScanResult("env var addition", violations, scanned)
+}
+
+// envRead is one environment variable read found on a line
+type envRead struct {
+	name           string
+	def            string   // inline default expression, if any
+	literalDefault bool     // def is a non-empty plain value
+	required       bool     // the read fails at startup when the variable is unset
+	idents         []string // identifiers the value is assigned to
+}
+
+// findEnvReads returns the environment reads on a line
+func findEnvReads(text string) []envRead {
+	var reads []envRead
+	for _, p := range envReadPatterns {
+		for _, m := range p.pattern.FindAllStringSubmatchIndex(text, -1) {
+			read := envRead{required: p.failsFast}
+			for g, name := range p.pattern.SubexpNames() {
+				if m[2*g] < 0 {
+					continue
+				}
+				switch value := text[m[2*g]:m[2*g+1]]; name {
+				case "name":
+					read.name = value
+				case "def":
+					read.def = strings.TrimSpace(value)
+				}
+			}
+			read.literalDefault = p.literalDefault && read.def != ""
+			if envValidatorCallPattern.MatchString(text[:m[0]]) || envRequiredTagPattern.MatchString(text) {
+				read.required = true
+			}
+			if a := envAssignPattern.FindStringSubmatch(text[:m[0]]); a != nil {
+				for _, ident := range a[1:] {
+					if ident != "" && ident != "_" {
+						read.idents = append(read.idents, ident)
+					}
+				}
+			}
+			reads = append(reads, read)
+		}
+	}
+	return reads
+}
+
+// followingLines returns up to n lines after lines[i] in the same file
+func followingLines(lines []diffLine, i, n int) []string {
+	var out []string
+	for _, l := range lines[i+1:] {
+		if l.File != lines[i].File || len(out) == n {
+			break
+		}
+		out = append(out, l.Text)
+	}
+	return out
+}
+
+// envValueChecked reports whether one of the following lines checks an identifier
+// the value was assigned to
+func envValueChecked(following []string, idents []string) bool {
+	for _, text := range following {
+		if envCheckPattern.MatchString(text) && mentionsAny(text, idents) {
+			return true
+		}
+	}
+	return false
+}
+
+// hardcodedFallback reports whether one of the following lines assigns a string
+// literal to an identifier the value was assigned to, e.g. if key == "" { key = "abc" }
+func hardcodedFallback(following []string, idents []string) bool {
+	for _, ident := range idents {
+		assign := regexp.MustCompile(`\b` + regexp.QuoteMeta(ident) + `\s*=\s*(?:"[^"]+"|'[^']+')`)
+		for _, text := range following {
+			if assign.MatchString(text) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func mentionsAny(text string, idents []string) bool {
+	for _, ident := range idents {
+		if regexp.MustCompile(`\b` + regexp.QuoteMeta(ident) + `\b`).MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const envVarAdditionDiff = `diff --git a/internal/config/config.go b/internal/config/config.go
+--- a/internal/config/config.go
++++ b/internal/config/config.go
+@@ -10,3 +10,7 @@ func Load() Config {
+ 	cfg := Config{Port: os.Getenv("PORT")}
++	cfg.CacheTTL = os.Getenv("CACHE_TTL")
++	if cfg.CacheTTL == "" {
++		cfg.CacheTTL = "5m"
++	}
+ 	return cfg
+ }
+`
+
+const envVarReference = "# Server\nPORT=8080\n# How long rule lookups are cached (default 5m)\nCACHE_TTL=5m\n"
+
+// TestCheckEnvVarAddition tests documentation, default and hardcoded secret checks
+func TestCheckEnvVarAddition(t *testing.T) {
+	tests := []struct {
+		name           string
+		diff           string
+		envReference   string
+		wantValid      bool
+		wantViolations []string // type/severity
+	}{
+		{
+			name:         "documented and defaulted",
+			diff:         envVarAdditionDiff,
+			envReference: envVarReference,
+			wantValid:    true,
+		},
+		{
+			name:           "undocumented",
+			diff:           envVarAdditionDiff,
+			envReference:   "PORT=8080\n",
+			wantValid:      false,
+			wantViolations: []string{"undocumented_env_var/error"},
+		},
+		{
+			name: "documented in the same diff without a default",
+			diff: `--- a/app/settings.py
++++ b/app/settings.py
+@@ -1,2 +1,3 @@
+ import os
++REGION = os.getenv("REGION")
+--- a/README.md
++++ b/README.md
+@@ -5,1 +5,2 @@
+ ## Configuration
++- REGION: cloud region to deploy to
+`,
+			wantValid:      true,
+			wantViolations: []string{"env_var_without_default/warning"},
+		},
+		{
+			name: "secret with an inline default",
+			diff: `--- a/src/client.js
++++ b/src/client.js
+@@ -1,1 +1,2 @@
+ const axios = require('axios');
++const apiKey = process.env.BILLING_API_KEY || 'dev-billing-key';
+`,
+			envReference:   "BILLING_API_KEY=\n",
+			wantValid:      false,
+			wantViolations: []string{"hardcoded_secret/error"},
+		},
+		{
+			name: "required and fail-fast reads",
+			diff: `--- a/config.go
++++ b/config.go
+@@ -1,1 +1,4 @@
+ type Config struct {
++	DatabaseURL string ` + "`env:\"DATABASE_URL,required\"`" + `
++	Token       string
++}
+--- a/worker.py
++++ b/worker.py
+@@ -1,1 +1,2 @@
+ import os
++QUEUE_URL = os.environ["QUEUE_URL"]
+`,
+			envReference: "DATABASE_URL=postgres://localhost/app\nQUEUE_URL=\n",
+			wantValid:    true,
+		},
+		{
+			name: "existing variable is not new",
+			diff: `--- a/main.go
++++ b/main.go
+@@ -1,2 +1,2 @@
+ port := os.Getenv("PORT")
+-log.Printf("port %s", port)
++log.Printf("listening on %s (PORT=%s)", port, os.Getenv("PORT"))
+`,
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkEnvVarAddition(tt.diff, tt.envReference)
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if len(result.Violations) != len(tt.wantViolations) {
+				t.Fatalf("violations = %+v, want %v", result.Violations, tt.wantViolations)
+			}
+			for i, want := range tt.wantViolations {
+				if got := result.Violations[i].Type + "/" + result.Violations[i].Severity; got != want {
+					t.Errorf("violation[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleValidateEnvVarAddition tests the tool result for good, bad and empty input
+func TestHandleValidateEnvVarAddition(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name:     "documented and defaulted passes",
+			args:     map[string]interface{}{"diff": envVarAdditionDiff, "env_reference": envVarReference},
+			wantText: "No env var addition issues",
+		},
+		{
+			name:      "undocumented flagged",
+			args:      map[string]interface{}{"diff": envVarAdditionDiff},
+			wantError: true,
+			wantText:  "New environment variable CACHE_TTL is not documented",
+		},
+		{name: "missing diff", args: map[string]interface{}{}, wantError: true, wantText: "diff is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateEnvVarAddition(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateEnvVarAddition() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
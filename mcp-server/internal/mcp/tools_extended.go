@@ -8,11 +8,13 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/audit"
 	"github.com/thearchitectit/guardrail-mcp/internal/database"
 	"github.com/thearchitectit/guardrail-mcp/internal/models"
 )
@@ -61,20 +63,31 @@ func (s *MCPServer) handleValidateScope(ctx context.Context, args map[string]int
 		return buildToolResult(result, false)
 	}
 
-	// Clean paths for comparison
-	cleanPath := filepath.Clean(filePath)
-	cleanScope := filepath.Clean(scope)
+	mode, _ := args["scope_mode"].(string)
+	if mode == "" {
+		mode = "prefix"
+	}
 
-	// Check if file is within scope
-	isValid := strings.HasPrefix(cleanPath, cleanScope)
+	isValid, err := matchScope(filePath, scope, mode)
+	if err != nil {
+		result := models.ScopeValidationResult{
+			Valid:     false,
+			Message:   err.Error(),
+			FilePath:  filePath,
+			Scope:     scope,
+			ScopeMode: mode,
+		}
+		return buildToolResult(result, true)
+	}
 
 	var result models.ScopeValidationResult
 	if isValid {
 		result = models.ScopeValidationResult{
-			Valid:    true,
-			Message:  fmt.Sprintf("File %s is within authorized scope", filePath),
-			FilePath: filePath,
-			Scope:    scope,
+			Valid:     true,
+			Message:   fmt.Sprintf("File %s is within authorized scope", filePath),
+			FilePath:  filePath,
+			Scope:     scope,
+			ScopeMode: mode,
 		}
 	} else {
 		result = models.ScopeValidationResult{
@@ -82,6 +95,7 @@ func (s *MCPServer) handleValidateScope(ctx context.Context, args map[string]int
 			Message:      fmt.Sprintf("File %s is OUTSIDE authorized scope %s", filePath, scope),
 			FilePath:     filePath,
 			Scope:        scope,
+			ScopeMode:    mode,
 			OutsideScope: true,
 		}
 	}
@@ -89,6 +103,72 @@ func (s *MCPServer) handleValidateScope(ctx context.Context, args map[string]int
 	return buildToolResult(result, !isValid)
 }
 
+// matchScope reports whether filePath falls within scope under the given mode:
+// "prefix" (directory prefix on a path-separator boundary), "glob" (filepath.Match
+// per segment, with ** matching any number of directories) or "regex".
+// Invalid patterns are returned as errors rather than treated as a match.
+func matchScope(filePath, scope, mode string) (bool, error) {
+	cleanPath := filepath.ToSlash(filepath.Clean(filePath))
+
+	switch mode {
+	case "prefix":
+		cleanScope := filepath.ToSlash(filepath.Clean(scope))
+		switch cleanScope {
+		case cleanPath:
+			return true, nil
+		case ".":
+			return cleanPath != ".." && !strings.HasPrefix(cleanPath, "../") && !filepath.IsAbs(cleanPath), nil
+		case "/":
+			return strings.HasPrefix(cleanPath, "/"), nil
+		}
+		return strings.HasPrefix(cleanPath, cleanScope+"/"), nil
+	case "glob":
+		return globMatch(filepath.ToSlash(scope), cleanPath)
+	case "regex":
+		re, err := regexp.Compile(scope)
+		if err != nil {
+			return false, fmt.Errorf("invalid regex scope %q: %v", scope, err)
+		}
+		return re.MatchString(cleanPath), nil
+	default:
+		return false, fmt.Errorf("unknown scope_mode %q: must be prefix, glob or regex", mode)
+	}
+}
+
+// globMatch matches a slash-separated path against a glob pattern in which a "**"
+// segment matches zero or more path segments
+func globMatch(pattern, name string) (bool, error) {
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+	for _, part := range patternParts {
+		if _, err := filepath.Match(part, ""); err != nil {
+			return false, fmt.Errorf("invalid glob scope %q: %v", pattern, err)
+		}
+	}
+	return matchSegments(patternParts, strings.Split(strings.Trim(name, "/"), "/")), nil
+}
+
+// matchSegments matches path segments against pattern segments, expanding "**"
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := filepath.Match(pattern[0], name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
 // handleValidateCommit validates a commit message against conventional commit format
 func (s *MCPServer) handleValidateCommit(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	message, _ := args["message"].(string)
@@ -102,19 +182,49 @@ func (s *MCPServer) handleValidateCommit(ctx context.Context, args map[string]in
 		return buildToolResult(result, true)
 	}
 
-	result := validateConventionalCommit(message)
+	cfg := defaultCommitValidationConfig()
+	// An explicit empty allowed_types array means "accept any type"
+	if _, ok := args["allowed_types"].([]interface{}); ok {
+		cfg.AllowedTypes = []string{}
+		for _, t := range stringSliceArg(args, "allowed_types") {
+			if t = strings.TrimSpace(t); t != "" {
+				cfg.AllowedTypes = append(cfg.AllowedTypes, t)
+			}
+		}
+		cfg.AnyType = len(cfg.AllowedTypes) == 0
+	}
+	if v, ok := args["max_subject_length"].(float64); ok {
+		if v < 1 {
+			result := models.CommitValidationResult{
+				Valid:   false,
+				Message: "max_subject_length must be at least 1",
+				Issues:  []string{"Invalid max_subject_length"},
+			}
+			return buildToolResult(result, true)
+		}
+		cfg.MaxSubjectLength = int(v)
+	}
+
+	result := validateConventionalCommit(message, cfg)
 	return buildToolResult(result, !result.Valid)
 }
 
+// defaultCommitValidationConfig returns the standard conventional commit types and
+// the 72-character subject limit
+func defaultCommitValidationConfig() models.CommitValidationConfig {
+	return models.CommitValidationConfig{
+		AllowedTypes:     []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "chore", "build", "ci", "revert"},
+		MaxSubjectLength: 72,
+	}
+}
+
 // validateConventionalCommit validates against conventional commit format
 // Format: type(scope): description
-func validateConventionalCommit(message string) models.CommitValidationResult {
+func validateConventionalCommit(message string, cfg models.CommitValidationConfig) models.CommitValidationResult {
 	issues := []string{}
 
-	// Valid conventional commit types
-	validTypes := []string{"feat", "fix", "docs", "style", "refactor", "perf", "test", "chore", "build", "ci", "revert"}
 	validTypesMap := make(map[string]bool)
-	for _, t := range validTypes {
+	for _, t := range cfg.AllowedTypes {
 		validTypesMap[t] = true
 	}
 
@@ -122,9 +232,10 @@ func validateConventionalCommit(message string) models.CommitValidationResult {
 	// Scope is optional
 	conventionalPattern := regexp.MustCompile(`^(\w+)(?:\(([^)]+)\))?!?: (.+)$`)
 
-	// Check message length
-	if len(message) > 72 {
-		issues = append(issues, "Message exceeds 72 characters (consider using body for details)")
+	// Check subject (first line) length
+	subject, _, _ := strings.Cut(message, "\n")
+	if len(subject) > cfg.MaxSubjectLength {
+		issues = append(issues, fmt.Sprintf("Message exceeds %d characters (consider using body for details)", cfg.MaxSubjectLength))
 	}
 
 	// Check for common issues
@@ -147,6 +258,7 @@ func validateConventionalCommit(message string) models.CommitValidationResult {
 			FormatCompliant: false,
 			Issues:          append(issues, "Message does not follow conventional commit format: type(scope): description"),
 			Message:         message,
+			Config:          &cfg,
 		}
 	}
 
@@ -155,8 +267,10 @@ func validateConventionalCommit(message string) models.CommitValidationResult {
 	description := matches[3]
 
 	// Validate type
-	if !validTypesMap[commitType] {
-		issues = append(issues, fmt.Sprintf("Invalid type '%s' - must be one of: %s", commitType, strings.Join(validTypes, ", ")))
+	rejectedType := ""
+	if !cfg.AnyType && !validTypesMap[commitType] {
+		rejectedType = commitType
+		issues = append(issues, fmt.Sprintf("Invalid type '%s' - must be one of: %s", commitType, strings.Join(cfg.AllowedTypes, ", ")))
 	}
 
 	// Validate description
@@ -181,6 +295,8 @@ func validateConventionalCommit(message string) models.CommitValidationResult {
 		Message:          message,
 		ConventionalType: commitType,
 		Scope:            scope,
+		RejectedType:     rejectedType,
+		Config:           &cfg,
 	}
 }
 
@@ -281,7 +397,16 @@ func (s *MCPServer) handleCheckTestProdSeparation(ctx context.Context, args map[
 		return buildToolResult(result, true)
 	}
 
-	violations := []string{}
+	rules, err := s.separationRules(args)
+	if err != nil {
+		result := models.TestProdSeparationResult{
+			Valid:       false,
+			Violations:  []string{err.Error()},
+			FilePath:    filePath,
+			Environment: environment,
+		}
+		return buildToolResult(result, true)
+	}
 
 	// Read file content if it exists
 	content := ""
@@ -289,52 +414,350 @@ func (s *MCPServer) handleCheckTestProdSeparation(ctx context.Context, args map[
 		content = string(data)
 	}
 
-	switch environment {
-	case "prod":
-		// In prod code, check for test database usage
-		if strings.Contains(content, "test_db") || strings.Contains(content, "test_database") {
-			violations = append(violations, "Production code references test database")
+	result := checkTestProdSeparation(filePath, environment, content, rules)
+	return buildToolResult(result, !result.Valid)
+}
+
+// separationPattern marks code that does not belong in an environment
+type separationPattern struct {
+	re      *regexp.Regexp
+	message string
+}
+
+// separationRules holds the patterns checked for each environment
+type separationRules map[string][]separationPattern
+
+// separationPatternSpec is a pattern as given in the patterns argument or in
+// .guardrails/test-prod-separation.json
+type separationPatternSpec struct {
+	Pattern string `json:"pattern"`
+	Message string `json:"message"`
+}
+
+// separationPolicyFile represents the .guardrails/test-prod-separation.json structure
+type separationPolicyFile struct {
+	Patterns map[string][]separationPatternSpec `json:"patterns"`
+}
+
+// defaultSeparationRules are the built-in patterns: prod code must not reach test
+// databases or enable test mode, and test code must not reach production systems or
+// hold real credentials
+var defaultSeparationRules = separationRules{
+	"prod": {
+		{re: regexp.MustCompile(`test_db|test_database`), message: "Production code references test database"},
+		{re: regexp.MustCompile(`localhost:543[34]`), message: "Production code uses test database port"},
+		{re: regexp.MustCompile(`testMode\s*=\s*true`), message: "Production code has test mode enabled"},
+	},
+	"test": {
+		{re: regexp.MustCompile(`prod_db|production_database`), message: "Test code references production database"},
+		{re: regexp.MustCompile(`https?://api\.production\.`), message: "Test code contains production API URL"},
+		{re: regexp.MustCompile(`(?i)(aws_access_key_id|aws_secret_access_key)\s*=\s*["'][A-Z0-9]{20}["']`), message: "Test code may contain hardcoded AWS credentials"},
+		{re: regexp.MustCompile(`(?i)production.*secret`), message: "Test code references production secrets"},
+	},
+}
+
+// compileSeparationRules compiles pattern specs keyed by environment
+func compileSeparationRules(specs map[string][]separationPatternSpec) (separationRules, error) {
+	rules := make(separationRules, len(specs))
+	for environment, list := range specs {
+		patterns := make([]separationPattern, 0, len(list))
+		for i, spec := range list {
+			if spec.Pattern == "" {
+				return nil, fmt.Errorf("patterns.%s[%d]: pattern is required", environment, i)
+			}
+			re, err := regexp.Compile(spec.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("patterns.%s[%d]: invalid pattern: %v", environment, i, err)
+			}
+			message := spec.Message
+			if message == "" {
+				message = fmt.Sprintf("Code for %s matches %s", environment, spec.Pattern)
+			}
+			patterns = append(patterns, separationPattern{re: re, message: message})
+		}
+		rules[environment] = patterns
+	}
+	return rules, nil
+}
+
+// separationRules returns the patterns to check: the built-ins, replaced per
+// environment by those in the project's .guardrails/test-prod-separation.json and
+// then by the patterns argument. An invalid project file is logged and ignored; an
+// invalid argument is an error.
+func (s *MCPServer) separationRules(args map[string]interface{}) (separationRules, error) {
+	rules := make(separationRules, len(defaultSeparationRules))
+	for environment, patterns := range defaultSeparationRules {
+		rules[environment] = patterns
+	}
+
+	if data, err := os.ReadFile(filepath.Join(s.getRepoPath(), ".guardrails", "test-prod-separation.json")); err == nil {
+		var policy separationPolicyFile
+		var fileRules separationRules
+		if err = json.Unmarshal(data, &policy); err == nil {
+			fileRules, err = compileSeparationRules(policy.Patterns)
 		}
-		if strings.Contains(content, "localhost:5433") || strings.Contains(content, "localhost:5434") {
-			violations = append(violations, "Production code uses test database port")
+		if err != nil {
+			slog.Warn("Ignoring invalid test/prod separation policy", "error", err)
 		}
-		// Check for test-only patterns
-		if regexp.MustCompile(`testMode\s*=\s*true`).MatchString(content) {
-			violations = append(violations, "Production code has test mode enabled")
+		for environment, patterns := range fileRules {
+			rules[environment] = patterns
 		}
+	}
 
-	case "test":
-		// In test code, check for production credentials/patterns
-		if strings.Contains(content, "prod_db") || strings.Contains(content, "production_database") {
-			violations = append(violations, "Test code references production database")
+	if raw := args["patterns"]; raw != nil {
+		var specs map[string][]separationPatternSpec
+		data, err := json.Marshal(raw)
+		if err == nil {
+			err = json.Unmarshal(data, &specs)
 		}
-		// Check for hardcoded production URLs
-		if regexp.MustCompile(`https?://api\.production\.`).MatchString(content) {
-			violations = append(violations, "Test code contains production API URL")
+		if err != nil {
+			return nil, fmt.Errorf("patterns must be an object of environment to [{pattern, message}]")
 		}
-		// Check for real credentials (basic patterns)
-		if regexp.MustCompile(`(?i)(aws_access_key_id|aws_secret_access_key)\s*=\s*["'][A-Z0-9]{20}["']`).MatchString(content) {
-			violations = append(violations, "Test code may contain hardcoded AWS credentials")
+		argRules, err := compileSeparationRules(specs)
+		if err != nil {
+			return nil, err
 		}
-		// Check for production secrets
-		if regexp.MustCompile(`(?i)production.*secret`).MatchString(content) {
-			violations = append(violations, "Test code references production secrets")
+		for environment, patterns := range argRules {
+			rules[environment] = patterns
 		}
+	}
+	return rules, nil
+}
 
-	default:
-		violations = append(violations, fmt.Sprintf("Unknown environment: %s (expected 'test' or 'prod')", environment))
+// checkTestProdSeparation checks one file's content for references that cross the
+// test/production boundary for its environment. Each pattern that matches is
+// reported once, with the line of its first match.
+func checkTestProdSeparation(filePath, environment, content string, rules separationRules) models.TestProdSeparationResult {
+	violations := []string{}
+	matches := []models.TestProdSeparationMatch{}
+
+	patterns, known := rules[environment]
+	if !known {
+		environments := make([]string, 0, len(rules))
+		for name := range rules {
+			environments = append(environments, "'"+name+"'")
+		}
+		sort.Strings(environments)
+		violations = append(violations, fmt.Sprintf("Unknown environment: %s (expected %s)", environment, strings.Join(environments, " or ")))
 	}
 
-	valid := len(violations) == 0
+	for _, pattern := range patterns {
+		loc := pattern.re.FindStringIndex(content)
+		if loc == nil {
+			continue
+		}
+		violations = append(violations, pattern.message)
+		matches = append(matches, models.TestProdSeparationMatch{
+			Pattern: pattern.re.String(),
+			Message: pattern.message,
+			Line:    strings.Count(content[:loc[0]], "\n") + 1,
+		})
+	}
 
-	result := models.TestProdSeparationResult{
-		Valid:       valid,
+	return models.TestProdSeparationResult{
+		Valid:       len(violations) == 0,
 		Violations:  violations,
+		Matches:     matches,
 		FilePath:    filePath,
 		Environment: environment,
 	}
+}
 
-	return buildToolResult(result, !valid)
+// handleValidateTestProdSeparation runs the test/production separation check over a
+// whole changeset. Each entry is {file_path, environment} with optional content;
+// without content the file is read from the working directory.
+func (s *MCPServer) handleValidateTestProdSeparation(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	rawFiles, _ := args["files"].([]interface{})
+	if len(rawFiles) == 0 {
+		result := models.TestProdSeparationBatchResult{
+			Valid:   false,
+			Message: "files is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	rules, err := s.separationRules(args)
+	if err != nil {
+		result := models.TestProdSeparationBatchResult{
+			Valid:   false,
+			Message: err.Error(),
+		}
+		return buildToolResult(result, true)
+	}
+
+	results := make([]models.TestProdSeparationResult, 0, len(rawFiles))
+	for i, raw := range rawFiles {
+		entry, _ := raw.(map[string]interface{})
+		filePath, _ := entry["file_path"].(string)
+		environment, _ := entry["environment"].(string)
+		if filePath == "" {
+			results = append(results, models.TestProdSeparationResult{
+				Valid:       false,
+				Violations:  []string{fmt.Sprintf("files[%d]: file_path is required", i)},
+				Environment: environment,
+			})
+			continue
+		}
+
+		content, hasContent := entry["content"].(string)
+		if !hasContent {
+			if data, err := safeReadFile(filePath); err == nil {
+				content = string(data)
+			}
+		}
+		results = append(results, checkTestProdSeparation(filePath, environment, content, rules))
+	}
+
+	result := aggregateTestProdSeparation(results)
+	return buildToolResult(result, !result.Valid)
+}
+
+// aggregateTestProdSeparation combines per-file results into a changeset verdict.
+// Aggregated violations are prefixed with the file they came from.
+func aggregateTestProdSeparation(results []models.TestProdSeparationResult) models.TestProdSeparationBatchResult {
+	batch := models.TestProdSeparationBatchResult{
+		Valid:        true,
+		FilesChecked: len(results),
+		Violations:   []string{},
+		Results:      results,
+	}
+	for _, r := range results {
+		if r.Valid {
+			continue
+		}
+		batch.Valid = false
+		batch.FilesWithViolations++
+		for _, v := range r.Violations {
+			if r.FilePath != "" {
+				v = r.FilePath + ": " + v
+			}
+			batch.Violations = append(batch.Violations, v)
+		}
+	}
+
+	if batch.Valid {
+		batch.Message = fmt.Sprintf("All %d files respect test/production separation", batch.FilesChecked)
+	} else {
+		batch.Message = fmt.Sprintf("%d of %d files violate test/production separation (%d violations)", batch.FilesWithViolations, batch.FilesChecked, len(batch.Violations))
+	}
+	return batch
+}
+
+// defaultProtectedBranches are protected when neither the request nor the project
+// config names any; each also covers its sub-branches
+var defaultProtectedBranches = []string{
+	"main", "main/*",
+	"master", "master/*",
+	"production", "production/*",
+	"release", "release/*",
+}
+
+// gitPolicyFile represents the .guardrails/git-policy.json structure
+type gitPolicyFile struct {
+	ProtectedBranches    []string `json:"protected_branches"`
+	AllowedAuthorDomains []string `json:"allowed_author_domains"`
+	AllowedAuthors       []string `json:"allowed_authors"`
+}
+
+// loadGitPolicy reads the project's .guardrails/git-policy.json. A missing file
+// gives an empty policy; an invalid one is logged and ignored.
+func (s *MCPServer) loadGitPolicy() gitPolicyFile {
+	var policy gitPolicyFile
+	data, err := os.ReadFile(filepath.Join(s.getRepoPath(), ".guardrails", "git-policy.json"))
+	if err != nil {
+		return policy
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		slog.Warn("Ignoring invalid git policy", "error", err)
+		return gitPolicyFile{}
+	}
+	return policy
+}
+
+// loadProtectedBranches returns the protected branches from the project's
+// .guardrails/git-policy.json, falling back to the defaults
+func (s *MCPServer) loadProtectedBranches() []string {
+	if policy := s.loadGitPolicy(); len(policy.ProtectedBranches) > 0 {
+		return policy.ProtectedBranches
+	}
+	return defaultProtectedBranches
+}
+
+// matchProtectedBranch returns the first rule protecting branch, or "" if none does.
+// Rules match case-insensitively, either exactly or, with a trailing "/*", any
+// branch below that prefix (e.g. "env/*" matches "env/staging").
+func matchProtectedBranch(branch string, rules []string) string {
+	for _, rule := range rules {
+		if prefix, ok := strings.CutSuffix(rule, "/*"); ok {
+			if len(branch) > len(prefix)+1 && strings.EqualFold(branch[:len(prefix)+1], prefix+"/") {
+				return rule
+			}
+			continue
+		}
+		if strings.EqualFold(branch, rule) {
+			return rule
+		}
+	}
+	return ""
+}
+
+// defaultMaxPushFileBytes is the per-file size limit for pushes (5MB); GitHub rejects
+// files over 100MB outright and warns above 50MB
+const defaultMaxPushFileBytes = 5 * 1024 * 1024
+
+// binaryArtifactExtensions are build artifacts and media that should not be committed
+var binaryArtifactExtensions = []string{".zip", ".tar", ".bin", ".mp4"}
+
+// stagedFile is a file about to be pushed, as passed in the staged_files argument
+type stagedFile struct {
+	Path string
+	Size int64
+}
+
+// stagedFilesArg reads staged_files given as {path, size} objects or plain paths
+func stagedFilesArg(args map[string]interface{}) []stagedFile {
+	raw, _ := args["staged_files"].([]interface{})
+	files := make([]stagedFile, 0, len(raw))
+	for _, item := range raw {
+		switch v := item.(type) {
+		case string:
+			files = append(files, stagedFile{Path: v})
+		case map[string]interface{}:
+			path, _ := v["path"].(string)
+			size, _ := v["size"].(float64)
+			if path != "" {
+				files = append(files, stagedFile{Path: path, Size: int64(size)})
+			}
+		}
+	}
+	return files
+}
+
+// checkStagedFiles returns a warning for every staged file over maxBytes or with a
+// binary artifact extension, the reasons that block the push and the blocked paths.
+// Only the size limit blocks; an artifact extension is a warning, since some
+// binaries such as test fixtures are committed on purpose.
+func checkStagedFiles(files []stagedFile, maxBytes int64) ([]string, []string, []string) {
+	warnings := []string{}
+	denials := []string{}
+	blocked := []string{}
+	for _, f := range files {
+		if f.Size > maxBytes {
+			reason := fmt.Sprintf("Staged file '%s' is %.1fMB, over the %.1fMB limit - use Git LFS or keep it out of the repository", f.Path, float64(f.Size)/(1024*1024), float64(maxBytes)/(1024*1024))
+			warnings = append(warnings, reason)
+			denials = append(denials, reason)
+			blocked = append(blocked, f.Path)
+			continue
+		}
+		lower := strings.ToLower(f.Path)
+		for _, ext := range binaryArtifactExtensions {
+			if strings.HasSuffix(lower, ext) {
+				warnings = append(warnings, fmt.Sprintf("Staged file '%s' looks like a binary artifact (%s) - build outputs and media do not belong in the repository", f.Path, ext))
+				break
+			}
+		}
+	}
+	return warnings, denials, blocked
 }
 
 // handleValidatePush validates git push safety conditions
@@ -344,6 +767,11 @@ func (s *MCPServer) handleValidatePush(ctx context.Context, args map[string]inte
 	hasUnpushedCommits, _ := args["has_unpushed_commits"].(bool)
 
 	warnings := []string{}
+	denials := []string{}
+	deny := func(message string) {
+		denials = append(denials, message)
+		warnings = append(warnings, message)
+	}
 	canPush := true
 	valid := true
 
@@ -351,24 +779,39 @@ func (s *MCPServer) handleValidatePush(ctx context.Context, args map[string]inte
 	if isForce {
 		valid = false
 		canPush = false
-		warnings = append(warnings, "Force push detected - this can cause data loss for other team members")
+		deny("Force push detected - this can cause data loss for other team members")
 		warnings = append(warnings, "Consider using 'git push --force-with-lease' instead")
 	}
 
-	// Check for main/master branch protection
-	protectedBranches := []string{"main", "master", "production", "release"}
-	for _, protected := range protectedBranches {
-		if branch == protected || strings.HasPrefix(branch, protected+"/") {
-			if !isForce {
-				warnings = append(warnings, fmt.Sprintf("Pushing directly to '%s' branch - consider using a pull request", branch))
-			} else {
-				valid = false
-				canPush = false
-				warnings = append(warnings, fmt.Sprintf("FORCE PUSH to '%s' is highly discouraged and potentially dangerous", branch))
-			}
+	// Check for protected branches: explicit argument, then project config, then defaults
+	protectedBranches := stringSliceArg(args, "protected_branches")
+	if len(protectedBranches) == 0 {
+		protectedBranches = s.loadProtectedBranches()
+	}
+	protectedRule := matchProtectedBranch(branch, protectedBranches)
+	if protectedRule != "" {
+		if !isForce {
+			warnings = append(warnings, fmt.Sprintf("Pushing directly to '%s' branch (protected by '%s') - consider using a pull request", branch, protectedRule))
+		} else {
+			valid = false
+			canPush = false
+			deny(fmt.Sprintf("FORCE PUSH to '%s' (protected by '%s') is highly discouraged and potentially dangerous", branch, protectedRule))
 		}
 	}
 
+	// Check staged files for large files and binary artifacts
+	maxFileBytes := int64(defaultMaxPushFileBytes)
+	if v, ok := args["max_file_bytes"].(float64); ok && v > 0 {
+		maxFileBytes = int64(v)
+	}
+	fileWarnings, fileDenials, blockedFiles := checkStagedFiles(stagedFilesArg(args), maxFileBytes)
+	if len(blockedFiles) > 0 {
+		valid = false
+		canPush = false
+		denials = append(denials, fileDenials...)
+	}
+	warnings = append(warnings, fileWarnings...)
+
 	// Check for unpushed commits
 	if !hasUnpushedCommits && !isForce {
 		warnings = append(warnings, "No unpushed commits detected - push may be unnecessary")
@@ -378,18 +821,24 @@ func (s *MCPServer) handleValidatePush(ctx context.Context, args map[string]inte
 	if branch == "" {
 		valid = false
 		canPush = false
-		warnings = append(warnings, "Branch name is required")
+		deny("Branch name is required")
 	} else if strings.Contains(branch, " ") {
 		valid = false
-		warnings = append(warnings, "Branch name contains spaces - this is unconventional")
+		deny("Branch name contains spaces - this is unconventional")
+	}
+
+	for _, denial := range denials {
+		s.auditDenial(ctx, "guardrail_validate_push", args, "", denial)
 	}
 
 	result := models.PushValidationResult{
-		Valid:    valid,
-		CanPush:  canPush,
-		Warnings: warnings,
-		Branch:   branch,
-		IsForce:  isForce,
+		Valid:         valid,
+		CanPush:       canPush,
+		Warnings:      warnings,
+		Branch:        branch,
+		IsForce:       isForce,
+		ProtectedRule: protectedRule,
+		BlockedFiles:  blockedFiles,
 	}
 
 	return buildToolResult(result, !valid)
@@ -434,9 +883,7 @@ func (s *MCPServer) handleVerifyFileRead(ctx context.Context, args map[string]in
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	session, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	session, exists := s.getSession(sessionToken)
 
 	if !exists {
 		result := models.FileReadVerificationResult{
@@ -502,9 +949,7 @@ func (s *MCPServer) handleRecordFileRead(ctx context.Context, args map[string]in
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		return &mcp.CallToolResult{
@@ -561,9 +1006,7 @@ func (s *MCPServer) handleRecordAttempt(ctx context.Context, args map[string]int
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		return &mcp.CallToolResult{
@@ -590,18 +1033,24 @@ func (s *MCPServer) handleRecordAttempt(ctx context.Context, args map[string]int
 		}, nil
 	}
 
-	// Get three strikes status
-	status, err := s.taskAttemptStore.GetThreeStrikesStatus(ctx, sessionToken, taskID)
+	// Get three strikes status against the limit for this error category
+	status, err := s.taskAttemptStore.GetThreeStrikesStatus(ctx, sessionToken, taskID, errorCategory, s.threeStrikesLimits())
 	if err != nil {
 		slog.Error("Failed to get three strikes status", "error", err)
+		return &mcp.CallToolResult{
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: fmt.Sprintf(`{"valid":false,"error":"Failed to check status: %s"}`, jsonEscapeString(err.Error()))}},
+			IsError: true,
+		}, nil
 	}
 
 	// Build response
-	response := fmt.Sprintf(`{"valid":true,"attempt_number":%d,"strikes_remaining":%d,"should_halt":%t,"max_attempts":%d,"message":"%s"}`,
+	response := fmt.Sprintf(`{"valid":true,"attempt_number":%d,"strikes_remaining":%d,"should_halt":%t,"max_attempts":%d,"error_category":"%s","limit_source":"%s","message":"%s"}`,
 		attempt.AttemptNumber,
 		status.RemainingStrikes,
 		status.ShouldHalt,
 		status.MaxAttempts,
+		jsonEscapeString(status.ErrorCategory),
+		status.LimitSource,
 		jsonEscapeString(fmt.Sprintf("Attempt %d recorded. %d strikes remaining.", attempt.AttemptNumber, status.RemainingStrikes)),
 	)
 
@@ -610,6 +1059,15 @@ func (s *MCPServer) handleRecordAttempt(ctx context.Context, args map[string]int
 	}, nil
 }
 
+// threeStrikesLimits returns the configured attempt limits, or the default limit
+// for every category when no config is loaded
+func (s *MCPServer) threeStrikesLimits() models.ThreeStrikesLimits {
+	if s.config == nil {
+		return models.ThreeStrikesLimits{Default: models.DefaultMaxAttempts}
+	}
+	return s.config.ThreeStrikesLimits()
+}
+
 // handleValidateThreeStrikes checks three strikes status and determines if should halt
 func (s *MCPServer) handleValidateThreeStrikes(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	// Panic recovery to prevent HTTP 500
@@ -621,6 +1079,7 @@ func (s *MCPServer) handleValidateThreeStrikes(ctx context.Context, args map[str
 
 	sessionToken, _ := args["session_token"].(string)
 	taskID, _ := args["task_id"].(string)
+	errorCategory, _ := args["error_category"].(string)
 
 	// Validate required parameters
 	if sessionToken == "" {
@@ -631,9 +1090,7 @@ func (s *MCPServer) handleValidateThreeStrikes(ctx context.Context, args map[str
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		return &mcp.CallToolResult{
@@ -650,8 +1107,8 @@ func (s *MCPServer) handleValidateThreeStrikes(ctx context.Context, args map[str
 		}, nil
 	}
 
-	// Get three strikes status
-	status, err := s.taskAttemptStore.GetThreeStrikesStatus(ctx, sessionToken, taskID)
+	// Get three strikes status; without a category, the limit closest to being reached
+	status, err := s.taskAttemptStore.GetThreeStrikesStatus(ctx, sessionToken, taskID, errorCategory, s.threeStrikesLimits())
 	if err != nil {
 		slog.Error("Failed to get three strikes status", "error", err, "session_token", sessionToken)
 		return &mcp.CallToolResult{
@@ -663,7 +1120,7 @@ func (s *MCPServer) handleValidateThreeStrikes(ctx context.Context, args map[str
 	// Determine message based on status
 	var message string
 	if status.ShouldHalt {
-		message = "Three strikes reached. Escalate to user or halt."
+		message = fmt.Sprintf("Attempt limit reached (%d of %d). Escalate to user or halt.", status.AttemptsCount, status.MaxAttempts)
 	} else if status.AttemptsCount == 0 {
 		message = "No failed attempts. Clear to proceed."
 	} else {
@@ -671,12 +1128,14 @@ func (s *MCPServer) handleValidateThreeStrikes(ctx context.Context, args map[str
 	}
 
 	// Build response
-	response := fmt.Sprintf(`{"valid":true,"halt":%t,"attempts_count":%d,"max_attempts":%d,"should_escalate":%t,"strikes_remaining":%d,"message":"%s"}`,
+	response := fmt.Sprintf(`{"valid":true,"halt":%t,"attempts_count":%d,"max_attempts":%d,"should_escalate":%t,"strikes_remaining":%d,"error_category":"%s","limit_source":"%s","message":"%s"}`,
 		status.ShouldHalt,
 		status.AttemptsCount,
 		status.MaxAttempts,
 		status.ShouldEscalate,
 		status.RemainingStrikes,
+		jsonEscapeString(status.ErrorCategory),
+		status.LimitSource,
 		jsonEscapeString(message),
 	)
 
@@ -710,9 +1169,7 @@ func (s *MCPServer) handleResetAttempts(ctx context.Context, args map[string]int
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		return &mcp.CallToolResult{
@@ -730,8 +1187,7 @@ func (s *MCPServer) handleResetAttempts(ctx context.Context, args map[string]int
 	}
 
 	// Get current count before resolving
-	status, _ := s.taskAttemptStore.GetThreeStrikesStatus(ctx, sessionToken, taskID)
-	attemptsCleared := status.AttemptsCount
+	attemptsCleared, _ := s.taskAttemptStore.GetRecentAttemptCount(ctx, sessionToken, taskID)
 
 	// Resolve attempts
 	resolveErr := s.taskAttemptStore.ResolveAttempts(ctx, sessionToken, taskID)
@@ -755,6 +1211,9 @@ func (s *MCPServer) handleResetAttempts(ctx context.Context, args map[string]int
 	}, nil
 }
 
+// defaultErrorRateThreshold is the error rate above which check_halt_conditions halts
+const defaultErrorRateThreshold = 0.5
+
 // handleCheckHaltConditions checks if halt conditions should be triggered
 func (s *MCPServer) handleCheckHaltConditions(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
 	// Panic recovery to prevent HTTP 500
@@ -776,9 +1235,7 @@ func (s *MCPServer) handleCheckHaltConditions(ctx context.Context, args map[stri
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		return &mcp.CallToolResult{
@@ -795,7 +1252,7 @@ func (s *MCPServer) handleCheckHaltConditions(ctx context.Context, args map[stri
 	// Check 1: Check for three strikes status (if store is available)
 	taskID, _ := args["task_id"].(string)
 	if s.taskAttemptStore != nil {
-		threeStrikesStatus, err := s.taskAttemptStore.GetThreeStrikesStatus(ctx, sessionToken, taskID)
+		threeStrikesStatus, err := s.taskAttemptStore.GetThreeStrikesStatus(ctx, sessionToken, taskID, "", s.threeStrikesLimits())
 		if err == nil && threeStrikesStatus.ShouldHalt {
 			haltReasons = append(haltReasons, "Three strikes reached")
 			severity = "high"
@@ -803,17 +1260,18 @@ func (s *MCPServer) handleCheckHaltConditions(ctx context.Context, args map[stri
 		}
 	}
 
-	// Check 2: Check for critical halt events
-	haltStore := database.NewHaltEventStore(s.db)
-	criticalEvents, err := haltStore.GetCriticalPending(ctx, sessionToken)
-	if err == nil && len(criticalEvents) > 0 {
+	// Check 2: Check for critical halt events. Critical outranks every other
+	// condition, so it overrides any severity set above.
+	if s.haltEvents != nil {
+		criticalEvents, err := s.haltEvents.GetCriticalPending(ctx, sessionToken)
+		if err != nil {
+			slog.Error("Failed to load critical halt events", "error", err, "session_token", sessionToken)
+		}
 		for _, event := range criticalEvents {
 			if event.Severity == string(models.HaltSeverityCritical) {
 				haltReasons = append(haltReasons, fmt.Sprintf("Critical halt: %s - %s", event.HaltType, event.Description))
-				if severity == "" || severity == "low" {
-					severity = "critical"
-					action = "Immediate halt required"
-				}
+				severity = string(models.HaltSeverityCritical)
+				action = "Immediate halt required"
 			}
 		}
 	}
@@ -829,9 +1287,13 @@ func (s *MCPServer) handleCheckHaltConditions(ctx context.Context, args map[stri
 			}
 		}
 
-		// Check for error rate
-		if errorRate, exists := contextData["error_rate"].(float64); exists && errorRate < 0.5 {
-			haltReasons = append(haltReasons, fmt.Sprintf("High error rate: %.0f%%", errorRate*100))
+		// Check for error rate above the threshold (default 50%)
+		threshold := defaultErrorRateThreshold
+		if t, ok := contextData["error_rate_threshold"].(float64); ok && t > 0 && t <= 1 {
+			threshold = t
+		}
+		if errorRate, exists := contextData["error_rate"].(float64); exists && errorRate > threshold {
+			haltReasons = append(haltReasons, fmt.Sprintf("High error rate: %.0f%% (threshold %.0f%%)", errorRate*100, threshold*100))
 			if severity == "" || severity == "low" || severity == "medium" {
 				severity = "high"
 				action = "Halt and review errors"
@@ -903,7 +1365,6 @@ func (s *MCPServer) handleRecordHalt(ctx context.Context, args map[string]interf
 	haltType, _ := args["halt_type"].(string)
 	description, _ := args["description"].(string)
 	severity, _ := args["severity"].(string)
-	contextData, _ := args["context"].(interface{})
 
 	// Validate required parameters
 	if sessionToken == "" {
@@ -920,6 +1381,19 @@ func (s *MCPServer) handleRecordHalt(ctx context.Context, args map[string]interf
 		}, nil
 	}
 
+	// context is optional, but when supplied it must be a JSON object
+	contextMap := map[string]interface{}{}
+	if raw, ok := args["context"]; ok && raw != nil {
+		cm, ok := raw.(map[string]interface{})
+		if !ok {
+			return &mcp.CallToolResult{
+				Content: []interface{}{mcp.TextContent{Type: "text", Text: `{"success":false,"error":"context must be an object"}`}},
+				IsError: true,
+			}, nil
+		}
+		contextMap = cm
+	}
+
 	if description == "" {
 		description = "Unspecified halt condition"
 	}
@@ -929,9 +1403,7 @@ func (s *MCPServer) handleRecordHalt(ctx context.Context, args map[string]interf
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		return &mcp.CallToolResult{
@@ -941,24 +1413,15 @@ func (s *MCPServer) handleRecordHalt(ctx context.Context, args map[string]interf
 	}
 
 	// Check if database is available
-	if s.db == nil {
+	if s.haltEvents == nil {
 		return &mcp.CallToolResult{
 			Content: []interface{}{mcp.TextContent{Type: "text", Text: `{"success":false,"error":"Database not available"}`}},
 			IsError: true,
 		}, nil
 	}
 
-	// Safe type assertion for contextData
-	var contextMap map[string]interface{}
-	if contextData != nil {
-		if cm, ok := contextData.(map[string]interface{}); ok {
-			contextMap = cm
-		}
-	}
-
 	// Record the halt event
-	haltStore := database.NewHaltEventStore(s.db)
-	recordID, haltErr := haltStore.Create(ctx, sessionToken, haltType, severity, description, contextMap)
+	recordID, haltErr := s.haltEvents.Create(ctx, sessionToken, haltType, description, severity, contextMap)
 	if haltErr != nil {
 		slog.Error("Failed to record halt", "error", haltErr, "session_token", sessionToken)
 		return &mcp.CallToolResult{
@@ -967,6 +1430,10 @@ func (s *MCPServer) handleRecordHalt(ctx context.Context, args map[string]interf
 		}, nil
 	}
 
+	if s.audit != nil {
+		s.audit.LogHalt(ctx, audit.EventHaltRecorded, sessionToken, recordID.ID.String(), haltType, severity, description)
+	}
+
 	// Return success confirmation
 	response := fmt.Sprintf(`{"success":true,"halt_id":"%s","recorded_at":"%s","status":"recorded"}`,
 		recordID.ID,
@@ -1004,9 +1471,7 @@ func (s *MCPServer) handleAcknowledgeHalt(ctx context.Context, args map[string]i
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		return &mcp.CallToolResult{
@@ -1015,18 +1480,25 @@ func (s *MCPServer) handleAcknowledgeHalt(ctx context.Context, args map[string]i
 		}, nil
 	}
 
-	// Get UUID from halt_id
-	haltUUID := uuid.UUID{}
-	if err := haltUUID.UnmarshalBinary([]byte(haltID)); err != nil {
+	// halt_id is the textual UUID returned by guardrail_record_halt
+	haltUUID, err := uuid.Parse(haltID)
+	if err != nil {
 		return &mcp.CallToolResult{
 			Content: []interface{}{mcp.TextContent{Type: "text", Text: `{"success":false,"error":"Invalid halt_id format"}`}},
 			IsError: true,
 		}, nil
 	}
 
+	// Check if database is available
+	if s.haltEvents == nil {
+		return &mcp.CallToolResult{
+			Content: []interface{}{mcp.TextContent{Type: "text", Text: `{"success":false,"error":"Database not available"}`}},
+			IsError: true,
+		}, nil
+	}
+
 	// Acknowledge the halt event
-	haltStore := database.NewHaltEventStore(s.db)
-	_, err := haltStore.Acknowledge(ctx, haltUUID, resolution)
+	halt, err := s.haltEvents.Acknowledge(ctx, haltUUID, resolution)
 	if err != nil {
 		slog.Error("Failed to acknowledge halt", "error", err, "halt_id", haltID)
 		return &mcp.CallToolResult{
@@ -1035,6 +1507,10 @@ func (s *MCPServer) handleAcknowledgeHalt(ctx context.Context, args map[string]i
 		}, nil
 	}
 
+	if s.audit != nil {
+		s.audit.LogHalt(ctx, audit.EventHaltAcked, sessionToken, haltID, halt.HaltType, halt.Severity, resolution)
+	}
+
 	// Return success confirmation
 	response := fmt.Sprintf(`{"success":true,"halt_id":"%s","acknowledged_at":"%s","resolution":"%s"}`,
 		haltID,
@@ -1090,9 +1566,7 @@ func (s *MCPServer) handleValidateProductionFirst(ctx context.Context, args map[
 	codeType := models.CodeType(codeTypeStr)
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		result := models.ProductionCodeValidationResult{
@@ -1199,9 +1673,7 @@ func (s *MCPServer) handleDetectFeatureCreep(ctx context.Context, args map[strin
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		result := models.FeatureCreepDetectionResult{
@@ -1218,13 +1690,77 @@ func (s *MCPServer) handleDetectFeatureCreep(ctx context.Context, args map[strin
 		return buildToolResult(result, true)
 	}
 
+	thresholds, err := featureCreepThresholdsArg(args["thresholds"])
+	if err != nil {
+		result := models.FeatureCreepDetectionResult{
+			CreepDetected:  false,
+			Recommendation: err.Error(),
+			Thresholds:     thresholds,
+		}
+		return buildToolResult(result, true)
+	}
+
+	language, _ := args["language"].(string)
+	lang, err := patternsForLanguage(language)
+	if err != nil {
+		result := models.FeatureCreepDetectionResult{
+			CreepDetected:  false,
+			Recommendation: err.Error(),
+			Thresholds:     thresholds,
+		}
+		return buildToolResult(result, true)
+	}
+
 	// Parse and analyze the git diff
-	result := detectFeatureCreep(gitDiff, changeDescription, isNewFile, filePath)
+	result := detectFeatureCreep(gitDiff, changeDescription, isNewFile, filePath, thresholds, lang)
 	return buildToolResult(result, result.CreepDetected)
 }
 
-// detectFeatureCreep analyzes git diff for feature creep patterns
-func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool, filePath string) models.FeatureCreepDetectionResult {
+// defaultFeatureCreepThresholds are the limits used for any threshold the caller
+// does not set
+var defaultFeatureCreepThresholds = models.FeatureCreepThresholds{
+	MaxAdditions:     100,
+	NewFileAdditions: 50,
+	MaxNewFunctions:  1,
+	MaxNewImports:    3,
+}
+
+// featureCreepThresholdsArg merges the optional thresholds argument over the
+// defaults. Each value must be a non-negative whole number.
+func featureCreepThresholdsArg(raw interface{}) (models.FeatureCreepThresholds, error) {
+	thresholds := defaultFeatureCreepThresholds
+	if raw == nil {
+		return thresholds, nil
+	}
+	values, ok := raw.(map[string]interface{})
+	if !ok {
+		return thresholds, fmt.Errorf("thresholds must be an object")
+	}
+
+	fields := map[string]*int{
+		"max_additions":      &thresholds.MaxAdditions,
+		"new_file_additions": &thresholds.NewFileAdditions,
+		"max_new_functions":  &thresholds.MaxNewFunctions,
+		"max_new_imports":    &thresholds.MaxNewImports,
+	}
+	for key, value := range values {
+		field, known := fields[key]
+		if !known {
+			return thresholds, fmt.Errorf("unknown threshold %q", key)
+		}
+		n, ok := value.(float64)
+		if !ok || n < 0 || n != float64(int(n)) {
+			return thresholds, fmt.Errorf("threshold %s must be a non-negative integer", key)
+		}
+		*field = int(n)
+	}
+	return thresholds, nil
+}
+
+// detectFeatureCreep analyzes git diff for feature creep patterns, recognising
+// functions, types and imports with the patterns of lang. A count is only reported
+// once it exceeds its threshold.
+func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool, filePath string, thresholds models.FeatureCreepThresholds, lang languagePatterns) models.FeatureCreepDetectionResult {
 	violations := []models.FeatureCreepViolation{}
 	additions := 0
 	deletions := 0
@@ -1239,10 +1775,7 @@ func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool
 	lines := strings.Split(gitDiff, "\n")
 
 	// Precompile regex patterns
-	funcPattern := regexp.MustCompile(`^\+.*\bfunc\s+(\w+\s*)?\(`)
-	importPattern := regexp.MustCompile(`^\+import\s+`)
 	thirdPartyImportPattern := regexp.MustCompile(`^\+.*import.*["'][^"'/]+/[^"']+["']`)
-	classPattern := regexp.MustCompile(`^\+.*\b(class|struct|interface)\s+\w+`)
 	endpointPattern := regexp.MustCompile(`^\+.*\b(http|endpoint|route|api|REST)\b`)
 	refactorPattern := regexp.MustCompile(`(?i)(refactor|rename|restructure|reorganize)`)
 	improvePattern := regexp.MustCompile(`(?i)\b(better|improved|optimized|enhanced|cleaned|simplified)\b`)
@@ -1252,14 +1785,15 @@ func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool
 	for _, line := range lines {
 		if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
 			additions++
+			code := line[1:]
 
 			// Check for new functions
-			if funcPattern.MatchString(line) {
+			if lang.isFunction(code) {
 				newFunctions++
 			}
 
 			// Check for new imports
-			if importPattern.MatchString(line) {
+			if lang.imp.MatchString(code) {
 				newImports++
 				// Check for third-party imports
 				if thirdPartyImportPattern.MatchString(line) {
@@ -1272,7 +1806,7 @@ func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool
 			}
 
 			// Check for new classes/structs
-			if classPattern.MatchString(line) {
+			if lang.class.MatchString(code) {
 				newClasses++
 			}
 
@@ -1308,20 +1842,20 @@ func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool
 	// Apply detection rules
 
 	// Rule: New file with substantial additions
-	if isNewFile && additions > 50 {
+	if isNewFile && additions > thresholds.NewFileAdditions {
 		violations = append(violations, models.FeatureCreepViolation{
 			Type:     "large_addition",
 			Severity: "warning",
-			Message:  fmt.Sprintf("New file with %d additions - potential feature creep", additions),
+			Message:  fmt.Sprintf("New file with %d additions (limit %d) - potential feature creep", additions, thresholds.NewFileAdditions),
 		})
 	}
 
 	// Rule: Multiple new functions
-	if newFunctions > 1 {
+	if newFunctions > thresholds.MaxNewFunctions {
 		violations = append(violations, models.FeatureCreepViolation{
 			Type:     "new_feature",
 			Severity: "warning",
-			Message:  fmt.Sprintf("Multiple new functions added (%d)", newFunctions),
+			Message:  fmt.Sprintf("New functions added (%d, limit %d)", newFunctions, thresholds.MaxNewFunctions),
 		})
 	}
 
@@ -1344,11 +1878,11 @@ func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool
 	}
 
 	// Rule: Large additions
-	if additions > 100 {
+	if additions > thresholds.MaxAdditions {
 		violations = append(violations, models.FeatureCreepViolation{
 			Type:     "large_addition",
 			Severity: "warning",
-			Message:  fmt.Sprintf("Large number of additions: %d lines", additions),
+			Message:  fmt.Sprintf("Large number of additions: %d lines (limit %d)", additions, thresholds.MaxAdditions),
 		})
 	}
 
@@ -1371,11 +1905,11 @@ func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool
 	}
 
 	// Rule: Excessive imports
-	if newImports > 3 {
+	if newImports > thresholds.MaxNewImports {
 		violations = append(violations, models.FeatureCreepViolation{
 			Type:     "new_import",
 			Severity: "warning",
-			Message:  fmt.Sprintf("Many new imports added (%d)", newImports),
+			Message:  fmt.Sprintf("Many new imports added (%d, limit %d)", newImports, thresholds.MaxNewImports),
 		})
 	}
 
@@ -1426,6 +1960,7 @@ func detectFeatureCreep(gitDiff string, changeDescription string, isNewFile bool
 			Deletions: deletions,
 		},
 		Recommendation: recommendation,
+		Thresholds:     thresholds,
 	}
 }
 
@@ -1458,9 +1993,7 @@ func (s *MCPServer) handleVerifyFixesIntact(ctx context.Context, args map[string
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		result := models.FixVerificationResult{
@@ -1651,6 +2184,17 @@ func (s *MCPServer) handleValidateExactReplacement(ctx context.Context, args map
 		return buildToolResult(result, true)
 	}
 
+	language, _ := args["language"].(string)
+	lang, err := patternsForLanguage(language)
+	if err != nil {
+		result := models.ExactReplacementValidationResult{
+			ExactMatch:     false,
+			Violations:     []models.ExactReplacementViolation{{Type: "validation_error", Severity: "error", Message: err.Error()}},
+			Recommendation: "Invalid input - unsupported language",
+		}
+		return buildToolResult(result, true)
+	}
+
 	// If original_content is empty and modified_content is empty or not provided,
 	// it's not an error but should be flagged as no validation needed
 	if originalContent == "" {
@@ -1682,9 +2226,7 @@ func (s *MCPServer) handleValidateExactReplacement(ctx context.Context, args map
 	}
 
 	// Validate session exists
-	s.sessionsMu.RLock()
-	_, exists := s.sessions[sessionToken]
-	s.sessionsMu.RUnlock()
+	exists := s.sessionExists(sessionToken)
 
 	if !exists {
 		result := models.ExactReplacementValidationResult{
@@ -1696,12 +2238,13 @@ func (s *MCPServer) handleValidateExactReplacement(ctx context.Context, args map
 	}
 
 	// Analyze the diff between original and modified content
-	result := detectExactReplacementViolations(originalContent, actualContent, replacementType)
+	result := detectExactReplacementViolations(originalContent, actualContent, replacementType, lang)
 	return buildToolResult(result, !result.ExactMatch)
 }
 
-// detectExactReplacementViolations analyzes content differences for violations
-func detectExactReplacementViolations(originalContent, actualContent, replacementType string) models.ExactReplacementValidationResult {
+// detectExactReplacementViolations analyzes content differences for violations,
+// recognising functions, type hints and imports with the patterns of lang
+func detectExactReplacementViolations(originalContent, actualContent, replacementType string, lang languagePatterns) models.ExactReplacementValidationResult {
 	violations := []models.ExactReplacementViolation{}
 	additions := 0
 	deletions := 0
@@ -1721,47 +2264,28 @@ func detectExactReplacementViolations(originalContent, actualContent, replacemen
 	actualLines := strings.Split(actualContent, "\n")
 
 	// Precompile regex patterns
-	importPattern := regexp.MustCompile(`^\+*\s*import\s+`)
-	typeHintPattern := regexp.MustCompile(`^\+*\s*.*:\s*\w+`) // e.g., name: Type
 	debugPattern := regexp.MustCompile(`^\+*\s*.*(fmt\.Print|console\.log|println|echo)`)
-	funcPattern := regexp.MustCompile(`^\+*\s*\b(func|def|function)\s+\w+`)
 	commentPattern := regexp.MustCompile(`^[-+]\s*//.*`)
 	variableRenamePattern := regexp.MustCompile(`^\+*\s*.*=.*//\s*renamed`)
 	formattingPattern := regexp.MustCompile(`^\+*\s*\s*$`) // Lines with only whitespace changes
 	reorderPattern := regexp.MustCompile(`^[-+]\s*package\s+|^\+*\s*package\s+`)
 
-	// Calculate line-by-line diff summary using a simple line comparison
-	// This is a simplified diff - in production you might use a proper diff library
-	minLines := len(originalLines)
-	if len(actualLines) < minLines {
-		minLines = len(actualLines)
-	}
-
-	// Track changes line by line
-	contentChanged := false
-	for i := 0; i < minLines; i++ {
-		if originalLines[i] != actualLines[i] {
-			contentChanged = true
-			// Count as modification (both add and delete)
+	// Line-level diff, so that duplicate and moved lines count as real edits
+	addedLines := []string{}
+	removedLines := []string{}
+	for _, edit := range diffLines(originalLines, actualLines) {
+		switch edit.op {
+		case lineAdded:
 			additions++
+			addedLines = append(addedLines, edit.text)
+		case lineRemoved:
 			deletions++
+			removedLines = append(removedLines, edit.text)
 		}
 	}
 
-	// Add extra lines
-	if len(actualLines) > minLines {
-		additions += len(actualLines) - minLines
-		contentChanged = true
-	}
-
-	// Removed lines
-	if len(originalLines) > minLines {
-		deletions += len(originalLines) - minLines
-		contentChanged = true
-	}
-
 	// If no content changes detected, return exact match
-	if !contentChanged && additions == 0 && deletions == 0 {
+	if additions == 0 && deletions == 0 {
 		return models.ExactReplacementValidationResult{
 			ExactMatch:     true,
 			Violations:     []models.ExactReplacementViolation{},
@@ -1770,42 +2294,12 @@ func detectExactReplacementViolations(originalContent, actualContent, replacemen
 		}
 	}
 
-	// Analyze actual content for violations
-	// Split into added and removed lines
-	addedLines := []string{}
-	removedLines := []string{}
-
-	// Simple diff calculation - find adds and removes
-	// In production, consider using a proper diff algorithm
-	originalSet := make(map[string]bool)
-	for _, line := range originalLines {
-		if strings.TrimSpace(line) != "" {
-			originalSet[line] = true
-		}
-	}
-
-	actualSet := make(map[string]bool)
-	for _, line := range actualLines {
-		if strings.TrimSpace(line) != "" {
-			actualSet[line] = true
-			if !originalSet[line] {
-				addedLines = append(addedLines, line)
-			}
-		}
-	}
-
-	for _, line := range originalLines {
-		if strings.TrimSpace(line) != "" && !actualSet[line] {
-			removedLines = append(removedLines, line)
-		}
-	}
-
 	// Check for violations in added lines
 	var criticalCount, warningCount int
 
 	for _, line := range addedLines {
 		// Check for new imports
-		if importPattern.MatchString(line) {
+		if lang.imp.MatchString(line) {
 			violations = append(violations, models.ExactReplacementViolation{
 				Type:     "new_import",
 				Severity: "warning",
@@ -1815,7 +2309,7 @@ func detectExactReplacementViolations(originalContent, actualContent, replacemen
 		}
 
 		// Check for type hint changes (particularly relevant for Python/JavaScript)
-		if typeHintPattern.MatchString(line) && !strings.Contains(line, "//") {
+		if lang.hasTypeHint(line) && !strings.Contains(line, "//") {
 			violations = append(violations, models.ExactReplacementViolation{
 				Type:     "type_change",
 				Severity: "warning",
@@ -1835,7 +2329,7 @@ func detectExactReplacementViolations(originalContent, actualContent, replacemen
 		}
 
 		// Check for new functions
-		if funcPattern.MatchString(line) {
+		if lang.isFunction(line) {
 			violations = append(violations, models.ExactReplacementViolation{
 				Type:     "extra_function",
 				Severity: "error",
@@ -1888,7 +2382,7 @@ func detectExactReplacementViolations(originalContent, actualContent, replacemen
 	// Check removed lines for significant deletions
 	for _, line := range removedLines {
 		// Check if a function was removed
-		if funcPattern.MatchString(line) {
+		if lang.isFunction(line) {
 			violations = append(violations, models.ExactReplacementViolation{
 				Type:     "function_removed",
 				Severity: "error",
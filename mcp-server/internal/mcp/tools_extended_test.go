@@ -0,0 +1,1002 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/audit"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// TestValidateConventionalCommit tests commit message validation against default and custom configurations
+func TestValidateConventionalCommit(t *testing.T) {
+	custom := models.CommitValidationConfig{
+		AllowedTypes:     []string{"feat", "fix", "hotfix", "deps", "wip"},
+		MaxSubjectLength: 50,
+	}
+
+	tests := []struct {
+		name         string
+		message      string
+		cfg          models.CommitValidationConfig
+		wantValid    bool
+		wantRejected string
+		wantIssue    string
+	}{
+		{name: "default type passes", message: "feat(api): add health endpoint", cfg: defaultCommitValidationConfig(), wantValid: true},
+		{name: "custom type rejected by defaults", message: "hotfix: patch login crash", cfg: defaultCommitValidationConfig(), wantRejected: "hotfix", wantIssue: "Invalid type 'hotfix'"},
+		{name: "custom type allowed", message: "hotfix: patch login crash", cfg: custom, wantValid: true},
+		{name: "type outside custom set rejected", message: "docs: update readme", cfg: custom, wantRejected: "docs", wantIssue: "hotfix"},
+		{name: "empty allowed types accepts any", message: "anything: goes here", cfg: models.CommitValidationConfig{AllowedTypes: []string{}, AnyType: true, MaxSubjectLength: 72}, wantValid: true},
+		{name: "custom subject length", message: "feat: " + strings.Repeat("a", 50), cfg: custom, wantIssue: "exceeds 50 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateConventionalCommit(tt.message, tt.cfg)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (issues: %v)", result.Valid, tt.wantValid, result.Issues)
+			}
+			if result.RejectedType != tt.wantRejected {
+				t.Errorf("RejectedType = %q, want %q", result.RejectedType, tt.wantRejected)
+			}
+			if tt.wantIssue != "" && !strings.Contains(strings.Join(result.Issues, "; "), tt.wantIssue) {
+				t.Errorf("Issues = %v, want one containing %q", result.Issues, tt.wantIssue)
+			}
+			if result.Config == nil || result.Config.MaxSubjectLength != tt.cfg.MaxSubjectLength {
+				t.Errorf("Config = %+v, want effective config %+v", result.Config, tt.cfg)
+			}
+		})
+	}
+}
+
+// TestHandleValidateCommit_Config tests that commit validation arguments are applied and echoed back
+func TestHandleValidateCommit_Config(t *testing.T) {
+	s := mockMCPServer()
+
+	tests := []struct {
+		name        string
+		args        map[string]interface{}
+		wantValid   bool
+		wantAnyType bool
+		wantMaxLen  int
+	}{
+		{
+			name:       "defaults when absent",
+			args:       map[string]interface{}{"message": "wip: half done"},
+			wantValid:  false,
+			wantMaxLen: 72,
+		},
+		{
+			name:       "custom types and length",
+			args:       map[string]interface{}{"message": "wip: half done", "allowed_types": []interface{}{"wip"}, "max_subject_length": float64(100)},
+			wantValid:  true,
+			wantMaxLen: 100,
+		},
+		{
+			name:        "empty allowed types accepts any",
+			args:        map[string]interface{}{"message": "wip: half done", "allowed_types": []interface{}{}},
+			wantValid:   true,
+			wantAnyType: true,
+			wantMaxLen:  72,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateCommit(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateCommit() error = %v", err)
+			}
+			var result models.CommitValidationResult
+			if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+				t.Fatalf("failed to decode result: %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (issues: %v)", result.Valid, tt.wantValid, result.Issues)
+			}
+			if result.Config == nil {
+				t.Fatal("Config not returned")
+			}
+			if result.Config.AnyType != tt.wantAnyType || result.Config.MaxSubjectLength != tt.wantMaxLen {
+				t.Errorf("Config = %+v, want any_type=%v max_subject_length=%d", result.Config, tt.wantAnyType, tt.wantMaxLen)
+			}
+		})
+	}
+}
+
+// TestMatchScope tests prefix, glob and regex scope matching
+func TestMatchScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		scope   string
+		mode    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "prefix child", path: "src/api/handler.go", scope: "src/api", mode: "prefix", want: true},
+		{name: "prefix trailing slash", path: "src/api/handler.go", scope: "src/api/", mode: "prefix", want: true},
+		{name: "prefix sibling with shared prefix", path: "src/api-internal/x.go", scope: "src/api", mode: "prefix", want: false},
+		{name: "prefix cleans traversal", path: "src/api/../web/app.go", scope: "src/api", mode: "prefix", want: false},
+		{name: "prefix current directory", path: "src/api/handler.go", scope: ".", mode: "prefix", want: true},
+		{name: "prefix current directory rejects parent", path: "../other/main.go", scope: ".", mode: "prefix", want: false},
+		{name: "glob single segment", path: "src/main.go", scope: "src/*.go", mode: "glob", want: true},
+		{name: "glob star does not cross directories", path: "src/api/main.go", scope: "src/*.go", mode: "glob", want: false},
+		{name: "glob doublestar nested", path: "src/api/v1/handler.go", scope: "src/**/*.go", mode: "glob", want: true},
+		{name: "glob doublestar zero segments", path: "src/main.go", scope: "src/**/*.go", mode: "glob", want: true},
+		{name: "glob doublestar wrong extension", path: "src/api/readme.md", scope: "src/**/*.go", mode: "glob", want: false},
+		{name: "glob trailing doublestar", path: "docs/guide/intro.md", scope: "docs/**", mode: "glob", want: true},
+		{name: "glob invalid pattern", path: "src/main.go", scope: "src/[a-.go", mode: "glob", wantErr: true},
+		{name: "regex match", path: "src/web/index.ts", scope: "^src/(api|web)/", mode: "regex", want: true},
+		{name: "regex no match", path: "src/cli/main.go", scope: "^src/(api|web)/", mode: "regex", want: false},
+		{name: "regex invalid pattern", path: "src/api/x.go", scope: "^src/(api", mode: "regex", wantErr: true},
+		{name: "unknown mode", path: "src/api/x.go", scope: "src", mode: "fuzzy", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := matchScope(tt.path, tt.scope, tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("matchScope() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("matchScope(%q, %q, %q) = %v, want %v", tt.path, tt.scope, tt.mode, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHandleValidateScope_PrefixBoundary tests that the default prefix mode only accepts
+// the scope directory itself and paths below it, never siblings sharing a name prefix
+func TestHandleValidateScope_PrefixBoundary(t *testing.T) {
+	s := mockMCPServer()
+
+	tests := []struct {
+		name      string
+		path      string
+		scope     string
+		wantValid bool
+	}{
+		{name: "sibling with dash suffix", path: "src/api-internal/x", scope: "src/api", wantValid: false},
+		{name: "sibling with version suffix", path: "src/apiv2/secret.go", scope: "src/api", wantValid: false},
+		{name: "file sharing the scope prefix", path: "src/api.go", scope: "src/api", wantValid: false},
+		{name: "exact match", path: "src/api", scope: "src/api", wantValid: true},
+		{name: "exact match after clean", path: "./src/api/", scope: "src/api", wantValid: true},
+		{name: "child of scope", path: "src/api/v1/users.go", scope: "src/api", wantValid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateScope(context.Background(), map[string]interface{}{
+				"file_path":        tt.path,
+				"authorized_scope": tt.scope,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var result models.ScopeValidationResult
+			if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+				t.Fatalf("failed to parse result: %v", err)
+			}
+			if result.Valid != tt.wantValid || result.OutsideScope == tt.wantValid {
+				t.Errorf("%s in %s: valid = %v, outside_scope = %v, want valid %v", tt.path, tt.scope, result.Valid, result.OutsideScope, tt.wantValid)
+			}
+			if res.IsError == tt.wantValid {
+				t.Errorf("IsError = %v, want %v", res.IsError, !tt.wantValid)
+			}
+		})
+	}
+}
+
+// TestHandleValidateScope_InvalidPattern tests that a bad pattern is a validation error, not an allow
+func TestHandleValidateScope_InvalidPattern(t *testing.T) {
+	s := mockMCPServer()
+
+	result, err := s.handleValidateScope(context.Background(), map[string]interface{}{
+		"file_path":        "src/api/handler.go",
+		"authorized_scope": "src/(api",
+		"scope_mode":       "regex",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Error("expected IsError for an invalid regex scope")
+	}
+
+	var parsed models.ScopeValidationResult
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &parsed); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if parsed.Valid || !strings.Contains(parsed.Message, "invalid regex scope") {
+		t.Errorf("got %+v, want invalid regex scope error", parsed)
+	}
+}
+
+// TestMatchProtectedBranch tests exact, wildcard and case-insensitive protected-branch rules
+func TestMatchProtectedBranch(t *testing.T) {
+	custom := []string{"develop", "trunk", "env/*"}
+
+	tests := []struct {
+		name   string
+		branch string
+		rules  []string
+		want   string
+	}{
+		{name: "default main", branch: "main", rules: defaultProtectedBranches, want: "main"},
+		{name: "default release sub-branch", branch: "release/2.1", rules: defaultProtectedBranches, want: "release/*"},
+		{name: "default feature branch", branch: "feature/login", rules: defaultProtectedBranches, want: ""},
+		{name: "custom exact", branch: "develop", rules: custom, want: "develop"},
+		{name: "custom case-insensitive", branch: "Trunk", rules: custom, want: "trunk"},
+		{name: "custom wildcard", branch: "ENV/staging", rules: custom, want: "env/*"},
+		{name: "wildcard needs a sub-branch", branch: "env", rules: custom, want: ""},
+		{name: "wildcard prefix boundary", branch: "environment/x", rules: custom, want: ""},
+		{name: "custom list replaces defaults", branch: "main", rules: custom, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchProtectedBranch(tt.branch, tt.rules); got != tt.want {
+				t.Errorf("matchProtectedBranch(%q) = %q, want %q", tt.branch, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHandleValidatePush_ProtectedBranches tests that protected_branches overrides the defaults and names the rule
+func TestHandleValidatePush_ProtectedBranches(t *testing.T) {
+	s := mockMCPServer()
+
+	res, err := s.handleValidatePush(context.Background(), map[string]interface{}{
+		"branch":             "env/prod",
+		"is_force":           true,
+		"protected_branches": []interface{}{"develop", "env/*"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result models.PushValidationResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if result.CanPush || result.ProtectedRule != "env/*" {
+		t.Errorf("got can_push=%v protected_rule=%q, want blocked by env/*", result.CanPush, result.ProtectedRule)
+	}
+}
+
+// TestCheckStagedFiles tests large-file and binary-artifact detection for pushes
+func TestCheckStagedFiles(t *testing.T) {
+	tests := []struct {
+		name         string
+		files        []stagedFile
+		maxBytes     int64
+		wantBlocked  []string
+		wantWarnings int
+	}{
+		{name: "small source files pass", files: []stagedFile{{Path: "main.go", Size: 2048}, {Path: "README.md", Size: 900}}, maxBytes: defaultMaxPushFileBytes, wantBlocked: []string{}},
+		{name: "file over default limit", files: []stagedFile{{Path: "data/dump.sql", Size: 6 * 1024 * 1024}}, maxBytes: defaultMaxPushFileBytes, wantBlocked: []string{"data/dump.sql"}, wantWarnings: 1},
+		{name: "custom limit", files: []stagedFile{{Path: "assets/logo.svg", Size: 200 * 1024}}, maxBytes: 100 * 1024, wantBlocked: []string{"assets/logo.svg"}, wantWarnings: 1},
+		{name: "binary extensions warned only", files: []stagedFile{{Path: "dist/app.zip"}, {Path: "demo.MP4", Size: 10}, {Path: "build/out.tar"}, {Path: "fw.bin"}}, maxBytes: defaultMaxPushFileBytes, wantBlocked: []string{}, wantWarnings: 4},
+		{name: "oversized binary is blocked once", files: []stagedFile{{Path: "dist/app.zip", Size: 6 * 1024 * 1024}}, maxBytes: defaultMaxPushFileBytes, wantBlocked: []string{"dist/app.zip"}, wantWarnings: 1},
+		{name: "jars are not artifacts", files: []stagedFile{{Path: "gradle/wrapper/gradle-wrapper.jar", Size: 60 * 1024}}, maxBytes: defaultMaxPushFileBytes, wantBlocked: []string{}},
+		{name: "extension must be a suffix", files: []stagedFile{{Path: "cmd/zipper/main.go", Size: 100}}, maxBytes: defaultMaxPushFileBytes, wantBlocked: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, denials, blocked := checkStagedFiles(tt.files, tt.maxBytes)
+			if strings.Join(blocked, ",") != strings.Join(tt.wantBlocked, ",") {
+				t.Errorf("blocked = %v, want %v", blocked, tt.wantBlocked)
+			}
+			if len(denials) != len(tt.wantBlocked) {
+				t.Errorf("denials = %v, want one per blocked file", denials)
+			}
+			if len(warnings) != tt.wantWarnings {
+				t.Errorf("warnings = %v, want %d", warnings, tt.wantWarnings)
+			}
+		})
+	}
+}
+
+// TestHandleValidatePush_StagedFiles tests that oversized staged files block the push
+// and binary artifacts are only warned about
+func TestHandleValidatePush_StagedFiles(t *testing.T) {
+	s := mockMCPServer()
+
+	res, err := s.handleValidatePush(context.Background(), map[string]interface{}{
+		"branch":               "feature/report",
+		"has_unpushed_commits": true,
+		"protected_branches":   []interface{}{"main"},
+		"max_file_bytes":       float64(1024),
+		"staged_files": []interface{}{
+			map[string]interface{}{"path": "report.go", "size": float64(512)},
+			map[string]interface{}{"path": "fixtures/big.json", "size": float64(4096)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result models.PushValidationResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if result.CanPush || len(result.BlockedFiles) != 1 || result.BlockedFiles[0] != "fixtures/big.json" {
+		t.Errorf("got can_push=%v blocked=%v, want push blocked by fixtures/big.json", result.CanPush, result.BlockedFiles)
+	}
+	if !strings.Contains(strings.Join(result.Warnings, "; "), "fixtures/big.json") {
+		t.Errorf("warnings = %v, want a warning naming the large file", result.Warnings)
+	}
+	// A binary artifact under the size limit is a warning only
+	res, err = s.handleValidatePush(context.Background(), map[string]interface{}{
+		"branch":               "feature/report",
+		"has_unpushed_commits": true,
+		"protected_branches":   []interface{}{"main"},
+		"staged_files":         []interface{}{map[string]interface{}{"path": "dist/report.zip", "size": float64(512)}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result = models.PushValidationResult{}
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !result.CanPush || !result.Valid || len(result.BlockedFiles) != 0 {
+		t.Errorf("got can_push=%v valid=%v blocked=%v, want the push allowed", result.CanPush, result.Valid, result.BlockedFiles)
+	}
+	if !strings.Contains(strings.Join(result.Warnings, "; "), "dist/report.zip") {
+		t.Errorf("warnings = %v, want a warning naming the artifact", result.Warnings)
+	}
+}
+
+// fakeHaltEvents records and serves halt events in memory in place of HaltEventStore
+type fakeHaltEvents struct {
+	events       []*models.HaltEvent
+	acknowledged []uuid.UUID
+}
+
+func (f *fakeHaltEvents) Acknowledge(ctx context.Context, id uuid.UUID, resolution string) (*models.HaltEvent, error) {
+	f.acknowledged = append(f.acknowledged, id)
+	for _, e := range f.events {
+		if e.ID == id {
+			now := time.Now()
+			e.Acknowledged, e.AcknowledgedAt, e.Resolution = true, &now, resolution
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("halt event not found: %s", id)
+}
+
+func (f *fakeHaltEvents) Create(ctx context.Context, sessionID, haltType, description, severity string, contextData map[string]interface{}) (*models.HaltEvent, error) {
+	event := &models.HaltEvent{
+		ID:          uuid.New(),
+		SessionID:   sessionID,
+		HaltType:    haltType,
+		Severity:    severity,
+		Description: description,
+		TriggeredAt: time.Now(),
+		Resolution:  "pending",
+		ContextData: contextData,
+	}
+	f.events = append(f.events, event)
+	return event, nil
+}
+
+func (f *fakeHaltEvents) GetCriticalPending(ctx context.Context, sessionID string) ([]*models.HaltEvent, error) {
+	var pending []*models.HaltEvent
+	for _, e := range f.events {
+		if e.SessionID == sessionID {
+			pending = append(pending, e)
+		}
+	}
+	return pending, nil
+}
+
+func (f *fakeHaltEvents) GetUnacknowledgedBySession(ctx context.Context, sessionID string) ([]*models.HaltEvent, error) {
+	var pending []*models.HaltEvent
+	for _, e := range f.events {
+		if e.SessionID == sessionID && !e.Acknowledged {
+			pending = append(pending, e)
+		}
+	}
+	return pending, nil
+}
+
+// TestHandleCheckHaltConditions_CriticalPending is a regression test: pending critical
+// halt events must halt the session with critical severity
+func TestHandleCheckHaltConditions_CriticalPending(t *testing.T) {
+	s := mockMCPServer()
+	sessionID := "halt-session"
+	s.sessions.Put(context.Background(), &Session{ID: sessionID, CreatedAt: time.Now(), LastActivity: time.Now()})
+	s.haltEvents = &fakeHaltEvents{events: []*models.HaltEvent{
+		{SessionID: sessionID, HaltType: string(models.HaltTypeSecurity), Severity: string(models.HaltSeverityCritical), Description: "credential exposure", Resolution: "pending"},
+		// Not critical: must be ignored even if the store returns it
+		{SessionID: sessionID, HaltType: string(models.HaltTypeSecurity), Severity: string(models.HaltSeverityHigh), Description: "noisy warning", Resolution: "pending"},
+		{SessionID: "other-session", HaltType: string(models.HaltTypeSecurity), Severity: string(models.HaltSeverityCritical), Description: "someone else", Resolution: "pending"},
+	}}
+
+	res, err := s.handleCheckHaltConditions(context.Background(), map[string]interface{}{
+		"session_token": sessionID,
+		"context":       map[string]interface{}{"should_halt": true},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var result struct {
+		Halt     bool     `json:"halt"`
+		Reasons  []string `json:"reasons"`
+		Severity string   `json:"severity"`
+	}
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if !result.Halt || result.Severity != "critical" {
+		t.Fatalf("got halt=%v severity=%q, want halt with critical severity", result.Halt, result.Severity)
+	}
+	reasons := strings.Join(result.Reasons, "; ")
+	if !strings.Contains(reasons, "credential exposure") {
+		t.Errorf("reasons = %v, want the critical event", result.Reasons)
+	}
+	if strings.Contains(reasons, "noisy warning") || strings.Contains(reasons, "someone else") {
+		t.Errorf("reasons = %v, want only this session's critical events", result.Reasons)
+	}
+}
+
+// TestHandleCheckHaltConditions_NoCriticalEvents tests that an empty store does not halt
+func TestHandleCheckHaltConditions_NoCriticalEvents(t *testing.T) {
+	s := mockMCPServer()
+	sessionID := "calm-session"
+	s.sessions.Put(context.Background(), &Session{ID: sessionID, CreatedAt: time.Now(), LastActivity: time.Now()})
+	s.haltEvents = &fakeHaltEvents{}
+
+	res, err := s.handleCheckHaltConditions(context.Background(), map[string]interface{}{"session_token": sessionID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(res.Content[0].(mcp.TextContent).Text, `"halt":false`) {
+		t.Errorf("got %s, want no halt", res.Content[0].(mcp.TextContent).Text)
+	}
+}
+
+// TestHandleValidateTestProdSeparation tests batch separation checks over a mixed changeset
+func TestHandleValidateTestProdSeparation(t *testing.T) {
+	s := mockMCPServer()
+
+	res, err := s.handleValidateTestProdSeparation(context.Background(), map[string]interface{}{
+		"files": []interface{}{
+			map[string]interface{}{
+				"file_path":   "internal/orders/store.go",
+				"environment": "prod",
+				"content":     "db, err := sql.Open(\"pgx\", cfg.DatabaseURL())\n",
+			},
+			map[string]interface{}{
+				"file_path":   "internal/orders/store_test.go",
+				"environment": "test",
+				"content":     "dsn := \"postgres://app@prod_db:5432/orders\"\n",
+			},
+			map[string]interface{}{
+				"file_path":   "internal/orders/fixtures.go",
+				"environment": "prod",
+				"content":     "const dsn = \"postgres://localhost:5433/test_db\"\n",
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.IsError {
+		t.Error("expected IsError for a changeset with violations")
+	}
+
+	var result models.TestProdSeparationBatchResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("failed to parse result: %v", err)
+	}
+	if result.Valid || result.FilesChecked != 3 || result.FilesWithViolations != 2 {
+		t.Fatalf("got valid=%v checked=%d with_violations=%d, want invalid 3/2", result.Valid, result.FilesChecked, result.FilesWithViolations)
+	}
+	if !result.Results[0].Valid {
+		t.Errorf("clean prod file flagged: %v", result.Results[0].Violations)
+	}
+	want := []string{
+		"internal/orders/store_test.go: Test code references production database",
+		"internal/orders/fixtures.go: Production code references test database",
+		"internal/orders/fixtures.go: Production code uses test database port",
+	}
+	if strings.Join(result.Violations, "\n") != strings.Join(want, "\n") {
+		t.Errorf("violations = %q, want %q", result.Violations, want)
+	}
+}
+
+// TestHandleValidateTestProdSeparation_Patterns tests that project and argument patterns replace the built-ins per environment
+func TestHandleValidateTestProdSeparation_Patterns(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".guardrails"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	policy := `{"patterns": {"prod": [{"pattern": "staging\\.example\\.com", "message": "Production code points at staging"}]}}`
+	if err := os.WriteFile(filepath.Join(repo, ".guardrails", "test-prod-separation.json"), []byte(policy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GUARDRAILS_REPO_PATH", repo)
+	s := mockMCPServer()
+
+	files := []interface{}{
+		map[string]interface{}{
+			"file_path":   "internal/orders/client.go",
+			"environment": "prod",
+			"content":     "package orders\n\nconst dsn = \"localhost:5433/test_db\"\nconst api = \"https://staging.example.com\"\n",
+		},
+		map[string]interface{}{
+			"file_path":   "internal/orders/client_test.go",
+			"environment": "test",
+			"content":     "dsn := \"postgres://app@prod_db:5432/orders\"\nseed := \"FIXTURE-PRIVATE\"\n",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		patterns  interface{}
+		wantProd  []models.TestProdSeparationMatch
+		wantTest  []string
+		wantError string
+	}{
+		{
+			name:     "project file replaces prod, built-ins kept for test",
+			wantProd: []models.TestProdSeparationMatch{{Pattern: `staging\.example\.com`, Message: "Production code points at staging", Line: 4}},
+			wantTest: []string{"Test code references production database"},
+		},
+		{
+			name: "argument replaces test",
+			patterns: map[string]interface{}{
+				"test": []interface{}{map[string]interface{}{"pattern": `FIXTURE-\w+`, "message": "Test code uses a private fixture"}},
+			},
+			wantProd: []models.TestProdSeparationMatch{{Pattern: `staging\.example\.com`, Message: "Production code points at staging", Line: 4}},
+			wantTest: []string{"Test code uses a private fixture"},
+		},
+		{
+			name:      "invalid argument pattern",
+			patterns:  map[string]interface{}{"prod": []interface{}{map[string]interface{}{"pattern": "(unclosed"}}},
+			wantError: "patterns.prod[0]: invalid pattern",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateTestProdSeparation(context.Background(), map[string]interface{}{"files": files, "patterns": tt.patterns})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var result models.TestProdSeparationBatchResult
+			if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+				t.Fatalf("failed to parse result: %v", err)
+			}
+			if tt.wantError != "" {
+				if !res.IsError || !strings.Contains(result.Message, tt.wantError) {
+					t.Errorf("message = %q, want %q", result.Message, tt.wantError)
+				}
+				return
+			}
+			if len(result.Results) != 2 {
+				t.Fatalf("results = %+v, want 2", result.Results)
+			}
+			if fmt.Sprint(result.Results[0].Matches) != fmt.Sprint(tt.wantProd) {
+				t.Errorf("prod matches = %+v, want %+v", result.Results[0].Matches, tt.wantProd)
+			}
+			if strings.Join(result.Results[1].Violations, "|") != strings.Join(tt.wantTest, "|") {
+				t.Errorf("test violations = %q, want %q", result.Results[1].Violations, tt.wantTest)
+			}
+		})
+	}
+}
+
+// TestHandleValidateTestProdSeparation_Empty tests that an empty changeset is rejected
+func TestHandleValidateTestProdSeparation_Empty(t *testing.T) {
+	s := mockMCPServer()
+
+	res, err := s.handleValidateTestProdSeparation(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !res.IsError || !strings.Contains(res.Content[0].(mcp.TextContent).Text, "files is required") {
+		t.Errorf("got %s, want files is required error", res.Content[0].(mcp.TextContent).Text)
+	}
+}
+
+// TestHandleCheckHaltConditions_ErrorRate tests that error rates above the threshold halt
+func TestHandleCheckHaltConditions_ErrorRate(t *testing.T) {
+	s := mockMCPServer()
+	sessionID := "error-rate-session"
+	s.sessions.Put(context.Background(), &Session{ID: sessionID, CreatedAt: time.Now(), LastActivity: time.Now()})
+
+	tests := []struct {
+		name     string
+		context  map[string]interface{}
+		wantHalt bool
+	}{
+		{name: "high error rate halts", context: map[string]interface{}{"error_rate": 0.9}, wantHalt: true},
+		{name: "low error rate continues", context: map[string]interface{}{"error_rate": 0.1}, wantHalt: false},
+		{name: "rate at threshold continues", context: map[string]interface{}{"error_rate": 0.5}, wantHalt: false},
+		{name: "custom threshold halts", context: map[string]interface{}{"error_rate": 0.3, "error_rate_threshold": 0.2}, wantHalt: true},
+		{name: "custom threshold continues", context: map[string]interface{}{"error_rate": 0.9, "error_rate_threshold": 0.95}, wantHalt: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleCheckHaltConditions(context.Background(), map[string]interface{}{
+				"session_token": sessionID,
+				"context":       tt.context,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var result struct {
+				Halt    bool     `json:"halt"`
+				Reasons []string `json:"reasons"`
+			}
+			if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+				t.Fatalf("failed to parse result: %v", err)
+			}
+			if result.Halt != tt.wantHalt {
+				t.Errorf("halt = %v, want %v (reasons %v)", result.Halt, tt.wantHalt, result.Reasons)
+			}
+			if tt.wantHalt && !strings.Contains(strings.Join(result.Reasons, "; "), "High error rate") {
+				t.Errorf("reasons = %v, want a high error rate reason", result.Reasons)
+			}
+		})
+	}
+}
+
+// TestHandleRecordHalt_Context tests that context is optional and must be an object when given
+func TestHandleRecordHalt_Context(t *testing.T) {
+	s := mockMCPServer()
+	sessionID := "record-halt-session"
+	s.sessions.Put(context.Background(), &Session{ID: sessionID, CreatedAt: time.Now(), LastActivity: time.Now()})
+
+	tests := []struct {
+		name      string
+		context   interface{}
+		omit      bool
+		wantError bool
+	}{
+		{name: "no context", omit: true},
+		{name: "null context", context: nil},
+		{name: "object context", context: map[string]interface{}{"file": "main.go"}},
+		{name: "array context", context: []interface{}{"main.go"}, wantError: true},
+		{name: "string context", context: "main.go", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeHaltEvents{}
+			s.haltEvents = store
+
+			args := map[string]interface{}{
+				"session_token": sessionID,
+				"halt_type":     string(models.HaltTypeSecurity),
+				"description":   "credential in diff",
+				"severity":      string(models.HaltSeverityCritical),
+			}
+			if !tt.omit {
+				args["context"] = tt.context
+			}
+
+			res, err := s.handleRecordHalt(context.Background(), args)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			text := res.Content[0].(mcp.TextContent).Text
+
+			if tt.wantError {
+				if !res.IsError || !strings.Contains(text, "context must be an object") {
+					t.Errorf("result = %s, want context error", text)
+				}
+				if len(store.events) != 0 {
+					t.Error("halt was recorded despite invalid context")
+				}
+				return
+			}
+
+			if res.IsError {
+				t.Fatalf("result = %s, want success", text)
+			}
+			if len(store.events) != 1 {
+				t.Fatalf("recorded %d halts, want 1", len(store.events))
+			}
+			event := store.events[0]
+			if event.ContextData == nil {
+				t.Error("context data is nil, want an empty map")
+			}
+			if event.Description != "credential in diff" || event.Severity != string(models.HaltSeverityCritical) {
+				t.Errorf("recorded description=%q severity=%q", event.Description, event.Severity)
+			}
+		})
+	}
+}
+
+// TestHandleAcknowledgeHalt_RoundTrip tests acknowledging a halt by the id that
+// guardrail_record_halt returned
+func TestHandleAcknowledgeHalt_RoundTrip(t *testing.T) {
+	s := mockMCPServer()
+	sessionID := "ack-halt-session"
+	s.sessions.Put(context.Background(), &Session{ID: sessionID, CreatedAt: time.Now(), LastActivity: time.Now()})
+	store := &fakeHaltEvents{}
+	s.haltEvents = store
+
+	res, err := s.handleRecordHalt(context.Background(), map[string]interface{}{
+		"session_token": sessionID,
+		"halt_type":     string(models.HaltTypeSecurity),
+		"description":   "credential in diff",
+		"severity":      string(models.HaltSeverityHigh),
+	})
+	if err != nil || res.IsError {
+		t.Fatalf("record halt failed: %v %+v", err, res)
+	}
+	var recorded struct {
+		HaltID string `json:"halt_id"`
+	}
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &recorded); err != nil {
+		t.Fatalf("failed to parse record result: %v", err)
+	}
+
+	res, err = s.handleAcknowledgeHalt(context.Background(), map[string]interface{}{
+		"session_token": sessionID,
+		"halt_id":       recorded.HaltID,
+		"resolution":    string(models.ResolutionResolved),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	text := res.Content[0].(mcp.TextContent).Text
+	if res.IsError || !strings.Contains(text, `"success":true`) {
+		t.Fatalf("acknowledge result = %s, want success", text)
+	}
+	if len(store.acknowledged) != 1 || store.acknowledged[0] != store.events[0].ID {
+		t.Errorf("Acknowledge called with %v, want [%s]", store.acknowledged, store.events[0].ID)
+	}
+
+	// A malformed id is rejected before reaching the store
+	res, _ = s.handleAcknowledgeHalt(context.Background(), map[string]interface{}{
+		"session_token": sessionID,
+		"halt_id":       "not-a-uuid",
+	})
+	if !res.IsError || !strings.Contains(res.Content[0].(mcp.TextContent).Text, "Invalid halt_id format") {
+		t.Errorf("malformed halt_id result = %+v, want invalid format error", res)
+	}
+	if len(store.acknowledged) != 1 {
+		t.Errorf("Acknowledge called %d times, want 1", len(store.acknowledged))
+	}
+}
+
+// TestHandleRecordHalt_AuditStream tests that recording and acknowledging a halt
+// publish audit events to the stream served on /api/events
+func TestHandleRecordHalt_AuditStream(t *testing.T) {
+	s := mockMCPServer()
+	sessionID := "stream-halt-session"
+	s.sessions.Put(context.Background(), &Session{ID: sessionID, CreatedAt: time.Now(), LastActivity: time.Now()})
+	s.haltEvents = &fakeHaltEvents{}
+	s.audit = audit.NewLogger(10)
+	defer s.audit.Stop()
+	events, unsubscribe := s.audit.Subscribe(10)
+	defer unsubscribe()
+
+	next := func() audit.Event {
+		t.Helper()
+		select {
+		case event := <-events:
+			return event
+		case <-time.After(2 * time.Second):
+			t.Fatal("no audit event published")
+			return audit.Event{}
+		}
+	}
+
+	res, err := s.handleRecordHalt(context.Background(), map[string]interface{}{
+		"session_token": sessionID,
+		"halt_type":     string(models.HaltTypeSecurity),
+		"description":   "credential in diff",
+		"severity":      string(models.HaltSeverityCritical),
+	})
+	if err != nil || res.IsError {
+		t.Fatalf("record halt failed: %v %+v", err, res)
+	}
+	var recorded struct {
+		HaltID string `json:"halt_id"`
+	}
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &recorded); err != nil {
+		t.Fatalf("failed to parse record result: %v", err)
+	}
+
+	event := next()
+	if event.Type != audit.EventHaltRecorded || event.Severity != audit.SevCritical || event.Resource != string(models.HaltTypeSecurity) {
+		t.Errorf("recorded event = %+v, want a critical %s event for %s", event, audit.EventHaltRecorded, models.HaltTypeSecurity)
+	}
+	if event.Details["halt_id"] != recorded.HaltID || event.Details["description"] != "credential in diff" {
+		t.Errorf("recorded event details = %v, want halt %s", event.Details, recorded.HaltID)
+	}
+
+	res, err = s.handleAcknowledgeHalt(context.Background(), map[string]interface{}{
+		"session_token": sessionID,
+		"halt_id":       recorded.HaltID,
+		"resolution":    string(models.ResolutionResolved),
+	})
+	if err != nil || res.IsError {
+		t.Fatalf("acknowledge halt failed: %v %+v", err, res)
+	}
+
+	event = next()
+	if event.Type != audit.EventHaltAcked || event.Severity != audit.SevInfo {
+		t.Errorf("acknowledged event = %+v, want an info %s event", event, audit.EventHaltAcked)
+	}
+	if event.Details["halt_id"] != recorded.HaltID || event.Details["resolution"] != string(models.ResolutionResolved) {
+		t.Errorf("acknowledged event details = %v, want halt %s resolved", event.Details, recorded.HaltID)
+	}
+}
+
+// TestDetectExactReplacementViolations_DiffStats tests that moved and duplicated lines count as edits
+func TestDetectExactReplacementViolations_DiffStats(t *testing.T) {
+	original := "package main\n\nfunc a() {}\n\nfunc b() {}\n"
+
+	tests := []struct {
+		name          string
+		actual        string
+		wantExact     bool
+		wantAdditions int
+		wantDeletions int
+	}{
+		{name: "identical", actual: original, wantExact: true},
+		{name: "functions reordered", actual: "package main\n\nfunc b() {}\n\nfunc a() {}\n", wantAdditions: 2, wantDeletions: 2},
+		{name: "line duplicated", actual: "package main\n\nfunc a() {}\nfunc a() {}\n\nfunc b() {}\n", wantAdditions: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := detectExactReplacementViolations(original, tt.actual, "", defaultLanguagePatterns)
+			if result.ExactMatch != tt.wantExact {
+				t.Errorf("ExactMatch = %v, want %v (violations %+v)", result.ExactMatch, tt.wantExact, result.Violations)
+			}
+			if result.DiffStats.Additions != tt.wantAdditions || result.DiffStats.Deletions != tt.wantDeletions {
+				t.Errorf("DiffStats = %+v, want %d additions, %d deletions", result.DiffStats, tt.wantAdditions, tt.wantDeletions)
+			}
+		})
+	}
+}
+
+// TestDetectFeatureCreep_Thresholds tests that caller thresholds replace the defaults they name
+func TestDetectFeatureCreep_Thresholds(t *testing.T) {
+	diff := "+++ b/handler.go\n+func parse() {}\n+func render() {}\n+import \"fmt\"\n"
+
+	tests := []struct {
+		name      string
+		raw       interface{}
+		want      models.FeatureCreepThresholds
+		wantCreep bool
+		wantErr   string
+	}{
+		{name: "defaults", raw: nil, want: defaultFeatureCreepThresholds, wantCreep: true},
+		{
+			name: "partial override",
+			raw:  map[string]interface{}{"max_new_functions": float64(2)},
+			want: models.FeatureCreepThresholds{MaxAdditions: 100, NewFileAdditions: 50, MaxNewFunctions: 2, MaxNewImports: 3},
+		},
+		{
+			name:      "stricter additions",
+			raw:       map[string]interface{}{"max_new_functions": float64(5), "max_additions": float64(2)},
+			want:      models.FeatureCreepThresholds{MaxAdditions: 2, NewFileAdditions: 50, MaxNewFunctions: 5, MaxNewImports: 3},
+			wantCreep: true,
+		},
+		{name: "negative", raw: map[string]interface{}{"max_new_imports": float64(-1)}, wantErr: "non-negative integer"},
+		{name: "fractional", raw: map[string]interface{}{"max_additions": 1.5}, wantErr: "non-negative integer"},
+		{name: "unknown key", raw: map[string]interface{}{"max_classes": float64(2)}, wantErr: "unknown threshold"},
+		{name: "not an object", raw: "strict", wantErr: "must be an object"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			thresholds, err := featureCreepThresholdsArg(tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("featureCreepThresholdsArg() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("featureCreepThresholdsArg() error = %v", err)
+			}
+			if thresholds != tt.want {
+				t.Errorf("thresholds = %+v, want %+v", thresholds, tt.want)
+			}
+
+			result := detectFeatureCreep(diff, "", false, "handler.go", thresholds, defaultLanguagePatterns)
+			if result.CreepDetected != tt.wantCreep {
+				t.Errorf("CreepDetected = %v, want %v (violations %+v)", result.CreepDetected, tt.wantCreep, result.Violations)
+			}
+			if result.Thresholds != tt.want {
+				t.Errorf("result.Thresholds = %+v, want %+v", result.Thresholds, tt.want)
+			}
+		})
+	}
+}
+
+// TestLanguagePatterns tests per-language function and type hint recognition
+func TestLanguagePatterns(t *testing.T) {
+	tests := []struct {
+		language     string
+		line         string
+		wantFunction bool
+		wantTypeHint bool
+	}{
+		{language: "go", line: "func (s *MCPServer) handleX(ctx context.Context) error {", wantFunction: true},
+		{language: "go", line: "func Map[T any](in []T) []T {", wantFunction: true},
+		{language: "go", line: "\tName string `json:\"name\"`"},
+		{language: "go", line: "\tresult := compute(x)"},
+		{language: "python", line: "    async def fetch(self, url: str) -> bytes:", wantFunction: true, wantTypeHint: true},
+		{language: "python", line: "    retries: int = 3", wantTypeHint: true},
+		{language: "javascript", line: "export const load = async (id) => {", wantFunction: true},
+		{language: "javascript", line: "  } else if (ready) {"},
+		{language: "typescript", line: "  private render(items: Item[]): string {", wantFunction: true, wantTypeHint: true},
+		{language: "rust", line: "pub async fn serve(addr: SocketAddr) -> Result<()> {", wantFunction: true, wantTypeHint: true},
+		{language: "rust", line: "    let parts = path.split(\"::\");"},
+		{language: "java", line: "    public List<String> names(int limit) throws IOException {", wantFunction: true},
+		{language: "java", line: "        return names(limit);"},
+		{language: "", line: "fn main() {", wantFunction: true},
+		{language: "", line: "\tName string `json:\"name\"`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.language+"/"+strings.TrimSpace(tt.line), func(t *testing.T) {
+			lang, err := patternsForLanguage(tt.language)
+			if err != nil {
+				t.Fatalf("patternsForLanguage(%q) error = %v", tt.language, err)
+			}
+			if got := lang.isFunction(tt.line); got != tt.wantFunction {
+				t.Errorf("isFunction = %v, want %v", got, tt.wantFunction)
+			}
+			if got := lang.hasTypeHint(tt.line); got != tt.wantTypeHint {
+				t.Errorf("hasTypeHint = %v, want %v", got, tt.wantTypeHint)
+			}
+		})
+	}
+
+	if _, err := patternsForLanguage("cobol"); err == nil || !strings.Contains(err.Error(), "supported: go, java") {
+		t.Errorf("patternsForLanguage(cobol) error = %v, want unsupported language", err)
+	}
+}
+
+// TestDetectExactReplacementViolations_Language tests that the language picks the patterns used for violations
+func TestDetectExactReplacementViolations_Language(t *testing.T) {
+	original := "type Config struct {\n\tName string\n}\n"
+	withTag := "type Config struct {\n\tName string `json:\"name\"`\n}\n"
+
+	lang, _ := patternsForLanguage("go")
+	for _, v := range detectExactReplacementViolations(original, withTag, "", lang).Violations {
+		if v.Type == "type_change" {
+			t.Errorf("go struct tag reported as type change: %+v", v)
+		}
+	}
+
+	rustOriginal := "fn parse(input: &str) -> u32 {\n    0\n}\n"
+	rustActual := rustOriginal + "\nfn helper() {}\n"
+	lang, _ = patternsForLanguage("rust")
+	result := detectExactReplacementViolations(rustOriginal, rustActual, "", lang)
+	found := false
+	for _, v := range result.Violations {
+		found = found || v.Type == "extra_function"
+	}
+	if !found || result.ExactMatch {
+		t.Errorf("rust fn not reported as extra function: %+v", result.Violations)
+	}
+}
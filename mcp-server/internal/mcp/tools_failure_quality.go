@@ -0,0 +1,145 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/database"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// Completeness weights of the fields of a failure entry; they sum to 100
+const (
+	failureWeightRegressionPattern = 40
+	failureWeightRootCause         = 30
+	failureWeightAffectedFiles     = 20
+	failureWeightErrorMessage      = 10
+)
+
+// minRootCauseLength is the length below which a root cause is unlikely to explain anything
+const minRootCauseLength = 20
+
+// handleCheckFailureQuality scores a failure registry entry, loaded by id or given
+// inline, and flags entries that regression checks cannot use
+func (s *MCPServer) handleCheckFailureQuality(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	var entry models.FailureEntry
+
+	if id, _ := args["id"].(string); id != "" {
+		failureUUID, err := uuid.Parse(id)
+		if err != nil {
+			result := models.FailureQualityResult{
+				Valid:   false,
+				Message: fmt.Sprintf("invalid id %q: must be a UUID", id),
+			}
+			return buildToolResult(result, true)
+		}
+		if s.db == nil {
+			result := models.FailureQualityResult{
+				Valid:   false,
+				Message: "Database not available",
+			}
+			return buildToolResult(result, true)
+		}
+		stored, err := database.NewFailureStore(s.db).GetByID(ctx, failureUUID)
+		if err != nil {
+			result := models.FailureQualityResult{
+				Valid:   false,
+				Message: fmt.Sprintf("failure %s not found", id),
+			}
+			return buildToolResult(result, true)
+		}
+		entry = *stored
+	} else {
+		entry.FailureID, _ = args["failure_id"].(string)
+		entry.ErrorMessage, _ = args["error_message"].(string)
+		entry.RootCause, _ = args["root_cause"].(string)
+		entry.RegressionPattern, _ = args["regression_pattern"].(string)
+		entry.AffectedFiles = models.ToTextArray(stringSliceArg(args, "affected_files"))
+	}
+
+	result := checkFailureQuality(entry)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkFailureQuality scores a failure entry by the fields guardrail_prevent_regression
+// relies on. A regression pattern lets it match code content, affected files select the
+// failure for a change and a root cause explains the match. Missing any of them, or a
+// pattern that does not compile, makes the entry incomplete. A pattern that matches
+// empty input, or a root cause that only repeats the error, is a warning.
+func checkFailureQuality(entry models.FailureEntry) models.FailureQualityResult {
+	issues := []models.FailureQualityIssue{}
+	score := 0
+	issue := func(field, severity, message string) {
+		issues = append(issues, models.FailureQualityIssue{Field: field, Severity: severity, Message: message})
+	}
+
+	pattern := strings.TrimSpace(entry.RegressionPattern)
+	if pattern == "" {
+		issue("regression_pattern", "error", "No regression pattern; prevent_regression cannot match code against this failure")
+	} else if re, err := regexp.Compile(pattern); err != nil {
+		issue("regression_pattern", "error", fmt.Sprintf("Regression pattern does not compile: %v", err))
+	} else {
+		score += failureWeightRegressionPattern
+		if re.MatchString("") {
+			issue("regression_pattern", "warning", "Regression pattern matches empty input and so matches any code")
+		}
+	}
+
+	rootCause := strings.TrimSpace(entry.RootCause)
+	switch {
+	case rootCause == "":
+		issue("root_cause", "error", "No root cause recorded")
+	case strings.EqualFold(rootCause, strings.TrimSpace(entry.ErrorMessage)):
+		score += failureWeightRootCause / 2
+		issue("root_cause", "warning", "Root cause repeats the error message; describe why the failure happened")
+	case len(rootCause) < minRootCauseLength:
+		score += failureWeightRootCause / 2
+		issue("root_cause", "warning", "Root cause is too short to explain the failure")
+	default:
+		score += failureWeightRootCause
+	}
+
+	files := 0
+	for _, file := range models.ToStringSlice(entry.AffectedFiles) {
+		if strings.TrimSpace(file) != "" {
+			files++
+		}
+	}
+	if files == 0 {
+		issue("affected_files", "error", "No affected files; the failure is never selected when those files change")
+	} else {
+		score += failureWeightAffectedFiles
+	}
+
+	if strings.TrimSpace(entry.ErrorMessage) == "" {
+		issue("error_message", "warning", "No error message recorded")
+	} else {
+		score += failureWeightErrorMessage
+	}
+
+	complete := true
+	for _, i := range issues {
+		if i.Severity == "error" {
+			complete = false
+			break
+		}
+	}
+
+	message := fmt.Sprintf("Failure entry is complete (score %d/100)", score)
+	if !complete {
+		message = fmt.Sprintf("Failure entry is incomplete (score %d/100)", score)
+	}
+
+	return models.FailureQualityResult{
+		Valid:     complete,
+		Complete:  complete,
+		Score:     score,
+		FailureID: entry.FailureID,
+		Message:   message,
+		Issues:    issues,
+	}
+}
@@ -0,0 +1,86 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// TestCheckFailureQuality tests completeness scoring of failure registry entries
+func TestCheckFailureQuality(t *testing.T) {
+	tests := []struct {
+		name          string
+		entry         models.FailureEntry
+		wantValid     bool
+		wantScore     int
+		wantIssueKeys []string // field/severity
+	}{
+		{
+			name: "full entry passes",
+			entry: models.FailureEntry{
+				FailureID:         "FAIL-042",
+				ErrorMessage:      "nil pointer dereference in session cleanup",
+				RootCause:         "Sessions removed during iteration left a nil entry in the map",
+				AffectedFiles:     models.ToTextArray([]string{"internal/mcp/server.go"}),
+				RegressionPattern: `delete\(s\.sessions,`,
+			},
+			wantValid: true,
+			wantScore: 100,
+		},
+		{
+			name: "entry without pattern flagged incomplete",
+			entry: models.FailureEntry{
+				FailureID:     "FAIL-043",
+				ErrorMessage:  "panic: index out of range",
+				RootCause:     "Pagination offset was not clamped to the result length",
+				AffectedFiles: models.ToTextArray([]string{"internal/web/handlers.go"}),
+			},
+			wantValid:     false,
+			wantScore:     60,
+			wantIssueKeys: []string{"regression_pattern/error"},
+		},
+		{
+			name: "invalid pattern and missing files flagged",
+			entry: models.FailureEntry{
+				ErrorMessage:      "timeout",
+				RootCause:         "timeout",
+				RegressionPattern: `http.Get(`,
+			},
+			wantValid:     false,
+			wantScore:     25,
+			wantIssueKeys: []string{"regression_pattern/error", "root_cause/warning", "affected_files/error"},
+		},
+		{
+			name: "pattern matching everything warns",
+			entry: models.FailureEntry{
+				ErrorMessage:      "race on shared map",
+				RootCause:         "Concurrent writers to the bucket map without holding the lock",
+				AffectedFiles:     models.ToTextArray([]string{"internal/mcp/team_tool_handlers.go"}),
+				RegressionPattern: `.*`,
+			},
+			wantValid:     true,
+			wantScore:     100,
+			wantIssueKeys: []string{"regression_pattern/warning"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkFailureQuality(tt.entry)
+			if result.Valid != tt.wantValid || result.Complete != tt.wantValid {
+				t.Errorf("Valid = %v, Complete = %v, want %v (issues %+v)", result.Valid, result.Complete, tt.wantValid, result.Issues)
+			}
+			if result.Score != tt.wantScore {
+				t.Errorf("Score = %d, want %d", result.Score, tt.wantScore)
+			}
+			if len(result.Issues) != len(tt.wantIssueKeys) {
+				t.Fatalf("Issues = %+v, want %v", result.Issues, tt.wantIssueKeys)
+			}
+			for i, want := range tt.wantIssueKeys {
+				if got := result.Issues[i].Field + "/" + result.Issues[i].Severity; got != want {
+					t.Errorf("Issues[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// defaultMaxFlagAgeDays is the age after which a feature flag is a candidate for removal
+const defaultMaxFlagAgeDays = 90
+
+// featureFlag is one entry of a feature-flag registry
+type featureFlag struct {
+	Name      string
+	Owner     string
+	CreatedAt time.Time
+	Permanent bool
+}
+
+// handleValidateFeatureToggleCleanup flags feature flags in a registry that are older
+// than the allowed age and should be removed
+func (s *MCPServer) handleValidateFeatureToggleCleanup(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	rawFlags, _ := args["flags"].([]interface{})
+
+	maxAgeDays := defaultMaxFlagAgeDays
+	if v, ok := args["max_age_days"].(float64); ok {
+		maxAgeDays = int(v)
+	}
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.FeatureToggleCleanupResult{
+			Valid:      false,
+			Message:    message,
+			MaxAgeDays: maxAgeDays,
+			StaleFlags: []models.StaleFeatureFlag{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if len(rawFlags) == 0 {
+		return invalid("flags is required")
+	}
+	if maxAgeDays < 1 {
+		return invalid("max_age_days must be positive")
+	}
+
+	flags := make([]featureFlag, 0, len(rawFlags))
+	for i, raw := range rawFlags {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			return invalid(fmt.Sprintf("flags[%d] must be an object", i))
+		}
+		flag := featureFlag{}
+		flag.Name, _ = entry["name"].(string)
+		flag.Owner, _ = entry["owner"].(string)
+		flag.Permanent, _ = entry["permanent"].(bool)
+		if flag.Name == "" {
+			return invalid(fmt.Sprintf("flags[%d].name is required", i))
+		}
+		createdAt, _ := entry["created_at"].(string)
+		if createdAt == "" {
+			return invalid(fmt.Sprintf("flags[%d].created_at is required", i))
+		}
+		t, err := parseCommitTime(createdAt)
+		if err != nil {
+			return invalid(fmt.Sprintf("flags[%d].created_at: %v", i, err))
+		}
+		flag.CreatedAt = t
+		flags = append(flags, flag)
+	}
+
+	now := time.Now()
+	if asOf, _ := args["as_of"].(string); asOf != "" {
+		t, err := parseCommitTime(asOf)
+		if err != nil {
+			return invalid(fmt.Sprintf("as_of: %v", err))
+		}
+		now = t
+	}
+
+	result := checkFeatureToggleCleanup(flags, maxAgeDays, now)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkFeatureToggleCleanup lists the flags created more than maxAgeDays before now,
+// oldest first. Release flags are meant to be short-lived, so an old one is
+// technical debt: a warning past the limit and an error past twice the limit.
+// Permanent flags, such as kill switches and entitlements, are never stale.
+func checkFeatureToggleCleanup(flags []featureFlag, maxAgeDays int, now time.Time) models.FeatureToggleCleanupResult {
+	result := models.FeatureToggleCleanupResult{
+		MaxAgeDays: maxAgeDays,
+		TotalFlags: len(flags),
+		StaleFlags: []models.StaleFeatureFlag{},
+	}
+
+	for _, flag := range flags {
+		if flag.Permanent {
+			result.Permanent++
+			continue
+		}
+		if !now.After(flag.CreatedAt) {
+			continue
+		}
+		ageDays := int(now.Sub(flag.CreatedAt).Hours() / 24)
+		if ageDays <= maxAgeDays {
+			continue
+		}
+
+		severity := "warning"
+		if ageDays > 2*maxAgeDays {
+			severity = "error"
+		}
+		message := fmt.Sprintf("Flag %s is %d days old (limit %d); remove it and the code paths it guards", flag.Name, ageDays, maxAgeDays)
+		if flag.Owner != "" {
+			message += fmt.Sprintf(" or ask %s to mark it permanent", flag.Owner)
+		}
+		result.StaleFlags = append(result.StaleFlags, models.StaleFeatureFlag{
+			Name:      flag.Name,
+			Owner:     flag.Owner,
+			CreatedAt: flag.CreatedAt.Format(time.RFC3339),
+			AgeDays:   ageDays,
+			Severity:  severity,
+			Message:   message,
+		})
+	}
+
+	sort.SliceStable(result.StaleFlags, func(i, j int) bool {
+		return result.StaleFlags[i].AgeDays > result.StaleFlags[j].AgeDays
+	})
+
+	result.Valid = len(result.StaleFlags) == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("No flags older than %d days among %d flag(s)", maxAgeDays, len(flags))
+	} else {
+		names := make([]string, len(result.StaleFlags))
+		for i, flag := range result.StaleFlags {
+			names[i] = flag.Name
+		}
+		result.Message = fmt.Sprintf("%d stale flag(s) to remove: %s", len(names), strings.Join(names, ", "))
+	}
+	return result
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TestCheckFeatureToggleCleanup tests that flags past the age limit are listed for removal
+func TestCheckFeatureToggleCleanup(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time { return now.AddDate(0, 0, -days) }
+
+	tests := []struct {
+		name          string
+		flags         []featureFlag
+		wantValid     bool
+		wantStale     []string // name/severity, oldest first
+		wantPermanent int
+	}{
+		{
+			name:      "recent flag passes",
+			flags:     []featureFlag{{Name: "new-checkout", CreatedAt: daysAgo(10)}},
+			wantValid: true,
+		},
+		{
+			name:      "flag past the age limit flagged",
+			flags:     []featureFlag{{Name: "new-checkout", CreatedAt: daysAgo(120)}, {Name: "dark-mode", CreatedAt: daysAgo(5)}},
+			wantValid: false,
+			wantStale: []string{"new-checkout/warning"},
+		},
+		{
+			name:      "flag past twice the limit is an error, oldest first",
+			flags:     []featureFlag{{Name: "beta-search", CreatedAt: daysAgo(100)}, {Name: "legacy-billing", CreatedAt: daysAgo(400)}},
+			wantValid: false,
+			wantStale: []string{"legacy-billing/error", "beta-search/warning"},
+		},
+		{
+			name:          "permanent flag is exempt",
+			flags:         []featureFlag{{Name: "payments-kill-switch", CreatedAt: daysAgo(700), Permanent: true}},
+			wantValid:     true,
+			wantPermanent: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkFeatureToggleCleanup(tt.flags, defaultMaxFlagAgeDays, now)
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if result.Permanent != tt.wantPermanent {
+				t.Errorf("permanent = %d, want %d", result.Permanent, tt.wantPermanent)
+			}
+			if len(result.StaleFlags) != len(tt.wantStale) {
+				t.Fatalf("stale flags = %+v, want %v", result.StaleFlags, tt.wantStale)
+			}
+			for i, want := range tt.wantStale {
+				if got := result.StaleFlags[i].Name + "/" + result.StaleFlags[i].Severity; got != want {
+					t.Errorf("stale[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleValidateFeatureToggleCleanup tests registry parsing and argument errors
+func TestHandleValidateFeatureToggleCleanup(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name: "stale flag reported",
+			args: map[string]interface{}{
+				"flags": []interface{}{
+					map[string]interface{}{"name": "old-flag", "created_at": "2024-01-01", "owner": "platform"},
+					map[string]interface{}{"name": "new-flag", "created_at": "2024-05-20"},
+				},
+				"as_of": "2024-06-01",
+			},
+			wantError: true,
+			wantText:  "old-flag",
+		},
+		{
+			name:      "missing registry rejected",
+			args:      map[string]interface{}{},
+			wantError: true,
+			wantText:  "flags is required",
+		},
+		{
+			name: "bad creation date rejected",
+			args: map[string]interface{}{
+				"flags": []interface{}{map[string]interface{}{"name": "x", "created_at": "last spring"}},
+			},
+			wantError: true,
+			wantText:  "flags[0].created_at",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateFeatureToggleCleanup(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateFeatureToggleCleanup() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// modeCallPattern matches file mode arguments in Go/Python file APIs, e.g. os.Chmod(p, 0777)
+	modeCallPattern = regexp.MustCompile(`\b(?:Chmod|chmod|OpenFile|WriteFile|MkdirAll|Mkdir|makedirs|mkdir|fchmod)\s*\(.*?[,\s(](0o?[0-7]{3,4})\b`)
+	// shellChmodPattern matches shell chmod invocations with numeric or symbolic modes
+	shellChmodPattern = regexp.MustCompile(`\bchmod\s+(?:-[A-Za-z]+\s+)*([0-7]{3,4}|[ugoa]*[+=][rwxXst]+)\b`)
+	// tempPathPattern matches hardcoded paths under shared temp directories
+	tempPathPattern = regexp.MustCompile("[\"'`](/tmp/|/var/tmp/)[^\"'`]*[\"'`]")
+)
+
+// handleValidateFilePermissions flags added code that sets world-writable file
+// modes or writes to predictable paths in shared temp directories
+func (s *MCPServer) handleValidateFilePermissions(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkFilePermissions(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkFilePermissions scans the added lines of a diff for permissive modes and predictable temp paths
+func checkFilePermissions(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := addedLines(diff)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || (isCommentLine(trimmed) && !strings.HasPrefix(trimmed, "#!")) {
+			continue
+		}
+
+		if m := modeCallPattern.FindStringSubmatch(line.Text); m != nil {
+			if v, bad := checkOctalMode(m[1]); bad {
+				v.File, v.LineNumber, v.Line = line.File, line.Number, trimmed
+				violations = append(violations, v)
+			}
+		} else if m := shellChmodPattern.FindStringSubmatch(line.Text); m != nil {
+			if v, bad := checkShellMode(m[1]); bad {
+				v.File, v.LineNumber, v.Line = line.File, line.Number, trimmed
+				violations = append(violations, v)
+			}
+		}
+
+		if m := tempPathPattern.FindStringSubmatch(line.Text); m != nil && !strings.Contains(line.Text, "mktemp") {
+			violations = append(violations, models.DiffViolation{
+				Type:       "predictable_temp_path",
+				Severity:   "warning",
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    fmt.Sprintf("Hardcoded path in shared temp directory %s is predictable and open to symlink attacks", m[1]),
+				Suggestion: "Use os.CreateTemp/os.MkdirTemp, tempfile.mkstemp or mktemp to create unique temp files",
+			})
+		}
+	}
+
+	return newDiffScanResult("file permission", violations, len(lines))
+}
+
+// checkOctalMode flags world-writable numeric modes such as 0777 or 0666
+func checkOctalMode(literal string) (models.DiffViolation, bool) {
+	mode, err := strconv.ParseUint(strings.TrimPrefix(strings.TrimPrefix(literal, "0o"), "0"), 8, 32)
+	if err != nil || mode&0o002 == 0 {
+		return models.DiffViolation{}, false
+	}
+	return models.DiffViolation{
+		Type:       "world_writable_mode",
+		Severity:   "error",
+		Message:    fmt.Sprintf("File mode %s is world-writable", literal),
+		Suggestion: "Use 0600 for files or 0700/0750 for directories unless wider access is required",
+	}, true
+}
+
+// checkShellMode flags shell chmod modes that grant write access to others
+func checkShellMode(mode string) (models.DiffViolation, bool) {
+	if mode[0] >= '0' && mode[0] <= '7' {
+		return checkOctalMode(mode)
+	}
+
+	op := strings.IndexAny(mode, "+=")
+	who, perms := mode[:op], mode[op+1:]
+	if !strings.Contains(perms, "w") || (who != "" && !strings.ContainsAny(who, "oa")) {
+		return models.DiffViolation{}, false
+	}
+	return models.DiffViolation{
+		Type:       "world_writable_mode",
+		Severity:   "error",
+		Message:    fmt.Sprintf("chmod %s grants write access to all users", mode),
+		Suggestion: "Restrict write access to the owner (e.g. chmod u+w or chmod 600)",
+	}, true
+}
@@ -0,0 +1,85 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckFilePermissions tests detection of permissive file modes and predictable temp paths
+func TestCheckFilePermissions(t *testing.T) {
+	tests := []struct {
+		name      string
+		diff      string
+		wantValid bool
+		wantType  string
+	}{
+		{
+			name:      "0777 chmod flagged",
+			diff:      "+++ b/main.go\n@@ -1,1 +1,2 @@\n package main\n+\tos.Chmod(path, 0777)\n",
+			wantValid: false,
+			wantType:  "world_writable_mode",
+		},
+		{
+			name:      "0600 chmod passes",
+			diff:      "+++ b/main.go\n@@ -1,1 +1,2 @@\n package main\n+\tos.Chmod(path, 0600)\n",
+			wantValid: true,
+		},
+		{
+			name:      "world writable OpenFile flagged",
+			diff:      "+\tf, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY, 0666)\n",
+			wantValid: false,
+			wantType:  "world_writable_mode",
+		},
+		{
+			name:      "python octal mode flagged",
+			diff:      "+os.chmod(path, 0o777)\n",
+			wantValid: false,
+			wantType:  "world_writable_mode",
+		},
+		{
+			name:      "shell chmod 777 flagged",
+			diff:      "+chmod -R 777 /srv/app\n",
+			wantValid: false,
+			wantType:  "world_writable_mode",
+		},
+		{
+			name:      "shell chmod o+w flagged",
+			diff:      "+chmod o+w deploy.sh\n",
+			wantValid: false,
+			wantType:  "world_writable_mode",
+		},
+		{
+			name:      "shell chmod u+x passes",
+			diff:      "+chmod u+x deploy.sh\n",
+			wantValid: true,
+		},
+		{
+			name:      "hardcoded temp path warns",
+			diff:      "+\tos.WriteFile(\"/tmp/cache.json\", data, 0600)\n",
+			wantValid: true,
+			wantType:  "predictable_temp_path",
+		},
+		{
+			name:      "removed line ignored",
+			diff:      "+++ b/main.go\n@@ -1,2 +1,1 @@\n package main\n-\tos.Chmod(path, 0777)\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkFilePermissions(tt.diff)
+			if result.Valid != tt.wantValid {
+				t.Errorf("checkFilePermissions() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if tt.wantType == "" {
+				if len(result.Violations) != 0 {
+					t.Errorf("expected no violations, got %+v", result.Violations)
+				}
+				return
+			}
+			if len(result.Violations) == 0 || result.Violations[0].Type != tt.wantType {
+				t.Errorf("violations = %+v, want type %s", result.Violations, tt.wantType)
+			}
+		})
+	}
+}
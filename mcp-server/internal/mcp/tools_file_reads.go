@@ -0,0 +1,138 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// Page size bounds for guardrail_list_file_reads
+const (
+	defaultFileReadListLimit = 100
+	maxFileReadListLimit     = 1000
+)
+
+// fileReadStore lists, checks and clears the files recorded as read in a session
+type fileReadStore interface {
+	ListBySession(ctx context.Context, sessionID string, limit, offset int) ([]models.FileRead, error)
+	CountBySession(ctx context.Context, sessionID string) (int, error)
+	DeleteBySession(ctx context.Context, sessionID string) (int, error)
+	fileReadChecker
+}
+
+// handleListFileReads returns the files recorded as read in a session, most recent
+// first, to show why guardrail_verify_file_read did or did not find a file
+func (s *MCPServer) handleListFileReads(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	sessionToken, _ := args["session_token"].(string)
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.FileReadListResult{
+			Valid:     false,
+			Message:   message,
+			SessionID: sessionToken,
+			FileReads: []models.FileReadEntry{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if sessionToken == "" {
+		return invalid("session_token is required")
+	}
+	if !s.sessionExists(sessionToken) {
+		return invalid("Invalid session token")
+	}
+	if s.fileReads == nil {
+		return invalid("File read store not available")
+	}
+
+	limit := defaultFileReadListLimit
+	if l, ok := args["limit"].(float64); ok && l > 0 {
+		limit = int(l)
+	}
+	if limit > maxFileReadListLimit {
+		limit = maxFileReadListLimit
+	}
+	offset := 0
+	if o, ok := args["offset"].(float64); ok && o > 0 {
+		offset = int(o)
+	}
+
+	records, err := s.fileReads.ListBySession(ctx, sessionToken, limit, offset)
+	if err != nil {
+		slog.Error("Failed to list file reads", "error", err, "session_token", sessionToken)
+		return invalid(fmt.Sprintf("Failed to list file reads: %v", err))
+	}
+	total, err := s.fileReads.CountBySession(ctx, sessionToken)
+	if err != nil {
+		slog.Error("Failed to count file reads", "error", err, "session_token", sessionToken)
+		return invalid(fmt.Sprintf("Failed to count file reads: %v", err))
+	}
+
+	entries := make([]models.FileReadEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, models.FileReadEntry{
+			FilePath: record.FilePath,
+			ReadAt:   record.ReadAt.Format(time.RFC3339),
+		})
+	}
+
+	result := models.FileReadListResult{
+		Valid:     true,
+		Message:   fmt.Sprintf("%d file(s) recorded as read", total),
+		SessionID: sessionToken,
+		FileReads: entries,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+	}
+	return buildToolResult(result, false)
+}
+
+// handleClearFileReads removes every file read recorded in a session, so a fresh
+// verification cycle requires each file to be read again before it is edited
+func (s *MCPServer) handleClearFileReads(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	sessionToken, _ := args["session_token"].(string)
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.FileReadClearResult{
+			Valid:     false,
+			Message:   message,
+			SessionID: sessionToken,
+		}
+		return buildToolResult(result, true)
+	}
+
+	if sessionToken == "" {
+		return invalid("session_token is required")
+	}
+	if !s.sessionExists(sessionToken) {
+		return invalid("Invalid session token")
+	}
+	if s.fileReads == nil {
+		return invalid("File read store not available")
+	}
+
+	cleared, err := s.fileReads.DeleteBySession(ctx, sessionToken)
+	if err != nil {
+		slog.Error("Failed to clear file reads", "error", err, "session_token", sessionToken)
+		return invalid(fmt.Sprintf("Failed to clear file reads: %v", err))
+	}
+
+	result := models.FileReadClearResult{
+		Valid:     true,
+		Message:   fmt.Sprintf("Cleared %d file read(s)", cleared),
+		SessionID: sessionToken,
+		Cleared:   cleared,
+	}
+	return buildToolResult(result, false)
+}
+
+// sessionExists reports whether the token belongs to an active session
+func (s *MCPServer) sessionExists(sessionToken string) bool {
+	_, exists := s.getSession(sessionToken)
+	return exists
+}
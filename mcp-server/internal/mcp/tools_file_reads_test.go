@@ -0,0 +1,105 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// fakeFileReads keeps file reads in memory in place of FileReadStore
+type fakeFileReads struct {
+	reads map[string][]models.FileRead
+}
+
+func (f *fakeFileReads) ListBySession(ctx context.Context, sessionID string, limit, offset int) ([]models.FileRead, error) {
+	reads := f.reads[sessionID]
+	if offset >= len(reads) {
+		return nil, nil
+	}
+	reads = reads[offset:]
+	if limit < len(reads) {
+		reads = reads[:limit]
+	}
+	return reads, nil
+}
+
+func (f *fakeFileReads) CountBySession(ctx context.Context, sessionID string) (int, error) {
+	return len(f.reads[sessionID]), nil
+}
+
+func (f *fakeFileReads) DeleteBySession(ctx context.Context, sessionID string) (int, error) {
+	n := len(f.reads[sessionID])
+	delete(f.reads, sessionID)
+	return n, nil
+}
+
+func (f *fakeFileReads) Exists(ctx context.Context, sessionID, filePath string) (bool, error) {
+	for _, read := range f.reads[sessionID] {
+		if read.FilePath == filePath {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// TestHandleListAndClearFileReads tests listing a session's file reads and clearing them
+func TestHandleListAndClearFileReads(t *testing.T) {
+	readAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := mockMCPServer()
+	s.sessions.Put(context.Background(), &Session{ID: "sess-1"})
+	s.fileReads = &fakeFileReads{reads: map[string][]models.FileRead{
+		"sess-1": {
+			{SessionID: "sess-1", FilePath: "cmd/main.go", ReadAt: readAt},
+			{SessionID: "sess-1", FilePath: "go.mod", ReadAt: readAt.Add(-time.Minute)},
+		},
+		"sess-2": {{SessionID: "sess-2", FilePath: "README.md", ReadAt: readAt}},
+	}}
+	ctx := context.Background()
+
+	res, err := s.handleListFileReads(ctx, map[string]interface{}{"session_token": "sess-1", "limit": float64(1)})
+	if err != nil {
+		t.Fatalf("handleListFileReads() error = %v", err)
+	}
+	var list models.FileReadListResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &list); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || list.Total != 2 || len(list.FileReads) != 1 || list.FileReads[0].FilePath != "cmd/main.go" || list.FileReads[0].ReadAt != "2026-03-01T12:00:00Z" {
+		t.Errorf("list = %+v, want the most recent of 2 reads", list)
+	}
+
+	res, err = s.handleClearFileReads(ctx, map[string]interface{}{"session_token": "sess-1"})
+	if err != nil {
+		t.Fatalf("handleClearFileReads() error = %v", err)
+	}
+	var cleared models.FileReadClearResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &cleared); err != nil {
+		t.Fatal(err)
+	}
+	if res.IsError || cleared.Cleared != 2 {
+		t.Errorf("clear = %+v, want 2 reads cleared", cleared)
+	}
+
+	res, _ = s.handleListFileReads(ctx, map[string]interface{}{"session_token": "sess-1"})
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &list); err != nil {
+		t.Fatal(err)
+	}
+	if list.Total != 0 || len(list.FileReads) != 0 {
+		t.Errorf("after clear: list = %+v, want no reads", list)
+	}
+
+	// Another session's reads are left alone, and unknown sessions are rejected
+	for _, tool := range []func(context.Context, map[string]interface{}) (*mcp.CallToolResult, error){s.handleListFileReads, s.handleClearFileReads} {
+		res, _ = tool(ctx, map[string]interface{}{"session_token": "sess-2"})
+		if !res.IsError {
+			t.Errorf("sess-2 is not an active session; result = %s, want an error", res.Content[0].(mcp.TextContent).Text)
+		}
+	}
+	if n, _ := s.fileReads.CountBySession(ctx, "sess-2"); n != 1 {
+		t.Errorf("sess-2 reads = %d, want 1", n)
+	}
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// maxGoroutineBodyLines bounds how far a goroutine body is followed through the diff
+const maxGoroutineBodyLines = 200
+
+var (
+	// goFuncPattern matches a goroutine launched with a function literal
+	goFuncPattern = regexp.MustCompile(`(?:^|[\s;{])go\s+func\s*\(`)
+	// goroutineStopPattern matches the ways a goroutine can be stopped or waited for:
+	// context cancellation, a WaitGroup or errgroup, or a done/stop/quit channel
+	goroutineStopPattern = regexp.MustCompile(`\bctx\.(?:Done|Err)\(\)|\.Done\(\)|<-\s*[\w.]*(?i:done|stop|quit|exit|close|shutdown|cancel)\w*\b|\berrgroup\.|\bcontext\.Context\b`)
+	// unboundedLoopPattern matches loops that only end when something stops them
+	unboundedLoopPattern = regexp.MustCompile(`^\s*for\s*\{|^\s*for\s+(?:[\w, ]+:=\s*)?range\s+[\w.]*(?:\.C|[Cc]h\w*|[Tt]icker)\b`)
+)
+
+// handleValidateGoroutineLifecycle flags goroutines added in Go code without a way to
+// stop them (no context, WaitGroup or done channel), which can leak
+func (s *MCPServer) handleValidateGoroutineLifecycle(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkGoroutineLifecycle(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkGoroutineLifecycle follows the body of each added go func() launch through the
+// diff and reports launches with no stop mechanism. A goroutine that loops forever is
+// a leak and blocks; other unmanaged goroutines are warnings, as are those in tests.
+func checkGoroutineLifecycle(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+	scanned := 0
+
+	for i, line := range lines {
+		if !line.Added {
+			continue
+		}
+		scanned++
+		trimmed := strings.TrimSpace(line.Text)
+		if isCommentLine(trimmed) || !goFuncPattern.MatchString(line.Text) {
+			continue
+		}
+
+		body := goroutineBody(lines, i)
+		managed, loops := false, false
+		for _, b := range body {
+			if goroutineStopPattern.MatchString(b) {
+				managed = true
+			}
+			if unboundedLoopPattern.MatchString(b) {
+				loops = true
+			}
+		}
+		if managed {
+			continue
+		}
+
+		severity := "warning"
+		message := "Goroutine started without a context, WaitGroup or done channel; nothing can stop or wait for it"
+		if loops && !strings.HasSuffix(line.File, "_test.go") {
+			severity = "error"
+			message = "Goroutine loops with no way to stop it and leaks until the process exits"
+		}
+		violations = append(violations, models.DiffViolation{
+			Type:       "unmanaged_goroutine",
+			Severity:   severity,
+			File:       line.File,
+			LineNumber: line.Number,
+			Line:       trimmed,
+			Message:    message,
+			Suggestion: "Pass a ctx and return on <-ctx.Done(), select on a done channel closed at shutdown, or track it with a sync.WaitGroup",
+		})
+	}
+
+	return newDiffScanResult("goroutine lifecycle", violations, scanned)
+}
+
+// goroutineBody returns the lines of the goroutine launched at lines[start], from the
+// launch through the closing brace and call arguments, by tracking brace depth
+func goroutineBody(lines []diffLine, start int) []string {
+	body := []string{}
+	depth := 0
+	opened := false
+	for i := start; i < len(lines) && i-start < maxGoroutineBodyLines; i++ {
+		if lines[i].File != lines[start].File {
+			break
+		}
+		text := lines[i].Text
+		if i == start {
+			// Only the part of the launch line from "go func" belongs to the goroutine
+			if loc := goFuncPattern.FindStringIndex(text); loc != nil {
+				text = text[loc[0]:]
+			}
+		}
+		body = append(body, text)
+		for _, r := range stripStringLiterals(text) {
+			switch r {
+			case '{':
+				depth++
+				opened = true
+			case '}':
+				depth--
+			}
+		}
+		if opened && depth <= 0 {
+			break
+		}
+	}
+	return body
+}
+
+// stripStringLiterals removes string literals and a trailing line comment so braces
+// inside them are not counted
+func stripStringLiterals(line string) string {
+	var b strings.Builder
+	var quote rune
+	escaped := false
+	for _, r := range line {
+		switch {
+		case quote != 0:
+			if escaped {
+				escaped = false
+			} else if r == '\\' && quote != '`' {
+				escaped = true
+			} else if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '`' || r == '\'':
+			quote = r
+		case r == '/' && strings.HasSuffix(b.String(), "/"):
+			return strings.TrimSuffix(b.String(), "/")
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckGoroutineLifecycle tests detection of goroutines added without a way to stop them
+func TestCheckGoroutineLifecycle(t *testing.T) {
+	tests := []struct {
+		name         string
+		diff         string
+		wantValid    bool
+		wantSeverity []string
+	}{
+		{
+			name:         "unmanaged looping goroutine flagged",
+			diff:         "+++ b/cache.go\n@@ -10,1 +10,8 @@\n func (c *Cache) start() {\n+\tgo func() {\n+\t\tfor {\n+\t\t\ttime.Sleep(time.Minute)\n+\t\t\tc.evict()\n+\t\t}\n+\t}()\n }\n",
+			wantValid:    false,
+			wantSeverity: []string{"error"},
+		},
+		{
+			name:      "context-cancelled goroutine passes",
+			diff:      "+++ b/cache.go\n+\tgo func() {\n+\t\tticker := time.NewTicker(time.Minute)\n+\t\tdefer ticker.Stop()\n+\t\tfor {\n+\t\t\tselect {\n+\t\t\tcase <-ctx.Done():\n+\t\t\t\treturn\n+\t\t\tcase <-ticker.C:\n+\t\t\t\tc.evict()\n+\t\t\t}\n+\t\t}\n+\t}()\n",
+			wantValid: true,
+		},
+		{
+			name:      "WaitGroup-tracked goroutine passes",
+			diff:      "+\twg.Add(1)\n+\tgo func(job Job) {\n+\t\tdefer wg.Done()\n+\t\tprocess(job)\n+\t}(job)\n",
+			wantValid: true,
+		},
+		{
+			name:      "stop channel goroutine passes",
+			diff:      "+\tgo func() {\n+\t\tfor {\n+\t\t\tselect {\n+\t\t\tcase <-s.stopCleanup:\n+\t\t\t\treturn\n+\t\t\tcase <-ticker.C:\n+\t\t\t\ts.cleanup()\n+\t\t\t}\n+\t\t}\n+\t}()\n",
+			wantValid: true,
+		},
+		{
+			name:         "fire-and-forget goroutine warns",
+			diff:         "+\tgo func() { s.notify(event) }()\n",
+			wantValid:    true,
+			wantSeverity: []string{"warning"},
+		},
+		{
+			name:         "looping goroutine in test file warns",
+			diff:         "+++ b/server_test.go\n+\tgo func() {\n+\t\tfor {\n+\t\t\tconn.Read(buf)\n+\t\t}\n+\t}()\n",
+			wantValid:    true,
+			wantSeverity: []string{"warning"},
+		},
+		{
+			name:      "brace in string does not end the body early",
+			diff:      "+\tgo func() {\n+\t\tlog.Printf(\"}\")\n+\t\t<-done\n+\t}()\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkGoroutineLifecycle(tt.diff)
+			if result.Valid != tt.wantValid {
+				t.Errorf("checkGoroutineLifecycle() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantSeverity) {
+				t.Fatalf("violations = %+v, want severities %v", result.Violations, tt.wantSeverity)
+			}
+			for i, severity := range tt.wantSeverity {
+				if result.Violations[i].Severity != severity || result.Violations[i].Type != "unmanaged_goroutine" {
+					t.Errorf("violation[%d] = %s/%s, want unmanaged_goroutine/%s", i, result.Violations[i].Type, result.Violations[i].Severity, severity)
+				}
+			}
+		})
+	}
+}
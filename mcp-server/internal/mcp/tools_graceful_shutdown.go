@@ -0,0 +1,160 @@
+package mcp
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// serverStartPattern matches a long-running server being started in Go, Node or Python
+	serverStartPattern = regexp.MustCompile(`\bListenAndServe(?:TLS)?\(|\.Serve(?:TLS)?\(|\b(?:e|echo|app|router|r|srv|server)\.Start(?:TLS)?\(|\b(?:app|server|srv)\.listen\(|\buvicorn\.run\(|\bapp\.run\(|\.serve_forever\(`)
+	// signalTrapPattern matches termination signal handling
+	signalTrapPattern = regexp.MustCompile(`\bsignal\.(?:Notify|NotifyContext)\(|process\.on\(\s*["']SIG(?:TERM|INT)["']|\bsignal\.signal\(\s*signal\.SIG(?:TERM|INT)|add_signal_handler\(|\bSIGTERM\b`)
+	// gracefulShutdownPattern matches calls that stop a server while draining in-flight work
+	gracefulShutdownPattern = regexp.MustCompile(`\.Shutdown\(|\.GracefulStop\(|\b(?:server|srv|httpServer)\.close\(|\.shutdown\(|\.server_close\(`)
+	// contextCancelPattern matches a cancellable root context for background work
+	contextCancelPattern = regexp.MustCompile(`\bcontext\.WithCancel(?:Cause)?\(|\bsignal\.NotifyContext\(|\bcancel\(\)|AbortController\(`)
+	// backgroundWorkPattern matches goroutines started with a function call
+	backgroundWorkPattern = regexp.MustCompile(`^\s*go\s+(?:func\b|[\w.]+\()`)
+	// fatalServePattern matches a server whose return value is fed straight to a fatal exit
+	fatalServePattern = regexp.MustCompile(`\blog\.Fatal(?:f|ln)?\(.*(?:ListenAndServe(?:TLS)?|\.Serve(?:TLS)?|\.Start(?:TLS)?)\(`)
+)
+
+// handleValidateGracefulShutdown flags added service entry points that start a server
+// without trapping termination signals and shutting down gracefully
+func (s *MCPServer) handleValidateGracefulShutdown(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkGracefulShutdown(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkGracefulShutdown looks at each file of a diff that adds a server start. The file
+// must trap SIGTERM/SIGINT and stop the server with a draining Shutdown call, otherwise
+// the service exits abruptly and drops in-flight requests on every deploy. Context lines
+// of the diff count as evidence of handling, so a shutdown path that already exists is
+// not reported again. Goroutines without a cancellable context and servers wrapped in
+// log.Fatal (which exits as soon as Shutdown makes Serve return) are warnings.
+func checkGracefulShutdown(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+
+	type fileSignals struct {
+		serverStart  *diffLine
+		goroutine    *diffLine
+		signalTrap   bool
+		shutdown     bool
+		cancellation bool
+	}
+	files := make(map[string]*fileSignals)
+	order := []string{}
+
+	scanned := 0
+	for i := range lines {
+		line := &lines[i]
+		if fileConcern(line.File) == "test" {
+			continue
+		}
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || isCommentLine(trimmed) {
+			continue
+		}
+		if line.Added {
+			scanned++
+		}
+
+		fs, ok := files[line.File]
+		if !ok {
+			fs = &fileSignals{}
+			files[line.File] = fs
+			order = append(order, line.File)
+		}
+		if signalTrapPattern.MatchString(line.Text) {
+			fs.signalTrap = true
+		}
+		if gracefulShutdownPattern.MatchString(line.Text) {
+			fs.shutdown = true
+		}
+		if contextCancelPattern.MatchString(line.Text) {
+			fs.cancellation = true
+		}
+		if !line.Added {
+			continue
+		}
+		if serverStartPattern.MatchString(line.Text) && fs.serverStart == nil {
+			fs.serverStart = line
+		}
+		if backgroundWorkPattern.MatchString(line.Text) && fs.goroutine == nil {
+			fs.goroutine = line
+		}
+		if fatalServePattern.MatchString(line.Text) {
+			violations = append(violations, models.DiffViolation{
+				Type:       "abrupt_exit",
+				Severity:   "warning",
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    "Server error is passed to log.Fatal; the process exits immediately once Shutdown makes Serve return",
+				Suggestion: "Ignore http.ErrServerClosed and return from main only after Shutdown has completed",
+			})
+		}
+	}
+
+	for _, file := range order {
+		fs := files[file]
+		if fs.serverStart == nil {
+			continue
+		}
+		start := fs.serverStart
+		trimmed := strings.TrimSpace(start.Text)
+
+		switch {
+		case !fs.signalTrap:
+			violations = append(violations, models.DiffViolation{
+				Type:       "missing_signal_handling",
+				Severity:   "error",
+				File:       start.File,
+				LineNumber: start.Number,
+				Line:       trimmed,
+				Message:    "Server is started without trapping SIGTERM/SIGINT; the service is killed mid-request on every deploy",
+				Suggestion: "Trap termination signals (signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM), process.on('SIGTERM'), signal.signal) and shut down on receipt",
+			})
+		case !fs.shutdown:
+			violations = append(violations, models.DiffViolation{
+				Type:       "missing_graceful_shutdown",
+				Severity:   "error",
+				File:       start.File,
+				LineNumber: start.Number,
+				Line:       trimmed,
+				Message:    "Termination signals are trapped but the server is never shut down gracefully",
+				Suggestion: "On signal, call srv.Shutdown(ctx) with a timeout (server.close() in Node) to drain in-flight requests before exiting",
+			})
+		}
+
+		if fs.goroutine != nil && !fs.cancellation {
+			violations = append(violations, models.DiffViolation{
+				Type:       "missing_context_cancellation",
+				Severity:   "warning",
+				File:       fs.goroutine.File,
+				LineNumber: fs.goroutine.Number,
+				Line:       strings.TrimSpace(fs.goroutine.Text),
+				Message:    "Background goroutine in a service without a cancellable context; it cannot be stopped on shutdown",
+				Suggestion: "Derive a context with signal.NotifyContext or context.WithCancel, pass it to workers and cancel it on shutdown",
+			})
+		}
+	}
+
+	return newDiffScanResult("graceful shutdown", violations, scanned)
+}
@@ -0,0 +1,115 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckGracefulShutdown tests detection of services that exit without draining
+func TestCheckGracefulShutdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		diff      string
+		wantValid bool
+		wantTypes []string
+	}{
+		{
+			name: "main without signal handling flagged",
+			diff: "+++ b/cmd/api/main.go\n" +
+				"+func main() {\n" +
+				"+\tsrv := &http.Server{Addr: \":8080\", Handler: router()}\n" +
+				"+\tif err := srv.ListenAndServe(); err != nil {\n" +
+				"+\t\tslog.Error(\"server stopped\", \"error\", err)\n" +
+				"+\t}\n" +
+				"+}\n",
+			wantValid: false,
+			wantTypes: []string{"missing_signal_handling"},
+		},
+		{
+			name: "main with proper shutdown passes",
+			diff: "+++ b/cmd/api/main.go\n" +
+				"+func main() {\n" +
+				"+\tctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)\n" +
+				"+\tdefer stop()\n" +
+				"+\tsrv := &http.Server{Addr: \":8080\", Handler: router()}\n" +
+				"+\tgo worker(ctx)\n" +
+				"+\tgo func() {\n" +
+				"+\t\tif err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {\n" +
+				"+\t\t\tslog.Error(\"server failed\", \"error\", err)\n" +
+				"+\t\t}\n" +
+				"+\t}()\n" +
+				"+\t<-ctx.Done()\n" +
+				"+\tshutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)\n" +
+				"+\tdefer cancel()\n" +
+				"+\tsrv.Shutdown(shutdownCtx)\n" +
+				"+}\n",
+			wantValid: true,
+		},
+		{
+			name: "signal trapped without shutdown flagged",
+			diff: "+++ b/cmd/api/main.go\n" +
+				"+\tsigCh := make(chan os.Signal, 1)\n" +
+				"+\tsignal.Notify(sigCh, syscall.SIGTERM)\n" +
+				"+\tgo e.Start(\":8080\")\n" +
+				"+\t<-sigCh\n" +
+				"+\tos.Exit(0)\n",
+			wantValid: false,
+			wantTypes: []string{"missing_graceful_shutdown", "missing_context_cancellation"},
+		},
+		{
+			name: "existing shutdown path in context lines passes",
+			diff: "+++ b/cmd/api/main.go\n" +
+				"@@ -10,3 +10,4 @@\n" +
+				" \tctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)\n" +
+				"+\tgo srv.Serve(listener)\n" +
+				" \t<-ctx.Done()\n" +
+				" \tsrv.Shutdown(shutdownCtx)\n",
+			wantValid: true,
+		},
+		{
+			name:      "log.Fatal around ListenAndServe warns",
+			diff:      "+++ b/main.go\n+\tsignal.Notify(ch, syscall.SIGTERM)\n+\tlog.Fatal(http.ListenAndServe(\":8080\", nil))\n+\tsrv.Shutdown(ctx)\n",
+			wantValid: true,
+			wantTypes: []string{"abrupt_exit"},
+		},
+		{
+			name:      "node server without SIGTERM handler flagged",
+			diff:      "+++ b/src/index.js\n+const server = app.listen(3000, () => console.log('listening'))\n",
+			wantValid: false,
+			wantTypes: []string{"missing_signal_handling"},
+		},
+		{
+			name: "node server closing on SIGTERM passes",
+			diff: "+++ b/src/index.js\n" +
+				"+const server = app.listen(3000)\n" +
+				"+process.on('SIGTERM', () => server.close(() => process.exit(0)))\n",
+			wantValid: true,
+		},
+		{
+			name:      "test server skipped",
+			diff:      "+++ b/internal/web/server_test.go\n+\tgo srv.ListenAndServe()\n",
+			wantValid: true,
+		},
+		{
+			name:      "code without a server passes",
+			diff:      "+++ b/internal/util/strings.go\n+func Reverse(s string) string {\n+\treturn s\n+}\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkGracefulShutdown(tt.diff)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (violations: %+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("got %d violations, want %d: %+v", len(result.Violations), len(tt.wantTypes), result.Violations)
+			}
+			for i, want := range tt.wantTypes {
+				if result.Violations[i].Type != want {
+					t.Errorf("violation %d type = %s, want %s", i, result.Violations[i].Type, want)
+				}
+			}
+		})
+	}
+}
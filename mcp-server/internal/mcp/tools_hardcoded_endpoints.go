@@ -0,0 +1,151 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	ipv4Pattern = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	urlPattern  = regexp.MustCompile("\\bhttps?://[^\\s\"'`<>()\\]]+")
+
+	// documentationNets are reserved for examples (RFC 5737) and never routable
+	documentationNets = mustParseCIDRs("192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24")
+
+	// exampleHostSuffixes are reserved example/test domains (RFC 2606) and XML/JSON schema namespaces
+	exampleHostSuffixes = []string{
+		"localhost", "example.com", "example.org", "example.net",
+		".example", ".test", ".invalid", ".localhost",
+		"w3.org", "json-schema.org", "schemas.xmlsoap.org",
+	}
+)
+
+// handleDetectHardcodedEndpoints flags hardcoded IP addresses and absolute URLs in
+// added lines that should come from configuration
+func (s *MCPServer) handleDetectHardcodedEndpoints(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := detectHardcodedEndpoints(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// detectHardcodedEndpoints scans added lines for non-loopback IPs and non-example URLs.
+// Comment lines are skipped so documented examples do not trigger violations.
+func detectHardcodedEndpoints(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := addedLines(diff)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || isCommentLine(trimmed) {
+			continue
+		}
+
+		// URLs first, so IPs inside a URL are reported once
+		urlHosts := map[string]bool{}
+		for _, raw := range urlPattern.FindAllString(line.Text, -1) {
+			u, err := url.Parse(strings.TrimRight(raw, ".,;:"))
+			if err != nil || u.Hostname() == "" {
+				continue
+			}
+			host := u.Hostname()
+			urlHosts[host] = true
+			if isLocalOrExampleHost(host) {
+				continue
+			}
+			severity := "warning"
+			if ip := net.ParseIP(host); ip != nil && isPublicIP(ip) {
+				severity = "error"
+			}
+			violations = append(violations, models.DiffViolation{
+				Type:       "hardcoded_url",
+				Severity:   severity,
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    fmt.Sprintf("Hardcoded URL %s", u.Scheme+"://"+u.Host),
+				Suggestion: "Move the URL to configuration (environment variable or config file)",
+			})
+		}
+
+		for _, candidate := range ipv4Pattern.FindAllString(line.Text, -1) {
+			ip := net.ParseIP(candidate)
+			if ip == nil || urlHosts[candidate] || ip.IsLoopback() || ip.IsUnspecified() || inNets(ip, documentationNets) {
+				continue
+			}
+			severity, kind := "warning", "private"
+			if isPublicIP(ip) {
+				severity, kind = "error", "public"
+			}
+			violations = append(violations, models.DiffViolation{
+				Type:       "hardcoded_ip",
+				Severity:   severity,
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    fmt.Sprintf("Hardcoded %s IP address %s", kind, candidate),
+				Suggestion: "Resolve the address from configuration or service discovery instead of embedding it",
+			})
+		}
+	}
+
+	return newDiffScanResult("hardcoded endpoint", violations, len(lines))
+}
+
+// isLocalOrExampleHost reports whether a URL host is loopback or a reserved example domain
+func isLocalOrExampleHost(host string) bool {
+	host = strings.ToLower(host)
+	if ip := net.ParseIP(host); ip != nil {
+		return ip.IsLoopback() || ip.IsUnspecified() || inNets(ip, documentationNets)
+	}
+	for _, suffix := range exampleHostSuffixes {
+		if host == strings.TrimPrefix(suffix, ".") || strings.HasSuffix(host, "."+strings.TrimPrefix(suffix, ".")) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPublicIP reports whether an IP is globally routable
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsUnspecified() && !ip.IsMulticast() && !inNets(ip, documentationNets)
+}
+
+// inNets reports whether ip is contained in any of nets
+func inNets(ip net.IP, nets []*net.IPNet) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// mustParseCIDRs parses CIDR literals, panicking on invalid input (package init only)
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}
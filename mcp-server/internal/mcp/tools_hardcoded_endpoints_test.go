@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestDetectHardcodedEndpoints tests detection of hardcoded IPs and URLs in added lines
+func TestDetectHardcodedEndpoints(t *testing.T) {
+	tests := []struct {
+		name      string
+		diff      string
+		wantValid bool
+		wantTypes []string
+	}{
+		{
+			name:      "hardcoded public IP flagged",
+			diff:      "+++ b/client.go\n@@ -1,1 +1,2 @@\n package client\n+\tconn, err := net.Dial(\"tcp\", \"8.8.8.8:53\")\n",
+			wantValid: false,
+			wantTypes: []string{"hardcoded_ip"},
+		},
+		{
+			name:      "localhost reference passes",
+			diff:      "+\tresp, err := http.Get(\"http://localhost:8080/health\")\n+\taddr := \"127.0.0.1:6379\"\n",
+			wantValid: true,
+		},
+		{
+			name:      "private IP warns",
+			diff:      "+\tdbHost := \"10.0.3.12\"\n",
+			wantValid: true,
+			wantTypes: []string{"hardcoded_ip"},
+		},
+		{
+			name:      "absolute URL warns",
+			diff:      "+\tconst apiBase = \"https://api.payments.internal.corp/v1\"\n",
+			wantValid: true,
+			wantTypes: []string{"hardcoded_url"},
+		},
+		{
+			name:      "URL with public IP host reported once",
+			diff:      "+\tendpoint := \"http://52.1.2.3:9000/ingest\"\n",
+			wantValid: false,
+			wantTypes: []string{"hardcoded_url"},
+		},
+		{
+			name:      "documented example ignored",
+			diff:      "+// Example: curl https://api.acme.io/v1 from 8.8.4.4\n+\tbase := \"https://example.com/api\"\n+\tip := \"192.0.2.10\"\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := detectHardcodedEndpoints(tt.diff)
+			if result.Valid != tt.wantValid {
+				t.Errorf("detectHardcodedEndpoints() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("violations = %+v, want types %v", result.Violations, tt.wantTypes)
+			}
+			for i, typ := range tt.wantTypes {
+				if result.Violations[i].Type != typ {
+					t.Errorf("violation[%d] type = %s, want %s", i, result.Violations[i].Type, typ)
+				}
+			}
+		})
+	}
+}
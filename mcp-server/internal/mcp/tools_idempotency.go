@@ -0,0 +1,117 @@
+package mcp
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// mutatingRoutePattern matches registration or dispatch of POST/PUT/PATCH handlers
+	// across common Go, Node, Python and Java frameworks
+	mutatingRoutePattern = regexp.MustCompile(`\.(?:POST|PUT|PATCH|Post|Put|Patch|post|put|patch)\(|\bhttp\.Method(?:Post|Put|Patch)\b|Methods\("(?:POST|PUT|PATCH)"|@(?:Post|Put|Patch)Mapping\b|methods=\[[^\]]*["'](?:POST|PUT|PATCH)["']`)
+	// idempotencyKeyPattern matches handling of an idempotency or deduplication key
+	idempotencyKeyPattern = regexp.MustCompile(`(?i)idempotency[-_]?key|idempotent|dedup(?:e|lication)?[-_]?key|request[-_]?dedup`)
+	// sideEffectPattern matches operations that repeat their effect when a request is retried
+	sideEffectPattern = regexp.MustCompile(`(?i)\binsert\s+into\b|\.(?:Create|Insert|InsertOne|Enqueue|Publish|Send|Charge)\(|\b(?:charge|send(?:Email|Mail|Notification|SMS)|publish|enqueue)\w*\(|\bset\s+\w+\s*=\s*\w+\s*[+-]\s*\d`)
+	// sideEffectGuardPattern matches constructs that make a write safe to repeat
+	sideEffectGuardPattern = regexp.MustCompile(`(?i)on\s+conflict|on\s+duplicate\s+key|insert\s+or\s+ignore|insert\s+ignore|\bupsert\b|\bmerge\s+into\b|FirstOrCreate|if\s+not\s+exists|where\s+not\s+exists`)
+)
+
+// handleValidateIdempotency flags added mutating HTTP handlers that neither accept an
+// idempotency key nor guard their side effects against retries
+func (s *MCPServer) handleValidateIdempotency(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkIdempotency(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkIdempotency scans the added lines of a diff file by file. A file that adds
+// POST/PUT/PATCH handlers without any idempotency key handling is flagged, and so is
+// each unguarded side effect in it. Guards and keys on context lines count, since the
+// surrounding handler may already implement them.
+func checkIdempotency(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+
+	files := []string{}
+	byFile := make(map[string][]diffLine)
+	for _, line := range lines {
+		if _, ok := byFile[line.File]; !ok {
+			files = append(files, line.File)
+		}
+		byFile[line.File] = append(byFile[line.File], line)
+	}
+
+	scanned := 0
+	for _, file := range files {
+		fileLines := byFile[file]
+		hasKey := false
+		for _, line := range fileLines {
+			if line.Added {
+				scanned++
+			}
+			if idempotencyKeyPattern.MatchString(line.Text) {
+				hasKey = true
+			}
+		}
+		if hasKey || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		hasRoute := false
+		for _, line := range fileLines {
+			trimmed := strings.TrimSpace(line.Text)
+			if !line.Added || trimmed == "" || isCommentLine(trimmed) {
+				continue
+			}
+			if mutatingRoutePattern.MatchString(line.Text) {
+				hasRoute = true
+				violations = append(violations, models.DiffViolation{
+					Type:       "missing_idempotency_key",
+					Severity:   "error",
+					File:       line.File,
+					LineNumber: line.Number,
+					Line:       trimmed,
+					Message:    "Mutating endpoint added without idempotency key support; client retries can apply the change twice",
+					Suggestion: "Accept an Idempotency-Key header, store the first response per key and replay it on retries",
+				})
+			}
+		}
+		if !hasRoute {
+			continue
+		}
+
+		for _, line := range fileLines {
+			trimmed := strings.TrimSpace(line.Text)
+			if !line.Added || trimmed == "" || isCommentLine(trimmed) {
+				continue
+			}
+			if sideEffectPattern.MatchString(line.Text) && !sideEffectGuardPattern.MatchString(line.Text) {
+				violations = append(violations, models.DiffViolation{
+					Type:       "unguarded_side_effect",
+					Severity:   "warning",
+					File:       line.File,
+					LineNumber: line.Number,
+					Line:       trimmed,
+					Message:    "Non-idempotent side effect in a mutating handler runs again on every retry",
+					Suggestion: "Guard the write (ON CONFLICT / upsert / existence check) or key it on the request's idempotency key",
+				})
+			}
+		}
+	}
+
+	return newDiffScanResult("idempotency", violations, scanned)
+}
@@ -0,0 +1,78 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckIdempotency tests detection of mutating handlers without idempotency support
+func TestCheckIdempotency(t *testing.T) {
+	tests := []struct {
+		name      string
+		diff      string
+		wantValid bool
+		wantTypes []string
+	}{
+		{
+			name: "create handler without idempotency key flagged",
+			diff: "+++ b/internal/api/orders.go\n@@ -1,1 +1,6 @@\n package api\n" +
+				"+\te.POST(\"/orders\", h.createOrder)\n" +
+				"+func (h *Handler) createOrder(c echo.Context) error {\n" +
+				"+\t_, err := h.db.ExecContext(ctx, \"INSERT INTO orders (id, total) VALUES ($1, $2)\", id, total)\n" +
+				"+\th.payments.Charge(ctx, total)\n" +
+				"+}\n",
+			wantValid: false,
+			wantTypes: []string{"missing_idempotency_key", "unguarded_side_effect", "unguarded_side_effect"},
+		},
+		{
+			name: "handler honoring idempotency key passes",
+			diff: "+++ b/internal/api/orders.go\n@@ -1,1 +1,6 @@\n package api\n" +
+				"+\te.POST(\"/orders\", h.createOrder)\n" +
+				"+func (h *Handler) createOrder(c echo.Context) error {\n" +
+				"+\tkey := c.Request().Header.Get(\"Idempotency-Key\")\n" +
+				"+\t_, err := h.db.ExecContext(ctx, \"INSERT INTO orders (id, key) VALUES ($1, $2)\", id, key)\n" +
+				"+}\n",
+			wantValid: true,
+		},
+		{
+			name: "key handling on context line counts",
+			diff: "+++ b/routes.js\n@@ -1,2 +1,3 @@\n const requireIdempotencyKey = require('./idempotency')\n" +
+				"+app.post('/payments', requireIdempotencyKey, createPayment)\n",
+			wantValid: true,
+		},
+		{
+			name: "guarded insert only reports missing key",
+			diff: "+++ b/api/users.py\n" +
+				"+@router.post(\"/users\")\n" +
+				"+    db.execute(\"INSERT INTO users (email) VALUES (%s) ON CONFLICT (email) DO NOTHING\", email)\n",
+			wantValid: false,
+			wantTypes: []string{"missing_idempotency_key"},
+		},
+		{
+			name:      "side effects outside mutating handlers ignored",
+			diff:      "+++ b/worker.go\n+\tq.Publish(ctx, msg)\n",
+			wantValid: true,
+		},
+		{
+			name:      "GET route ignored",
+			diff:      "+\te.GET(\"/orders\", h.listOrders)\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkIdempotency(tt.diff)
+			if result.Valid != tt.wantValid {
+				t.Errorf("checkIdempotency() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("violations = %+v, want types %v", result.Violations, tt.wantTypes)
+			}
+			for i, typ := range tt.wantTypes {
+				if result.Violations[i].Type != typ {
+					t.Errorf("violation[%d] type = %s, want %s", i, result.Violations[i].Type, typ)
+				}
+			}
+		})
+	}
+}
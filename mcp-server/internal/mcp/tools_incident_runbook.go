@@ -0,0 +1,180 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// runbookSection is a section an incident runbook must have, recognised by any of
+// its heading keywords
+type runbookSection struct {
+	name     string
+	keywords []string
+}
+
+// requiredRunbookSections follow an incident from first alert to the postmortem
+var requiredRunbookSections = []runbookSection{
+	{"detection", []string{"detection", "detect", "alerting", "alerts", "symptoms"}},
+	{"triage", []string{"triage", "diagnos", "assessment", "investigation"}},
+	{"mitigation", []string{"mitigation", "mitigate", "remediation", "workaround"}},
+	{"rollback", []string{"rollback", "roll back", "revert"}},
+	{"comms", []string{"communication", "comms", "stakeholder", "status page"}},
+	{"postmortem", []string{"postmortem", "post-mortem", "post mortem", "retrospective", "incident review"}},
+}
+
+// runbookContactKeywords recognise the on-call and escalation contacts section
+var runbookContactKeywords = []string{"on-call", "oncall", "on call", "escalation", "contacts"}
+
+var (
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s+(.+?)\s*#*\s*$`)
+	// runbookLink matches a markdown link or bare URL, e.g. to the postmortem template
+	runbookLink = regexp.MustCompile(`\[[^\]]+\]\([^)]+\)|https?://\S+`)
+	// runbookContact matches a way to reach someone: a list entry, @handle, email or phone number
+	runbookContact = regexp.MustCompile(`(?m)^\s*(?:[-*+]|\d+\.)\s+\S|@[\w.-]+|\+?\d[\d\s().-]{6,}\d`)
+)
+
+// markdownSection is a heading and the text under it, including its subsections
+type markdownSection struct {
+	title string
+	body  string
+}
+
+// handleValidateIncidentRunbook checks that an incident runbook covers detection,
+// triage, mitigation, rollback, communications and the postmortem and lists
+// on-call and escalation contacts
+func (s *MCPServer) handleValidateIncidentRunbook(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	content, _ := args["content"].(string)
+
+	if strings.TrimSpace(content) == "" {
+		result := models.IncidentRunbookResult{
+			Valid:         false,
+			Message:       "content is required",
+			SectionsFound: []string{},
+			Issues:        []models.RunbookIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkIncidentRunbook(content)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkIncidentRunbook matches the runbook's headings against the required sections.
+// A missing section, a postmortem section without a link, and no on-call or
+// escalation contacts are errors; a required section with nothing under it is a
+// warning.
+func checkIncidentRunbook(content string) models.IncidentRunbookResult {
+	sections := parseMarkdownSections(content)
+	found := []string{}
+	issues := []models.RunbookIssue{}
+	issue := func(section, severity, message string) {
+		issues = append(issues, models.RunbookIssue{Section: section, Severity: severity, Message: message})
+	}
+
+	for _, required := range requiredRunbookSections {
+		section, ok := findMarkdownSection(sections, required.keywords)
+		if !ok {
+			issue(required.name, "error", fmt.Sprintf("No %s section", required.name))
+			continue
+		}
+		found = append(found, required.name)
+		if strings.TrimSpace(section.body) == "" {
+			issue(required.name, "warning", fmt.Sprintf("Section %q is empty", section.title))
+			continue
+		}
+		if required.name == "postmortem" && !runbookLink.MatchString(section.body) {
+			issue(required.name, "error", fmt.Sprintf("Section %q does not link to the postmortem template or document", section.title))
+		}
+	}
+
+	contacts, ok := findMarkdownSection(sections, runbookContactKeywords)
+	switch {
+	case !ok:
+		issue("contacts", "error", "No on-call or escalation contacts section")
+	case !runbookContact.MatchString(contacts.body):
+		issue("contacts", "error", fmt.Sprintf("Section %q lists no contacts", contacts.title))
+	default:
+		found = append(found, "contacts")
+	}
+
+	errorCount := 0
+	for _, i := range issues {
+		if i.Severity == "error" {
+			errorCount++
+		}
+	}
+
+	message := "Runbook has every required section and contacts"
+	if errorCount > 0 {
+		message = fmt.Sprintf("Runbook is incomplete: %d gap(s)", errorCount)
+	} else if len(issues) > 0 {
+		message = fmt.Sprintf("Runbook is complete with %d warning(s)", len(issues))
+	}
+
+	return models.IncidentRunbookResult{
+		Valid:         errorCount == 0,
+		Message:       message,
+		SectionsFound: found,
+		Issues:        issues,
+	}
+}
+
+// parseMarkdownSections splits markdown into its headed sections. A section's body
+// runs to the next heading of the same or a higher level, so it includes its
+// subsections. Headings inside fenced code blocks are ignored.
+func parseMarkdownSections(content string) []markdownSection {
+	type heading struct {
+		level int
+		title string
+		line  int
+	}
+	lines := strings.Split(content, "\n")
+	var headings []heading
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if m := markdownHeading.FindStringSubmatch(line); m != nil {
+			headings = append(headings, heading{level: len(m[1]), title: m[2], line: i})
+		}
+	}
+
+	sections := make([]markdownSection, 0, len(headings))
+	for i, h := range headings {
+		end := len(lines)
+		for _, next := range headings[i+1:] {
+			if next.level <= h.level {
+				end = next.line
+				break
+			}
+		}
+		sections = append(sections, markdownSection{
+			title: h.title,
+			body:  strings.Join(lines[h.line+1:end], "\n"),
+		})
+	}
+	return sections
+}
+
+// findMarkdownSection returns the first section whose title contains one of the keywords
+func findMarkdownSection(sections []markdownSection, keywords []string) (markdownSection, bool) {
+	for _, section := range sections {
+		title := strings.ToLower(section.title)
+		for _, keyword := range keywords {
+			if strings.Contains(title, keyword) {
+				return section, true
+			}
+		}
+	}
+	return markdownSection{}, false
+}
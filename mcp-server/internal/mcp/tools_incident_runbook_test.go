@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// completeRunbook is an incident runbook with every required section
+const completeRunbook = `# Checkout outage runbook
+
+## Detection
+The checkout-5xx alert fires when the error rate is above 2% for 5 minutes.
+
+## Triage
+Check the checkout dashboard and recent deploys.
+
+## Mitigation
+Scale the checkout service or fail over to the secondary region.
+
+## Rollback
+Redeploy the previous release with ` + "`deploy rollback checkout`" + `.
+
+## Communications
+Post updates to #incidents and the status page every 30 minutes.
+
+## Postmortem
+Use the [postmortem template](https://wiki.example.com/postmortem) within 5 days.
+
+## On-call and escalation
+- Primary: @checkout-oncall
+- Escalation: payments-lead@example.com
+`
+
+// TestCheckIncidentRunbook tests that missing sections and contacts are flagged
+func TestCheckIncidentRunbook(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantValid  bool
+		wantIssues []string // section/severity
+	}{
+		{
+			name:      "complete runbook passes",
+			content:   completeRunbook,
+			wantValid: true,
+		},
+		{
+			name:       "missing rollback section flagged",
+			content:    strings.Replace(completeRunbook, "## Rollback\n", "## Notes\n", 1),
+			wantValid:  false,
+			wantIssues: []string{"rollback/error"},
+		},
+		{
+			name:       "postmortem without a link flagged",
+			content:    strings.Replace(completeRunbook, "[postmortem template](https://wiki.example.com/postmortem)", "the postmortem template", 1),
+			wantValid:  false,
+			wantIssues: []string{"postmortem/error"},
+		},
+		{
+			name:       "contacts section without contacts flagged",
+			content:    strings.Replace(completeRunbook, "- Primary: @checkout-oncall\n- Escalation: payments-lead@example.com\n", "TBD\n", 1),
+			wantValid:  false,
+			wantIssues: []string{"contacts/error"},
+		},
+		{
+			name:       "empty section is a warning",
+			content:    strings.Replace(completeRunbook, "Check the checkout dashboard and recent deploys.\n", "", 1),
+			wantValid:  true,
+			wantIssues: []string{"triage/warning"},
+		},
+		{
+			name:       "headings in code blocks ignored",
+			content:    strings.Replace(completeRunbook, "## Rollback\n", "```\n## Rollback\n```\n", 1),
+			wantValid:  false,
+			wantIssues: []string{"rollback/error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkIncidentRunbook(tt.content)
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if len(result.Issues) != len(tt.wantIssues) {
+				t.Fatalf("issues = %+v, want %v", result.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := result.Issues[i].Section + "/" + result.Issues[i].Severity; got != want {
+					t.Errorf("issue[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleValidateIncidentRunbook tests the tool result for a missing section and no content
+func TestHandleValidateIncidentRunbook(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		content   string
+		wantError bool
+		wantText  string
+	}{
+		{name: "complete", content: completeRunbook, wantError: false, wantText: "every required section"},
+		{name: "missing rollback", content: strings.Replace(completeRunbook, "## Rollback", "## Notes", 1), wantError: true, wantText: "No rollback section"},
+		{name: "no content", content: "", wantError: true, wantText: "content is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateIncidentRunbook(context.Background(), map[string]interface{}{"content": tt.content})
+			if err != nil {
+				t.Fatalf("handleValidateIncidentRunbook() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// MessageConsumer is a registered consumer of a message topic and the fields it reads
+type MessageConsumer struct {
+	Name   string   `json:"name"`
+	Topic  string   `json:"topic"`
+	Fields []string `json:"fields"`
+}
+
+// ConsumerRegistryFile represents the consumer-registry.json structure
+type ConsumerRegistryFile struct {
+	Version   string            `json:"version"`
+	Consumers []MessageConsumer `json:"consumers"`
+}
+
+// loadConsumerRegistry loads registered message consumers from the guardrails repo
+func (s *MCPServer) loadConsumerRegistry() ([]MessageConsumer, error) {
+	registryPath := filepath.Join(s.getRepoPath(), ".guardrails", "consumer-registry.json")
+
+	data, err := os.ReadFile(registryPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read consumer registry: %w", err)
+	}
+
+	var registry ConsumerRegistryFile
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse consumer registry: %w", err)
+	}
+	return registry.Consumers, nil
+}
+
+// handleValidateIntegrationContract checks a message/event schema change against
+// the fields and topics its consumers depend on
+func (s *MCPServer) handleValidateIntegrationContract(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	originalSchema, _ := args["original_schema"].(string)
+	modifiedSchema, _ := args["modified_schema"].(string)
+	topic, _ := args["topic"].(string)
+	newTopic, _ := args["new_topic"].(string)
+
+	if originalSchema == "" || modifiedSchema == "" {
+		result := models.IntegrationContractResult{
+			Valid:   false,
+			Message: "original_schema and modified_schema are required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	var consumers []MessageConsumer
+	if raw, ok := args["consumers"]; ok {
+		data, _ := json.Marshal(raw)
+		if err := json.Unmarshal(data, &consumers); err != nil {
+			result := models.IntegrationContractResult{
+				Valid:   false,
+				Message: fmt.Sprintf("invalid consumers: %v", err),
+			}
+			return buildToolResult(result, true)
+		}
+	} else {
+		registered, err := s.loadConsumerRegistry()
+		if err != nil {
+			slog.Debug("No consumer registry available", "error", err)
+		}
+		consumers = registered
+	}
+
+	result, err := validateIntegrationContract(originalSchema, modifiedSchema, topic, newTopic, consumers)
+	if err != nil {
+		result := models.IntegrationContractResult{
+			Valid:   false,
+			Message: err.Error(),
+		}
+		return buildToolResult(result, true)
+	}
+	return buildToolResult(result, !result.Valid)
+}
+
+// validateIntegrationContract compares two message schemas and reports changes that
+// break consumers subscribed to the topic. Removed fields nobody reads are warnings.
+func validateIntegrationContract(originalSchema, modifiedSchema, topic, newTopic string, consumers []MessageConsumer) (models.IntegrationContractResult, error) {
+	before, err := schemaFields(originalSchema)
+	if err != nil {
+		return models.IntegrationContractResult{}, fmt.Errorf("failed to parse original_schema: %w", err)
+	}
+	after, err := schemaFields(modifiedSchema)
+	if err != nil {
+		return models.IntegrationContractResult{}, fmt.Errorf("failed to parse modified_schema: %w", err)
+	}
+
+	// Only consumers of this topic (or consumers registered without a topic) are affected
+	affected := make([]MessageConsumer, 0, len(consumers))
+	for _, c := range consumers {
+		if topic == "" || c.Topic == "" || c.Topic == topic {
+			affected = append(affected, c)
+		}
+	}
+
+	result := models.IntegrationContractResult{
+		Topic:         topic,
+		AddedFields:   []string{},
+		RemovedFields: []string{},
+		Violations:    []models.ContractViolation{},
+		ConsumerCount: len(affected),
+	}
+
+	for _, field := range sortedKeys(before) {
+		newType, exists := after[field]
+		dependents := consumersOfField(affected, field)
+		switch {
+		case !exists:
+			result.RemovedFields = append(result.RemovedFields, field)
+			v := models.ContractViolation{
+				Type:      "removed_field",
+				Severity:  "warning",
+				Field:     field,
+				Consumers: dependents,
+				Message:   fmt.Sprintf("Field %s was removed (no registered consumer reads it)", field),
+			}
+			if len(dependents) > 0 {
+				v.Severity = "error"
+				v.Message = fmt.Sprintf("Field %s was removed but is read by %s", field, strings.Join(dependents, ", "))
+			}
+			result.Violations = append(result.Violations, v)
+		case before[field] != "" && newType != "" && before[field] != newType && len(dependents) > 0:
+			result.Violations = append(result.Violations, models.ContractViolation{
+				Type:      "type_changed",
+				Severity:  "error",
+				Field:     field,
+				Consumers: dependents,
+				Message:   fmt.Sprintf("Field %s changed type from %s to %s but is read by %s", field, before[field], newType, strings.Join(dependents, ", ")),
+			})
+		}
+	}
+	for _, field := range sortedKeys(after) {
+		if _, exists := before[field]; !exists {
+			result.AddedFields = append(result.AddedFields, field)
+		}
+	}
+
+	if topic != "" && newTopic != "" && newTopic != topic {
+		subscribers := make([]string, 0, len(affected))
+		for _, c := range affected {
+			if c.Topic == topic {
+				subscribers = append(subscribers, c.Name)
+			}
+		}
+		v := models.ContractViolation{
+			Type:      "topic_renamed",
+			Severity:  "warning",
+			Consumers: subscribers,
+			Message:   fmt.Sprintf("Topic renamed from %s to %s", topic, newTopic),
+		}
+		if len(subscribers) > 0 {
+			v.Severity = "error"
+			v.Message = fmt.Sprintf("Topic renamed from %s to %s but %s still subscribe to %s", topic, newTopic, strings.Join(subscribers, ", "), topic)
+		}
+		result.Violations = append(result.Violations, v)
+	}
+
+	breaking := 0
+	for _, v := range result.Violations {
+		if v.Severity == "error" {
+			breaking++
+		}
+	}
+	result.Valid = breaking == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("Schema change is compatible with %d consumer(s)", len(affected))
+	} else {
+		result.Message = fmt.Sprintf("%d breaking change(s) for registered consumers", breaking)
+	}
+	return result, nil
+}
+
+// schemaFields flattens a message schema into dotted field paths mapped to their type.
+// JSON Schema documents (with "properties") use declared types; sample payloads use
+// the JSON type of each value.
+func schemaFields(schema string) (map[string]string, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &doc); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]string)
+	if props, ok := doc["properties"].(map[string]interface{}); ok {
+		collectSchemaProperties(props, "", fields)
+	} else {
+		collectPayloadFields(doc, "", fields)
+	}
+	return fields, nil
+}
+
+func collectSchemaProperties(props map[string]interface{}, prefix string, fields map[string]string) {
+	for name, raw := range props {
+		path := prefix + name
+		prop, _ := raw.(map[string]interface{})
+		fieldType, _ := prop["type"].(string)
+		fields[path] = fieldType
+		if nested, ok := prop["properties"].(map[string]interface{}); ok {
+			collectSchemaProperties(nested, path+".", fields)
+		}
+	}
+}
+
+func collectPayloadFields(obj map[string]interface{}, prefix string, fields map[string]string) {
+	for name, value := range obj {
+		path := prefix + name
+		switch v := value.(type) {
+		case map[string]interface{}:
+			fields[path] = "object"
+			collectPayloadFields(v, path+".", fields)
+		case []interface{}:
+			fields[path] = "array"
+		case string:
+			fields[path] = "string"
+		case float64:
+			fields[path] = "number"
+		case bool:
+			fields[path] = "boolean"
+		default:
+			fields[path] = ""
+		}
+	}
+}
+
+// consumersOfField returns the names of consumers reading field or one of its children
+func consumersOfField(consumers []MessageConsumer, field string) []string {
+	names := []string{}
+	for _, c := range consumers {
+		for _, f := range c.Fields {
+			if f == field || strings.HasPrefix(f, field+".") {
+				names = append(names, c.Name)
+				break
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestValidateIntegrationContract tests schema changes against registered consumers
+func TestValidateIntegrationContract(t *testing.T) {
+	original := `{"type":"object","properties":{"order_id":{"type":"string"},"amount":{"type":"number"},"customer":{"type":"object","properties":{"email":{"type":"string"}}}}}`
+	consumers := []MessageConsumer{
+		{Name: "billing", Topic: "orders.created", Fields: []string{"order_id", "amount"}},
+		{Name: "mailer", Topic: "orders.created", Fields: []string{"customer.email"}},
+		{Name: "analytics", Topic: "payments.settled", Fields: []string{"legacy_flag"}},
+	}
+
+	tests := []struct {
+		name      string
+		modified  string
+		newTopic  string
+		wantValid bool
+		wantType  string
+	}{
+		{
+			name:      "additive field passes",
+			modified:  `{"type":"object","properties":{"order_id":{"type":"string"},"amount":{"type":"number"},"currency":{"type":"string"},"customer":{"type":"object","properties":{"email":{"type":"string"}}}}}`,
+			wantValid: true,
+		},
+		{
+			name:      "removing consumed field flagged",
+			modified:  `{"type":"object","properties":{"order_id":{"type":"string"},"customer":{"type":"object","properties":{"email":{"type":"string"}}}}}`,
+			wantValid: false,
+			wantType:  "removed_field",
+		},
+		{
+			name:      "removing parent of consumed nested field flagged",
+			modified:  `{"type":"object","properties":{"order_id":{"type":"string"},"amount":{"type":"number"}}}`,
+			wantValid: false,
+			wantType:  "removed_field",
+		},
+		{
+			name:      "changing consumed field type flagged",
+			modified:  `{"type":"object","properties":{"order_id":{"type":"integer"},"amount":{"type":"number"},"customer":{"type":"object","properties":{"email":{"type":"string"}}}}}`,
+			wantValid: false,
+			wantType:  "type_changed",
+		},
+		{
+			name:      "renaming subscribed topic flagged",
+			modified:  original,
+			newTopic:  "orders.placed",
+			wantValid: false,
+			wantType:  "topic_renamed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := validateIntegrationContract(original, tt.modified, "orders.created", tt.newTopic, consumers)
+			if err != nil {
+				t.Fatalf("validateIntegrationContract() error = %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if tt.wantType != "" && (len(result.Violations) == 0 || result.Violations[0].Type != tt.wantType) {
+				t.Errorf("violations = %+v, want type %s", result.Violations, tt.wantType)
+			}
+			if result.ConsumerCount != 2 {
+				t.Errorf("consumer_count = %d, want 2 (other topics excluded)", result.ConsumerCount)
+			}
+		})
+	}
+}
+
+// TestValidateIntegrationContractPayloadSamples tests sample payloads instead of JSON Schema
+func TestValidateIntegrationContractPayloadSamples(t *testing.T) {
+	consumers := []MessageConsumer{{Name: "search-indexer", Fields: []string{"title"}}}
+
+	result, err := validateIntegrationContract(`{"id":1,"title":"a","draft":true}`, `{"id":1,"title":"a"}`, "", "", consumers)
+	if err != nil {
+		t.Fatalf("validateIntegrationContract() error = %v", err)
+	}
+	if !result.Valid || len(result.RemovedFields) != 1 || result.RemovedFields[0] != "draft" {
+		t.Errorf("removing unconsumed field should warn only, got %+v", result)
+	}
+
+	if _, err := validateIntegrationContract(`not json`, `{}`, "", "", nil); err == nil {
+		t.Error("expected error for invalid schema")
+	}
+}
@@ -0,0 +1,122 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// logCallPattern matches leveled log calls such as slog.Info(, logger.Errorf( or console.error(
+	logCallPattern = regexp.MustCompile(`\b(?:\w+\.)*(?:slog|log|logger|logrus|zap|sugar|console|logging)\.(?i:(debug|info|warn|warning|error))(?:f|w|Context)?\(`)
+	// goLoopPattern matches the opening line of a Go for loop
+	goLoopPattern = regexp.MustCompile(`^\s*for\b.*\{\s*$`)
+	// benignErrorPattern matches messages describing expected, recoverable conditions
+	benignErrorPattern = regexp.MustCompile(`(?i)\b(not found|no rows|cache miss|retrying|will retry|skipping|skipped|falling back|fallback|using default|already exists|deprecated|not configured|optional)\b`)
+	// formattedMessagePattern matches log messages built by formatting or concatenation
+	formattedMessagePattern = regexp.MustCompile(`\(\s*(?:ctx,\s*)?(?:fmt\.Sprintf\(|"[^"]*"\s*\+)`)
+)
+
+// handleValidateLoggingLevel flags added log statements at inappropriate levels or
+// without structured fields
+func (s *MCPServer) handleValidateLoggingLevel(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkLoggingLevels(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkLoggingLevels scans the added lines of a diff for verbose logs in loops,
+// error-level logs of expected conditions and unstructured messages. Context lines
+// are used to track Go loop nesting.
+func checkLoggingLevels(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+
+	scanned := 0
+	currentFile := ""
+	depth := 0
+	loopDepths := []int{}
+
+	for _, line := range lines {
+		if line.File != currentFile {
+			currentFile, depth, loopDepths = line.File, 0, loopDepths[:0]
+		}
+		trimmed := strings.TrimSpace(line.Text)
+		inLoop := len(loopDepths) > 0
+
+		if line.Added {
+			scanned++
+		}
+		if line.Added && trimmed != "" && !isCommentLine(trimmed) {
+			if m := logCallPattern.FindStringSubmatch(line.Text); m != nil {
+				violations = append(violations, checkLogCall(line, trimmed, strings.ToLower(m[1]), inLoop)...)
+			}
+		}
+
+		if goLoopPattern.MatchString(line.Text) {
+			loopDepths = append(loopDepths, depth)
+		}
+		depth += strings.Count(line.Text, "{") - strings.Count(line.Text, "}")
+		for len(loopDepths) > 0 && depth <= loopDepths[len(loopDepths)-1] {
+			loopDepths = loopDepths[:len(loopDepths)-1]
+		}
+	}
+
+	return newDiffScanResult("logging level", violations, scanned)
+}
+
+// checkLogCall applies the level heuristics to a single added log statement
+func checkLogCall(line diffLine, trimmed, level string, inLoop bool) []models.DiffViolation {
+	violations := []models.DiffViolation{}
+	add := func(kind, message, suggestion string) {
+		violations = append(violations, models.DiffViolation{
+			Type:       kind,
+			Severity:   "warning",
+			File:       line.File,
+			LineNumber: line.Number,
+			Line:       trimmed,
+			Message:    message,
+			Suggestion: suggestion,
+		})
+	}
+
+	switch level {
+	case "debug", "info":
+		if inLoop {
+			add("log_in_hot_loop",
+				fmt.Sprintf("%s log inside a loop is emitted once per iteration", strings.ToUpper(level[:1])+level[1:]),
+				"Log a summary after the loop, or sample/rate-limit the message")
+		}
+	case "error":
+		if m := benignErrorPattern.FindString(line.Text); m != "" {
+			add("error_level_for_non_error",
+				fmt.Sprintf("Error-level log describes an expected condition (%q)", m),
+				"Use Warn (or Info) for recoverable or expected conditions; reserve Error for failures needing attention")
+		}
+	}
+
+	if formattedMessagePattern.MatchString(line.Text) {
+		add("unstructured_log",
+			"Log message is built with formatting or concatenation instead of structured fields",
+			`Use a constant message with key-value fields, e.g. slog.Info("user created", "user_id", id)`)
+	} else if level == "error" && strings.Contains(line.Text, "slog.") && !strings.Contains(line.Text, ",") {
+		add("missing_fields",
+			"Error log has no structured fields",
+			`Attach the error and identifying context, e.g. slog.Error("save failed", "error", err, "id", id)`)
+	}
+
+	return violations
+}
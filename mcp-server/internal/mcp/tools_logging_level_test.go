@@ -0,0 +1,68 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckLoggingLevels tests detection of misleveled and unstructured log statements
+func TestCheckLoggingLevels(t *testing.T) {
+	tests := []struct {
+		name      string
+		diff      string
+		wantTypes []string
+	}{
+		{
+			name:      "error-level log of a non-error flagged",
+			diff:      "+++ b/cache.go\n@@ -1,1 +1,2 @@\n package cache\n+\tslog.Error(\"cache miss, falling back to database\", \"key\", key)\n",
+			wantTypes: []string{"error_level_for_non_error"},
+		},
+		{
+			name: "correct usage passes",
+			diff: "+\tif err := s.store.Save(ctx, user); err != nil {\n" +
+				"+\t\tslog.Error(\"failed to save user\", \"error\", err, \"user_id\", user.ID)\n" +
+				"+\t\treturn err\n+\t}\n" +
+				"+\tslog.Warn(\"cache miss\", \"key\", key)\n" +
+				"+\tslog.Info(\"user saved\", \"user_id\", user.ID)\n",
+		},
+		{
+			name: "debug log in loop flagged",
+			diff: "+++ b/worker.go\n@@ -10,3 +10,6 @@ func process(items []Item) {\n" +
+				" \tfor _, item := range items {\n" +
+				"+\t\tslog.Debug(\"processing item\", \"id\", item.ID)\n" +
+				" \t\thandle(item)\n" +
+				" \t}\n" +
+				"+\tslog.Info(\"processed items\", \"count\", len(items))\n",
+			wantTypes: []string{"log_in_hot_loop"},
+		},
+		{
+			name:      "formatted message flagged",
+			diff:      "+\tlogger.Info(fmt.Sprintf(\"user %s logged in\", name))\n",
+			wantTypes: []string{"unstructured_log"},
+		},
+		{
+			name:      "error without fields flagged",
+			diff:      "+\tslog.Error(\"save failed\")\n",
+			wantTypes: []string{"missing_fields"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkLoggingLevels(tt.diff)
+			if !result.Valid {
+				t.Errorf("checkLoggingLevels() valid = false, want true (warnings only)")
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("violations = %+v, want types %v", result.Violations, tt.wantTypes)
+			}
+			for i, typ := range tt.wantTypes {
+				if result.Violations[i].Type != typ {
+					t.Errorf("violation[%d] type = %s, want %s", i, result.Violations[i].Type, typ)
+				}
+				if result.Violations[i].Suggestion == "" {
+					t.Errorf("violation[%d] has no suggestion", i)
+				}
+			}
+		})
+	}
+}
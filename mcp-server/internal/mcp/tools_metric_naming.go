@@ -0,0 +1,265 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// maxMetricLabels is the number of labels above which a metric's series count is
+// likely to grow beyond what dashboards and storage handle well
+const maxMetricLabels = 5
+
+var (
+	// goMetricOptsPattern matches the start of a client_golang metric options literal
+	goMetricOptsPattern = regexp.MustCompile(`prometheus\.(Counter|Gauge|Histogram|Summary)Opts\s*\{`)
+	// goMetricFieldPattern matches a string field of a metric options literal
+	goMetricFieldPattern = regexp.MustCompile(`\b(Namespace|Subsystem|Name)\s*:\s*"([^"]*)"`)
+	// goLabelsPattern matches the label names slice that follows a Vec's options
+	goLabelsPattern = regexp.MustCompile(`^\s*,\s*\[\]string\s*\{([^}]*)\}`)
+	// pyMetricPattern matches a prometheus_client metric constructor with its labels
+	pyMetricPattern = regexp.MustCompile(`\b(Counter|Gauge|Histogram|Summary)\(\s*["']([^"']+)["']\s*,\s*["'][^"']*["'](?:\s*,\s*(?:labelnames\s*=\s*)?[\[(]([^\])]*)[\])])?`)
+	// jsMetricPattern matches the start of a prom-client metric constructor
+	jsMetricPattern = regexp.MustCompile(`new\s+(?:\w+\.)?(Counter|Gauge|Histogram|Summary)\(\s*\{`)
+	// jsFieldPattern and jsLabelsPattern extract the name and labelNames of a prom-client config
+	jsFieldPattern  = regexp.MustCompile(`\bname\s*:\s*["']([^"']+)["']`)
+	jsLabelsPattern = regexp.MustCompile(`\blabelNames\s*:\s*\[([^\]]*)\]`)
+	// quotedPattern extracts the quoted strings of a label list
+	quotedPattern = regexp.MustCompile(`["']([^"']*)["']`)
+
+	// metricNamePattern is the snake_case form metric and label names should take
+	metricNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(?:_[a-z0-9]+)*$`)
+	// highCardinalityLabelPattern matches labels whose values are unbounded per user or request
+	highCardinalityLabelPattern = regexp.MustCompile(`(?i)^(?:user_?id|uid|customer_?id|account_?id|email|e_?mail_?address|username|session_?id|request_?id|trace_?id|span_?id|correlation_?id|ip|ip_?address|client_?ip|remote_?addr|url|uri|query|token|uuid|guid|timestamp|order_?id|transaction_?id|device_?id)$`)
+	// timeMetricPattern matches names that measure time or size and so need a unit
+	timeMetricPattern = regexp.MustCompile(`(?:^|_)(?:duration|latency|time|elapsed|age|size|length)(?:_|$)`)
+	// baseUnitSuffixPattern matches the base-unit suffixes Prometheus recommends
+	baseUnitSuffixPattern = regexp.MustCompile(`_(?:seconds|bytes|ratio|meters|volts|amperes|joules|grams|celsius|info)(?:_total|_bucket|_count|_sum)?$`)
+	// nonBaseUnitPattern matches suffixes that use a scaled unit instead of the base unit
+	nonBaseUnitPattern = regexp.MustCompile(`_(?:ms|millis|milliseconds|microseconds|us|nanoseconds|ns|minutes|hours|days|kb|mb|gb|kilobytes|megabytes|gigabytes|percent)(?:_total)?$`)
+)
+
+// metricRegistration is a metric declared in added code
+type metricRegistration struct {
+	kind   string // counter, gauge, histogram, summary
+	name   string // full name, with namespace and subsystem where they are literals
+	labels []string
+	file   string
+	line   int
+	text   string
+}
+
+// handleValidateMetricNaming checks Prometheus metrics registered in a diff against
+// naming conventions and flags labels with unbounded cardinality
+func (s *MCPServer) handleValidateMetricNaming(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkMetricNaming(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkMetricNaming parses metric registrations (client_golang, prometheus_client and
+// prom-client) from the added lines of a diff. Names must be snake_case; counters end
+// in _total and other types do not; time and size metrics carry a base-unit suffix
+// such as _seconds or _bytes. Labels that identify users or requests are errors, since
+// every distinct value creates a new series.
+func checkMetricNaming(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	added := addedLines(diff)
+
+	for _, metric := range parseMetricRegistrations(added) {
+		violation := func(typ, severity, message, suggestion string) {
+			violations = append(violations, models.DiffViolation{
+				Type:       typ,
+				Severity:   severity,
+				File:       metric.file,
+				LineNumber: metric.line,
+				Line:       metric.text,
+				Message:    message,
+				Suggestion: suggestion,
+			})
+		}
+
+		switch {
+		case !metricNamePattern.MatchString(metric.name):
+			violation("metric_name_not_snake_case", "error",
+				fmt.Sprintf("Metric name %q is not snake_case", metric.name),
+				"Use lowercase words separated by single underscores, e.g. http_requests_total")
+		case metric.kind == "counter" && !strings.HasSuffix(metric.name, "_total"):
+			violation("counter_missing_total_suffix", "warning",
+				fmt.Sprintf("Counter %q should end in _total", metric.name),
+				fmt.Sprintf("Rename to %s_total", metric.name))
+		case metric.kind != "counter" && strings.HasSuffix(metric.name, "_total"):
+			violation("non_counter_total_suffix", "warning",
+				fmt.Sprintf("%s %q ends in _total, which is reserved for counters", metric.kind, metric.name),
+				"Drop the _total suffix or make the metric a counter")
+		}
+
+		switch {
+		case nonBaseUnitPattern.MatchString(metric.name):
+			violation("non_base_unit", "warning",
+				fmt.Sprintf("Metric %q uses a scaled unit", metric.name),
+				"Use base units (_seconds, _bytes, _ratio) and convert values when recording")
+		case timeMetricPattern.MatchString(strings.TrimSuffix(metric.name, "_total")) && !baseUnitSuffixPattern.MatchString(metric.name):
+			violation("missing_unit_suffix", "warning",
+				fmt.Sprintf("Metric %q measures time or size but has no unit suffix", metric.name),
+				"Add the base unit as a suffix, e.g. _seconds or _bytes")
+		}
+
+		for _, label := range metric.labels {
+			if highCardinalityLabelPattern.MatchString(label) {
+				violation("high_cardinality_label", "error",
+					fmt.Sprintf("Label %q on %q has unbounded values; each distinct value creates a new time series", label, metric.name),
+					"Drop the label and record per-user or per-request detail in logs or traces instead")
+			} else if !metricNamePattern.MatchString(label) {
+				violation("label_not_snake_case", "warning",
+					fmt.Sprintf("Label %q on %q is not snake_case", label, metric.name),
+					"Use lowercase words separated by single underscores")
+			}
+		}
+		if len(metric.labels) > maxMetricLabels {
+			violation("too_many_labels", "warning",
+				fmt.Sprintf("Metric %q has %d labels; series count grows with the product of their values", metric.name, len(metric.labels)),
+				fmt.Sprintf("Keep metrics to %d labels or fewer", maxMetricLabels))
+		}
+	}
+
+	return newDiffScanResult("metric naming", violations, len(added))
+}
+
+// parseMetricRegistrations finds metric registrations in added lines. Lines of each
+// file are joined so that registrations spanning several lines are parsed whole.
+func parseMetricRegistrations(lines []diffLine) []metricRegistration {
+	metrics := []metricRegistration{}
+
+	// Group consecutive lines by file, remembering where each line starts in the text
+	type fileText struct {
+		file   string
+		text   strings.Builder
+		starts []int
+		lines  []diffLine
+	}
+	var files []*fileText
+	for _, line := range lines {
+		if len(files) == 0 || files[len(files)-1].file != line.File {
+			files = append(files, &fileText{file: line.File})
+		}
+		ft := files[len(files)-1]
+		ft.starts = append(ft.starts, ft.text.Len())
+		ft.lines = append(ft.lines, line)
+		ft.text.WriteString(line.Text)
+		ft.text.WriteString("\n")
+	}
+
+	for _, ft := range files {
+		text := ft.text.String()
+		lineAt := func(offset int) diffLine {
+			i := len(ft.starts) - 1
+			for i > 0 && ft.starts[i] > offset {
+				i--
+			}
+			return ft.lines[i]
+		}
+		register := func(kind, name string, labels []string, offset int) {
+			line := lineAt(offset)
+			metrics = append(metrics, metricRegistration{
+				kind:   strings.ToLower(kind),
+				name:   name,
+				labels: labels,
+				file:   line.File,
+				line:   line.Number,
+				text:   strings.TrimSpace(line.Text),
+			})
+		}
+
+		for _, loc := range goMetricOptsPattern.FindAllStringSubmatchIndex(text, -1) {
+			end := matchingBrace(text, loc[1]-1)
+			if end < 0 {
+				continue
+			}
+			fields := make(map[string]string)
+			for _, m := range goMetricFieldPattern.FindAllStringSubmatch(text[loc[1]:end], -1) {
+				fields[m[1]] = m[2]
+			}
+			if fields["Name"] == "" {
+				continue
+			}
+			parts := []string{}
+			for _, key := range []string{"Namespace", "Subsystem", "Name"} {
+				if fields[key] != "" {
+					parts = append(parts, fields[key])
+				}
+			}
+			var labels []string
+			if m := goLabelsPattern.FindStringSubmatch(text[end+1:]); m != nil {
+				labels = quotedStrings(m[1])
+			}
+			register(text[loc[2]:loc[3]], strings.Join(parts, "_"), labels, loc[0])
+		}
+
+		for _, loc := range pyMetricPattern.FindAllStringSubmatchIndex(text, -1) {
+			var labels []string
+			if loc[6] >= 0 {
+				labels = quotedStrings(text[loc[6]:loc[7]])
+			}
+			register(text[loc[2]:loc[3]], text[loc[4]:loc[5]], labels, loc[0])
+		}
+
+		for _, loc := range jsMetricPattern.FindAllStringSubmatchIndex(text, -1) {
+			end := matchingBrace(text, loc[1]-1)
+			if end < 0 {
+				continue
+			}
+			config := text[loc[1]:end]
+			m := jsFieldPattern.FindStringSubmatch(config)
+			if m == nil {
+				continue
+			}
+			var labels []string
+			if l := jsLabelsPattern.FindStringSubmatch(config); l != nil {
+				labels = quotedStrings(l[1])
+			}
+			register(text[loc[2]:loc[3]], m[1], labels, loc[0])
+		}
+	}
+
+	return metrics
+}
+
+// matchingBrace returns the index of the brace closing the one at open, or -1
+func matchingBrace(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// quotedStrings returns the contents of the quoted strings in a list literal
+func quotedStrings(list string) []string {
+	values := []string{}
+	for _, m := range quotedPattern.FindAllStringSubmatch(list, -1) {
+		values = append(values, m[1])
+	}
+	return values
+}
@@ -0,0 +1,139 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckMetricNaming tests Prometheus metric naming and label cardinality checks
+func TestCheckMetricNaming(t *testing.T) {
+	tests := []struct {
+		name      string
+		diff      string
+		wantValid bool
+		wantTypes []string
+	}{
+		{
+			name: "total counter passes",
+			diff: "+++ b/internal/metrics/metrics.go\n" +
+				"+\tValidationsTotal = promauto.NewCounterVec(\n" +
+				"+\t\tprometheus.CounterOpts{\n" +
+				"+\t\t\tNamespace: namespace,\n" +
+				"+\t\t\tName:      \"validations_total\",\n" +
+				"+\t\t\tHelp:      \"Total number of validations\",\n" +
+				"+\t\t},\n" +
+				"+\t\t[]string{\"tool\", \"result\"},\n" +
+				"+\t)\n",
+			wantValid: true,
+		},
+		{
+			name: "user id label flagged",
+			diff: "+++ b/internal/metrics/metrics.go\n" +
+				"+\tLoginsTotal = promauto.NewCounterVec(prometheus.CounterOpts{\n" +
+				"+\t\tName: \"logins_total\",\n" +
+				"+\t\tHelp: \"Logins\",\n" +
+				"+\t}, []string{\"user_id\", \"method\"})\n",
+			wantValid: false,
+			wantTypes: []string{"high_cardinality_label"},
+		},
+		{
+			name:      "counter without total suffix warns",
+			diff:      "+\tErrors = promauto.NewCounter(prometheus.CounterOpts{Name: \"errors\", Help: \"Errors\"})\n",
+			wantValid: true,
+			wantTypes: []string{"counter_missing_total_suffix"},
+		},
+		{
+			name:      "camelCase name flagged",
+			diff:      "+\tQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{Name: \"queueDepth\", Help: \"Queue depth\"})\n",
+			wantValid: false,
+			wantTypes: []string{"metric_name_not_snake_case"},
+		},
+		{
+			name: "histogram in milliseconds warns",
+			diff: "+\tLatency = promauto.NewHistogram(prometheus.HistogramOpts{\n" +
+				"+\t\tSubsystem: \"db\",\n" +
+				"+\t\tName:      \"query_duration_ms\",\n" +
+				"+\t})\n",
+			wantValid: true,
+			wantTypes: []string{"non_base_unit"},
+		},
+		{
+			name:      "duration without unit warns",
+			diff:      "+\tLatency = promauto.NewHistogram(prometheus.HistogramOpts{Name: \"request_duration\"})\n",
+			wantValid: true,
+			wantTypes: []string{"missing_unit_suffix"},
+		},
+		{
+			name:      "gauge with total suffix warns",
+			diff:      "+\tActive = promauto.NewGauge(prometheus.GaugeOpts{Name: \"active_sessions_total\"})\n",
+			wantValid: true,
+			wantTypes: []string{"non_counter_total_suffix"},
+		},
+		{
+			name:      "python counter with email label flagged",
+			diff:      "+++ b/app/metrics.py\n+SIGNUPS = Counter('signups_total', 'Signups', ['email'])\n",
+			wantValid: false,
+			wantTypes: []string{"high_cardinality_label"},
+		},
+		{
+			name: "prom-client histogram passes",
+			diff: "+++ b/src/metrics.js\n" +
+				"+const httpDuration = new client.Histogram({\n" +
+				"+  name: 'http_request_duration_seconds',\n" +
+				"+  help: 'HTTP request duration',\n" +
+				"+  labelNames: ['method', 'route', 'status_code'],\n" +
+				"+})\n",
+			wantValid: true,
+		},
+		{
+			name:      "too many labels warns",
+			diff:      "+\tX = promauto.NewCounterVec(prometheus.CounterOpts{Name: \"jobs_total\"}, []string{\"a\", \"b\", \"c\", \"d\", \"e\", \"f\"})\n",
+			wantValid: true,
+			wantTypes: []string{"too_many_labels"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkMetricNaming(tt.diff)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (violations: %+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("got %d violations, want %d: %+v", len(result.Violations), len(tt.wantTypes), result.Violations)
+			}
+			for i, want := range tt.wantTypes {
+				if result.Violations[i].Type != want {
+					t.Errorf("violation %d type = %s, want %s", i, result.Violations[i].Type, want)
+				}
+			}
+		})
+	}
+}
+
+// TestParseMetricRegistrations tests that multi-line registrations are parsed with their full name and labels
+func TestParseMetricRegistrations(t *testing.T) {
+	lines := addedLines("+++ b/internal/metrics/metrics.go\n" +
+		"@@ -1,1 +10,7 @@\n" +
+		"+\tHTTPRequestDuration = promauto.NewHistogramVec(\n" +
+		"+\t\tprometheus.HistogramOpts{\n" +
+		"+\t\t\tNamespace: \"guardrail\",\n" +
+		"+\t\t\tSubsystem: \"http\",\n" +
+		"+\t\t\tName:      \"request_duration_seconds\",\n" +
+		"+\t\t},\n" +
+		"+\t\t[]string{\"method\", \"path\"},\n")
+
+	metrics := parseMetricRegistrations(lines)
+	if len(metrics) != 1 {
+		t.Fatalf("got %d metrics, want 1: %+v", len(metrics), metrics)
+	}
+	m := metrics[0]
+	if m.kind != "histogram" || m.name != "guardrail_http_request_duration_seconds" {
+		t.Errorf("got %s %s, want histogram guardrail_http_request_duration_seconds", m.kind, m.name)
+	}
+	if len(m.labels) != 2 || m.labels[0] != "method" || m.labels[1] != "path" {
+		t.Errorf("labels = %v, want [method path]", m.labels)
+	}
+	if m.line != 11 {
+		t.Errorf("line = %d, want 11 (the options literal)", m.line)
+	}
+}
@@ -0,0 +1,173 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// migrationVersionPattern extracts the leading version/timestamp of a migration filename,
+// e.g. 000012_add_users.up.sql, 20240115093000_init.sql or V3__create_table.sql
+var migrationVersionPattern = regexp.MustCompile(`^[Vv]?(\d+)`)
+
+// handleValidateMigrationOrder checks that migration versions are monotonically ordered
+// and that pending migrations do not sort before the latest applied migration
+func (s *MCPServer) handleValidateMigrationOrder(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	migrations := stringSliceArg(args, "migrations")
+	newMigrations := stringSliceArg(args, "new_migrations")
+	latestApplied, _ := args["latest_applied"].(string)
+
+	if len(migrations) == 0 && len(newMigrations) == 0 {
+		result := models.MigrationOrderResult{
+			Valid:   false,
+			Message: "migrations is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := validateMigrationOrder(migrations, newMigrations, latestApplied)
+	return buildToolResult(result, !result.Valid)
+}
+
+// validateMigrationOrder validates migration filenames in the order they will run.
+// Pending migrations are those in newMigrations plus any listed after latestApplied.
+func validateMigrationOrder(migrations, newMigrations []string, latestApplied string) models.MigrationOrderResult {
+	violations := []models.MigrationOrderViolation{}
+
+	// Monotonic ordering and duplicate detection across the full list
+	seen := make(map[string]string)
+	prevVersion, prevFile := "", ""
+	latestIndex := -1
+	for i, file := range migrations {
+		name := path.Base(file)
+		version, ok := migrationVersion(name)
+		if !ok {
+			violations = append(violations, models.MigrationOrderViolation{
+				Type:     "unparseable_version",
+				Severity: "error",
+				File:     name,
+				Message:  fmt.Sprintf("Migration %s does not start with a numeric version or timestamp", name),
+			})
+			continue
+		}
+
+		if latestApplied != "" && (name == path.Base(latestApplied) || version == normalizeVersion(latestApplied)) {
+			latestIndex = i
+		}
+
+		key := version + "/" + migrationDirection(name)
+		if other, dup := seen[key]; dup {
+			violations = append(violations, models.MigrationOrderViolation{
+				Type:     "duplicate_version",
+				Severity: "error",
+				File:     name,
+				Version:  version,
+				Message:  fmt.Sprintf("Migration %s reuses version %s already used by %s", name, version, other),
+			})
+		}
+		seen[key] = name
+
+		if prevVersion != "" && compareVersions(version, prevVersion) < 0 {
+			violations = append(violations, models.MigrationOrderViolation{
+				Type:     "out_of_order",
+				Severity: "error",
+				File:     name,
+				Version:  version,
+				Message:  fmt.Sprintf("Migration %s (version %s) is listed after %s (version %s)", name, version, prevFile, prevVersion),
+			})
+		}
+		prevVersion, prevFile = version, name
+	}
+
+	// Pending migrations must sort after the latest applied one
+	latestVersion := ""
+	if latestApplied != "" {
+		if v, ok := migrationVersion(path.Base(latestApplied)); ok {
+			latestVersion = v
+		}
+	}
+	if latestVersion != "" {
+		pending := append([]string{}, newMigrations...)
+		if latestIndex >= 0 {
+			pending = append(pending, migrations[latestIndex+1:]...)
+		}
+		flagged := make(map[string]bool)
+		for _, file := range pending {
+			name := path.Base(file)
+			version, ok := migrationVersion(name)
+			if !ok || flagged[name] || name == path.Base(latestApplied) {
+				continue
+			}
+			// Equal versions are the other half of an up/down pair of the latest migration
+			cmp := compareVersions(version, latestVersion)
+			if cmp < 0 || (cmp == 0 && migrationDirection(name) == "") {
+				flagged[name] = true
+				violations = append(violations, models.MigrationOrderViolation{
+					Type:     "before_latest_applied",
+					Severity: "error",
+					File:     name,
+					Version:  version,
+					Message:  fmt.Sprintf("Pending migration %s (version %s) is not newer than latest applied version %s and may never run", name, version, latestVersion),
+				})
+			}
+		}
+	}
+
+	result := models.MigrationOrderResult{
+		Valid:         len(violations) == 0,
+		Checked:       len(migrations) + len(newMigrations),
+		LatestApplied: latestApplied,
+		Violations:    violations,
+	}
+	if result.Valid {
+		result.Message = "Migrations are in order"
+	} else {
+		result.Message = fmt.Sprintf("%d migration ordering issue(s) found", len(violations))
+	}
+	return result
+}
+
+// migrationVersion returns the normalized numeric version prefix of a migration filename
+func migrationVersion(name string) (string, bool) {
+	m := migrationVersionPattern.FindStringSubmatch(name)
+	if m == nil {
+		return "", false
+	}
+	return normalizeVersion(m[1]), true
+}
+
+// normalizeVersion strips leading zeros so 0001 and 1 compare equal
+func normalizeVersion(v string) string {
+	v = strings.TrimLeft(strings.TrimPrefix(strings.TrimPrefix(v, "V"), "v"), "0")
+	if v == "" {
+		return "0"
+	}
+	return v
+}
+
+// compareVersions compares two normalized numeric strings of arbitrary length
+func compareVersions(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// migrationDirection returns "up" or "down" for paired migration files, empty otherwise
+func migrationDirection(name string) string {
+	switch {
+	case strings.Contains(name, ".up."):
+		return "up"
+	case strings.Contains(name, ".down."):
+		return "down"
+	}
+	return ""
+}
@@ -0,0 +1,70 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestValidateMigrationOrder tests migration ordering against the latest applied marker
+func TestValidateMigrationOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		migrations    []string
+		newMigrations []string
+		latestApplied string
+		wantValid     bool
+		wantType      string
+	}{
+		{
+			name:          "in-order migrations pass",
+			migrations:    []string{"000001_init.up.sql", "000001_init.down.sql", "000002_users.up.sql", "000002_users.down.sql", "000003_teams.up.sql"},
+			latestApplied: "000002_users.up.sql",
+			wantValid:     true,
+		},
+		{
+			name:          "out-of-order migration flagged",
+			migrations:    []string{"20240101000000_init.sql", "20240301000000_users.sql", "20240201000000_teams.sql"},
+			latestApplied: "20240101000000_init.sql",
+			wantValid:     false,
+			wantType:      "out_of_order",
+		},
+		{
+			name:          "new migration older than latest applied flagged",
+			migrations:    []string{"20240101000000_init.sql", "20240115000000_backfill.sql", "20240301000000_users.sql"},
+			newMigrations: []string{"20240115000000_backfill.sql"},
+			latestApplied: "20240301000000_users.sql",
+			wantValid:     false,
+			wantType:      "before_latest_applied",
+		},
+		{
+			name:          "latest applied given as version",
+			migrations:    []string{"001_init.sql", "002_users.sql"},
+			newMigrations: []string{"003_teams.sql"},
+			latestApplied: "2",
+			wantValid:     true,
+		},
+		{
+			name:       "duplicate version flagged",
+			migrations: []string{"001_init.sql", "002_users.sql", "002_teams.sql"},
+			wantValid:  false,
+			wantType:   "duplicate_version",
+		},
+		{
+			name:       "unparseable filename flagged",
+			migrations: []string{"001_init.sql", "add_users.sql"},
+			wantValid:  false,
+			wantType:   "unparseable_version",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateMigrationOrder(tt.migrations, tt.newMigrations, tt.latestApplied)
+			if result.Valid != tt.wantValid {
+				t.Errorf("validateMigrationOrder() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if tt.wantType != "" && (len(result.Violations) == 0 || result.Violations[0].Type != tt.wantType) {
+				t.Errorf("violations = %+v, want type %s", result.Violations, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// classificationTagPattern matches a data-classification annotation on a field, e.g.
+	// `classification:"restricted"`, // @classification(sensitive) or # data-classification: restricted
+	classificationTagPattern = regexp.MustCompile(`(?i)(?:@|data-)?classification\s*[:=(]\s*"?(public|internal|sensitive|restricted)\b`)
+	// fieldNamePattern captures the declared field name at the start of an annotated line
+	fieldNamePattern = regexp.MustCompile(`^\s*(?:(?:pub|private|public|readonly|let|var|const|val)\s+)*(?:self\.|this\.)?([A-Za-z_]\w*)`)
+	// jsonTagNamePattern captures the serialized name from a Go json struct tag
+	jsonTagNamePattern = regexp.MustCompile(`json:"([A-Za-z_]\w*)`)
+	// logSinkPattern matches print and log calls that are not leveled (leveled calls use logCallPattern)
+	logSinkPattern = regexp.MustCompile(`\b(?:log\.(?:Print|Fatal|Panic)\w*|fmt\.(?:Print|Fprint|Sprint)\w*|console\.log|print|println|logging\.\w+|logger\.\w+|System\.out\.print\w*)\(`)
+	// serializationSinkPattern matches calls that serialize data for output or storage
+	serializationSinkPattern = regexp.MustCompile(`\b(?:json\.(?:Marshal\w*|NewEncoder|dumps?)|JSON\.stringify|\w+\.JSON|to_json|serialize|Serialize\w*|yaml\.(?:Marshal|dump)|pickle\.dumps?)\(`)
+	identifierPattern        = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*`)
+)
+
+// classificationSeverity maps data classifications that must not reach logs or
+// serialized output to the severity of a violation
+var classificationSeverity = map[string]string{
+	"restricted": "error",
+	"sensitive":  "warning",
+}
+
+// classificationRank orders classifications from least to most restrictive
+var classificationRank = map[string]int{"public": 0, "internal": 1, "sensitive": 2, "restricted": 3}
+
+// handleValidatePIILogging flags added code that logs or serializes fields classified
+// as sensitive or restricted
+func (s *MCPServer) handleValidatePIILogging(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	classification := map[string]string{}
+	if _, ok := args["classification"]; ok {
+		raw, err := configArg(args, "classification")
+		if err != nil {
+			result := models.DiffScanResult{
+				Valid:   false,
+				Message: err.Error(),
+			}
+			return buildToolResult(result, true)
+		}
+		for field, level := range raw {
+			if l, ok := level.(string); ok {
+				classification[field] = l
+			}
+		}
+	}
+
+	result := checkPIILogging(diff, classification)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkPIILogging merges the classification map with classification tags found in
+// the diff (added or context lines), then scans added log and serialization calls
+// for classified field names. Names match case-insensitively with underscores
+// ignored, so date_of_birth also matches DateOfBirth.
+func checkPIILogging(diff string, classification map[string]string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+
+	// normalized field name -> classification; the stricter level wins on conflict
+	classified := make(map[string]string)
+	names := make(map[string]string)
+	classify := func(field, level string) {
+		key := normalizeFieldName(field)
+		level = strings.ToLower(level)
+		if key == "" {
+			return
+		}
+		if current, ok := classified[key]; ok && classificationRank[current] >= classificationRank[level] {
+			return
+		}
+		classified[key] = level
+		names[key] = field
+	}
+	for field, level := range classification {
+		classify(field, level)
+	}
+	for _, line := range lines {
+		m := classificationTagPattern.FindStringSubmatch(line.Text)
+		if m == nil {
+			continue
+		}
+		if f := fieldNamePattern.FindStringSubmatch(line.Text); f != nil {
+			classify(f[1], m[1])
+		}
+		if j := jsonTagNamePattern.FindStringSubmatch(line.Text); j != nil {
+			classify(j[1], m[1])
+		}
+	}
+
+	scanned := 0
+	for _, line := range lines {
+		if !line.Added {
+			continue
+		}
+		scanned++
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || isCommentLine(trimmed) || classificationTagPattern.MatchString(line.Text) {
+			continue
+		}
+
+		sink := ""
+		switch {
+		case logCallPattern.MatchString(line.Text), logSinkPattern.MatchString(line.Text):
+			sink = "logged"
+		case serializationSinkPattern.MatchString(line.Text):
+			sink = "serialized"
+		default:
+			continue
+		}
+
+		seen := make(map[string]bool)
+		fields := []string{}
+		for _, ident := range identifierPattern.FindAllString(line.Text, -1) {
+			key := normalizeFieldName(ident)
+			if _, ok := classificationSeverity[classified[key]]; ok && !seen[key] {
+				seen[key] = true
+				fields = append(fields, key)
+			}
+		}
+		sort.Strings(fields)
+
+		for _, key := range fields {
+			level := classified[key]
+			violations = append(violations, models.DiffViolation{
+				Type:       "classified_field_" + sink,
+				Severity:   classificationSeverity[level],
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    fmt.Sprintf("%s field %q is %s", level, names[key], sink),
+				Suggestion: "Remove the field, or mask/hash it before it reaches logs or serialized output",
+			})
+		}
+	}
+
+	return newDiffScanResult("classified data", violations, scanned)
+}
+
+// normalizeFieldName lowercases a field name and strips separators for comparison
+func normalizeFieldName(name string) string {
+	return strings.NewReplacer("_", "", "-", "").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,88 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckPIILogging tests detection of classified fields reaching logs or serialized output
+func TestCheckPIILogging(t *testing.T) {
+	classification := map[string]string{
+		"ssn":           "restricted",
+		"date_of_birth": "sensitive",
+		"display_name":  "public",
+	}
+
+	tests := []struct {
+		name           string
+		diff           string
+		classification map[string]string
+		wantValid      bool
+		wantTypes      []string
+	}{
+		{
+			name:           "logging a restricted field flagged",
+			diff:           "+++ b/internal/users/service.go\n+\tslog.Info(\"user created\", \"id\", u.ID, \"ssn\", u.SSN)\n",
+			classification: classification,
+			wantValid:      false,
+			wantTypes:      []string{"classified_field_logged"},
+		},
+		{
+			name:           "logging a public field passes",
+			diff:           "+++ b/internal/users/service.go\n+\tslog.Info(\"user created\", \"name\", u.DisplayName)\n",
+			classification: classification,
+			wantValid:      true,
+		},
+		{
+			name:           "sensitive field warns with camelCase match",
+			diff:           "+\tlogger.Debugf(\"dob=%s\", user.DateOfBirth)\n",
+			classification: classification,
+			wantValid:      true,
+			wantTypes:      []string{"classified_field_logged"},
+		},
+		{
+			name:           "serializing a restricted field flagged",
+			diff:           "+\tbody, _ := json.Marshal(map[string]string{\"ssn\": u.SSN})\n",
+			classification: classification,
+			wantValid:      false,
+			wantTypes:      []string{"classified_field_serialized"},
+		},
+		{
+			name: "struct tag annotation classifies field",
+			diff: "+++ b/models/patient.go\n@@ -1,2 +1,4 @@\n type Patient struct {\n" +
+				" \tDiagnosis string `json:\"diagnosis\" classification:\"restricted\"`\n" +
+				"+\tName string `json:\"name\" classification:\"public\"`\n" +
+				"+\tlog.Printf(\"patient %s diagnosis %s\", p.Name, p.Diagnosis)\n",
+			wantValid: false,
+			wantTypes: []string{"classified_field_logged"},
+		},
+		{
+			name:      "comment annotation classifies field",
+			diff:      "+    email: str  # data-classification: sensitive\n+    print(user.email)\n",
+			wantValid: true,
+			wantTypes: []string{"classified_field_logged"},
+		},
+		{
+			name:           "restricted field outside log calls ignored",
+			diff:           "+\thashed := hash(u.SSN)\n",
+			classification: classification,
+			wantValid:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkPIILogging(tt.diff, tt.classification)
+			if result.Valid != tt.wantValid {
+				t.Errorf("checkPIILogging() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("violations = %+v, want types %v", result.Violations, tt.wantTypes)
+			}
+			for i, typ := range tt.wantTypes {
+				if result.Violations[i].Type != typ {
+					t.Errorf("violation[%d] type = %s, want %s", i, result.Violations[i].Type, typ)
+				}
+			}
+		})
+	}
+}
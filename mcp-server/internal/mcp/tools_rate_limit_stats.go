@@ -0,0 +1,43 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+const (
+	// defaultRateLimitTopN is how many callers the rate limit stats list by default
+	defaultRateLimitTopN = 10
+	maxRateLimitTopN     = 100
+)
+
+// RateLimitStats snapshots the team tool rate limiters, listing the topN callers of
+// each that have used the most of their current window. The web server exposes it
+// to admins.
+func (s *MCPServer) RateLimitStats(topN int) models.RateLimitStats {
+	if topN <= 0 {
+		topN = defaultRateLimitTopN
+	}
+	topN = min(topN, maxRateLimitTopN)
+
+	var stats models.RateLimitStats
+	if s.teamRateLimiter != nil {
+		stats.Mutating = s.teamRateLimiter.snapshot(topN)
+	}
+	if s.teamReadRateLimiter != nil {
+		stats.ReadOnly = s.teamReadRateLimiter.snapshot(topN)
+	}
+	return stats
+}
+
+// handleRateLimitStats reports the team tool rate limits and who is using them,
+// to diagnose why a client is being throttled
+func (s *MCPServer) handleRateLimitStats(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	topN := defaultRateLimitTopN
+	if v, ok := args["top_n"].(float64); ok {
+		topN = int(v)
+	}
+	return buildToolResult(s.RateLimitStats(topN), false)
+}
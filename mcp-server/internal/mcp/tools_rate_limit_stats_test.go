@@ -0,0 +1,74 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// TestRateLimiterSnapshot tests that the snapshot reflects consumed tokens per caller
+func TestRateLimiterSnapshot(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	rl := newRateLimiter(5, 60)
+	rl.now = func() time.Time { return now }
+
+	for i := 0; i < 6; i++ {
+		rl.checkRateLimit("ip:203.0.113.7")
+	}
+	rl.checkRateLimit("session:0123456789abcdef")
+	rl.checkRateLimit("session:0123456789abcdef")
+	rl.checkRateLimit("ip:198.51.100.2")
+
+	snap := rl.snapshot(2)
+	if snap.Limit != 5 || snap.WindowSeconds != 60 || snap.Buckets != 3 || snap.ActiveBuckets != 3 || snap.Throttled != 1 {
+		t.Errorf("snapshot = %+v, want 3 active buckets with 1 throttled", snap)
+	}
+	want := []models.RateLimitConsumer{
+		{Key: "ip:203.0.113.7", Used: 5, Remaining: 0, ResetAt: now.Add(time.Minute)},
+		{Key: "session:01234567...", Used: 2, Remaining: 3, ResetAt: now.Add(time.Minute)},
+	}
+	if len(snap.TopConsumers) != len(want) {
+		t.Fatalf("top consumers = %+v, want %+v", snap.TopConsumers, want)
+	}
+	for i := range want {
+		if snap.TopConsumers[i] != want[i] {
+			t.Errorf("top consumer[%d] = %+v, want %+v", i, snap.TopConsumers[i], want[i])
+		}
+	}
+
+	// Once the window has passed the buckets are held but no longer active
+	now = now.Add(2 * time.Minute)
+	if snap := rl.snapshot(10); snap.Buckets != 3 || snap.ActiveBuckets != 0 || len(snap.TopConsumers) != 0 {
+		t.Errorf("snapshot after the window = %+v, want only expired buckets", snap)
+	}
+}
+
+// TestHandleRateLimitStats tests the tool reports both team limiters
+func TestHandleRateLimitStats(t *testing.T) {
+	s := mockMCPServer()
+	s.teamRateLimiter, s.teamReadRateLimiter = newRateLimiter(3, 60), newRateLimiter(600, 60)
+	ctx := withClientIP(context.Background(), "203.0.113.7")
+	s.checkTeamRateLimit(ctx, map[string]interface{}{}, "team_assign", s.teamRateLimiter)
+
+	res, err := s.handleRateLimitStats(ctx, map[string]interface{}{"top_n": float64(5)})
+	if err != nil {
+		t.Fatalf("handleRateLimitStats() error = %v", err)
+	}
+	var stats models.RateLimitStats
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &stats); err != nil {
+		t.Fatalf("invalid result: %v", err)
+	}
+	if stats.Mutating.Limit != 3 || stats.ReadOnly.Limit != 600 {
+		t.Errorf("limits = %d/%d, want 3/600", stats.Mutating.Limit, stats.ReadOnly.Limit)
+	}
+	if len(stats.Mutating.TopConsumers) != 1 || stats.Mutating.TopConsumers[0].Key != "ip:203.0.113.7" || stats.Mutating.TopConsumers[0].Remaining != 2 {
+		t.Errorf("mutating consumers = %+v, want the one call from 203.0.113.7", stats.Mutating.TopConsumers)
+	}
+	if stats.ReadOnly.ActiveBuckets != 0 {
+		t.Errorf("read-only buckets = %d, want 0", stats.ReadOnly.ActiveBuckets)
+	}
+}
@@ -0,0 +1,153 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// userFacingAddition is a pattern for a newly added CLI flag, command or config option.
+// The first capture group is the name users would look up in the docs.
+type userFacingAddition struct {
+	kind    string
+	pattern *regexp.Regexp
+}
+
+// userFacingAdditions matches flag, command and config option declarations across
+// the CLI and config libraries in common use
+var userFacingAdditions = []userFacingAddition{
+	// Go flag/pflag/cobra: flag.String("name", ...), cmd.Flags().StringVarP(&v, "name", "n", ...)
+	{"flag", regexp.MustCompile(`\b(?:flag|pflag|Flags\(\)|PersistentFlags\(\))\.\w+\(\s*(?:&[\w.]+\s*,\s*)?"([\w-]+)"`)},
+	// Python argparse and click: add_argument("--name"), @click.option("--name")
+	{"flag", regexp.MustCompile(`(?:add_argument|click\.option)\(\s*(?:["']-\w["']\s*,\s*)?["'](--[\w-]+)["']`)},
+	// JS commander/yargs: .option('-n, --name <value>')
+	{"flag", regexp.MustCompile(`\.option\(\s*["'](?:-\w,\s*)?(--[\w-]+)`)},
+	// Cobra command: Use: "name [args]"
+	{"command", regexp.MustCompile(`\bUse:\s*"([\w-]+)`)},
+	// Subcommand registration: add_parser("name"), .command('name')
+	{"command", regexp.MustCompile(`(?:add_parser|\.command)\(\s*["']([\w-]+)`)},
+	// Config from the environment: env:"NAME" struct tags, os.Getenv("NAME"), os.environ["NAME"], process.env.NAME
+	{"config option", regexp.MustCompile(`\benv:"([A-Z][A-Z0-9_]*)"`)},
+	{"config option", regexp.MustCompile(`(?:Getenv|LookupEnv|getenv|environ\.get)\(\s*["']([A-Z][A-Z0-9_]*)["']`)},
+	{"config option", regexp.MustCompile(`(?:os\.environ\[\s*["']|process\.env\.)([A-Z][A-Z0-9_]*)`)},
+}
+
+// handleValidateReadmeSync flags CLI flags, commands and config options added in a
+// changeset whose documentation was not updated alongside them
+func (s *MCPServer) handleValidateReadmeSync(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkReadmeSync(diff, stringSliceArg(args, "changed_files"))
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkReadmeSync scans the added source lines of a diff for new user-facing flags,
+// commands and config options. An addition is undocumented when no documentation file
+// (README, *.md, docs/, .env.example) is part of the changeset. When documentation was
+// changed in the diff but never mentions the addition by name, a warning is reported.
+// otherFiles lists further files in the changeset whose content is not in the diff.
+func checkReadmeSync(diff string, otherFiles []string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := parseDiffLines(diff)
+
+	docsChanged := false
+	for _, file := range append(otherFiles, changedFiles(diff)...) {
+		if isDocumentationFile(file) {
+			docsChanged = true
+		}
+	}
+
+	// Documentation text added in the diff, for checking that additions are mentioned
+	var docsText strings.Builder
+	for _, line := range lines {
+		if line.Added && isDocumentationFile(line.File) {
+			docsText.WriteString(line.Text)
+			docsText.WriteString("\n")
+		}
+	}
+
+	scanned := 0
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		if !line.Added || isDocumentationFile(line.File) || fileConcern(line.File) == "test" {
+			continue
+		}
+		scanned++
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || isCommentLine(trimmed) {
+			continue
+		}
+
+		for _, addition := range userFacingAdditions {
+			m := addition.pattern.FindStringSubmatch(line.Text)
+			if m == nil {
+				continue
+			}
+			key := addition.kind + ":" + m[1]
+			if seen[key] {
+				break
+			}
+			seen[key] = true
+
+			switch {
+			case !docsChanged:
+				violations = append(violations, models.DiffViolation{
+					Type:       "undocumented_addition",
+					Severity:   "error",
+					File:       line.File,
+					LineNumber: line.Number,
+					Line:       trimmed,
+					Message:    fmt.Sprintf("New %s %q is added without a documentation change", addition.kind, m[1]),
+					Suggestion: "Document it in the README or docs/ in the same changeset",
+				})
+			case docsText.Len() > 0 && !strings.Contains(docsText.String(), strings.TrimLeft(m[1], "-")):
+				violations = append(violations, models.DiffViolation{
+					Type:       "addition_not_mentioned",
+					Severity:   "warning",
+					File:       line.File,
+					LineNumber: line.Number,
+					Line:       trimmed,
+					Message:    fmt.Sprintf("New %s %q is not mentioned in the documentation changes", addition.kind, m[1]),
+					Suggestion: "Describe the new option by name where the documentation was updated",
+				})
+			}
+			break
+		}
+	}
+
+	return newDiffScanResult("readme sync", violations, scanned)
+}
+
+// isDocumentationFile reports whether a path is user documentation: a README, a
+// markdown/rst/adoc file, anything under docs/, or an example env file
+func isDocumentationFile(path string) bool {
+	if path == "" {
+		return false
+	}
+	lower := strings.ToLower(filepath.ToSlash(path))
+	base := filepath.Base(lower)
+	switch {
+	case strings.HasPrefix(base, "readme"):
+		return true
+	case strings.HasPrefix(base, ".env.") && (strings.HasSuffix(base, "example") || strings.HasSuffix(base, "sample") || strings.HasSuffix(base, "template")):
+		return true
+	}
+	switch filepath.Ext(lower) {
+	case ".md", ".rst", ".adoc":
+		return true
+	}
+	return strings.HasPrefix(lower, "docs/") || strings.Contains(lower, "/docs/")
+}
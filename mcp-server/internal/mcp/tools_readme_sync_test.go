@@ -0,0 +1,110 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckReadmeSync tests detection of user-facing additions without documentation changes
+func TestCheckReadmeSync(t *testing.T) {
+	tests := []struct {
+		name         string
+		diff         string
+		changedFiles []string
+		wantValid    bool
+		wantTypes    []string
+	}{
+		{
+			name:      "new flag without docs change flagged",
+			diff:      "+++ b/cmd/team-cli/main.go\n@@ -40,1 +40,2 @@\n \troot := &cobra.Command{}\n+\troot.PersistentFlags().StringVarP(&timeout, \"timeout\", \"t\", \"30s\", \"Command timeout\")\n",
+			wantValid: false,
+			wantTypes: []string{"undocumented_addition"},
+		},
+		{
+			name: "new flag with docs change passes",
+			diff: "+++ b/cmd/team-cli/main.go\n" +
+				"+\troot.PersistentFlags().StringVarP(&timeout, \"timeout\", \"t\", \"30s\", \"Command timeout\")\n" +
+				"+++ b/cmd/team-cli/README.md\n" +
+				"+- `--timeout duration` - Command timeout (default: `30s`)\n",
+			wantValid: true,
+		},
+		{
+			name:         "docs change listed in changed files passes",
+			diff:         "+++ b/scripts/team_manager.py\n+    parser.add_argument(\"--replace\", action=\"store_true\")\n",
+			changedFiles: []string{"docs/TEAM_MANAGER.md"},
+			wantValid:    true,
+		},
+		{
+			name:      "new config option without docs flagged",
+			diff:      "+++ b/mcp-server/internal/config/config.go\n+\tSSEPingInterval time.Duration `env:\"SSE_PING_INTERVAL\" envDefault:\"30s\"`\n",
+			wantValid: false,
+			wantTypes: []string{"undocumented_addition"},
+		},
+		{
+			name: "config option documented in env example passes",
+			diff: "+++ b/mcp-server/internal/config/config.go\n" +
+				"+\tSSEPingInterval time.Duration `env:\"SSE_PING_INTERVAL\" envDefault:\"30s\"`\n" +
+				"+++ b/mcp-server/.env.example\n" +
+				"+SSE_PING_INTERVAL=30s\n",
+			wantValid: true,
+		},
+		{
+			name: "docs changed without mentioning the addition warns",
+			diff: "+++ b/cmd/team-cli/main.go\n" +
+				"+\tcmd := &cobra.Command{Use: \"doctor\", Short: \"Diagnose the environment\"}\n" +
+				"+++ b/cmd/team-cli/README.md\n" +
+				"+Fixed a typo in the install section.\n",
+			wantValid: true,
+			wantTypes: []string{"addition_not_mentioned"},
+		},
+		{
+			name:      "flag in test file skipped",
+			diff:      "+++ b/cmd/team-cli/main_test.go\n+\tflag.Bool(\"update\", false, \"update golden files\")\n",
+			wantValid: true,
+		},
+		{
+			name:      "ordinary code passes",
+			diff:      "+++ b/internal/mcp/server.go\n+\tresult := compute(x)\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkReadmeSync(tt.diff, tt.changedFiles)
+			if result.Valid != tt.wantValid {
+				t.Errorf("Valid = %v, want %v (violations: %+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("got %d violations, want %d: %+v", len(result.Violations), len(tt.wantTypes), result.Violations)
+			}
+			for i, want := range tt.wantTypes {
+				if result.Violations[i].Type != want {
+					t.Errorf("violation %d type = %s, want %s", i, result.Violations[i].Type, want)
+				}
+			}
+		})
+	}
+}
+
+// TestIsDocumentationFile tests classification of documentation paths
+func TestIsDocumentationFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{"README.md", true},
+		{"cmd/team-cli/README", true},
+		{"docs/setup/INSTALL.txt", true},
+		{"mcp-server/.env.example", true},
+		{"CHANGELOG.md", true},
+		{"mcp-server/.env", false},
+		{"internal/docs.go", false},
+		{"cmd/team-cli/main.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := isDocumentationFile(tt.path); got != tt.want {
+			t.Errorf("isDocumentationFile(%q) = %v, want %v", tt.path, got, tt.want)
+		}
+	}
+}
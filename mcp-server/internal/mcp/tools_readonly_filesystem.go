@@ -0,0 +1,367 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// dockerfileFromPattern identifies a Dockerfile by its FROM instruction
+	dockerfileFromPattern = regexp.MustCompile(`(?im)^\s*FROM\s+\S+`)
+	// worldWritableChmodPattern matches chmod calls in RUN that make paths writable by everyone
+	worldWritableChmodPattern = regexp.MustCompile(`\bchmod\s+(?:-[A-Za-z]+\s+)*(?:[0-7]?[0-7][0-7][2367]|[ugo]*a[ugo]*\+[rx]*w|o\+[rx]*w)\b`)
+)
+
+// readonlyPodSpecPaths are where the pod spec sits in each workload kind
+var readonlyPodSpecPaths = map[string][]string{
+	"Pod":                   {"spec"},
+	"Deployment":            {"spec", "template", "spec"},
+	"StatefulSet":           {"spec", "template", "spec"},
+	"DaemonSet":             {"spec", "template", "spec"},
+	"ReplicaSet":            {"spec", "template", "spec"},
+	"ReplicationController": {"spec", "template", "spec"},
+	"Job":                   {"spec", "template", "spec"},
+	"CronJob":               {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// readonlyVolumeSources are Kubernetes volume types the kubelet always mounts read-only
+var readonlyVolumeSources = []string{"configMap", "secret", "downwardAPI", "projected"}
+
+// handleValidateReadonlyFilesystem checks Kubernetes workloads, Docker Compose
+// services and Dockerfiles for a writable root filesystem and writable mounts
+func (s *MCPServer) handleValidateReadonlyFilesystem(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	spec, _ := args["spec"].(string)
+	format, _ := args["format"].(string)
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.ReadonlyFilesystemResult{
+			Valid:   false,
+			Message: message,
+			Format:  format,
+			Issues:  []models.ReadonlyFilesystemIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if strings.TrimSpace(spec) == "" {
+		return invalid("spec is required")
+	}
+
+	result, err := checkReadonlyFilesystem(spec, format)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkReadonlyFilesystem detects the spec format when it is not given and checks
+// every container in it. Any error-severity issue fails the check.
+func checkReadonlyFilesystem(spec, format string) (models.ReadonlyFilesystemResult, error) {
+	result := models.ReadonlyFilesystemResult{Issues: []models.ReadonlyFilesystemIssue{}}
+
+	if format == "" {
+		format = detectContainerSpecFormat(spec)
+	}
+	result.Format = format
+
+	var err error
+	switch format {
+	case "dockerfile":
+		result.ContainersChecked = 1
+		result.Issues = checkDockerfileWritable(spec)
+	case "kubernetes", "compose":
+		var docs []map[string]interface{}
+		if docs, err = decodeYAMLDocuments(spec); err != nil {
+			return result, fmt.Errorf("failed to parse spec: %w", err)
+		}
+		for _, doc := range docs {
+			var issues []models.ReadonlyFilesystemIssue
+			var containers int
+			if format == "compose" {
+				issues, containers = checkComposeWritable(doc)
+			} else {
+				issues, containers = checkKubernetesWritable(doc)
+			}
+			result.Issues = append(result.Issues, issues...)
+			result.ContainersChecked += containers
+		}
+	default:
+		return result, fmt.Errorf("unsupported format %q (use kubernetes, compose or dockerfile)", format)
+	}
+
+	if result.ContainersChecked == 0 {
+		return result, fmt.Errorf("no containers found in the %s spec", format)
+	}
+
+	errorCount := 0
+	for _, issue := range result.Issues {
+		if issue.Severity == "error" {
+			errorCount++
+		}
+	}
+	result.Valid = errorCount == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("%d container(s) run with a read-only root filesystem", result.ContainersChecked)
+		if format == "dockerfile" {
+			result.Message = "Dockerfile keeps the image read-only friendly; enforce it at runtime with --read-only or readOnlyRootFilesystem"
+		}
+	} else {
+		result.Message = fmt.Sprintf("Found %d filesystem hardening gap(s) in %d container(s)", errorCount, result.ContainersChecked)
+	}
+	return result, nil
+}
+
+// detectContainerSpecFormat tells a Dockerfile, a Compose file and Kubernetes
+// manifests apart
+func detectContainerSpecFormat(spec string) string {
+	if dockerfileFromPattern.MatchString(spec) && !strings.Contains(spec, "apiVersion:") {
+		return "dockerfile"
+	}
+	if docs, err := decodeYAMLDocuments(spec); err == nil {
+		for _, doc := range docs {
+			if _, ok := doc["services"]; ok {
+				return "compose"
+			}
+		}
+	}
+	return "kubernetes"
+}
+
+// decodeYAMLDocuments decodes every document of a multi-document YAML (or JSON) spec
+func decodeYAMLDocuments(spec string) ([]map[string]interface{}, error) {
+	var docs []map[string]interface{}
+	dec := yaml.NewDecoder(bytes.NewReader([]byte(spec)))
+	for {
+		var doc map[string]interface{}
+		err := dec.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return docs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if doc != nil {
+			docs = append(docs, doc)
+		}
+	}
+}
+
+// checkKubernetesWritable checks each container of a workload for
+// readOnlyRootFilesystem and each of its volume mounts for write access
+func checkKubernetesWritable(doc map[string]interface{}) ([]models.ReadonlyFilesystemIssue, int) {
+	kind, _ := doc["kind"].(string)
+	if kind == "List" {
+		var issues []models.ReadonlyFilesystemIssue
+		containers := 0
+		items, _ := doc["items"].([]interface{})
+		for _, item := range items {
+			if m, ok := item.(map[string]interface{}); ok {
+				i, c := checkKubernetesWritable(m)
+				issues = append(issues, i...)
+				containers += c
+			}
+		}
+		return issues, containers
+	}
+
+	path, ok := readonlyPodSpecPaths[kind]
+	if !ok {
+		return nil, 0
+	}
+	podSpec := nestedMap(doc, path...)
+	if podSpec == nil {
+		return nil, 0
+	}
+	name, _ := nestedMap(doc, "metadata")["name"].(string)
+	resource := kind + "/" + name
+
+	volumes := map[string]map[string]interface{}{}
+	for _, v := range mapList(podSpec["volumes"]) {
+		if n, _ := v["name"].(string); n != "" {
+			volumes[n] = v
+		}
+	}
+
+	var issues []models.ReadonlyFilesystemIssue
+	containers := 0
+	for _, field := range []string{"initContainers", "containers"} {
+		for _, c := range mapList(podSpec[field]) {
+			containers++
+			container, _ := c["name"].(string)
+			issue := func(issueType, severity, message string) {
+				issues = append(issues, models.ReadonlyFilesystemIssue{
+					Resource: resource, Container: container, Type: issueType, Severity: severity, Message: message,
+				})
+			}
+
+			switch ro, set := nestedMap(c, "securityContext")["readOnlyRootFilesystem"].(bool); {
+			case !set:
+				issue("missing_read_only_root", "error", "securityContext.readOnlyRootFilesystem is not set; the root filesystem is writable")
+			case !ro:
+				issue("writable_root", "error", "securityContext.readOnlyRootFilesystem is false")
+			}
+			if privileged, _ := nestedMap(c, "securityContext")["privileged"].(bool); privileged {
+				issue("privileged", "error", "Privileged containers can remount the root filesystem read-write")
+			}
+
+			for _, mount := range mapList(c["volumeMounts"]) {
+				if ro, _ := mount["readOnly"].(bool); ro {
+					continue
+				}
+				volumeName, _ := mount["name"].(string)
+				mountPath, _ := mount["mountPath"].(string)
+				volume := volumes[volumeName]
+				switch {
+				case volume["hostPath"] != nil:
+					issue("writable_host_mount", "error", fmt.Sprintf("hostPath volume %s is mounted read-write at %s; set readOnly: true", volumeName, mountPath))
+				case volume["emptyDir"] != nil:
+					// Scratch space is the intended way to give a read-only container somewhere to write
+				case hasAnyKey(volume, readonlyVolumeSources):
+				default:
+					issue("writable_mount", "warning", fmt.Sprintf("Volume %s is mounted read-write at %s; set readOnly: true unless the container must persist data there", volumeName, mountPath))
+				}
+			}
+		}
+	}
+	return issues, containers
+}
+
+// checkComposeWritable checks each Compose service for read_only: true and each of
+// its volumes for write access
+func checkComposeWritable(doc map[string]interface{}) ([]models.ReadonlyFilesystemIssue, int) {
+	services, _ := doc["services"].(map[string]interface{})
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var issues []models.ReadonlyFilesystemIssue
+	for _, name := range names {
+		svc, _ := services[name].(map[string]interface{})
+		issue := func(issueType, severity, message string) {
+			issues = append(issues, models.ReadonlyFilesystemIssue{
+				Resource: "service/" + name, Container: name, Type: issueType, Severity: severity, Message: message,
+			})
+		}
+
+		if ro, _ := svc["read_only"].(bool); !ro {
+			issue("missing_read_only_root", "error", "read_only: true is not set; the root filesystem is writable")
+		}
+		if privileged, _ := svc["privileged"].(bool); privileged {
+			issue("privileged", "error", "Privileged containers can remount the root filesystem read-write")
+		}
+
+		volumes, _ := svc["volumes"].([]interface{})
+		for _, v := range volumes {
+			source, target, kind, readOnly := composeVolume(v)
+			if readOnly || kind == "tmpfs" {
+				continue
+			}
+			if kind == "bind" {
+				issue("writable_host_mount", "error", fmt.Sprintf("Host path %s is mounted read-write at %s; add :ro", source, target))
+			} else {
+				issue("writable_mount", "warning", fmt.Sprintf("Volume %s is mounted read-write at %s; add :ro unless the service must persist data there", source, target))
+			}
+		}
+	}
+	return issues, len(names)
+}
+
+// composeVolume reads a Compose volume in short ("src:dst:ro") or long syntax
+func composeVolume(v interface{}) (source, target, kind string, readOnly bool) {
+	switch vol := v.(type) {
+	case string:
+		parts := strings.Split(vol, ":")
+		if len(parts) == 1 {
+			return "(anonymous)", parts[0], "volume", false
+		}
+		source, target = parts[0], parts[1]
+		if len(parts) > 2 {
+			for _, opt := range strings.Split(parts[2], ",") {
+				readOnly = readOnly || opt == "ro"
+			}
+		}
+		kind = "volume"
+		if strings.HasPrefix(source, "/") || strings.HasPrefix(source, ".") || strings.HasPrefix(source, "~") {
+			kind = "bind"
+		}
+		return source, target, kind, readOnly
+	case map[string]interface{}:
+		source, _ = vol["source"].(string)
+		target, _ = vol["target"].(string)
+		kind, _ = vol["type"].(string)
+		readOnly, _ = vol["read_only"].(bool)
+		if kind == "" {
+			kind = "volume"
+		}
+		return source, target, kind, readOnly
+	}
+	return "", "", "", false
+}
+
+// checkDockerfileWritable flags Dockerfile instructions that only work with a
+// writable filesystem: declared VOLUMEs and world-writable paths
+func checkDockerfileWritable(spec string) []models.ReadonlyFilesystemIssue {
+	issues := []models.ReadonlyFilesystemIssue{}
+	for i, line := range strings.Split(spec, "\n") {
+		trimmed := strings.TrimSpace(line)
+		upper := strings.ToUpper(trimmed)
+		switch {
+		case strings.HasPrefix(upper, "VOLUME "):
+			issues = append(issues, models.ReadonlyFilesystemIssue{
+				Resource: "Dockerfile", Type: "writable_mount", Severity: "warning", Line: i + 1,
+				Message: "VOLUME creates a writable mount in every container; mount volumes explicitly at runtime and read-only where possible",
+			})
+		case strings.HasPrefix(upper, "RUN ") && worldWritableChmodPattern.MatchString(trimmed):
+			issues = append(issues, models.ReadonlyFilesystemIssue{
+				Resource: "Dockerfile", Type: "writable_root", Severity: "error", Line: i + 1,
+				Message: "Paths made world-writable in the image expect a writable root filesystem; write to a tmpfs or emptyDir mount instead",
+			})
+		}
+	}
+	return issues
+}
+
+// nestedMap follows keys through nested YAML mappings, returning nil when one is missing
+func nestedMap(m map[string]interface{}, keys ...string) map[string]interface{} {
+	for _, key := range keys {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	return m
+}
+
+// mapList returns the mappings in a YAML sequence
+func mapList(v interface{}) []map[string]interface{} {
+	items, _ := v.([]interface{})
+	out := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		if m, ok := item.(map[string]interface{}); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func hasAnyKey(m map[string]interface{}, keys []string) bool {
+	for _, key := range keys {
+		if _, ok := m[key]; ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,226 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const unhardenedDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  template:
+    spec:
+      containers:
+        - name: api
+          image: example/api:1.4
+          volumeMounts:
+            - name: logs
+              mountPath: /var/log/api
+      volumes:
+        - name: logs
+          hostPath:
+            path: /var/log/api
+`
+
+const hardenedDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: api
+spec:
+  template:
+    spec:
+      containers:
+        - name: api
+          image: example/api:1.4
+          securityContext:
+            readOnlyRootFilesystem: true
+            allowPrivilegeEscalation: false
+          volumeMounts:
+            - name: tmp
+              mountPath: /tmp
+            - name: config
+              mountPath: /etc/api
+            - name: logs
+              mountPath: /var/log/api
+              readOnly: true
+      volumes:
+        - name: tmp
+          emptyDir: {}
+        - name: config
+          configMap:
+            name: api-config
+        - name: logs
+          hostPath:
+            path: /var/log/api
+`
+
+// TestCheckReadonlyFilesystem tests Kubernetes, Compose and Dockerfile specs
+func TestCheckReadonlyFilesystem(t *testing.T) {
+	tests := []struct {
+		name           string
+		spec           string
+		wantFormat     string
+		wantValid      bool
+		wantContainers int
+		wantIssues     []string // type/severity
+	}{
+		{
+			name:           "deployment without read-only root",
+			spec:           unhardenedDeployment,
+			wantFormat:     "kubernetes",
+			wantValid:      false,
+			wantContainers: 1,
+			wantIssues:     []string{"missing_read_only_root/error", "writable_host_mount/error"},
+		},
+		{
+			name:           "hardened deployment",
+			spec:           hardenedDeployment,
+			wantFormat:     "kubernetes",
+			wantValid:      true,
+			wantContainers: 1,
+		},
+		{
+			name: "cronjob with writable root and persistent volume",
+			spec: `apiVersion: batch/v1
+kind: CronJob
+metadata:
+  name: report
+spec:
+  jobTemplate:
+    spec:
+      template:
+        spec:
+          containers:
+            - name: report
+              securityContext:
+                readOnlyRootFilesystem: false
+              volumeMounts:
+                - name: data
+                  mountPath: /data
+          volumes:
+            - name: data
+              persistentVolumeClaim:
+                claimName: reports
+`,
+			wantFormat:     "kubernetes",
+			wantValid:      false,
+			wantContainers: 1,
+			wantIssues:     []string{"writable_root/error", "writable_mount/warning"},
+		},
+		{
+			name: "compose services",
+			spec: `services:
+  api:
+    image: example/api:1.4
+    read_only: true
+    tmpfs:
+      - /tmp
+    volumes:
+      - ./config:/etc/api:ro
+      - data:/var/lib/api
+  worker:
+    image: example/worker:1.4
+    volumes:
+      - /var/run/docker.sock:/var/run/docker.sock
+volumes:
+  data: {}
+`,
+			wantFormat:     "compose",
+			wantValid:      false,
+			wantContainers: 2,
+			wantIssues:     []string{"writable_mount/warning", "missing_read_only_root/error", "writable_host_mount/error"},
+		},
+		{
+			name:           "dockerfile with world-writable path",
+			spec:           "FROM alpine:3.20\nRUN mkdir /app && chmod 777 /app\nVOLUME /data\nUSER 1000\n",
+			wantFormat:     "dockerfile",
+			wantValid:      false,
+			wantContainers: 1,
+			wantIssues:     []string{"writable_root/error", "writable_mount/warning"},
+		},
+		{
+			name:           "clean dockerfile",
+			spec:           "FROM alpine:3.20\nCOPY app /app\nUSER 1000\nENTRYPOINT [\"/app\"]\n",
+			wantFormat:     "dockerfile",
+			wantValid:      true,
+			wantContainers: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkReadonlyFilesystem(tt.spec, "")
+			if err != nil {
+				t.Fatalf("checkReadonlyFilesystem() error = %v", err)
+			}
+			if result.Format != tt.wantFormat {
+				t.Errorf("format = %s, want %s", result.Format, tt.wantFormat)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if result.ContainersChecked != tt.wantContainers {
+				t.Errorf("containers checked = %d, want %d", result.ContainersChecked, tt.wantContainers)
+			}
+			if len(result.Issues) != len(tt.wantIssues) {
+				t.Fatalf("issues = %+v, want %v", result.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := result.Issues[i].Type + "/" + result.Issues[i].Severity; got != want {
+					t.Errorf("issue[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleValidateReadonlyFilesystem tests the tool result for good, bad and empty input
+func TestHandleValidateReadonlyFilesystem(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name:     "hardened spec passes",
+			args:     map[string]interface{}{"spec": hardenedDeployment},
+			wantText: "read-only root filesystem",
+		},
+		{
+			name:      "spec without read-only root flagged",
+			args:      map[string]interface{}{"spec": unhardenedDeployment},
+			wantError: true,
+			wantText:  `"type":"missing_read_only_root"`,
+		},
+		{name: "missing spec", args: map[string]interface{}{}, wantError: true, wantText: "spec is required"},
+		{
+			name:      "no containers",
+			args:      map[string]interface{}{"spec": "apiVersion: v1\nkind: ConfigMap\n", "format": "kubernetes"},
+			wantError: true,
+			wantText:  "no containers found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateReadonlyFilesystem(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateReadonlyFilesystem() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// handleRuleCoverage reports which active prevention rules have matched in at least
+// one validation and which have never fired. Rules that never fire are either dead
+// or untested, and are worth a look before they are trusted to catch anything.
+func (s *MCPServer) handleRuleCoverage(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	if s.rules == nil || s.ruleHits == nil {
+		return buildToolResult(map[string]interface{}{
+			"error": "rule coverage is not available",
+		}, true)
+	}
+
+	enabled := true
+	var rules []models.PreventionRule
+	for offset := 0; ; offset += maxActiveRulesPageSize {
+		page, err := s.rules.List(ctx, &enabled, "", maxActiveRulesPageSize, offset)
+		if err != nil {
+			return buildToolResult(map[string]interface{}{
+				"error": fmt.Sprintf("failed to list active rules: %v", err),
+			}, true)
+		}
+		rules = append(rules, page...)
+		if len(page) < maxActiveRulesPageSize {
+			break
+		}
+	}
+
+	hits, err := s.ruleHits.List(ctx)
+	if err != nil {
+		return buildToolResult(map[string]interface{}{
+			"error": fmt.Sprintf("failed to list rule hits: %v", err),
+		}, true)
+	}
+
+	return buildToolResult(checkRuleCoverage(rules, hits), false)
+}
+
+// checkRuleCoverage splits the rules into those with at least one recorded hit, most
+// hits first, and those with none, by rule ID. Hits for rules that are no longer
+// active are ignored.
+func checkRuleCoverage(rules []models.PreventionRule, hits []models.RuleHit) models.RuleCoverageResult {
+	hitsByRule := make(map[string]models.RuleHit, len(hits))
+	for _, hit := range hits {
+		hitsByRule[hit.RuleID] = hit
+	}
+
+	matched := []models.RuleCoverageEntry{}
+	untested := []models.RuleCoverageEntry{}
+	for _, rule := range rules {
+		entry := models.RuleCoverageEntry{RuleID: rule.RuleID, Name: rule.Name}
+		hit, ok := hitsByRule[rule.RuleID]
+		if !ok || hit.HitCount == 0 {
+			untested = append(untested, entry)
+			continue
+		}
+		lastHitAt := hit.LastHitAt
+		entry.HitCount = hit.HitCount
+		entry.LastHitAt = &lastHitAt
+		matched = append(matched, entry)
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].HitCount > matched[j].HitCount })
+
+	message := fmt.Sprintf("All %d active rule(s) have matched at least once", len(rules))
+	if len(untested) > 0 {
+		message = fmt.Sprintf("%d of %d active rule(s) have never matched", len(untested), len(rules))
+	}
+
+	return models.RuleCoverageResult{
+		Message:    message,
+		TotalRules: len(rules),
+		Matched:    matched,
+		Untested:   untested,
+	}
+}
@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// fakeRuleHits counts rule hits in memory in place of RuleHitStore
+type fakeRuleHits struct {
+	hits     []models.RuleHit
+	recorded chan []string
+}
+
+func (f *fakeRuleHits) RecordHits(ctx context.Context, ruleIDs []string) error {
+	f.recorded <- ruleIDs
+	return nil
+}
+
+func (f *fakeRuleHits) List(ctx context.Context) ([]models.RuleHit, error) {
+	return f.hits, nil
+}
+
+// TestCheckRuleCoverage tests that rules without hits are reported as untested
+func TestCheckRuleCoverage(t *testing.T) {
+	rules := []models.PreventionRule{
+		{RuleID: "PREVENT-001", Name: "No force push", Enabled: true},
+		{RuleID: "PREVENT-002", Name: "No rm -rf", Enabled: true},
+		{RuleID: "PREVENT-003", Name: "No secrets", Enabled: true},
+	}
+	now := time.Now()
+	hits := []models.RuleHit{
+		{RuleID: "PREVENT-001", HitCount: 2, LastHitAt: now},
+		{RuleID: "PREVENT-003", HitCount: 7, LastHitAt: now},
+		{RuleID: "PREVENT-999", HitCount: 1, LastHitAt: now},
+	}
+
+	result := checkRuleCoverage(rules, hits)
+
+	if result.TotalRules != 3 {
+		t.Errorf("total rules = %d, want 3", result.TotalRules)
+	}
+	if len(result.Untested) != 1 || result.Untested[0].RuleID != "PREVENT-002" {
+		t.Errorf("untested = %+v, want only PREVENT-002", result.Untested)
+	}
+	if len(result.Matched) != 2 || result.Matched[0].RuleID != "PREVENT-003" || result.Matched[1].RuleID != "PREVENT-001" {
+		t.Errorf("matched = %+v, want PREVENT-003 then PREVENT-001", result.Matched)
+	}
+	for _, entry := range result.Untested {
+		if entry.RuleID == "PREVENT-001" || entry.RuleID == "PREVENT-003" {
+			t.Errorf("matched rule %s listed as untested", entry.RuleID)
+		}
+	}
+}
+
+// TestHandleRuleCoverage tests the tool result with rules paged from the rule store
+func TestHandleRuleCoverage(t *testing.T) {
+	rules := newFakeRules(maxActiveRulesPageSize + 5)
+	s := &MCPServer{
+		rules:    rules,
+		ruleHits: &fakeRuleHits{hits: []models.RuleHit{{RuleID: "RULE-0000", HitCount: 1, LastHitAt: time.Now()}}},
+	}
+
+	res, err := s.handleRuleCoverage(context.Background(), map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("handleRuleCoverage() error = %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("IsError = true: %s", res.Content[0].(mcp.TextContent).Text)
+	}
+	var result models.RuleCoverageResult
+	if err := json.Unmarshal([]byte(res.Content[0].(mcp.TextContent).Text), &result); err != nil {
+		t.Fatalf("invalid JSON result: %v", err)
+	}
+	if result.TotalRules != maxActiveRulesPageSize+5 {
+		t.Errorf("total rules = %d, want %d", result.TotalRules, maxActiveRulesPageSize+5)
+	}
+	if len(result.Matched) != 1 || len(result.Untested) != maxActiveRulesPageSize+4 {
+		t.Errorf("matched %d, untested %d, want 1 and %d", len(result.Matched), len(result.Untested), maxActiveRulesPageSize+4)
+	}
+
+	if res, _ := (&MCPServer{}).handleRuleCoverage(context.Background(), nil); !res.IsError {
+		t.Error("handleRuleCoverage() without stores succeeded, want an error")
+	}
+}
@@ -0,0 +1,71 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+	"github.com/thearchitectit/guardrail-mcp/internal/security"
+)
+
+// handleScanSecrets scans content an agent is about to write for embedded secrets,
+// using the same scanner the web layer applies before saving documents. Without
+// content, the file at file_path is scanned.
+func (s *MCPServer) handleScanSecrets(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	content, hasContent := args["content"].(string)
+	filePath, _ := args["file_path"].(string)
+
+	if !hasContent {
+		if filePath == "" {
+			result := models.SecretScanResult{
+				Valid:    false,
+				Message:  "content or file_path is required",
+				Findings: []models.SecretFinding{},
+			}
+			return buildToolResult(result, true)
+		}
+		data, err := safeReadFile(filePath)
+		if err != nil {
+			result := models.SecretScanResult{
+				Valid:    false,
+				Message:  fmt.Sprintf("Cannot read %s: %v", filePath, err),
+				FilePath: filePath,
+				Findings: []models.SecretFinding{},
+			}
+			return buildToolResult(result, true)
+		}
+		content = string(data)
+	}
+
+	result := scanSecrets(filePath, content)
+	return buildToolResult(result, !result.Valid)
+}
+
+// scanSecrets reports each secret security.ScanContent finds in content. Snippets
+// are redacted so the result does not repeat the secret.
+func scanSecrets(filePath, content string) models.SecretScanResult {
+	findings := []models.SecretFinding{}
+	for _, match := range security.ScanContent(content) {
+		findings = append(findings, models.SecretFinding{
+			Type:        match.Pattern,
+			Line:        match.Line,
+			Column:      match.Column,
+			Snippet:     match.Match,
+			Description: match.Description,
+			Fingerprint: secretFingerprint(match.Pattern, filePath, match.Match),
+		})
+	}
+
+	result := models.SecretScanResult{
+		Valid:    len(findings) == 0,
+		FilePath: filePath,
+		Findings: findings,
+	}
+	if result.Valid {
+		result.Message = "No secrets detected"
+	} else {
+		result.Message = fmt.Sprintf("%d potential secret(s) detected; use environment variables or a secrets manager instead", len(findings))
+	}
+	return result
+}
@@ -0,0 +1,460 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// schemaEvolutionField is one field of a message schema. Required means a reader
+// fails when the field is missing from the data: no default in Avro, listed in
+// "required" in JSON Schema, the required label in Protobuf.
+type schemaEvolutionField struct {
+	Type     string
+	Required bool
+	Tag      int // Protobuf field number, 0 otherwise
+}
+
+// schemaEvolutionSchema is a parsed schema: fields keyed by dotted path, plus the
+// field numbers a Protobuf schema reserves
+type schemaEvolutionSchema struct {
+	Fields   map[string]schemaEvolutionField
+	Reserved map[string]bool // "Message.7" and "Message.name" entries
+}
+
+// schemaReadDirection is one way data crosses a schema change: data written with
+// one schema is read with the other
+type schemaReadDirection struct {
+	mode           string
+	writer, reader schemaEvolutionSchema
+}
+
+var (
+	protoMessageStart = regexp.MustCompile(`^(message|enum|oneof|service|extend)\s+(\w+)\s*\{`)
+	protoField        = regexp.MustCompile(`^(optional|required|repeated)?\s*(map\s*<[^>]+>|[\w.]+)\s+(\w+)\s*=\s*(\d+)`)
+	protoReserved     = regexp.MustCompile(`^reserved\s+([^;]+);`)
+)
+
+// avroPromotions lists the writer types an Avro reader of each type can read
+var avroPromotions = map[string][]string{
+	"long":   {"int"},
+	"float":  {"int", "long"},
+	"double": {"int", "long", "float"},
+	"string": {"bytes"},
+	"bytes":  {"string"},
+}
+
+// protoWireGroups are Protobuf scalar types that share a wire encoding, so a field
+// can change between them without breaking existing data
+var protoWireGroups = [][]string{
+	{"int32", "uint32", "int64", "uint64", "bool"},
+	{"sint32", "sint64"},
+	{"fixed32", "sfixed32"},
+	{"fixed64", "sfixed64"},
+	{"string", "bytes"},
+}
+
+// handleValidateSchemaEvolution checks an Avro, Protobuf or JSON Schema change
+// against a compatibility mode, as a schema registry would before accepting it
+func (s *MCPServer) handleValidateSchemaEvolution(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	oldSchema, _ := args["old_schema"].(string)
+	newSchema, _ := args["new_schema"].(string)
+	format, _ := args["format"].(string)
+	mode, _ := args["mode"].(string)
+	if mode == "" {
+		mode = "backward"
+	}
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.SchemaEvolutionResult{
+			Valid:         false,
+			Message:       message,
+			Format:        format,
+			Mode:          mode,
+			AddedFields:   []string{},
+			RemovedFields: []string{},
+			Issues:        []models.SchemaEvolutionIssue{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	if oldSchema == "" || newSchema == "" {
+		return invalid("old_schema and new_schema are required")
+	}
+	if mode != "backward" && mode != "forward" && mode != "full" {
+		return invalid(fmt.Sprintf("unsupported mode %q (use backward, forward or full)", mode))
+	}
+	if format == "" {
+		format = detectSchemaFormat(oldSchema)
+	}
+
+	result, err := checkSchemaEvolution(oldSchema, newSchema, format, mode)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkSchemaEvolution reports the changes that break the compatibility mode.
+// Backward means consumers on the new schema can read data written with the old
+// one; forward means consumers still on the old schema can read new data; full is
+// both. Type changes and Protobuf field number changes break every mode.
+func checkSchemaEvolution(oldSchema, newSchema, format, mode string) (models.SchemaEvolutionResult, error) {
+	result := models.SchemaEvolutionResult{
+		Format:        format,
+		Mode:          mode,
+		AddedFields:   []string{},
+		RemovedFields: []string{},
+		Issues:        []models.SchemaEvolutionIssue{},
+	}
+
+	before, err := parseEvolutionSchema(oldSchema, format)
+	if err != nil {
+		return result, fmt.Errorf("failed to parse old_schema: %w", err)
+	}
+	after, err := parseEvolutionSchema(newSchema, format)
+	if err != nil {
+		return result, fmt.Errorf("failed to parse new_schema: %w", err)
+	}
+
+	reported := make(map[string]bool)
+	issue := func(field, changeType, severity, message string) {
+		if reported[field+"/"+changeType] {
+			return
+		}
+		reported[field+"/"+changeType] = true
+		result.Issues = append(result.Issues, models.SchemaEvolutionIssue{Field: field, Type: changeType, Severity: severity, Message: message})
+	}
+
+	var directions []schemaReadDirection
+	if mode == "backward" || mode == "full" {
+		directions = append(directions, schemaReadDirection{mode: "backward", writer: before, reader: after})
+	}
+	if mode == "forward" || mode == "full" {
+		directions = append(directions, schemaReadDirection{mode: "forward", writer: after, reader: before})
+	}
+
+	for _, d := range directions {
+		for _, name := range sortedEvolutionFields(d.reader.Fields) {
+			readerField := d.reader.Fields[name]
+			writerField, exists := d.writer.Fields[name]
+			switch {
+			case !exists && readerField.Required && d.mode == "backward":
+				issue(name, "added_required", "error", fmt.Sprintf("Field %s was added as required without a default, so data written with the old schema cannot be read", name))
+			case !exists && readerField.Required:
+				issue(name, "removed_required", "error", fmt.Sprintf("Required field %s was removed, so consumers on the old schema cannot read new data", name))
+			case !exists:
+			case writerField.Type != readerField.Type && !evolutionTypeReadable(format, writerField.Type, readerField.Type):
+				issue(name, "type_changed", "error", fmt.Sprintf("Field %s changed type from %s to %s", name, before.Fields[name].Type, after.Fields[name].Type))
+			case format != "avro" && readerField.Required && !writerField.Required && d.mode == "backward":
+				issue(name, "made_required", "error", fmt.Sprintf("Field %s became required, but data written with the old schema may omit it", name))
+			case format != "avro" && readerField.Required && !writerField.Required:
+				issue(name, "made_optional", "error", fmt.Sprintf("Field %s became optional, but consumers on the old schema still require it", name))
+			}
+		}
+	}
+
+	if format == "protobuf" {
+		checkProtoFieldNumbers(before, after, issue)
+	}
+
+	for _, name := range sortedEvolutionFields(before.Fields) {
+		if _, exists := after.Fields[name]; !exists {
+			result.RemovedFields = append(result.RemovedFields, name)
+		}
+	}
+	for _, name := range sortedEvolutionFields(after.Fields) {
+		if _, exists := before.Fields[name]; !exists {
+			result.AddedFields = append(result.AddedFields, name)
+		}
+	}
+
+	breaking := 0
+	for _, i := range result.Issues {
+		if i.Severity == "error" {
+			breaking++
+		}
+	}
+	result.Valid = breaking == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("Schema change is %s compatible (%d field(s) added, %d removed)", mode, len(result.AddedFields), len(result.RemovedFields))
+	} else {
+		result.Message = fmt.Sprintf("%d change(s) break %s compatibility", breaking, mode)
+	}
+	return result, nil
+}
+
+// checkProtoFieldNumbers flags renumbered fields, numbers reused by a field of a
+// different type and removed fields whose number is not reserved
+func checkProtoFieldNumbers(before, after schemaEvolutionSchema, issue func(field, changeType, severity, message string)) {
+	byTag := make(map[string]string, len(after.Fields))
+	for name, f := range after.Fields {
+		byTag[fmt.Sprintf("%s.%d", protoMessageOf(name), f.Tag)] = name
+	}
+
+	for _, name := range sortedEvolutionFields(before.Fields) {
+		old := before.Fields[name]
+		if f, exists := after.Fields[name]; exists {
+			if f.Tag != old.Tag {
+				issue(name, "field_number_changed", "error", fmt.Sprintf("Field %s changed number from %d to %d", name, old.Tag, f.Tag))
+			}
+			continue
+		}
+		tagKey := fmt.Sprintf("%s.%d", protoMessageOf(name), old.Tag)
+		if reused, ok := byTag[tagKey]; ok {
+			if evolutionTypeReadable("protobuf", old.Type, after.Fields[reused].Type) {
+				issue(reused, "field_renamed", "warning", fmt.Sprintf("Field %s was renamed to %s; binary data is unaffected but JSON encodings change", name, reused))
+			} else {
+				issue(reused, "field_number_reused", "error", fmt.Sprintf("Field %s reuses number %d of removed field %s with type %s", reused, old.Tag, name, old.Type))
+			}
+			continue
+		}
+		if !after.Reserved[tagKey] {
+			issue(name, "unreserved_removal", "warning", fmt.Sprintf("Field %s was removed; reserve number %d so it is not reused", name, old.Tag))
+		}
+	}
+}
+
+// evolutionTypeReadable reports whether a reader expecting readerType can read a
+// value written as writerType
+func evolutionTypeReadable(format, writerType, readerType string) bool {
+	if writerType == readerType {
+		return true
+	}
+	switch format {
+	case "avro":
+		for _, t := range avroPromotions[readerType] {
+			if t == writerType {
+				return true
+			}
+		}
+	case "protobuf":
+		for _, group := range protoWireGroups {
+			if contains(group, writerType) && contains(group, readerType) {
+				return true
+			}
+		}
+	case "json_schema":
+		return writerType == "integer" && readerType == "number"
+	}
+	return false
+}
+
+// detectSchemaFormat guesses the format from the schema text
+func detectSchemaFormat(schema string) string {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(schema), &doc); err == nil {
+		if _, ok := doc["fields"].([]interface{}); ok {
+			return "avro"
+		}
+		return "json_schema"
+	}
+	return "protobuf"
+}
+
+// parseEvolutionSchema parses an avro, protobuf or json_schema schema
+func parseEvolutionSchema(schema, format string) (schemaEvolutionSchema, error) {
+	parsed := schemaEvolutionSchema{Fields: map[string]schemaEvolutionField{}, Reserved: map[string]bool{}}
+	switch format {
+	case "avro":
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(schema), &doc); err != nil {
+			return parsed, err
+		}
+		fields, ok := doc["fields"].([]interface{})
+		if !ok {
+			return parsed, fmt.Errorf("avro schema must be a record with fields")
+		}
+		collectAvroFields(fields, "", parsed.Fields)
+	case "json_schema":
+		var doc map[string]interface{}
+		if err := json.Unmarshal([]byte(schema), &doc); err != nil {
+			return parsed, err
+		}
+		collectJSONSchemaFields(doc, "", parsed.Fields)
+	case "protobuf":
+		if err := collectProtoFields(schema, parsed); err != nil {
+			return parsed, err
+		}
+	default:
+		return parsed, fmt.Errorf("unsupported format %q (use avro, protobuf or json_schema)", format)
+	}
+	if len(parsed.Fields) == 0 {
+		return parsed, fmt.Errorf("no fields found")
+	}
+	return parsed, nil
+}
+
+func collectAvroFields(fields []interface{}, prefix string, out map[string]schemaEvolutionField) {
+	for _, raw := range fields {
+		field, _ := raw.(map[string]interface{})
+		name, _ := field["name"].(string)
+		if name == "" {
+			continue
+		}
+		_, hasDefault := field["default"]
+		out[prefix+name] = schemaEvolutionField{Type: avroTypeName(field["type"]), Required: !hasDefault}
+		if record, ok := field["type"].(map[string]interface{}); ok {
+			if nested, ok := record["fields"].([]interface{}); ok {
+				collectAvroFields(nested, prefix+name+".", out)
+			}
+		}
+	}
+}
+
+// avroTypeName renders an Avro type as a comparable string: unions as "null|string",
+// named types by name and arrays and maps by their element type
+func avroTypeName(t interface{}) string {
+	switch v := t.(type) {
+	case string:
+		return v
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, branch := range v {
+			names = append(names, avroTypeName(branch))
+		}
+		return strings.Join(names, "|")
+	case map[string]interface{}:
+		kind, _ := v["type"].(string)
+		switch kind {
+		case "array":
+			return "array<" + avroTypeName(v["items"]) + ">"
+		case "map":
+			return "map<" + avroTypeName(v["values"]) + ">"
+		case "record", "enum", "fixed":
+			if name, ok := v["name"].(string); ok {
+				return name
+			}
+		}
+		if logical, ok := v["logicalType"].(string); ok {
+			return kind + ":" + logical
+		}
+		return kind
+	}
+	return ""
+}
+
+func collectJSONSchemaFields(node map[string]interface{}, prefix string, out map[string]schemaEvolutionField) {
+	props, _ := node["properties"].(map[string]interface{})
+	required := make(map[string]bool)
+	list, _ := node["required"].([]interface{})
+	for _, r := range list {
+		if name, ok := r.(string); ok {
+			required[name] = true
+		}
+	}
+	for name, raw := range props {
+		prop, _ := raw.(map[string]interface{})
+		fieldType := ""
+		switch t := prop["type"].(type) {
+		case string:
+			fieldType = t
+		case []interface{}:
+			names := make([]string, 0, len(t))
+			for _, n := range t {
+				if s, ok := n.(string); ok {
+					names = append(names, s)
+				}
+			}
+			sort.Strings(names)
+			fieldType = strings.Join(names, "|")
+		}
+		out[prefix+name] = schemaEvolutionField{Type: fieldType, Required: required[name]}
+		collectJSONSchemaFields(prop, prefix+name+".", out)
+	}
+}
+
+// collectProtoFields reads the fields of every message, keyed as Message.field,
+// along with reserved numbers and names. Enum values and service methods are skipped.
+func collectProtoFields(schema string, parsed schemaEvolutionSchema) error {
+	type block struct{ kind, name string }
+	var stack []block
+
+	message := func() string {
+		names := []string{}
+		for _, b := range stack {
+			if b.kind == "message" {
+				names = append(names, b.name)
+			}
+		}
+		return strings.Join(names, ".")
+	}
+	inMessage := func() bool {
+		return len(stack) > 0 && (stack[len(stack)-1].kind == "message" || stack[len(stack)-1].kind == "oneof")
+	}
+
+	for _, line := range strings.Split(schema, "\n") {
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+		case protoMessageStart.MatchString(line):
+			m := protoMessageStart.FindStringSubmatch(line)
+			stack = append(stack, block{kind: m[1], name: m[2]})
+			if strings.HasSuffix(line, "}") {
+				stack = stack[:len(stack)-1]
+			}
+		case strings.HasPrefix(line, "}"):
+			if len(stack) == 0 {
+				return fmt.Errorf("unbalanced braces")
+			}
+			stack = stack[:len(stack)-1]
+		case !inMessage():
+		case protoReserved.MatchString(line):
+			for _, item := range strings.Split(protoReserved.FindStringSubmatch(line)[1], ",") {
+				item = strings.Trim(strings.TrimSpace(item), `"`)
+				from, to, isRange := strings.Cut(item, " to ")
+				if !isRange {
+					parsed.Reserved[message()+"."+item] = true
+					continue
+				}
+				lo, err1 := strconv.Atoi(strings.TrimSpace(from))
+				hi, err2 := strconv.Atoi(strings.TrimSpace(to))
+				if err1 != nil || err2 != nil {
+					continue // "max" and similar open ranges
+				}
+				for n := lo; n <= hi && n-lo < 10000; n++ {
+					parsed.Reserved[fmt.Sprintf("%s.%d", message(), n)] = true
+				}
+			}
+		case protoField.MatchString(line):
+			m := protoField.FindStringSubmatch(line)
+			tag, _ := strconv.Atoi(m[4])
+			fieldType := strings.Join(strings.Fields(m[2]), "")
+			if m[1] == "repeated" {
+				fieldType = "repeated " + fieldType
+			}
+			parsed.Fields[message()+"."+m[3]] = schemaEvolutionField{Type: fieldType, Required: m[1] == "required", Tag: tag}
+		}
+	}
+	if len(stack) != 0 {
+		return fmt.Errorf("unbalanced braces")
+	}
+	return nil
+}
+
+// protoMessageOf returns the message part of a Message.field key
+func protoMessageOf(field string) string {
+	if i := strings.LastIndex(field, "."); i >= 0 {
+		return field[:i]
+	}
+	return ""
+}
+
+func sortedEvolutionFields(fields map[string]schemaEvolutionField) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,207 @@
+package mcp
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const avroOrderV1 = `{"type": "record", "name": "Order", "fields": [
+	{"name": "id", "type": "string"},
+	{"name": "amount", "type": "int"},
+	{"name": "note", "type": ["null", "string"], "default": null}
+]}`
+
+// TestCheckSchemaEvolution tests compatibility checks per format and mode
+func TestCheckSchemaEvolution(t *testing.T) {
+	tests := []struct {
+		name       string
+		oldSchema  string
+		newSchema  string
+		format     string
+		mode       string
+		wantValid  bool
+		wantIssues []string // field/type
+	}{
+		{
+			name:      "avro optional field added is backward compatible",
+			oldSchema: avroOrderV1,
+			newSchema: strings.Replace(avroOrderV1, `{"name": "id"`, `{"name": "currency", "type": "string", "default": "EUR"}, {"name": "id"`, 1),
+			format:    "avro",
+			mode:      "full",
+			wantValid: true,
+		},
+		{
+			name:       "avro required field added breaks backward",
+			oldSchema:  avroOrderV1,
+			newSchema:  strings.Replace(avroOrderV1, `{"name": "id"`, `{"name": "currency", "type": "string"}, {"name": "id"`, 1),
+			format:     "avro",
+			mode:       "backward",
+			wantValid:  false,
+			wantIssues: []string{"currency/added_required"},
+		},
+		{
+			name:      "avro required field added keeps forward",
+			oldSchema: avroOrderV1,
+			newSchema: strings.Replace(avroOrderV1, `{"name": "id"`, `{"name": "currency", "type": "string"}, {"name": "id"`, 1),
+			format:    "avro",
+			mode:      "forward",
+			wantValid: true,
+		},
+		{
+			name:       "avro required field removed breaks forward",
+			oldSchema:  avroOrderV1,
+			newSchema:  strings.Replace(avroOrderV1, `{"name": "amount", "type": "int"},`, ``, 1),
+			format:     "avro",
+			mode:       "forward",
+			wantValid:  false,
+			wantIssues: []string{"amount/removed_required"},
+		},
+		{
+			name:      "avro int widened to long is backward compatible",
+			oldSchema: avroOrderV1,
+			newSchema: strings.Replace(avroOrderV1, `"type": "int"`, `"type": "long"`, 1),
+			format:    "avro",
+			mode:      "backward",
+			wantValid: true,
+		},
+		{
+			name:       "avro int widened to long breaks forward",
+			oldSchema:  avroOrderV1,
+			newSchema:  strings.Replace(avroOrderV1, `"type": "int"`, `"type": "long"`, 1),
+			format:     "avro",
+			mode:       "full",
+			wantValid:  false,
+			wantIssues: []string{"amount/type_changed"},
+		},
+		{
+			name:       "json schema field made required breaks backward",
+			oldSchema:  `{"type": "object", "properties": {"id": {"type": "string"}, "email": {"type": "string"}}, "required": ["id"]}`,
+			newSchema:  `{"type": "object", "properties": {"id": {"type": "string"}, "email": {"type": "string"}}, "required": ["id", "email"]}`,
+			format:     "json_schema",
+			mode:       "backward",
+			wantValid:  false,
+			wantIssues: []string{"email/made_required"},
+		},
+		{
+			name:       "json schema type change breaks every mode",
+			oldSchema:  `{"type": "object", "properties": {"id": {"type": "string"}}}`,
+			newSchema:  `{"type": "object", "properties": {"id": {"type": "integer"}}}`,
+			format:     "json_schema",
+			mode:       "forward",
+			wantValid:  false,
+			wantIssues: []string{"id/type_changed"},
+		},
+		{
+			name:       "protobuf field removed without reserving its number",
+			oldSchema:  "message Order {\n  string id = 1;\n  int64 amount = 2;\n}",
+			newSchema:  "message Order {\n  string id = 1;\n}",
+			format:     "protobuf",
+			mode:       "full",
+			wantValid:  true,
+			wantIssues: []string{"Order.amount/unreserved_removal"},
+		},
+		{
+			name:      "protobuf reserved removal and added field pass",
+			oldSchema: "message Order {\n  string id = 1;\n  int64 amount = 2;\n}",
+			newSchema: "message Order {\n  reserved 2;\n  string id = 1;\n  repeated string tags = 3; // new\n}",
+			format:    "protobuf",
+			mode:      "full",
+			wantValid: true,
+		},
+		{
+			name:       "protobuf number reused with another type",
+			oldSchema:  "message Order {\n  string id = 1;\n  int64 amount = 2;\n}",
+			newSchema:  "message Order {\n  string id = 1;\n  string total = 2;\n}",
+			format:     "protobuf",
+			mode:       "backward",
+			wantValid:  false,
+			wantIssues: []string{"Order.total/field_number_reused"},
+		},
+		{
+			name:       "protobuf field renumbered",
+			oldSchema:  "message Order {\n  string id = 1;\n}",
+			newSchema:  "message Order {\n  string id = 4;\n}",
+			format:     "protobuf",
+			mode:       "backward",
+			wantValid:  false,
+			wantIssues: []string{"Order.id/field_number_changed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := checkSchemaEvolution(tt.oldSchema, tt.newSchema, tt.format, tt.mode)
+			if err != nil {
+				t.Fatalf("checkSchemaEvolution() error = %v", err)
+			}
+			if result.Valid != tt.wantValid {
+				t.Errorf("valid = %v, want %v (%s)", result.Valid, tt.wantValid, result.Message)
+			}
+			if len(result.Issues) != len(tt.wantIssues) {
+				t.Fatalf("issues = %+v, want %v", result.Issues, tt.wantIssues)
+			}
+			for i, want := range tt.wantIssues {
+				if got := result.Issues[i].Field + "/" + result.Issues[i].Type; got != want {
+					t.Errorf("issue[%d] = %s, want %s", i, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestHandleValidateSchemaEvolution tests the tool result, format detection and bad input
+func TestHandleValidateSchemaEvolution(t *testing.T) {
+	s := &MCPServer{}
+
+	tests := []struct {
+		name      string
+		args      map[string]interface{}
+		wantError bool
+		wantText  string
+	}{
+		{
+			name: "additive avro change detected and passing",
+			args: map[string]interface{}{
+				"old_schema": avroOrderV1,
+				"new_schema": strings.Replace(avroOrderV1, `{"name": "id"`, `{"name": "currency", "type": "string", "default": "EUR"}, {"name": "id"`, 1),
+			},
+			wantText: `"format":"avro"`,
+		},
+		{
+			name: "backward incompatible change",
+			args: map[string]interface{}{
+				"old_schema": avroOrderV1,
+				"new_schema": strings.Replace(avroOrderV1, `{"name": "id"`, `{"name": "currency", "type": "string"}, {"name": "id"`, 1),
+				"mode":       "backward",
+			},
+			wantError: true,
+			wantText:  "break backward compatibility",
+		},
+		{
+			name:     "protobuf detected",
+			args:     map[string]interface{}{"old_schema": "message A {\n  string id = 1;\n}", "new_schema": "message A {\n  string id = 1;\n  string name = 2;\n}"},
+			wantText: `"format":"protobuf"`,
+		},
+		{name: "missing schema", args: map[string]interface{}{"old_schema": avroOrderV1}, wantError: true, wantText: "are required"},
+		{name: "bad mode", args: map[string]interface{}{"old_schema": avroOrderV1, "new_schema": avroOrderV1, "mode": "transitive"}, wantError: true, wantText: "unsupported mode"},
+		{name: "bad schema", args: map[string]interface{}{"old_schema": avroOrderV1, "new_schema": "{", "format": "avro"}, wantError: true, wantText: "failed to parse new_schema"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := s.handleValidateSchemaEvolution(context.Background(), tt.args)
+			if err != nil {
+				t.Fatalf("handleValidateSchemaEvolution() error = %v", err)
+			}
+			if res.IsError != tt.wantError {
+				t.Errorf("IsError = %v, want %v", res.IsError, tt.wantError)
+			}
+			if text := res.Content[0].(mcp.TextContent).Text; !strings.Contains(text, tt.wantText) {
+				t.Errorf("result %s does not mention %q", text, tt.wantText)
+			}
+		})
+	}
+}
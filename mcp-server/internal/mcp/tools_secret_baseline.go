@@ -0,0 +1,230 @@
+package mcp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// Actions accepted by guardrail_validate_secret_scanning_baseline
+const (
+	secretBaselineCheck  = "check"
+	secretBaselineAdd    = "add"
+	secretBaselineRemove = "remove"
+)
+
+// secretBaselineStore keeps each project's baseline of known secret findings
+type secretBaselineStore interface {
+	Add(ctx context.Context, entry *models.SecretBaselineEntry) error
+	List(ctx context.Context, projectSlug string) ([]models.SecretBaselineEntry, error)
+	Remove(ctx context.Context, projectSlug, fingerprint string) (bool, error)
+}
+
+// secretFingerprint identifies a finding across scans by its type, file and redacted
+// snippet. The line is left out so that edits elsewhere in the file do not turn a
+// baselined finding into a new one.
+func secretFingerprint(findingType, filePath, snippet string) string {
+	sum := sha256.Sum256([]byte(findingType + "\x00" + filePath + "\x00" + snippet))
+	return hex.EncodeToString(sum[:])
+}
+
+// handleValidateSecretScanningBaseline compares current secret findings with the
+// project's baseline of known findings and reports only new ones, so scanning can be
+// adopted without fixing every legacy hit first. The add and remove actions update
+// the stored baseline with the given findings instead.
+func (s *MCPServer) handleValidateSecretScanningBaseline(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	project, _ := args["project"].(string)
+	action, _ := args["action"].(string)
+	reason, _ := args["reason"].(string)
+	if action == "" {
+		action = secretBaselineCheck
+	}
+
+	invalid := func(message string) (*mcp.CallToolResult, error) {
+		result := models.SecretBaselineResult{
+			Valid:       false,
+			Message:     message,
+			Project:     project,
+			Action:      action,
+			NewFindings: []models.SecretBaselineFinding{},
+		}
+		return buildToolResult(result, true)
+	}
+
+	findings, err := secretBaselineFindingsArg(args)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	if action != secretBaselineCheck {
+		if project == "" {
+			return invalid(fmt.Sprintf("project is required to %s baseline entries", action))
+		}
+		if s.secretBaselines == nil {
+			return invalid("Secret baseline store not configured")
+		}
+	}
+
+	switch action {
+	case secretBaselineAdd:
+		for _, f := range findings {
+			entry := &models.SecretBaselineEntry{
+				ProjectSlug: project,
+				Fingerprint: f.Fingerprint,
+				Type:        f.Type,
+				FilePath:    f.FilePath,
+				Reason:      reason,
+			}
+			if err := s.secretBaselines.Add(ctx, entry); err != nil {
+				return invalid(err.Error())
+			}
+		}
+		result := models.SecretBaselineResult{
+			Valid:       true,
+			Message:     fmt.Sprintf("Added %d finding(s) to the baseline for %s", len(findings), project),
+			Project:     project,
+			Action:      action,
+			Updated:     len(findings),
+			NewFindings: []models.SecretBaselineFinding{},
+		}
+		return buildToolResult(result, false)
+
+	case secretBaselineRemove:
+		removed := 0
+		for _, f := range findings {
+			ok, err := s.secretBaselines.Remove(ctx, project, f.Fingerprint)
+			if err != nil {
+				return invalid(err.Error())
+			}
+			if ok {
+				removed++
+			}
+		}
+		result := models.SecretBaselineResult{
+			Valid:       true,
+			Message:     fmt.Sprintf("Removed %d finding(s) from the baseline for %s", removed, project),
+			Project:     project,
+			Action:      action,
+			Updated:     removed,
+			NewFindings: []models.SecretBaselineFinding{},
+		}
+		return buildToolResult(result, false)
+	}
+
+	baseline, err := secretBaselineArg(args)
+	if err != nil {
+		return invalid(err.Error())
+	}
+	if project != "" && s.secretBaselines != nil {
+		entries, err := s.secretBaselines.List(ctx, project)
+		if err != nil {
+			return invalid(err.Error())
+		}
+		for _, entry := range entries {
+			baseline[entry.Fingerprint] = true
+		}
+	}
+
+	result := checkSecretBaseline(findings, baseline)
+	result.Project = project
+	result.Action = action
+	return buildToolResult(result, !result.Valid)
+}
+
+// secretBaselineFindingsArg reads the findings to check: the findings argument, as
+// returned by guardrail_scan_secrets, or the secrets found by scanning content
+func secretBaselineFindingsArg(args map[string]interface{}) ([]models.SecretBaselineFinding, error) {
+	findings := []models.SecretBaselineFinding{}
+	if content, ok := args["content"].(string); ok {
+		filePath, _ := args["file_path"].(string)
+		for _, f := range scanSecrets(filePath, content).Findings {
+			findings = append(findings, models.SecretBaselineFinding{
+				Type:        f.Type,
+				FilePath:    filePath,
+				Line:        f.Line,
+				Snippet:     f.Snippet,
+				Fingerprint: f.Fingerprint,
+			})
+		}
+	}
+
+	if raw := args["findings"]; raw != nil {
+		var given []models.SecretBaselineFinding
+		data, err := json.Marshal(raw)
+		if err == nil {
+			err = json.Unmarshal(data, &given)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("findings must be an array of {type, file_path, line, snippet}")
+		}
+		for i, f := range given {
+			if f.Fingerprint == "" {
+				if f.Type == "" || f.Snippet == "" {
+					return nil, fmt.Errorf("findings[%d]: type and snippet are required without a fingerprint", i)
+				}
+				f.Fingerprint = secretFingerprint(f.Type, f.FilePath, f.Snippet)
+			}
+			findings = append(findings, f)
+		}
+	}
+
+	if args["content"] == nil && args["findings"] == nil {
+		return nil, fmt.Errorf("findings or content is required")
+	}
+	return findings, nil
+}
+
+// secretBaselineArg reads the inline baseline: fingerprints, or findings whose
+// fingerprints are computed
+func secretBaselineArg(args map[string]interface{}) (map[string]bool, error) {
+	baseline := make(map[string]bool)
+	raw, _ := args["baseline"].([]interface{})
+	for i, item := range raw {
+		switch v := item.(type) {
+		case string:
+			baseline[v] = true
+		case map[string]interface{}:
+			findingType, _ := v["type"].(string)
+			filePath, _ := v["file_path"].(string)
+			snippet, _ := v["snippet"].(string)
+			if fingerprint, _ := v["fingerprint"].(string); fingerprint != "" {
+				baseline[fingerprint] = true
+			} else if findingType != "" && snippet != "" {
+				baseline[secretFingerprint(findingType, filePath, snippet)] = true
+			} else {
+				return nil, fmt.Errorf("baseline[%d]: fingerprint or type and snippet are required", i)
+			}
+		default:
+			return nil, fmt.Errorf("baseline[%d] must be a fingerprint or a finding", i)
+		}
+	}
+	return baseline, nil
+}
+
+// checkSecretBaseline separates the findings whose fingerprints are in the baseline
+// from new ones; only new findings fail the check
+func checkSecretBaseline(findings []models.SecretBaselineFinding, baseline map[string]bool) models.SecretBaselineResult {
+	result := models.SecretBaselineResult{
+		TotalFindings: len(findings),
+		NewFindings:   []models.SecretBaselineFinding{},
+	}
+	for _, f := range findings {
+		if baseline[f.Fingerprint] {
+			result.Baselined++
+			continue
+		}
+		result.NewFindings = append(result.NewFindings, f)
+	}
+
+	result.Valid = len(result.NewFindings) == 0
+	if result.Valid {
+		result.Message = fmt.Sprintf("No new secrets (%d baselined)", result.Baselined)
+	} else {
+		result.Message = fmt.Sprintf("%d new potential secret(s) not in the baseline (%d baselined)", len(result.NewFindings), result.Baselined)
+	}
+	return result
+}
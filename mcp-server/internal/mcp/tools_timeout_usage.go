@@ -0,0 +1,101 @@
+package mcp
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+var (
+	// rootContextArgPattern matches context.Background()/TODO() passed as a call argument
+	rootContextArgPattern = regexp.MustCompile(`(\w+(?:\.\w+)*)\(\s*context\.(?:Background|TODO)\(\)`)
+	// deadlineWrapperPattern matches calls that attach a deadline to a root context
+	deadlineWrapperPattern = regexp.MustCompile(`^context\.(?:WithTimeout|WithDeadline|WithTimeoutCause|WithDeadlineCause)$`)
+	// externalCallPattern matches calls that usually leave the process (HTTP, SQL, RPC, cache)
+	externalCallPattern = regexp.MustCompile(`(?:NewRequestWithContext|QueryContext|QueryRowContext|ExecContext|PrepareContext|BeginTx|PingContext|DialContext|Do|Invoke|Get|Set|Publish|Send)$`)
+	// defaultHTTPClientPattern matches HTTP helpers that use the default client without a timeout
+	defaultHTTPClientPattern = regexp.MustCompile(`\bhttp\.(?:Get|Post|PostForm|Head)\(|\bhttp\.DefaultClient\b`)
+	// bareHTTPClientPattern matches an http.Client literal declared without a Timeout field
+	bareHTTPClientPattern = regexp.MustCompile(`&?http\.Client\{\s*\}`)
+	sleepPattern          = regexp.MustCompile(`\btime\.Sleep\(`)
+)
+
+// handleValidateTimeoutUsage flags added Go code that makes external calls without a
+// deadline or sleeps in what may be a request path
+func (s *MCPServer) handleValidateTimeoutUsage(ctx context.Context, args map[string]interface{}) (*mcp.CallToolResult, error) {
+	diff, _ := args["diff"].(string)
+
+	if diff == "" {
+		result := models.DiffScanResult{
+			Valid:   false,
+			Message: "diff is required",
+		}
+		return buildToolResult(result, true)
+	}
+
+	result := checkTimeoutUsage(diff)
+	return buildToolResult(result, !result.Valid)
+}
+
+// checkTimeoutUsage scans the added lines of a diff for missing deadlines and sleeps
+func checkTimeoutUsage(diff string) models.DiffScanResult {
+	violations := []models.DiffViolation{}
+	lines := addedLines(diff)
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line.Text)
+		if trimmed == "" || isCommentLine(trimmed) {
+			continue
+		}
+		isTestFile := strings.HasSuffix(line.File, "_test.go")
+
+		for _, m := range rootContextArgPattern.FindAllStringSubmatch(line.Text, -1) {
+			callee := m[1]
+			if deadlineWrapperPattern.MatchString(callee) {
+				continue
+			}
+			severity := "warning"
+			if externalCallPattern.MatchString(callee) && !isTestFile {
+				severity = "error"
+			}
+			violations = append(violations, models.DiffViolation{
+				Type:       "context_without_deadline",
+				Severity:   severity,
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    "Root context without a deadline passed to " + callee + "; the call can hang indefinitely",
+				Suggestion: "Propagate the caller's ctx or wrap it: ctx, cancel := context.WithTimeout(ctx, timeout); defer cancel()",
+			})
+		}
+
+		if defaultHTTPClientPattern.MatchString(line.Text) || bareHTTPClientPattern.MatchString(line.Text) {
+			violations = append(violations, models.DiffViolation{
+				Type:       "http_client_without_timeout",
+				Severity:   "warning",
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    "HTTP client without a Timeout; requests can block forever on a slow server",
+				Suggestion: "Use an http.Client with Timeout set, or requests built with http.NewRequestWithContext and a deadline",
+			})
+		}
+
+		if sleepPattern.MatchString(line.Text) && !isTestFile {
+			violations = append(violations, models.DiffViolation{
+				Type:       "sleep_in_request_path",
+				Severity:   "warning",
+				File:       line.File,
+				LineNumber: line.Number,
+				Line:       trimmed,
+				Message:    "time.Sleep blocks the goroutine and ignores cancellation",
+				Suggestion: "Use a select on ctx.Done() and time.After (or a ticker) so the wait is cancellable",
+			})
+		}
+	}
+
+	return newDiffScanResult("timeout usage", violations, len(lines))
+}
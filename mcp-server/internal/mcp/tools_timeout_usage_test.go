@@ -0,0 +1,67 @@
+package mcp
+
+import (
+	"testing"
+)
+
+// TestCheckTimeoutUsage tests detection of missing deadlines and sleeps in added Go code
+func TestCheckTimeoutUsage(t *testing.T) {
+	tests := []struct {
+		name      string
+		diff      string
+		wantValid bool
+		wantTypes []string
+	}{
+		{
+			name:      "background context passed to HTTP call flagged",
+			diff:      "+++ b/client.go\n@@ -1,1 +1,2 @@\n package client\n+\treq, err := http.NewRequestWithContext(context.Background(), \"GET\", url, nil)\n",
+			wantValid: false,
+			wantTypes: []string{"context_without_deadline"},
+		},
+		{
+			name:      "context.WithTimeout usage passes",
+			diff:      "+\tctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)\n+\tdefer cancel()\n+\treq, err := http.NewRequestWithContext(ctx, \"GET\", url, nil)\n",
+			wantValid: true,
+		},
+		{
+			name:      "TODO context passed to database query flagged",
+			diff:      "+\trows, err := s.db.QueryContext(context.TODO(), query)\n",
+			wantValid: false,
+			wantTypes: []string{"context_without_deadline"},
+		},
+		{
+			name:      "sleep in handler warns",
+			diff:      "+++ b/handler.go\n+\ttime.Sleep(2 * time.Second)\n",
+			wantValid: true,
+			wantTypes: []string{"sleep_in_request_path"},
+		},
+		{
+			name:      "default HTTP client warns",
+			diff:      "+\tresp, err := http.Get(endpoint)\n",
+			wantValid: true,
+			wantTypes: []string{"http_client_without_timeout"},
+		},
+		{
+			name:      "sleep in test file ignored",
+			diff:      "+++ b/handler_test.go\n+\ttime.Sleep(10 * time.Millisecond)\n",
+			wantValid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := checkTimeoutUsage(tt.diff)
+			if result.Valid != tt.wantValid {
+				t.Errorf("checkTimeoutUsage() valid = %v, want %v (%+v)", result.Valid, tt.wantValid, result.Violations)
+			}
+			if len(result.Violations) != len(tt.wantTypes) {
+				t.Fatalf("violations = %+v, want types %v", result.Violations, tt.wantTypes)
+			}
+			for i, typ := range tt.wantTypes {
+				if result.Violations[i].Type != typ {
+					t.Errorf("violation[%d] type = %s, want %s", i, result.Violations[i].Type, typ)
+				}
+			}
+		})
+	}
+}
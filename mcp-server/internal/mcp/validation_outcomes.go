@@ -0,0 +1,210 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+const (
+	// outcomeQueueSize is how many validation outcomes can wait to be written
+	outcomeQueueSize = 256
+	// outcomeWriteTimeout bounds each outcome write
+	outcomeWriteTimeout = 5 * time.Second
+)
+
+// validationOutcomeStore persists the outcome of each validation for trends
+type validationOutcomeStore interface {
+	Record(ctx context.Context, outcome *models.ValidationOutcome) error
+}
+
+// ruleHitStore counts prevention rule matches for rule coverage, as RuleHitStore does
+type ruleHitStore interface {
+	RecordHits(ctx context.Context, ruleIDs []string) error
+	List(ctx context.Context) ([]models.RuleHit, error)
+}
+
+// outcomeRecord is what one validation leaves behind: its outcome, when the call
+// belongs to a project, and the rules that matched
+type outcomeRecord struct {
+	outcome *models.ValidationOutcome
+	ruleIDs []string
+}
+
+// outcomeRecorder writes validation outcomes and rule hits in the background so that
+// tool calls never wait on the database. Records are dropped when the queue is full.
+type outcomeRecorder struct {
+	store validationOutcomeStore
+	hits  ruleHitStore // nil disables rule hit counting
+	queue chan outcomeRecord
+}
+
+// newOutcomeRecorder creates a recorder; call run to start writing
+func newOutcomeRecorder(store validationOutcomeStore, hits ruleHitStore) *outcomeRecorder {
+	return &outcomeRecorder{
+		store: store,
+		hits:  hits,
+		queue: make(chan outcomeRecord, outcomeQueueSize),
+	}
+}
+
+// record queues a record without blocking
+func (r *outcomeRecorder) record(rec outcomeRecord) {
+	select {
+	case r.queue <- rec:
+	default:
+		slog.Warn("Validation outcome queue full, dropping outcome", "rules", len(rec.ruleIDs))
+	}
+}
+
+// run writes queued records until stop is closed
+func (r *outcomeRecorder) run(stop <-chan struct{}) {
+	for {
+		select {
+		case <-stop:
+			return
+		case rec := <-r.queue:
+			ctx, cancel := context.WithTimeout(context.Background(), outcomeWriteTimeout)
+			if outcome := rec.outcome; outcome != nil {
+				if err := r.store.Record(ctx, outcome); err != nil {
+					slog.Error("Failed to record validation outcome", "project", outcome.ProjectSlug, "tool", outcome.ToolName, "error", err)
+				}
+			}
+			if r.hits != nil && len(rec.ruleIDs) > 0 {
+				if err := r.hits.RecordHits(ctx, rec.ruleIDs); err != nil {
+					slog.Error("Failed to record rule hits", "error", err)
+				}
+			}
+			cancel()
+		}
+	}
+}
+
+// recordValidationOutcome queues the outcome of a guardrail_validate_* tool call for
+// the project named in the arguments or bound to the session, and a hit for each
+// prevention rule the result reports. Outcomes of calls without a project, and
+// results that are not JSON, are not recorded.
+func (s *MCPServer) recordValidationOutcome(name string, args map[string]interface{}, result *mcp.CallToolResult) {
+	if s.outcomes == nil || result == nil || !strings.HasPrefix(name, "guardrail_validate_") {
+		return
+	}
+	rec := outcomeRecord{ruleIDs: ruleIDsFromResult(result)}
+	if project := s.outcomeProject(args); project != "" {
+		if outcome, ok := validationOutcomeFromResult(result); ok {
+			outcome.ProjectSlug = project
+			outcome.ToolName = name
+			outcome.RecordedAt = time.Now().UTC()
+			rec.outcome = &outcome
+		}
+	}
+	if rec.outcome == nil && len(rec.ruleIDs) == 0 {
+		return
+	}
+	s.outcomes.record(rec)
+}
+
+// outcomeProject returns the project a validation belongs to: the project or
+// project_slug argument, or the project of the session
+func (s *MCPServer) outcomeProject(args map[string]interface{}) string {
+	for _, key := range []string{"project", "project_slug"} {
+		if project, _ := args[key].(string); project != "" {
+			return project
+		}
+	}
+	sessionToken, _ := args["session_token"].(string)
+	if sessionToken == "" {
+		return ""
+	}
+	if session, ok := s.getSession(sessionToken); ok {
+		return session.ProjectSlug
+	}
+	return ""
+}
+
+// outcomeViolationKeys are the result fields validation tools list problems under
+var outcomeViolationKeys = []string{"violations", "issues", "findings", "new_findings"}
+
+// validationOutcomeFromResult counts the violations in a validation tool result by
+// severity. Entries without a severity count as errors in a failed result and as
+// warnings otherwise; a failed result with no entries counts as one error. Results
+// that list no violations, such as argument errors, are not outcomes.
+func validationOutcomeFromResult(result *mcp.CallToolResult) (models.ValidationOutcome, bool) {
+	outcome := models.ValidationOutcome{Valid: !result.IsError}
+	body, ok := resultBody(result)
+	if !ok {
+		return outcome, false
+	}
+
+	fallback := "warning"
+	if !outcome.Valid {
+		fallback = "error"
+	}
+	listed := false
+	for _, key := range outcomeViolationKeys {
+		entries, ok := body[key].([]interface{})
+		if !ok {
+			continue
+		}
+		listed = true
+		for _, entry := range entries {
+			severity := fallback
+			if fields, ok := entry.(map[string]interface{}); ok {
+				if sev, _ := fields["severity"].(string); sev != "" {
+					severity = strings.ToLower(sev)
+				}
+			}
+			outcome.AddViolation(severity)
+		}
+	}
+	if !listed {
+		// Rejected arguments and other errors before validation ran
+		return outcome, false
+	}
+	if !outcome.Valid && outcome.Violations() == 0 {
+		outcome.AddViolation("error")
+	}
+	return outcome, true
+}
+
+// ruleIDsFromResult returns the distinct rule_id values of the violations a
+// validation tool result lists
+func ruleIDsFromResult(result *mcp.CallToolResult) []string {
+	body, ok := resultBody(result)
+	if !ok {
+		return nil
+	}
+	var ruleIDs []string
+	seen := make(map[string]bool)
+	for _, key := range outcomeViolationKeys {
+		entries, _ := body[key].([]interface{})
+		for _, entry := range entries {
+			fields, _ := entry.(map[string]interface{})
+			if ruleID, _ := fields["rule_id"].(string); ruleID != "" && !seen[ruleID] {
+				seen[ruleID] = true
+				ruleIDs = append(ruleIDs, ruleID)
+			}
+		}
+	}
+	return ruleIDs
+}
+
+// resultBody decodes the JSON object in a tool result's first text content
+func resultBody(result *mcp.CallToolResult) (map[string]interface{}, bool) {
+	if len(result.Content) == 0 {
+		return nil, false
+	}
+	text, ok := result.Content[0].(mcp.TextContent)
+	if !ok {
+		return nil, false
+	}
+	var body map[string]interface{}
+	if err := json.Unmarshal([]byte(text.Text), &body); err != nil {
+		return nil, false
+	}
+	return body, true
+}
@@ -0,0 +1,133 @@
+package mcp
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// fakeOutcomeStore hands recorded outcomes to the test in place of ValidationOutcomeStore
+type fakeOutcomeStore struct {
+	recorded chan models.ValidationOutcome
+}
+
+func (f *fakeOutcomeStore) Record(ctx context.Context, outcome *models.ValidationOutcome) error {
+	f.recorded <- *outcome
+	return nil
+}
+
+// TestValidationOutcomeFromResult tests counting violations by severity in tool results
+func TestValidationOutcomeFromResult(t *testing.T) {
+	text := func(body string, isError bool) *mcp.CallToolResult {
+		return &mcp.CallToolResult{Content: []interface{}{mcp.TextContent{Type: "text", Text: body}}, IsError: isError}
+	}
+
+	tests := []struct {
+		name   string
+		result *mcp.CallToolResult
+		want   models.ValidationOutcome
+		wantOK bool
+	}{
+		{
+			name:   "diff scan with errors and warnings",
+			result: text(`{"valid":false,"violations":[{"severity":"error"},{"severity":"warning"},{"severity":"error"}]}`, true),
+			want:   models.ValidationOutcome{Valid: false, Errors: 2, Warnings: 1},
+			wantOK: true,
+		},
+		{
+			name:   "domain severities",
+			result: text(`{"valid":false,"violations":[{"severity":"CRITICAL"},{"severity":"medium"},{"severity":"low"}]}`, true),
+			want:   models.ValidationOutcome{Valid: false, Critical: 1, Warnings: 1, Info: 1},
+			wantOK: true,
+		},
+		{
+			name:   "issues without severity in a failed result",
+			result: text(`{"valid":false,"issues":[{"type":"unauthorized_author"}]}`, true),
+			want:   models.ValidationOutcome{Valid: false, Errors: 1},
+			wantOK: true,
+		},
+		{
+			name:   "clean result",
+			result: text(`{"valid":true,"violations":[]}`, false),
+			want:   models.ValidationOutcome{Valid: true},
+			wantOK: true,
+		},
+		{
+			name:   "argument error is not an outcome",
+			result: text(`{"valid":false,"message":"diff is required"}`, true),
+		},
+		{
+			name:   "plain text is not an outcome",
+			result: text("Tool guardrail_validate_bash is disabled", true),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := validationOutcomeFromResult(tt.result)
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Errorf("outcome = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRecordValidationOutcome tests that validation outcomes are written in the background for the session's project
+func TestRecordValidationOutcome(t *testing.T) {
+	store := &fakeOutcomeStore{recorded: make(chan models.ValidationOutcome, 1)}
+	stop := make(chan struct{})
+	defer close(stop)
+
+	s := mockMCPServer()
+	s.sessions.Put(context.Background(), &Session{ID: "sess-1", ProjectSlug: "demo"})
+	s.outcomes = newOutcomeRecorder(store, nil)
+	go s.outcomes.run(stop)
+
+	result := &mcp.CallToolResult{Content: []interface{}{mcp.TextContent{Type: "text", Text: `{"valid":true,"violations":[{"severity":"warning"}]}`}}}
+	s.recordValidationOutcome("guardrail_list_file_reads", map[string]interface{}{"session_token": "sess-1"}, result)
+	s.recordValidationOutcome("guardrail_validate_timeout_usage", map[string]interface{}{"diff": "+x"}, result)
+	s.recordValidationOutcome("guardrail_validate_timeout_usage", map[string]interface{}{"session_token": "sess-1"}, result)
+
+	select {
+	case outcome := <-store.recorded:
+		if outcome.ProjectSlug != "demo" || outcome.ToolName != "guardrail_validate_timeout_usage" || !outcome.Valid || outcome.Warnings != 1 {
+			t.Errorf("recorded = %+v, want the timeout usage outcome for demo", outcome)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("outcome was not recorded")
+	}
+	select {
+	case outcome := <-store.recorded:
+		t.Errorf("unexpected outcome recorded: %+v", outcome)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// TestRecordValidationOutcome_RuleHits tests that the rules a validation matched are counted, with or without a project
+func TestRecordValidationOutcome_RuleHits(t *testing.T) {
+	hits := &fakeRuleHits{recorded: make(chan []string, 1)}
+	stop := make(chan struct{})
+	defer close(stop)
+
+	s := mockMCPServer()
+	s.outcomes = newOutcomeRecorder(&fakeOutcomeStore{recorded: make(chan models.ValidationOutcome, 1)}, hits)
+	go s.outcomes.run(stop)
+
+	result := &mcp.CallToolResult{Content: []interface{}{mcp.TextContent{Type: "text", Text: `{"valid":false,"violations":[{"rule_id":"PREVENT-001"},{"rule_id":"PREVENT-002"},{"rule_id":"PREVENT-001"}]}`}}, IsError: true}
+	s.recordValidationOutcome("guardrail_validate_bash", map[string]interface{}{"command": "rm -rf /"}, result)
+
+	select {
+	case ruleIDs := <-hits.recorded:
+		if len(ruleIDs) != 2 || ruleIDs[0] != "PREVENT-001" || ruleIDs[1] != "PREVENT-002" {
+			t.Errorf("recorded hits = %v, want [PREVENT-001 PREVENT-002]", ruleIDs)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("rule hits were not recorded")
+	}
+}
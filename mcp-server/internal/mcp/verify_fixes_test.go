@@ -24,7 +24,7 @@ func TestVerifyFixesIntact(t *testing.T) {
 
 	// Create a mock server for testing
 	s := &MCPServer{
-		sessions: make(map[string]*Session),
+		sessions: newSessionStore(nil, time.Hour, 0),
 	}
 
 	// Test that error is returned when session_token is missing
@@ -45,13 +45,13 @@ func TestVerifyFixesIntact(t *testing.T) {
 
 	// Test with valid session
 	sessionID := "test-session-123"
-	s.sessions[sessionID] = &Session{
+	s.sessions.Put(context.Background(), &Session{
 		ID:           sessionID,
 		ProjectSlug:  "test-project",
 		AgentType:    "claude-code",
 		CreatedAt:    time.Now(),
 		LastActivity: time.Now(),
-	}
+	})
 
 	args2 := map[string]interface{}{
 		"session_token": sessionID,
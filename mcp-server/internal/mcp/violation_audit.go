@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// maxAuditedViolations caps the audit events one denial can emit, so a single
+// command matching many rules cannot crowd other events out of the audit buffer
+const maxAuditedViolations = 20
+
+// ruleEngineTools are the validation tools whose denials list the prevention rules
+// that matched
+var ruleEngineTools = map[string]bool{
+	"guardrail_validate_bash":          true,
+	"guardrail_validate_file_edit":     true,
+	"guardrail_validate_git_operation": true,
+}
+
+// auditDenial records a guardrail denial for incident review, with the session and
+// project the call came from when they are known
+func (s *MCPServer) auditDenial(ctx context.Context, tool string, args map[string]interface{}, ruleID, message string) {
+	if s.audit == nil {
+		return
+	}
+	sessionToken, _ := args["session_token"].(string)
+	s.audit.LogViolation(ctx, sessionToken, s.outcomeProject(args), tool, ruleID, message)
+}
+
+// auditRuleViolations audits each violation a rule engine tool denied a call for.
+// Denials are ordinary results reporting "valid": false, not error results, so the
+// decoded body decides; errors such as a missing command carry no "valid" field.
+func (s *MCPServer) auditRuleViolations(ctx context.Context, name string, args map[string]interface{}, result *mcp.CallToolResult) {
+	if s.audit == nil || result == nil || !ruleEngineTools[name] {
+		return
+	}
+	body, ok := resultBody(result)
+	if !ok {
+		return
+	}
+	if valid, ok := body["valid"].(bool); !ok || valid {
+		return
+	}
+	violations, _ := body["violations"].([]interface{})
+	if len(violations) > maxAuditedViolations {
+		slog.Warn("Auditing only the first violations of a denial", "tool", name, "violations", len(violations), "audited", maxAuditedViolations)
+		violations = violations[:maxAuditedViolations]
+	}
+	for _, entry := range violations {
+		fields, _ := entry.(map[string]interface{})
+		ruleID, _ := fields["rule_id"].(string)
+		message, _ := fields["message"].(string)
+		s.auditDenial(ctx, name, args, ruleID, message)
+	}
+}
@@ -0,0 +1,129 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/thearchitectit/guardrail-mcp/internal/audit"
+	"github.com/thearchitectit/guardrail-mcp/internal/domain"
+)
+
+// collectAuditEvents waits for want events from the subscription, or fails
+func collectAuditEvents(t *testing.T, events <-chan audit.Event, want int) []audit.Event {
+	t.Helper()
+	var got []audit.Event
+	for len(got) < want {
+		select {
+		case event := <-events:
+			got = append(got, event)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("got %d audit events, want %d", len(got), want)
+		}
+	}
+	select {
+	case event := <-events:
+		t.Errorf("unexpected audit event: %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+	return got
+}
+
+// textResult wraps a validator's JSON the way GuardrailHandlers returns it
+func textResult(text string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{Content: []interface{}{mcp.TextContent{Type: "text", Text: text}}}
+}
+
+// TestAuditRuleViolations tests that each violation of a denied call is audited with its session's project
+func TestAuditRuleViolations(t *testing.T) {
+	ctx := context.Background()
+	s := mockMCPServer()
+	s.audit = audit.NewLogger(10)
+	events, unsubscribe := s.audit.Subscribe(10)
+	defer unsubscribe()
+	s.sessions.Put(ctx, &Session{ID: "sess-0123456789", ProjectSlug: "demo"})
+
+	denial := domain.NewValidationResult([]domain.Violation{
+		{RuleID: "PREVENT-001", RuleName: "No rm -rf", Severity: domain.SeverityCritical, Message: "rm -rf is forbidden"},
+		{RuleID: "PREVENT-002", RuleName: "No sudo", Severity: domain.SeverityCritical, Message: "sudo is forbidden"},
+	})
+	denied := textResult(formatValidationResult(denial, "sudo rm -rf /"))
+	failed := textResult(`{"error":"command is required"}`)
+	failed.IsError = true
+	args := map[string]interface{}{"command": "sudo rm -rf /", "session_token": "sess-0123456789"}
+	s.auditRuleViolations(ctx, "guardrail_validate_bash", args, denied)
+	s.auditRuleViolations(ctx, "guardrail_validate_backup_policy", args, denied)
+	s.auditRuleViolations(ctx, "guardrail_validate_bash", args, textResult(formatValidationResult(domain.NewValidationResult(nil), "ls")))
+	s.auditRuleViolations(ctx, "guardrail_validate_bash", args, failed)
+
+	got := collectAuditEvents(t, events, 2)
+	for i, ruleID := range []string{"PREVENT-001", "PREVENT-002"} {
+		event := got[i]
+		if event.Type != audit.EventViolation || event.Resource != "guardrail_validate_bash" || event.Status != "denied" {
+			t.Errorf("event[%d] = %+v, want a bash denial", i, event)
+		}
+		if event.Details["rule_id"] != ruleID || event.Details["project"] != "demo" {
+			t.Errorf("event[%d] details = %v, want rule %s in project demo", i, event.Details, ruleID)
+		}
+	}
+}
+
+// TestAuditRuleViolations_FileEdit tests that file edit denials, formatted with the file, are audited
+func TestAuditRuleViolations_FileEdit(t *testing.T) {
+	s := mockMCPServer()
+	s.audit = audit.NewLogger(10)
+	events, unsubscribe := s.audit.Subscribe(10)
+	defer unsubscribe()
+
+	denial := domain.NewValidationResult([]domain.Violation{
+		{RuleID: "PREVENT-010", RuleName: "No secrets", Severity: domain.SeverityCritical, Message: "secret in file"},
+	})
+	denied := textResult(formatValidationResultWithFile(denial, ".env", 42))
+	s.auditRuleViolations(context.Background(), "guardrail_validate_file_edit", map[string]interface{}{"file_path": ".env"}, denied)
+
+	got := collectAuditEvents(t, events, 1)
+	if got[0].Resource != "guardrail_validate_file_edit" || got[0].Details["rule_id"] != "PREVENT-010" {
+		t.Errorf("event = %+v, want the file edit denial", got[0])
+	}
+}
+
+// TestAuditRuleViolations_Bounded tests that one denial cannot flood the audit buffer
+func TestAuditRuleViolations_Bounded(t *testing.T) {
+	s := mockMCPServer()
+	s.audit = audit.NewLogger(maxAuditedViolations * 2)
+	events, unsubscribe := s.audit.Subscribe(maxAuditedViolations * 2)
+	defer unsubscribe()
+
+	violations := make([]domain.Violation, maxAuditedViolations+10)
+	for i := range violations {
+		violations[i] = domain.Violation{RuleID: fmt.Sprintf("RULE-%d", i), Message: "denied"}
+	}
+	denied := textResult(formatValidationResult(domain.NewValidationResult(violations), "git push --force"))
+	s.auditRuleViolations(context.Background(), "guardrail_validate_git_operation", map[string]interface{}{}, denied)
+
+	collectAuditEvents(t, events, maxAuditedViolations)
+}
+
+// TestHandleValidatePush_AuditsDenials tests that a blocked push is audited and an allowed one is not
+func TestHandleValidatePush_AuditsDenials(t *testing.T) {
+	ctx := context.Background()
+	s := mockMCPServer()
+	s.audit = audit.NewLogger(10)
+	events, unsubscribe := s.audit.Subscribe(10)
+	defer unsubscribe()
+
+	if _, err := s.handleValidatePush(ctx, map[string]interface{}{"branch": "feature/x", "has_unpushed_commits": true}); err != nil {
+		t.Fatalf("handleValidatePush() error = %v", err)
+	}
+	if _, err := s.handleValidatePush(ctx, map[string]interface{}{"branch": "feature/x", "is_force": true, "project_slug": "demo"}); err != nil {
+		t.Fatalf("handleValidatePush() error = %v", err)
+	}
+
+	got := collectAuditEvents(t, events, 1)
+	if got[0].Resource != "guardrail_validate_push" || got[0].Details["project"] != "demo" || !strings.Contains(got[0].Details["message"].(string), "Force push") {
+		t.Errorf("event = %+v, want the force push denial for demo", got[0])
+	}
+}
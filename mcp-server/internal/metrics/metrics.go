@@ -617,6 +617,11 @@ func DecrementActiveSessions() {
 	MCPSessionsActive.Dec()
 }
 
+// RecordSessionCreated records a session creation without counting it as active
+func RecordSessionCreated() {
+	MCPSessionsCreatedTotal.Inc()
+}
+
 // RecordSessionExpired records a session expiration
 func RecordSessionExpired() {
 	MCPSessionsExpiredTotal.Inc()
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// RateLimitConsumer is one caller's bucket in a rate limiter
+type RateLimitConsumer struct {
+	Key       string    `json:"key"` // session and SSE ids are shortened
+	Used      int       `json:"used"`
+	Remaining int       `json:"remaining"`
+	ResetAt   time.Time `json:"reset_at"`
+}
+
+// RateLimiterSnapshot is the configured limit and current buckets of one rate limiter
+type RateLimiterSnapshot struct {
+	Limit         int                 `json:"limit"`
+	WindowSeconds int                 `json:"window_seconds"`
+	Buckets       int                 `json:"buckets"`        // buckets held, including expired ones awaiting cleanup
+	ActiveBuckets int                 `json:"active_buckets"` // buckets still inside their window
+	Throttled     int                 `json:"throttled"`      // active buckets with no tokens left
+	TopConsumers  []RateLimitConsumer `json:"top_consumers"`
+}
+
+// RateLimitStats is the state of the team tool rate limiters
+type RateLimitStats struct {
+	Mutating RateLimiterSnapshot `json:"mutating"`
+	ReadOnly RateLimiterSnapshot `json:"read_only"`
+}
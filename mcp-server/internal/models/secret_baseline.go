@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// SecretBaselineEntry is a known secret-scanning finding, usually a false positive,
+// that is suppressed for a project
+type SecretBaselineEntry struct {
+	ID          string    `json:"id"`
+	ProjectSlug string    `json:"project_slug"`
+	Fingerprint string    `json:"fingerprint"`
+	Type        string    `json:"type"`
+	FilePath    string    `json:"file_path,omitempty"`
+	Reason      string    `json:"reason,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,10 +26,11 @@ type TaskAttempt struct {
 type ErrorCategory string
 
 const (
-	ErrorCategorySyntax  ErrorCategory = "syntax"
+	ErrorCategorySyntax    ErrorCategory = "syntax"
 	ErrorCategoryRuntime   ErrorCategory = "runtime"
 	ErrorCategoryLogic     ErrorCategory = "logic"
 	ErrorCategoryTimeout   ErrorCategory = "timeout"
+	ErrorCategoryTestFlake ErrorCategory = "test_flake"
 	ErrorCategoryOther     ErrorCategory = "other"
 )
 
@@ -38,6 +40,7 @@ var ValidErrorCategories = []string{
 	string(ErrorCategoryRuntime),
 	string(ErrorCategoryLogic),
 	string(ErrorCategoryTimeout),
+	string(ErrorCategoryTestFlake),
 	string(ErrorCategoryOther),
 }
 
@@ -51,6 +54,88 @@ func IsValidErrorCategory(category string) bool {
 	return false
 }
 
+// DefaultMaxAttempts is the number of failed attempts allowed before three strikes halts
+const DefaultMaxAttempts = 3
+
+// Sources of the limit applied by a three strikes check
+const (
+	LimitSourceDefault  = "default"
+	LimitSourceCategory = "category"
+)
+
+// ThreeStrikesLimits holds the maximum attempts before halting. Categories with
+// their own limit are counted separately; all other categories share Default.
+type ThreeStrikesLimits struct {
+	Default     int
+	PerCategory map[string]int
+}
+
+// ThreeStrikesCheck is the outcome of applying the limits to pending attempt counts
+type ThreeStrikesCheck struct {
+	ErrorCategory string
+	LimitSource   string
+	AttemptsCount int
+	MaxAttempts   int
+}
+
+// Remaining returns the attempts left before the limit is reached
+func (c ThreeStrikesCheck) Remaining() int {
+	if c.AttemptsCount >= c.MaxAttempts {
+		return 0
+	}
+	return c.MaxAttempts - c.AttemptsCount
+}
+
+// Check applies the limits to pending attempt counts keyed by error category. With
+// a category, that category's limit is applied; without one, the limit closest to
+// being reached is reported.
+func (l ThreeStrikesLimits) Check(counts map[string]int, category string) ThreeStrikesCheck {
+	defaultLimit := l.Default
+	if defaultLimit <= 0 {
+		defaultLimit = DefaultMaxAttempts
+	}
+
+	shared := ThreeStrikesCheck{LimitSource: LimitSourceDefault, MaxAttempts: defaultLimit}
+	for c, n := range counts {
+		if _, ok := l.PerCategory[c]; !ok {
+			shared.AttemptsCount += n
+		}
+	}
+
+	if category != "" {
+		if limit, ok := l.PerCategory[category]; ok {
+			return ThreeStrikesCheck{
+				ErrorCategory: category,
+				LimitSource:   LimitSourceCategory,
+				AttemptsCount: counts[category],
+				MaxAttempts:   limit,
+			}
+		}
+		shared.ErrorCategory = category
+		return shared
+	}
+
+	categories := make([]string, 0, len(l.PerCategory))
+	for c := range l.PerCategory {
+		categories = append(categories, c)
+	}
+	sort.Strings(categories)
+
+	closest := shared
+	for _, c := range categories {
+		check := ThreeStrikesCheck{
+			ErrorCategory: c,
+			LimitSource:   LimitSourceCategory,
+			AttemptsCount: counts[c],
+			MaxAttempts:   l.PerCategory[c],
+		}
+		if check.AttemptsCount > 0 && check.Remaining() < closest.Remaining() {
+			closest = check
+		}
+	}
+	return closest
+}
+
 // Validate checks if the task attempt is valid for creation
 func (t *TaskAttempt) Validate() error {
 	if t.SessionID == "" {
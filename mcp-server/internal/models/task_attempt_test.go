@@ -0,0 +1,86 @@
+package models
+
+import "testing"
+
+func TestThreeStrikesLimits_Check(t *testing.T) {
+	limits := ThreeStrikesLimits{
+		Default:     3,
+		PerCategory: map[string]int{"test_flake": 5, "syntax": 2},
+	}
+
+	tests := []struct {
+		name          string
+		limits        ThreeStrikesLimits
+		counts        map[string]int
+		category      string
+		wantCategory  string
+		wantSource    string
+		wantCount     int
+		wantMax       int
+		wantRemaining int
+	}{
+		{
+			name:          "no limits configured counts every category together",
+			counts:        map[string]int{"runtime": 1, "logic": 1},
+			wantSource:    LimitSourceDefault,
+			wantCount:     2,
+			wantMax:       DefaultMaxAttempts,
+			wantRemaining: 1,
+		},
+		{
+			name:          "category with its own limit",
+			limits:        limits,
+			counts:        map[string]int{"test_flake": 4, "runtime": 1},
+			category:      "test_flake",
+			wantCategory:  "test_flake",
+			wantSource:    LimitSourceCategory,
+			wantCount:     4,
+			wantMax:       5,
+			wantRemaining: 1,
+		},
+		{
+			name:          "category without a limit shares the default",
+			limits:        limits,
+			counts:        map[string]int{"test_flake": 4, "runtime": 1, "other": 1},
+			category:      "runtime",
+			wantCategory:  "runtime",
+			wantSource:    LimitSourceDefault,
+			wantCount:     2,
+			wantMax:       3,
+			wantRemaining: 1,
+		},
+		{
+			name:          "no category reports the limit closest to being reached",
+			limits:        limits,
+			counts:        map[string]int{"syntax": 2, "runtime": 1},
+			wantCategory:  "syntax",
+			wantSource:    LimitSourceCategory,
+			wantCount:     2,
+			wantMax:       2,
+			wantRemaining: 0,
+		},
+		{
+			name:          "no category and no attempts",
+			limits:        limits,
+			counts:        map[string]int{},
+			wantSource:    LimitSourceDefault,
+			wantMax:       3,
+			wantRemaining: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.limits.Check(tt.counts, tt.category)
+			if got.ErrorCategory != tt.wantCategory || got.LimitSource != tt.wantSource {
+				t.Errorf("Check() category/source = %q/%q, want %q/%q", got.ErrorCategory, got.LimitSource, tt.wantCategory, tt.wantSource)
+			}
+			if got.AttemptsCount != tt.wantCount || got.MaxAttempts != tt.wantMax {
+				t.Errorf("Check() count/max = %d/%d, want %d/%d", got.AttemptsCount, got.MaxAttempts, tt.wantCount, tt.wantMax)
+			}
+			if got.Remaining() != tt.wantRemaining {
+				t.Errorf("Remaining() = %d, want %d", got.Remaining(), tt.wantRemaining)
+			}
+		})
+	}
+}
@@ -31,6 +31,17 @@ type CommitValidationResult struct {
 	Message          string   `json:"message,omitempty"`
 	ConventionalType string   `json:"conventional_type,omitempty"`
 	Scope            string   `json:"scope,omitempty"`
+	RejectedType     string   `json:"rejected_type,omitempty"`
+
+	// Config is the effective validation configuration that was applied
+	Config *CommitValidationConfig `json:"config,omitempty"`
+}
+
+// CommitValidationConfig is the set of commit types and subject limit a commit message is checked against
+type CommitValidationConfig struct {
+	AllowedTypes     []string `json:"allowed_types"`
+	AnyType          bool     `json:"any_type"` // true when an empty allowed_types list was supplied
+	MaxSubjectLength int      `json:"max_subject_length"`
 }
 
 // ScopeValidationResult represents the result of validating a file scope
@@ -39,6 +50,7 @@ type ScopeValidationResult struct {
 	Message      string `json:"message"`
 	FilePath     string `json:"file_path"`
 	Scope        string `json:"scope"`
+	ScopeMode    string `json:"scope_mode,omitempty"`
 	OutsideScope bool   `json:"outside_scope,omitempty"`
 }
 
@@ -61,19 +73,39 @@ type RegressionMatch struct {
 
 // TestProdSeparationResult represents the result of test/production separation check
 type TestProdSeparationResult struct {
-	Valid       bool     `json:"valid"`
-	Violations  []string `json:"violations,omitempty"`
-	FilePath    string   `json:"file_path"`
-	Environment string   `json:"environment"`
+	Valid       bool                      `json:"valid"`
+	Violations  []string                  `json:"violations,omitempty"`
+	Matches     []TestProdSeparationMatch `json:"matches,omitempty"`
+	FilePath    string                    `json:"file_path"`
+	Environment string                    `json:"environment"`
+}
+
+// TestProdSeparationMatch is the first match of a separation pattern in a file
+type TestProdSeparationMatch struct {
+	Pattern string `json:"pattern"`
+	Message string `json:"message"`
+	Line    int    `json:"line"`
+}
+
+// TestProdSeparationBatchResult aggregates test/production separation checks over a changeset
+type TestProdSeparationBatchResult struct {
+	Valid               bool                       `json:"valid"`
+	Message             string                     `json:"message"`
+	FilesChecked        int                        `json:"files_checked"`
+	FilesWithViolations int                        `json:"files_with_violations"`
+	Violations          []string                   `json:"violations"`
+	Results             []TestProdSeparationResult `json:"results"`
 }
 
 // PushValidationResult represents the result of validating a git push
 type PushValidationResult struct {
-	Valid    bool     `json:"valid"`
-	CanPush  bool     `json:"can_push"`
-	Warnings []string `json:"warnings,omitempty"`
-	Branch   string   `json:"branch"`
-	IsForce  bool     `json:"is_force"`
+	Valid         bool     `json:"valid"`
+	CanPush       bool     `json:"can_push"`
+	Warnings      []string `json:"warnings,omitempty"`
+	Branch        string   `json:"branch"`
+	IsForce       bool     `json:"is_force"`
+	ProtectedRule string   `json:"protected_rule,omitempty"` // protected-branch rule that matched
+	BlockedFiles  []string `json:"blocked_files,omitempty"`  // staged files over the size limit or binary artifacts
 }
 
 // FileReadVerificationResult represents the result of verifying if a file was read
@@ -86,6 +118,39 @@ type FileReadVerificationResult struct {
 	FilePath  string `json:"file_path"`
 }
 
+// FileReadEntry is a file recorded as read in a session
+type FileReadEntry struct {
+	FilePath string `json:"file_path"`
+	ReadAt   string `json:"read_at"`
+}
+
+// FileReadListResult represents the files recorded as read in a session
+type FileReadListResult struct {
+	Valid     bool            `json:"valid"`
+	Message   string          `json:"message,omitempty"`
+	SessionID string          `json:"session_id"`
+	FileReads []FileReadEntry `json:"file_reads"`
+	Total     int             `json:"total"`
+	Limit     int             `json:"limit"`
+	Offset    int             `json:"offset"`
+}
+
+// FileReadClearResult represents the result of clearing a session's file reads
+type FileReadClearResult struct {
+	Valid     bool   `json:"valid"`
+	Message   string `json:"message,omitempty"`
+	SessionID string `json:"session_id"`
+	Cleared   int    `json:"cleared"`
+}
+
+// SessionCloseResult represents the result of closing a guardrail session
+type SessionCloseResult struct {
+	Valid     bool   `json:"valid"`
+	Message   string `json:"message,omitempty"`
+	SessionID string `json:"session_id"`
+	Closed    bool   `json:"closed"` // false when the session was already closed or expired
+}
+
 // MetaInfo contains metadata about the validation (used by some handlers)
 type MetaInfo struct {
 	CheckedAt      time.Time `json:"checked_at"`
@@ -112,7 +177,16 @@ type FeatureCreepDetectionResult struct {
 		Additions int `json:"additions"`
 		Deletions int `json:"deletions"`
 	} `json:"total_changes"`
-	Recommendation string `json:"recommendation"`
+	Recommendation string                 `json:"recommendation"`
+	Thresholds     FeatureCreepThresholds `json:"thresholds"`
+}
+
+// FeatureCreepThresholds are the counts above which feature creep is reported
+type FeatureCreepThresholds struct {
+	MaxAdditions     int `json:"max_additions"`
+	NewFileAdditions int `json:"new_file_additions"`
+	MaxNewFunctions  int `json:"max_new_functions"`
+	MaxNewImports    int `json:"max_new_imports"`
 }
 
 // TestValidationResult represents the result of verifying tests before commit
@@ -157,3 +231,502 @@ type MergeConflictResult struct {
 	Conflicts []ConflictFinding `json:"conflicts,omitempty"`
 	Checked   int               `json:"checked"`
 }
+
+// APIChange represents a single change to an exported declaration
+type APIChange struct {
+	Kind     string `json:"kind"` // removed, changed, added
+	Symbol   string `json:"symbol"`
+	Before   string `json:"before,omitempty"`
+	After    string `json:"after,omitempty"`
+	Breaking bool   `json:"breaking"`
+	Message  string `json:"message"`
+}
+
+// APIStabilityResult represents the result of validating public API stability
+type APIStabilityResult struct {
+	Valid          bool        `json:"valid"`
+	Breaking       bool        `json:"breaking"`
+	Message        string      `json:"message"`
+	FilePath       string      `json:"file_path,omitempty"`
+	Changes        []APIChange `json:"changes,omitempty"`
+	ExportedBefore int         `json:"exported_before"`
+	ExportedAfter  int         `json:"exported_after"`
+}
+
+// UndocumentedSymbol represents an exported declaration added without a doc comment
+type UndocumentedSymbol struct {
+	Symbol     string `json:"symbol"`
+	LineNumber int    `json:"line_number"`
+	Line       string `json:"line"`
+}
+
+// CommentRatioResult represents the result of checking comment density of added code
+type CommentRatioResult struct {
+	Valid        bool                 `json:"valid"`
+	Message      string               `json:"message"`
+	CodeLines    int                  `json:"code_lines"`
+	CommentLines int                  `json:"comment_lines"`
+	Ratio        float64              `json:"ratio"`
+	MinRatio     float64              `json:"min_ratio"`
+	RatioChecked bool                 `json:"ratio_checked"`
+	Undocumented []UndocumentedSymbol `json:"undocumented,omitempty"`
+	Issues       []string             `json:"issues,omitempty"`
+}
+
+// DiffViolation represents a single issue found on an added line of a diff
+type DiffViolation struct {
+	Type       string `json:"type"`
+	Severity   string `json:"severity"` // error, warning, info
+	File       string `json:"file,omitempty"`
+	LineNumber int    `json:"line_number,omitempty"`
+	Line       string `json:"line,omitempty"`
+	Message    string `json:"message"`
+	Suggestion string `json:"suggestion,omitempty"`
+}
+
+// DiffScanResult represents the result of scanning the added lines of a diff
+type DiffScanResult struct {
+	Valid        bool            `json:"valid"`
+	Message      string          `json:"message"`
+	Violations   []DiffViolation `json:"violations,omitempty"`
+	LinesScanned int             `json:"lines_scanned"`
+}
+
+// MigrationOrderViolation represents a single migration ordering problem
+type MigrationOrderViolation struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	File     string `json:"file"`
+	Version  string `json:"version,omitempty"`
+	Message  string `json:"message"`
+}
+
+// MigrationOrderResult represents the result of validating migration ordering
+type MigrationOrderResult struct {
+	Valid         bool                      `json:"valid"`
+	Message       string                    `json:"message"`
+	Checked       int                       `json:"checked"`
+	LatestApplied string                    `json:"latest_applied,omitempty"`
+	Violations    []MigrationOrderViolation `json:"violations,omitempty"`
+}
+
+// ContractViolation represents a schema change that breaks a registered consumer
+type ContractViolation struct {
+	Type      string   `json:"type"` // removed_field, type_changed, topic_renamed
+	Severity  string   `json:"severity"`
+	Field     string   `json:"field,omitempty"`
+	Consumers []string `json:"consumers,omitempty"`
+	Message   string   `json:"message"`
+}
+
+// IntegrationContractResult represents the result of validating a message schema change
+type IntegrationContractResult struct {
+	Valid         bool                `json:"valid"`
+	Message       string              `json:"message"`
+	Topic         string              `json:"topic,omitempty"`
+	AddedFields   []string            `json:"added_fields,omitempty"`
+	RemovedFields []string            `json:"removed_fields,omitempty"`
+	Violations    []ContractViolation `json:"violations,omitempty"`
+	ConsumerCount int                 `json:"consumer_count"`
+}
+
+// AtomicityFactor is one signal that lowered a commit's atomicity score
+type AtomicityFactor struct {
+	Name    string `json:"name"` // feature_creep, mixed_concerns, file_count, diff_size
+	Penalty int    `json:"penalty"`
+	Detail  string `json:"detail"`
+}
+
+// CommitAtomicityResult represents the combined verdict on whether a commit is focused
+type CommitAtomicityResult struct {
+	Valid        bool              `json:"valid"`
+	Atomic       bool              `json:"atomic"`
+	Score        int               `json:"score"` // 0-100, higher is more focused
+	Message      string            `json:"message"`
+	FilesChanged int               `json:"files_changed"`
+	Concerns     []string          `json:"concerns"`
+	Additions    int               `json:"additions"`
+	Deletions    int               `json:"deletions"`
+	Factors      []AtomicityFactor `json:"factors"`
+}
+
+// ConfigDrift is a single difference between desired and observed configuration
+type ConfigDrift struct {
+	Key          string      `json:"key"`
+	Type         string      `json:"type"`         // missing_in_observed, unexpected_in_observed, value_changed
+	Significance string      `json:"significance"` // high, medium, low
+	Desired      interface{} `json:"desired,omitempty"`
+	Observed     interface{} `json:"observed,omitempty"`
+	Message      string      `json:"message"`
+}
+
+// ConfigDriftResult represents the result of comparing desired and observed configuration
+type ConfigDriftResult struct {
+	Valid        bool          `json:"valid"`
+	Message      string        `json:"message"`
+	KeysCompared int           `json:"keys_compared"`
+	Drift        []ConfigDrift `json:"drift"`
+}
+
+// DeleteValidationResult represents the verdict on a proposed file deletion
+type DeleteValidationResult struct {
+	Valid     bool     `json:"valid"`
+	CanDelete bool     `json:"can_delete"`
+	Message   string   `json:"message"`
+	SessionID string   `json:"session_id,omitempty"`
+	FilePath  string   `json:"file_path"`
+	Scope     string   `json:"scope,omitempty"`
+	WasRead   bool     `json:"was_read"`
+	Reasons   []string `json:"reasons"`
+}
+
+// EnvParityIssue is a single environment variable that differs across environments
+type EnvParityIssue struct {
+	Variable     string   `json:"variable"`
+	Type         string   `json:"type"`     // missing_in_environment, partially_defined, inconsistent_value
+	Severity     string   `json:"severity"` // error, warning
+	Environments []string `json:"environments"`
+	Message      string   `json:"message"`
+}
+
+// EnvParityResult represents the result of comparing environment variables across environments
+type EnvParityResult struct {
+	Valid            bool             `json:"valid"`
+	Message          string           `json:"message"`
+	Environments     []string         `json:"environments"`
+	VariablesChecked int              `json:"variables_checked"`
+	Issues           []EnvParityIssue `json:"issues"`
+}
+
+// FailureQualityIssue is a missing or weak field of a failure registry entry
+type FailureQualityIssue struct {
+	Field    string `json:"field"`
+	Severity string `json:"severity"` // error, warning
+	Message  string `json:"message"`
+}
+
+// FailureQualityResult scores how useful a failure registry entry is for regression checks
+type FailureQualityResult struct {
+	Valid     bool                  `json:"valid"`
+	Complete  bool                  `json:"complete"`
+	Score     int                   `json:"score"` // 0-100
+	FailureID string                `json:"failure_id,omitempty"`
+	Message   string                `json:"message"`
+	Issues    []FailureQualityIssue `json:"issues"`
+}
+
+// CommitFrequencyRisk is a churn or staleness signal found in a file's commit history
+type CommitFrequencyRisk struct {
+	Type     string `json:"type"`     // high_churn, stale_critical_file
+	Severity string `json:"severity"` // error, warning
+	Message  string `json:"message"`
+}
+
+// CommitFrequencyResult represents the result of checking how often a file changes
+type CommitFrequencyResult struct {
+	Valid               bool                  `json:"valid"`
+	Message             string                `json:"message"`
+	FilePath            string                `json:"file_path"`
+	TotalCommits        int                   `json:"total_commits"`
+	RecentCommits       int                   `json:"recent_commits"`
+	WindowDays          int                   `json:"window_days"`
+	MaxCommits          int                   `json:"max_commits"`
+	LastCommit          string                `json:"last_commit,omitempty"`
+	DaysSinceLastCommit int                   `json:"days_since_last_commit"`
+	Critical            bool                  `json:"critical"`
+	Risks               []CommitFrequencyRisk `json:"risks"`
+}
+
+// DependencyCycle is an import cycle found in a package dependency graph
+type DependencyCycle struct {
+	Path         []string `json:"path"` // packages around the cycle, first repeated at the end
+	New          bool     `json:"new"`
+	IntroducedBy string   `json:"introduced_by,omitempty"` // import edge not in the baseline, "a -> b"
+	Severity     string   `json:"severity"`                // error for new cycles, warning for existing ones
+	Message      string   `json:"message"`
+}
+
+// DependencyGraphResult represents the result of checking a package import graph for cycles
+type DependencyGraphResult struct {
+	Valid    bool              `json:"valid"`
+	Message  string            `json:"message"`
+	Packages int               `json:"packages"`
+	Imports  int               `json:"imports"`
+	Cycles   []DependencyCycle `json:"cycles"`
+}
+
+// CommitAuthorIssue is a commit author policy violation
+type CommitAuthorIssue struct {
+	Type     string `json:"type"`     // invalid_email, unauthorized_author, bot_author, human_author
+	Severity string `json:"severity"` // error
+	Message  string `json:"message"`
+}
+
+// CommitAuthorResult represents the result of checking a commit's author against policy
+type CommitAuthorResult struct {
+	Valid       bool                `json:"valid"`
+	Message     string              `json:"message"`
+	AuthorName  string              `json:"author_name,omitempty"`
+	AuthorEmail string              `json:"author_email"`
+	Domain      string              `json:"domain,omitempty"`
+	IsBot       bool                `json:"is_bot"`
+	Issues      []CommitAuthorIssue `json:"issues"`
+}
+
+// SecretFinding is a potential secret found in scanned content
+type SecretFinding struct {
+	Type        string `json:"type"`
+	Line        int    `json:"line"`
+	Column      int    `json:"column"`
+	Snippet     string `json:"snippet"` // redacted match
+	Description string `json:"description"`
+	Fingerprint string `json:"fingerprint"` // identifies the finding in a secret scanning baseline
+}
+
+// SecretScanResult represents the result of scanning content for embedded secrets
+type SecretScanResult struct {
+	Valid    bool            `json:"valid"`
+	Message  string          `json:"message"`
+	FilePath string          `json:"file_path,omitempty"`
+	Findings []SecretFinding `json:"findings"`
+}
+
+// SecretBaselineFinding is a secret scanning finding compared against a baseline
+type SecretBaselineFinding struct {
+	Type        string `json:"type"`
+	FilePath    string `json:"file_path,omitempty"`
+	Line        int    `json:"line,omitempty"`
+	Snippet     string `json:"snippet,omitempty"` // redacted match
+	Fingerprint string `json:"fingerprint"`
+}
+
+// SecretBaselineResult represents the result of checking secret findings against a baseline
+type SecretBaselineResult struct {
+	Valid         bool                    `json:"valid"`
+	Message       string                  `json:"message"`
+	Project       string                  `json:"project,omitempty"`
+	Action        string                  `json:"action"`
+	TotalFindings int                     `json:"total_findings"`
+	Baselined     int                     `json:"baselined"`
+	Updated       int                     `json:"updated,omitempty"` // baseline entries added or removed
+	NewFindings   []SecretBaselineFinding `json:"new_findings"`
+}
+
+// StaleFeatureFlag is a feature flag old enough to be a candidate for removal
+type StaleFeatureFlag struct {
+	Name      string `json:"name"`
+	Owner     string `json:"owner,omitempty"`
+	CreatedAt string `json:"created_at"`
+	AgeDays   int    `json:"age_days"`
+	Severity  string `json:"severity"` // warning past the age limit, error past twice it
+	Message   string `json:"message"`
+}
+
+// FeatureToggleCleanupResult represents the result of checking a flag registry for stale flags
+type FeatureToggleCleanupResult struct {
+	Valid      bool               `json:"valid"`
+	Message    string             `json:"message"`
+	MaxAgeDays int                `json:"max_age_days"`
+	TotalFlags int                `json:"total_flags"`
+	Permanent  int                `json:"permanent"` // flags exempt from cleanup, such as kill switches
+	StaleFlags []StaleFeatureFlag `json:"stale_flags"`
+}
+
+// DeploymentBlock is a reason a deployment is not allowed at the requested time
+type DeploymentBlock struct {
+	Type    string `json:"type"` // change_freeze, incident_mode or outside_deploy_window
+	Message string `json:"message"`
+	Until   string `json:"until,omitempty"` // end of a change freeze
+}
+
+// DeploymentWindowResult represents whether a deployment may go ahead at a given time
+type DeploymentWindowResult struct {
+	Valid       bool              `json:"valid"`
+	Allowed     bool              `json:"allowed"`
+	Message     string            `json:"message"`
+	Environment string            `json:"environment"`
+	DeployTime  string            `json:"deploy_time"`
+	Reasons     []DeploymentBlock `json:"reasons"`
+}
+
+// BackupPolicyIssue is a missing or out-of-policy element of a backup configuration
+type BackupPolicyIssue struct {
+	Field    string `json:"field"`
+	Severity string `json:"severity"` // error, warning
+	Message  string `json:"message"`
+}
+
+// BackupPolicyResult represents the result of checking a backup configuration against policy
+type BackupPolicyResult struct {
+	Valid            bool                `json:"valid"`
+	Message          string              `json:"message"`
+	MinRetentionDays int                 `json:"min_retention_days"`
+	MaxRetentionDays int                 `json:"max_retention_days"`
+	Issues           []BackupPolicyIssue `json:"issues"`
+}
+
+// RunbookIssue is a required section or contact list missing from an incident runbook
+type RunbookIssue struct {
+	Section  string `json:"section"`
+	Severity string `json:"severity"` // error, warning
+	Message  string `json:"message"`
+}
+
+// IncidentRunbookResult represents the result of checking an incident runbook for completeness
+type IncidentRunbookResult struct {
+	Valid         bool           `json:"valid"`
+	Message       string         `json:"message"`
+	SectionsFound []string       `json:"sections_found"`
+	Issues        []RunbookIssue `json:"issues"`
+}
+
+// RuleCoverageEntry is an active prevention rule and how often it has matched
+type RuleCoverageEntry struct {
+	RuleID    string     `json:"rule_id"`
+	Name      string     `json:"name"`
+	HitCount  int64      `json:"hit_count"`
+	LastHitAt *time.Time `json:"last_hit_at,omitempty"`
+}
+
+// RuleCoverageResult represents which active rules have matched in a validation and which never have
+type RuleCoverageResult struct {
+	Message    string              `json:"message"`
+	TotalRules int                 `json:"total_rules"`
+	Matched    []RuleCoverageEntry `json:"matched"`
+	Untested   []RuleCoverageEntry `json:"untested"`
+}
+
+// CommitSizeStats counts the files and lines a commit changes
+type CommitSizeStats struct {
+	Files     int `json:"files"`
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+}
+
+// CommitSizeIssue is a size limit a commit exceeds
+type CommitSizeIssue struct {
+	Field    string `json:"field"` // lines, files, generated_lines, generated_files
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// CommitSizeResult represents the result of checking a commit against line and file limits
+type CommitSizeResult struct {
+	Valid          bool              `json:"valid"`
+	Message        string            `json:"message"`
+	HandWritten    CommitSizeStats   `json:"hand_written"`
+	Generated      CommitSizeStats   `json:"generated"`
+	GeneratedFiles []string          `json:"generated_files"`
+	Issues         []CommitSizeIssue `json:"issues"`
+}
+
+// DependencyAdvisory is a known vulnerability affecting the checked dependency version
+type DependencyAdvisory struct {
+	ID           string `json:"id"`
+	Severity     string `json:"severity"`
+	FixedVersion string `json:"fixed_version"`
+	Summary      string `json:"summary,omitempty"`
+}
+
+// DependencyFreshnessIssue is a reason a dependency should be upgraded
+type DependencyFreshnessIssue struct {
+	Field    string `json:"field"` // major, minor, advisory
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// DependencyFreshnessResult represents how far a dependency is behind its latest release
+type DependencyFreshnessResult struct {
+	Valid           bool                       `json:"valid"`
+	Message         string                     `json:"message"`
+	Name            string                     `json:"name"`
+	CurrentVersion  string                     `json:"current_version"`
+	LatestVersion   string                     `json:"latest_version"`
+	MajorsBehind    int                        `json:"majors_behind"`
+	MinorsBehind    int                        `json:"minors_behind"`
+	Vulnerabilities []DependencyAdvisory       `json:"vulnerabilities"`
+	Issues          []DependencyFreshnessIssue `json:"issues"`
+	Recommendation  string                     `json:"recommendation,omitempty"`
+}
+
+// SchemaEvolutionIssue is a schema change that breaks the compatibility mode
+type SchemaEvolutionIssue struct {
+	Field    string `json:"field"`
+	Type     string `json:"type"` // added_required, removed_required, type_changed, made_required, made_optional, field_number_changed, field_number_reused, field_renamed, unreserved_removal
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// SchemaEvolutionResult represents whether a message schema change keeps the
+// selected compatibility mode
+type SchemaEvolutionResult struct {
+	Valid         bool                   `json:"valid"`
+	Message       string                 `json:"message"`
+	Format        string                 `json:"format"`
+	Mode          string                 `json:"mode"`
+	AddedFields   []string               `json:"added_fields"`
+	RemovedFields []string               `json:"removed_fields"`
+	Issues        []SchemaEvolutionIssue `json:"issues"`
+}
+
+// CommitRevertIssue is a problem found in a revert commit
+type CommitRevertIssue struct {
+	Field    string `json:"field"` // subject, reference, body, diff
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// CommitRevertResult represents whether a revert commit references the reverted
+// commit, uses the revert type and undoes all of its changes
+type CommitRevertResult struct {
+	Valid               bool                `json:"valid"`
+	Message             string              `json:"message"`
+	Subject             string              `json:"subject"`
+	RevertedSHA         string              `json:"reverted_sha,omitempty"`
+	CompletenessChecked bool                `json:"completeness_checked"`
+	MissingChanges      []string            `json:"missing_changes"`
+	ExtraChanges        []string            `json:"extra_changes"`
+	Issues              []CommitRevertIssue `json:"issues"`
+	Suggestion          string              `json:"suggestion,omitempty"`
+}
+
+// ReadonlyFilesystemIssue is a container filesystem hardening gap
+type ReadonlyFilesystemIssue struct {
+	Resource  string `json:"resource"`
+	Container string `json:"container,omitempty"`
+	Type      string `json:"type"` // missing_read_only_root, writable_root, privileged, writable_host_mount, writable_mount
+	Severity  string `json:"severity"`
+	Line      int    `json:"line,omitempty"`
+	Message   string `json:"message"`
+}
+
+// ReadonlyFilesystemResult represents whether containers run with a read-only root
+// filesystem and no writable mounts beyond scratch space
+type ReadonlyFilesystemResult struct {
+	Valid             bool                      `json:"valid"`
+	Message           string                    `json:"message"`
+	Format            string                    `json:"format"`
+	ContainersChecked int                       `json:"containers_checked"`
+	Issues            []ReadonlyFilesystemIssue `json:"issues"`
+}
+
+// CommitWIPFinding is a work-in-progress marker found in a commit message
+type CommitWIPFinding struct {
+	Commit   int    `json:"commit"` // index into the checked messages
+	Subject  string `json:"subject"`
+	Marker   string `json:"marker"`   // wip, fixup!, squash!, amend!, do_not_merge
+	Severity string `json:"severity"` // error on a protected branch, otherwise warning
+	Message  string `json:"message"`
+}
+
+// CommitWIPResult represents whether commits bound for a branch still carry
+// work-in-progress markers
+type CommitWIPResult struct {
+	Valid          bool               `json:"valid"`
+	Message        string             `json:"message"`
+	Branch         string             `json:"branch"`
+	Protected      bool               `json:"protected"`
+	ProtectedBy    string             `json:"protected_by,omitempty"`
+	CommitsChecked int                `json:"commits_checked"`
+	Findings       []CommitWIPFinding `json:"findings"`
+}
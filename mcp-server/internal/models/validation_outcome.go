@@ -0,0 +1,170 @@
+package models
+
+import (
+	"sort"
+	"time"
+)
+
+// Trend bucket sizes accepted by BuildValidationTrend
+const (
+	TrendIntervalDay  = "day"
+	TrendIntervalWeek = "week"
+)
+
+// Directions reported for a validation trend
+const (
+	TrendImproving        = "improving"
+	TrendWorsening        = "worsening"
+	TrendStable           = "stable"
+	TrendInsufficientData = "insufficient_data"
+)
+
+// trendChangeThreshold is the relative change in violations per validation, between
+// the first and second half of a trend, below which the trend is stable
+const trendChangeThreshold = 0.1
+
+// ValidationOutcome is the result of one validation tool call for a project
+type ValidationOutcome struct {
+	ID          string    `json:"id"`
+	ProjectSlug string    `json:"project_slug"`
+	ToolName    string    `json:"tool_name"`
+	Valid       bool      `json:"valid"`
+	Critical    int       `json:"critical"`
+	Errors      int       `json:"errors"`
+	Warnings    int       `json:"warnings"`
+	Info        int       `json:"info"`
+	RecordedAt  time.Time `json:"recorded_at"`
+}
+
+// AddViolation counts a violation under its severity. Tools use either
+// error/warning/info or critical/high/medium/low; high counts as an error, medium as
+// a warning and low or unknown severities as info.
+func (o *ValidationOutcome) AddViolation(severity string) {
+	switch severity {
+	case "critical":
+		o.Critical++
+	case "error", "high":
+		o.Errors++
+	case "warning", "medium":
+		o.Warnings++
+	default:
+		o.Info++
+	}
+}
+
+// Violations returns the total number of violations in the outcome
+func (o ValidationOutcome) Violations() int {
+	return o.Critical + o.Errors + o.Warnings + o.Info
+}
+
+// ValidationTrendPoint aggregates the outcomes recorded in one interval
+type ValidationTrendPoint struct {
+	Period      time.Time `json:"period"`
+	Validations int       `json:"validations"`
+	Failed      int       `json:"failed"`
+	Critical    int       `json:"critical"`
+	Errors      int       `json:"errors"`
+	Warnings    int       `json:"warnings"`
+	Info        int       `json:"info"`
+}
+
+// ViolationsPerValidation returns the average number of violations per validation
+func (p ValidationTrendPoint) ViolationsPerValidation() float64 {
+	if p.Validations == 0 {
+		return 0
+	}
+	return float64(p.Critical+p.Errors+p.Warnings+p.Info) / float64(p.Validations)
+}
+
+// ValidationTrend is a project's validation outcomes as a time series
+type ValidationTrend struct {
+	Project   string                 `json:"project"`
+	Interval  string                 `json:"interval"`
+	Direction string                 `json:"direction"`
+	Points    []ValidationTrendPoint `json:"points"`
+}
+
+// BuildValidationTrend groups outcomes into day or week intervals (UTC, weeks start on
+// Monday) in time order. The direction compares violations per validation in the
+// first and second half of the series: fewer violations is improving.
+func BuildValidationTrend(project, interval string, outcomes []ValidationOutcome) ValidationTrend {
+	if interval != TrendIntervalWeek {
+		interval = TrendIntervalDay
+	}
+
+	byPeriod := make(map[time.Time]*ValidationTrendPoint)
+	for _, o := range outcomes {
+		period := trendPeriod(o.RecordedAt, interval)
+		point, ok := byPeriod[period]
+		if !ok {
+			point = &ValidationTrendPoint{Period: period}
+			byPeriod[period] = point
+		}
+		point.Validations++
+		if !o.Valid {
+			point.Failed++
+		}
+		point.Critical += o.Critical
+		point.Errors += o.Errors
+		point.Warnings += o.Warnings
+		point.Info += o.Info
+	}
+
+	points := make([]ValidationTrendPoint, 0, len(byPeriod))
+	for _, point := range byPeriod {
+		points = append(points, *point)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Period.Before(points[j].Period) })
+
+	return ValidationTrend{
+		Project:   project,
+		Interval:  interval,
+		Direction: trendDirection(points),
+		Points:    points,
+	}
+}
+
+// trendPeriod truncates t to the start of its interval
+func trendPeriod(t time.Time, interval string) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == TrendIntervalWeek {
+		offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+		day = day.AddDate(0, 0, -offset)
+	}
+	return day
+}
+
+// trendDirection compares the average violations per validation of the first and
+// second half of the points
+func trendDirection(points []ValidationTrendPoint) string {
+	if len(points) < 2 {
+		return TrendInsufficientData
+	}
+
+	average := func(points []ValidationTrendPoint) float64 {
+		sum := 0.0
+		for _, p := range points {
+			sum += p.ViolationsPerValidation()
+		}
+		return sum / float64(len(points))
+	}
+	half := len(points) / 2
+	before, after := average(points[:half]), average(points[len(points)-half:])
+
+	switch {
+	case after < before*(1-trendChangeThreshold):
+		return TrendImproving
+	case after > before*(1+trendChangeThreshold):
+		return TrendWorsening
+	default:
+		return TrendStable
+	}
+}
+
+// RuleHit is how often a prevention rule has matched in validations
+type RuleHit struct {
+	RuleID    string    `json:"rule_id"`
+	HitCount  int64     `json:"hit_count"`
+	LastHitAt time.Time `json:"last_hit_at"`
+}
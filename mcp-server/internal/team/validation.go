@@ -32,12 +32,18 @@ const (
 	MaxProjectNameLength = 64
 	MaxRoleNameLength    = 128
 	MaxPersonNameLength  = 256
+
+	// Team IDs of the standard 12-team structure
+	MinTeamID = 1
+	MaxTeamID = 12
 )
 
 var (
 	projectNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
 	roleNameRegex    = regexp.MustCompile(`^[a-zA-Z0-9\s\-_/\&\(\)\.]+$`)
 	personNameRegex  = regexp.MustCompile(`^[a-zA-Z\s\-'']+$`)
+	// SEC-010: only the short phase names are accepted as filters
+	phaseFilterRegex = regexp.MustCompile(`^Phase [1-3]$`)
 )
 
 // ValidateProjectName validates a project name
@@ -122,6 +128,27 @@ func ValidatePhase(phase string) error {
 	return &ValidationError{Field: "phase", Message: fmt.Sprintf("invalid phase: %s", phase)}
 }
 
+// ValidateTeamID validates a team ID against the standard 12-team structure
+func ValidateTeamID(id int) error {
+	if id < MinTeamID || id > MaxTeamID {
+		return &ValidationError{Field: "team_id", Message: fmt.Sprintf("team_id must be between %d and %d", MinTeamID, MaxTeamID)}
+	}
+	return nil
+}
+
+// ValidatePhaseFilter validates a phase filter value (SEC-010: Phase injection hardening).
+// An empty filter selects every phase; otherwise only "Phase 1", "Phase 2" and
+// "Phase 3" are accepted, which keeps injected text out of the backend command.
+func ValidatePhaseFilter(phase string) error {
+	if phase == "" {
+		return nil
+	}
+	if !phaseFilterRegex.MatchString(phase) {
+		return &ValidationError{Field: "phase", Message: "invalid phase: must be 'Phase 1', 'Phase 2', or 'Phase 3'"}
+	}
+	return nil
+}
+
 // EnsureDir ensures a directory exists
 func EnsureDir(path string) error {
 	return os.MkdirAll(path, 0755)
@@ -12,6 +12,7 @@ import (
 	"github.com/thearchitectit/guardrail-mcp/internal/cache"
 	"github.com/thearchitectit/guardrail-mcp/internal/database"
 	"github.com/thearchitectit/guardrail-mcp/internal/models"
+	"github.com/thearchitectit/guardrail-mcp/internal/security"
 )
 
 // RuleCategory defines the type of rules for validation
@@ -224,6 +225,19 @@ func (e *ValidationEngine) ValidateFileEdit(ctx context.Context, filePath string
 		}
 	}
 
+	// Detected secrets are a hard violation whatever rules are configured
+	for _, finding := range security.ScanContent(content) {
+		violations = append(violations, Violation{
+			RuleID:         "SECRET-001",
+			RuleName:       "Secret In Content",
+			Severity:       models.SeverityCritical,
+			Message:        fmt.Sprintf("Potential secret (%s) at line %d of '%s'. Use environment variables or a secrets manager instead.", finding.Pattern, finding.Line, filePath),
+			Category:       string(CategoryFileEdit),
+			MatchedPattern: finding.Pattern,
+			MatchedInput:   finding.Match,
+		})
+	}
+
 	// Check both file path and content
 	inputs := []string{filePath, content}
 	inputLabels := []string{"path", "content"}
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -15,9 +16,11 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/thearchitectit/guardrail-mcp/internal/audit"
 	"github.com/thearchitectit/guardrail-mcp/internal/ingest"
 	"github.com/thearchitectit/guardrail-mcp/internal/models"
 	"github.com/thearchitectit/guardrail-mcp/internal/security"
+	"github.com/thearchitectit/guardrail-mcp/internal/team"
 	"github.com/thearchitectit/guardrail-mcp/internal/validation"
 	"github.com/thearchitectit/guardrail-mcp/internal/updates"
 )
@@ -45,6 +48,22 @@ var (
 	lastRuleSyncStatusLock sync.RWMutex
 )
 
+// paginationMeta builds the pagination block of a list response, including the
+// derived has_more and total_pages so that clients need not compute them
+func paginationMeta(total, limit, offset int) map[string]interface{} {
+	totalPages := 0
+	if limit > 0 {
+		totalPages = (total + limit - 1) / limit
+	}
+	return map[string]interface{}{
+		"total":       total,
+		"limit":       limit,
+		"offset":      offset,
+		"has_more":    offset+limit < total,
+		"total_pages": totalPages,
+	}
+}
+
 // Document handlers
 
 func (s *Server) listDocuments(c echo.Context) error {
@@ -73,11 +92,7 @@ func (s *Server) listDocuments(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"data": docs,
-		"pagination": map[string]interface{}{
-			"total":  total,
-			"limit":  limit,
-			"offset": offset,
-		},
+		"pagination": paginationMeta(total, limit, offset),
 	})
 }
 
@@ -193,11 +208,7 @@ func (s *Server) listRules(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"data": rules,
-		"pagination": map[string]interface{}{
-			"total":  total,
-			"limit":  limit,
-			"offset": offset,
-		},
+		"pagination": paginationMeta(total, limit, offset),
 	})
 }
 
@@ -568,11 +579,7 @@ func (s *Server) listProjects(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
 		"data": projects,
-		"pagination": map[string]interface{}{
-			"total":  total,
-			"limit":  limit,
-			"offset": offset,
-		},
+		"pagination": paginationMeta(total, limit, offset),
 	})
 }
 
@@ -650,6 +657,224 @@ func (s *Server) deleteProject(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// Team handlers
+
+// ProjectTeamRole is a role within a team and its current assignee
+type ProjectTeamRole struct {
+	Name           string  `json:"name"`
+	Responsibility string  `json:"responsibility"`
+	AssignedTo     *string `json:"assigned_to"`
+}
+
+// ProjectTeam is a team with its roles, as returned by the project teams endpoint
+type ProjectTeam struct {
+	ID            int               `json:"id"`
+	Name          string            `json:"name"`
+	Phase         string            `json:"phase"`
+	Status        string            `json:"status"`
+	AssignedCount int               `json:"assigned_count"`
+	TotalRoles    int               `json:"total_roles"`
+	Roles         []ProjectTeamRole `json:"roles"`
+}
+
+func (s *Server) getProjectTeams(c echo.Context) error {
+	slug := c.Param("slug")
+	if !isValidSlug(slug) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid project slug"})
+	}
+
+	mgr, err := team.NewManager(slug, team.WithBaseDir(s.cfg.TeamsBaseDir))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid project slug"})
+	}
+	if err := mgr.Load(); err != nil {
+		if strings.Contains(err.Error(), "project not found") {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "no team configuration for project"})
+		}
+		slog.Error("Failed to load project teams", "project", slug, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to load project teams"})
+	}
+
+	allTeams := mgr.GetAllTeams()
+	sort.Slice(allTeams, func(i, j int) bool { return allTeams[i].ID < allTeams[j].ID })
+
+	teams := make([]ProjectTeam, 0, len(allTeams))
+	assigned, totalRoles := 0, 0
+	for _, t := range allTeams {
+		pt := ProjectTeam{
+			ID:         t.ID,
+			Name:       t.Name,
+			Phase:      t.Phase,
+			Status:     string(t.Status),
+			TotalRoles: len(t.Roles),
+			Roles:      make([]ProjectTeamRole, 0, len(t.Roles)),
+		}
+		for _, role := range t.Roles {
+			if role.AssignedTo != nil {
+				pt.AssignedCount++
+			}
+			pt.Roles = append(pt.Roles, ProjectTeamRole{
+				Name:           role.Name,
+				Responsibility: role.Responsibility,
+				AssignedTo:     role.AssignedTo,
+			})
+		}
+		assigned += pt.AssignedCount
+		totalRoles += pt.TotalRoles
+		teams = append(teams, pt)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"project": slug,
+		"teams":   teams,
+		"summary": map[string]interface{}{
+			"total_teams":    len(teams),
+			"total_roles":    totalRoles,
+			"assigned_roles": assigned,
+		},
+	})
+}
+
+// Validation trend defaults and bounds for getValidationTrend
+const (
+	defaultTrendDays = 30
+	maxTrendDays     = 365
+)
+
+// validationOutcomeLister loads a project's recorded validation outcomes
+type validationOutcomeLister interface {
+	ListSince(ctx context.Context, projectSlug string, since time.Time) ([]models.ValidationOutcome, error)
+}
+
+// getValidationTrend returns a project's validation outcomes over the last days as a
+// day or week time series, with whether violations are trending down or up
+func (s *Server) getValidationTrend(c echo.Context) error {
+	slug := c.Param("slug")
+	if !isValidSlug(slug) {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid project slug"})
+	}
+
+	interval := c.QueryParam("interval")
+	if interval == "" {
+		interval = models.TrendIntervalDay
+	}
+	if interval != models.TrendIntervalDay && interval != models.TrendIntervalWeek {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "interval must be day or week"})
+	}
+	days, err := strconv.Atoi(c.QueryParam("days"))
+	if err != nil || days <= 0 || days > maxTrendDays {
+		days = defaultTrendDays
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -days)
+	outcomes, err := s.outcomeStore.ListSince(c.Request().Context(), slug, since)
+	if err != nil {
+		slog.Error("Failed to load validation outcomes", "project", slug, "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to load validation trend"})
+	}
+
+	return c.JSON(http.StatusOK, models.BuildValidationTrend(slug, interval, outcomes))
+}
+
+// Event stream handlers
+
+// eventSeverityRank orders audit severities for minimum-severity filtering
+var eventSeverityRank = map[string]int{
+	string(audit.SevInfo):     0,
+	string(audit.SevWarning):  1,
+	string(audit.SevCritical): 2,
+}
+
+// streamEvents streams audit events as Server-Sent Events. Buffered events newer
+// than ?since= (RFC3339) are replayed first; ?severity= sets the minimum severity.
+func (s *Server) streamEvents(c echo.Context) error {
+	if s.auditLogger == nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"error": "audit logging is not enabled"})
+	}
+
+	minRank := 0
+	if sev := c.QueryParam("severity"); sev != "" {
+		rank, ok := eventSeverityRank[strings.ToLower(sev)]
+		if !ok {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "severity must be one of info, warning, critical"})
+		}
+		minRank = rank
+	}
+
+	var since time.Time
+	if raw := c.QueryParam("since"); raw != "" {
+		parsed, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "since must be an RFC3339 timestamp"})
+		}
+		since = parsed
+	}
+
+	// Subscribe before replaying so nothing is lost in between
+	events, unsubscribe := s.auditLogger.Subscribe(64)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	write := func(event audit.Event) error {
+		if eventSeverityRank[string(event.Severity)] < minRank {
+			return nil
+		}
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(res, "event: audit\ndata: %s\n\n", data); err != nil {
+			return err
+		}
+		res.Flush()
+		return nil
+	}
+
+	// Events replayed here may also already be queued on the live subscription
+	replayed := make(map[string]bool)
+	if !since.IsZero() {
+		for _, event := range s.auditLogger.Recent(since) {
+			replayed[event.ID] = true
+			if err := write(event); err != nil {
+				return nil
+			}
+		}
+	}
+
+	keepalive := time.NewTicker(30 * time.Second)
+	defer keepalive.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if replayed[event.ID] {
+				delete(replayed, event.ID)
+				continue
+			}
+			if err := write(event); err != nil {
+				return nil
+			}
+		case <-keepalive.C:
+			if _, err := fmt.Fprint(res, ": keepalive\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
 // Failure handlers
 
 func (s *Server) listFailures(c echo.Context) error {
@@ -671,13 +896,17 @@ func (s *Server) listFailures(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
+	total, err := s.failStore.CountFiltered(c.Request().Context(), status, category, projectSlug)
+	if err != nil {
+		slog.Warn("Failed to count failures", "error", err)
+		total = offset + len(failures) // Fallback to what has been seen so far
+	}
+
+	pagination := paginationMeta(total, limit, offset)
+	pagination["count"] = len(failures)
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"data": failures,
-		"pagination": map[string]interface{}{
-			"limit":  limit,
-			"offset": offset,
-			"count":  len(failures),
-		},
+		"data":       failures,
+		"pagination": pagination,
 	})
 }
 
@@ -0,0 +1,243 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/thearchitectit/guardrail-mcp/internal/audit"
+	"github.com/thearchitectit/guardrail-mcp/internal/config"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+	"github.com/thearchitectit/guardrail-mcp/internal/team"
+)
+
+// TestGetProjectTeams tests that a seeded project returns its team structure and assignees
+func TestGetProjectTeams(t *testing.T) {
+	baseDir := t.TempDir()
+
+	mgr, err := team.NewManager("demo-project", team.WithBaseDir(baseDir))
+	if err != nil {
+		t.Fatalf("NewManager() error = %v", err)
+	}
+	if err := mgr.InitializeProject(); err != nil {
+		t.Fatalf("InitializeProject() error = %v", err)
+	}
+	if err := mgr.AssignRole(1, "Lead Product Manager", "Alice"); err != nil {
+		t.Fatalf("AssignRole() error = %v", err)
+	}
+
+	s := &Server{echo: echo.New(), cfg: &config.Config{TeamsBaseDir: baseDir}}
+	s.echo.GET("/api/projects/:id", func(c echo.Context) error { return c.NoContent(http.StatusTeapot) })
+	s.echo.GET("/api/projects/:slug/teams", s.getProjectTeams)
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "seeded project", path: "/api/projects/demo-project/teams", wantStatus: http.StatusOK},
+		{name: "unknown project", path: "/api/projects/missing/teams", wantStatus: http.StatusNotFound},
+		{name: "invalid slug", path: "/api/projects/bad%20slug/teams", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("GET %s = %d, want %d: %s", tt.path, rec.Code, tt.wantStatus, rec.Body.String())
+			}
+		})
+	}
+
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/projects/demo-project/teams", nil))
+
+	var resp struct {
+		Project string        `json:"project"`
+		Teams   []ProjectTeam `json:"teams"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	if resp.Project != "demo-project" {
+		t.Errorf("project = %q, want demo-project", resp.Project)
+	}
+	if len(resp.Teams) != len(team.StandardTeams) {
+		t.Fatalf("teams = %d, want %d", len(resp.Teams), len(team.StandardTeams))
+	}
+	first := resp.Teams[0]
+	if first.ID != 1 || first.Status != string(team.TeamStatusNotStarted) || first.AssignedCount != 1 {
+		t.Errorf("team 1 = %+v, want id 1 not_started with 1 assignment", first)
+	}
+	found := false
+	for _, role := range first.Roles {
+		if role.Name == "Lead Product Manager" && role.AssignedTo != nil && *role.AssignedTo == "Alice" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected Lead Product Manager assigned to Alice, got %+v", first.Roles)
+	}
+}
+
+// TestStreamEvents tests replay and severity filtering on the audit event stream
+func TestStreamEvents(t *testing.T) {
+	logger := audit.NewLogger(10)
+	defer logger.Stop()
+
+	start := time.Now().Add(-time.Second).UTC()
+	logger.LogDocChange(context.Background(), "actor", "doc-1", "update")
+	logger.LogRuleChange(context.Background(), "actor", "rule-1", "delete")
+
+	// Wait for the logger to process both events
+	deadline := time.Now().Add(2 * time.Second)
+	for len(logger.Recent(start)) < 2 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	s := &Server{echo: echo.New(), auditLogger: logger}
+	s.echo.GET("/api/events", s.streamEvents)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req := httptest.NewRequest(http.MethodGet, "/api/events?severity=critical&since="+start.Format(time.RFC3339), nil).WithContext(ctx)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if got := rec.Header().Get(echo.HeaderContentType); got != "text/event-stream" {
+		t.Errorf("Content-Type = %q, want text/event-stream", got)
+	}
+	if !strings.Contains(body, `"resource":"rule-1"`) {
+		t.Errorf("stream missing critical rule event: %s", body)
+	}
+	if strings.Contains(body, `"resource":"doc-1"`) {
+		t.Errorf("stream included info event below severity filter: %s", body)
+	}
+
+	rec = httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/events?severity=loud", nil))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid severity status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// fakeOutcomes serves recorded validation outcomes in place of ValidationOutcomeStore
+type fakeOutcomes []models.ValidationOutcome
+
+func (f fakeOutcomes) ListSince(ctx context.Context, projectSlug string, since time.Time) ([]models.ValidationOutcome, error) {
+	var outcomes []models.ValidationOutcome
+	for _, o := range f {
+		if o.ProjectSlug == projectSlug && !o.RecordedAt.Before(since) {
+			outcomes = append(outcomes, o)
+		}
+	}
+	return outcomes, nil
+}
+
+// TestGetValidationTrend tests that recorded outcomes produce the expected trend direction
+func TestGetValidationTrend(t *testing.T) {
+	today := time.Now().UTC()
+	// outcomes records one validation per day, oldest first, with the given violation counts
+	outcomes := func(project string, errors ...int) fakeOutcomes {
+		var recorded fakeOutcomes
+		for i, n := range errors {
+			recorded = append(recorded, models.ValidationOutcome{
+				ProjectSlug: project,
+				ToolName:    "guardrail_validate_timeout_usage",
+				Valid:       n == 0,
+				Errors:      n,
+				RecordedAt:  today.AddDate(0, 0, i-len(errors)+1),
+			})
+		}
+		return recorded
+	}
+
+	var recorded fakeOutcomes
+	recorded = append(recorded, outcomes("cleanup", 8, 6, 4, 1)...)
+	recorded = append(recorded, outcomes("regressing", 0, 1, 3, 5)...)
+	recorded = append(recorded, outcomes("steady", 2, 2, 2, 2)...)
+	s := &Server{echo: echo.New(), outcomeStore: recorded}
+	s.echo.GET("/api/projects/:slug/validation-trend", s.getValidationTrend)
+
+	tests := []struct {
+		name          string
+		path          string
+		wantStatus    int
+		wantDirection string
+		wantPoints    int
+	}{
+		{name: "descending violations improve", path: "/api/projects/cleanup/validation-trend", wantStatus: http.StatusOK, wantDirection: models.TrendImproving, wantPoints: 4},
+		{name: "ascending violations worsen", path: "/api/projects/regressing/validation-trend", wantStatus: http.StatusOK, wantDirection: models.TrendWorsening, wantPoints: 4},
+		{name: "flat violations are stable", path: "/api/projects/steady/validation-trend", wantStatus: http.StatusOK, wantDirection: models.TrendStable, wantPoints: 4},
+		{name: "window excludes older outcomes", path: "/api/projects/cleanup/validation-trend?days=1", wantStatus: http.StatusOK, wantDirection: models.TrendInsufficientData, wantPoints: 1},
+		{name: "no outcomes", path: "/api/projects/unknown/validation-trend", wantStatus: http.StatusOK, wantDirection: models.TrendInsufficientData},
+		{name: "invalid interval", path: "/api/projects/cleanup/validation-trend?interval=hour", wantStatus: http.StatusBadRequest},
+		{name: "invalid slug", path: "/api/projects/bad%20slug/validation-trend", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("GET %s = %d, want %d: %s", tt.path, rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var trend models.ValidationTrend
+			if err := json.Unmarshal(rec.Body.Bytes(), &trend); err != nil {
+				t.Fatalf("invalid JSON response: %v", err)
+			}
+			if trend.Direction != tt.wantDirection || len(trend.Points) != tt.wantPoints {
+				t.Errorf("trend = %s with %d points, want %s with %d", trend.Direction, len(trend.Points), tt.wantDirection, tt.wantPoints)
+			}
+			for i := 1; i < len(trend.Points); i++ {
+				if !trend.Points[i-1].Period.Before(trend.Points[i].Period) {
+					t.Errorf("points not in time order: %+v", trend.Points)
+				}
+			}
+		})
+	}
+}
+
+// TestPaginationMeta tests the derived has_more and total_pages fields
+func TestPaginationMeta(t *testing.T) {
+	tests := []struct {
+		name           string
+		total          int
+		limit          int
+		offset         int
+		wantHasMore    bool
+		wantTotalPages int
+	}{
+		{name: "first page of several", total: 45, limit: 20, offset: 0, wantHasMore: true, wantTotalPages: 3},
+		{name: "middle page", total: 45, limit: 20, offset: 20, wantHasMore: true, wantTotalPages: 3},
+		{name: "last partial page", total: 45, limit: 20, offset: 40, wantHasMore: false, wantTotalPages: 3},
+		{name: "last full page", total: 40, limit: 20, offset: 20, wantHasMore: false, wantTotalPages: 2},
+		{name: "offset past the end", total: 5, limit: 20, offset: 40, wantHasMore: false, wantTotalPages: 1},
+		{name: "empty", total: 0, limit: 20, offset: 0, wantHasMore: false, wantTotalPages: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := paginationMeta(tt.total, tt.limit, tt.offset)
+			if got["has_more"] != tt.wantHasMore {
+				t.Errorf("has_more = %v, want %v", got["has_more"], tt.wantHasMore)
+			}
+			if got["total_pages"] != tt.wantTotalPages {
+				t.Errorf("total_pages = %v, want %d", got["total_pages"], tt.wantTotalPages)
+			}
+			if got["total"] != tt.total || got["limit"] != tt.limit || got["offset"] != tt.offset {
+				t.Errorf("pagination = %v, want total %d limit %d offset %d", got, tt.total, tt.limit, tt.offset)
+			}
+		})
+	}
+}
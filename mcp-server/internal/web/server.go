@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"os"
 	"runtime/debug"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -19,6 +21,7 @@ import (
 	"github.com/thearchitectit/guardrail-mcp/internal/ingest"
 	metricsMiddleware "github.com/thearchitectit/guardrail-mcp/internal/metrics"
 	loggingMiddleware "github.com/thearchitectit/guardrail-mcp/internal/middleware"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
 	"github.com/thearchitectit/guardrail-mcp/internal/updates"
 )
 
@@ -33,9 +36,12 @@ type Server struct {
 	ruleStore     *database.RuleStore
 	projStore     *database.ProjectStore
 	failStore     *database.FailureStore
+	outcomeStore  validationOutcomeLister
 	ingestSvc     *ingest.Service
 	updateChecker *updates.Checker
 	version       string
+	maintenance   atomic.Bool
+	rateLimits    func(topN int) models.RateLimitStats
 }
 
 // NewServer creates a new web server
@@ -56,17 +62,26 @@ func NewServer(cfg *config.Config, db *database.DB, cacheClient *cache.Client, a
 		ruleStore:     database.NewRuleStore(db),
 		projStore:     database.NewProjectStore(db),
 		failStore:     database.NewFailureStore(db),
+		outcomeStore:  database.NewValidationOutcomeStore(db),
 		ingestSvc:     ingest.NewService(docStore, database.NewRuleStore(db), []string{"/app/docs"}, "/app/docs"),
 		updateChecker: updates.NewChecker(db, version, os.Getenv("GIT_COMMIT")),
 		version:       version,
 	}
 
+	s.maintenance.Store(cfg.MaintenanceMode)
+
 	s.setupMiddleware()
 	s.setupRoutes()
 
 	return s
 }
 
+// SetRateLimitStats sets the source of the MCP server's rate limiter stats served
+// at /api/admin/rate-limits.
+func (s *Server) SetRateLimitStats(stats func(topN int) models.RateLimitStats) {
+	s.rateLimits = stats
+}
+
 // Echo exposes the underlying Echo instance for external route registration.
 func (s *Server) Echo() *echo.Echo {
 	return s.echo
@@ -117,11 +132,13 @@ func (s *Server) setupMiddleware() {
 	limiter := s.cache.NewDistributedLimiter()
 	s.echo.Use(RateLimitMiddleware(limiter, s.cfg))
 
-	// Request timeout (skip vision endpoints — they have long-running inference)
+	// Request timeout (skip vision endpoints — they have long-running inference —
+	// and the event stream, which stays open)
 	s.echo.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
 		Timeout: s.cfg.RequestTimeout,
 		Skipper: func(c echo.Context) bool {
-			return strings.HasPrefix(c.Request().URL.Path, "/v1/vision")
+			path := c.Request().URL.Path
+			return strings.HasPrefix(path, "/v1/vision") || path == "/api/events"
 		},
 	}))
 
@@ -172,6 +189,8 @@ func (s *Server) setupRoutes() {
 	api.POST("/projects", s.createProject)
 	api.PUT("/projects/:id", s.updateProject)
 	api.DELETE("/projects/:id", s.deleteProject)
+	api.GET("/projects/:slug/teams", s.getProjectTeams)
+	api.GET("/projects/:slug/validation-trend", s.getValidationTrend)
 
 	// Failure registry routes
 	api.GET("/failures", s.listFailures)
@@ -183,6 +202,15 @@ func (s *Server) setupRoutes() {
 	api.GET("/stats", s.getStats)
 	api.POST("/ingest", s.triggerIngest)
 
+	// Event stream
+	api.GET("/events", s.streamEvents)
+
+	// Admin routes
+	api.GET("/admin/maintenance", s.getMaintenance)
+	api.PUT("/admin/maintenance", s.setMaintenance)
+	api.GET("/admin/rate-limits", s.getRateLimits)
+	api.GET("/config", s.getConfig)
+
 	// Update routes
 	api.GET("/updates/status", s.getUpdateStatus)
 	api.POST("/updates/check", s.checkForUpdates)
@@ -375,6 +403,18 @@ func (s *Server) healthLive(c echo.Context) error {
 }
 
 func (s *Server) healthReady(c echo.Context) error {
+	// During a planned maintenance window dependencies may be intentionally
+	// unavailable. Report ready (200) with a maintenance flag so orchestrators
+	// keep the pod in rotation instead of cycling it.
+	if s.maintenance.Load() {
+		return c.JSON(http.StatusOK, map[string]interface{}{
+			"status":      "maintenance",
+			"maintenance": true,
+			"version":     s.version,
+			"timestamp":   time.Now().UTC().Format(time.RFC3339),
+		})
+	}
+
 	ctx, cancel := context.WithTimeout(c.Request().Context(), s.cfg.HealthCheckTimeout)
 	defer cancel()
 
@@ -399,8 +439,85 @@ func (s *Server) healthReady(c echo.Context) error {
 	}
 
 	return c.JSON(http.StatusOK, map[string]interface{}{
-		"status":    "ready",
-		"version":   s.version,
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
+		"status":      "ready",
+		"maintenance": false,
+		"version":     s.version,
+		"timestamp":   time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// getConfig returns the effective configuration with secrets masked, so
+// operators can check what a running server was actually started with
+func (s *Server) getConfig(c echo.Context) error {
+	// Only the MCP (admin) key may read the server configuration
+	if keyType, _ := c.Get("api_key_type").(string); keyType != "mcp" {
+		return echo.NewHTTPError(http.StatusForbidden, "MCP API key required for this endpoint")
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"version":     s.version,
+		"maintenance": s.maintenance.Load(),
+		"config":      s.cfg.Effective(),
+	})
+}
+
+// getRateLimits returns the team tool rate limiters' configured limits and their
+// heaviest callers, to diagnose why a client is being throttled
+func (s *Server) getRateLimits(c echo.Context) error {
+	// Only the MCP (admin) key may see who is using the rate limits
+	if keyType, _ := c.Get("api_key_type").(string); keyType != "mcp" {
+		return echo.NewHTTPError(http.StatusForbidden, "MCP API key required for this endpoint")
+	}
+	if s.rateLimits == nil {
+		return echo.NewHTTPError(http.StatusServiceUnavailable, "Rate limiter stats are not available")
+	}
+
+	top := 10
+	if v := c.QueryParam("top"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, "top must be a positive integer")
+		}
+		top = n
+	}
+
+	return c.JSON(http.StatusOK, s.rateLimits(top))
+}
+
+// Maintenance handlers
+
+// MaintenanceRequest toggles maintenance mode
+type MaintenanceRequest struct {
+	Enabled bool `json:"enabled"`
+}
+
+func (s *Server) getMaintenance(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"maintenance": s.maintenance.Load(),
+	})
+}
+
+func (s *Server) setMaintenance(c echo.Context) error {
+	// Only the MCP (admin) key may toggle maintenance mode
+	if keyType, _ := c.Get("api_key_type").(string); keyType != "mcp" {
+		return echo.NewHTTPError(http.StatusForbidden, "MCP API key required for this endpoint")
+	}
+
+	var req MaintenanceRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "Invalid request body")
+	}
+
+	previous := s.maintenance.Swap(req.Enabled)
+	if previous != req.Enabled {
+		slog.Warn("Maintenance mode changed",
+			"maintenance", req.Enabled,
+			"key_hash", getAPIKeyHash(c),
+		)
+	}
+
+	return c.JSON(http.StatusOK, map[string]interface{}{
+		"maintenance": req.Enabled,
+		"previous":    previous,
 	})
 }
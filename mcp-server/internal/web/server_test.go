@@ -0,0 +1,202 @@
+package web
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/thearchitectit/guardrail-mcp/internal/config"
+	"github.com/thearchitectit/guardrail-mcp/internal/database"
+	"github.com/thearchitectit/guardrail-mcp/internal/models"
+)
+
+// unavailableConnector is a sql connector whose connections always fail,
+// simulating a database taken down for maintenance
+type unavailableConnector struct{}
+
+func (unavailableConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("database unavailable")
+}
+
+func (unavailableConnector) Driver() driver.Driver { return unavailableDriver{} }
+
+type unavailableDriver struct{}
+
+func (unavailableDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("database unavailable")
+}
+
+// newTestServer creates a Server without starting it or connecting to dependencies
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	db := &database.DB{DB: sql.OpenDB(unavailableConnector{})}
+	t.Cleanup(func() { db.Close() })
+	return &Server{
+		echo:    echo.New(),
+		cfg:     &config.Config{HealthCheckTimeout: time.Second},
+		db:      db,
+		version: "test",
+	}
+}
+
+func callHandler(t *testing.T, s *Server, h echo.HandlerFunc, method, body string, keyType string) (int, map[string]interface{}) {
+	t.Helper()
+	req := httptest.NewRequest(method, "/", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := s.echo.NewContext(req, rec)
+	if keyType != "" {
+		c.Set("api_key_type", keyType)
+	}
+	if err := h(c); err != nil {
+		var he *echo.HTTPError
+		if errors.As(err, &he) {
+			return he.Code, nil
+		}
+		t.Fatalf("handler error = %v", err)
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid JSON response: %v", err)
+	}
+	return rec.Code, resp
+}
+
+// TestMaintenanceToggle tests that maintenance mode changes readiness but not liveness
+func TestMaintenanceToggle(t *testing.T) {
+	s := newTestServer(t)
+
+	liveCode, liveBody := callHandler(t, s, s.healthLive, http.MethodGet, "", "")
+	readyCode, readyBody := callHandler(t, s, s.healthReady, http.MethodGet, "", "")
+	if readyCode != http.StatusServiceUnavailable {
+		t.Fatalf("readiness with unavailable database = %d, want %d", readyCode, http.StatusServiceUnavailable)
+	}
+	if _, ok := readyBody["maintenance"]; ok {
+		t.Errorf("readiness body should not report maintenance when disabled: %v", readyBody)
+	}
+
+	code, _ := callHandler(t, s, s.setMaintenance, http.MethodPut, `{"enabled":true}`, "mcp")
+	if code != http.StatusOK {
+		t.Fatalf("setMaintenance() = %d, want %d", code, http.StatusOK)
+	}
+
+	readyCode, readyBody = callHandler(t, s, s.healthReady, http.MethodGet, "", "")
+	if readyCode != http.StatusOK {
+		t.Errorf("readiness during maintenance = %d, want %d", readyCode, http.StatusOK)
+	}
+	if readyBody["maintenance"] != true || readyBody["status"] != "maintenance" {
+		t.Errorf("readiness body during maintenance = %v", readyBody)
+	}
+
+	code, body := callHandler(t, s, s.healthLive, http.MethodGet, "", "")
+	if code != liveCode || body["status"] != liveBody["status"] {
+		t.Errorf("liveness changed during maintenance: %d %v, want %d %v", code, body, liveCode, liveBody)
+	}
+	if _, ok := body["maintenance"]; ok {
+		t.Errorf("liveness body should not report maintenance: %v", body)
+	}
+
+	callHandler(t, s, s.setMaintenance, http.MethodPut, `{"enabled":false}`, "mcp")
+	if code, _ := callHandler(t, s, s.healthReady, http.MethodGet, "", ""); code != http.StatusServiceUnavailable {
+		t.Errorf("readiness after maintenance disabled = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
+
+// TestSetMaintenanceRequiresMCPKey tests that only the MCP key can toggle maintenance
+func TestSetMaintenanceRequiresMCPKey(t *testing.T) {
+	s := newTestServer(t)
+
+	code, _ := callHandler(t, s, s.setMaintenance, http.MethodPut, `{"enabled":true}`, "ide")
+	if code != http.StatusForbidden {
+		t.Errorf("setMaintenance() with IDE key = %d, want %d", code, http.StatusForbidden)
+	}
+	if s.maintenance.Load() {
+		t.Error("maintenance mode enabled by IDE key")
+	}
+}
+
+// TestGetConfig tests that the effective config is admin-only and never exposes secrets
+func TestGetConfig(t *testing.T) {
+	s := newTestServer(t)
+	s.cfg.MCPPort = 8080
+	s.cfg.RequestTimeout = 30 * time.Second
+	s.cfg.EnableValidation = true
+	s.cfg.DisabledTools = []string{"guardrail_validate_bash"}
+	s.cfg.DBPassword = "db-password-value"
+	s.cfg.MCPAPIKey = "mcp-key-value"
+	s.cfg.JWTSecret = "jwt-secret-value"
+
+	if code, _ := callHandler(t, s, s.getConfig, http.MethodGet, "", "ide"); code != http.StatusForbidden {
+		t.Errorf("getConfig() with IDE key = %d, want %d", code, http.StatusForbidden)
+	}
+
+	code, body := callHandler(t, s, s.getConfig, http.MethodGet, "", "mcp")
+	if code != http.StatusOK {
+		t.Fatalf("getConfig() = %d, want %d", code, http.StatusOK)
+	}
+	settings, ok := body["config"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("response has no config object: %v", body)
+	}
+	for key, want := range map[string]interface{}{
+		"MCP_PORT":          float64(8080),
+		"REQUEST_TIMEOUT":   "30s",
+		"ENABLE_VALIDATION": true,
+		"DB_PASSWORD":       "***",
+		"MCP_API_KEY":       "***",
+		"JWT_SECRET":        "***",
+	} {
+		if settings[key] != want {
+			t.Errorf("config[%s] = %v, want %v", key, settings[key], want)
+		}
+	}
+	if tools, _ := settings["DISABLED_TOOLS"].([]interface{}); len(tools) != 1 {
+		t.Errorf("config[DISABLED_TOOLS] = %v, want the disabled tool", settings["DISABLED_TOOLS"])
+	}
+	raw, _ := json.Marshal(body)
+	for _, secret := range []string{"db-password-value", "mcp-key-value", "jwt-secret-value"} {
+		if strings.Contains(string(raw), secret) {
+			t.Errorf("response leaks secret %q", secret)
+		}
+	}
+}
+
+// TestGetRateLimits tests that rate limiter stats are admin-only and honour the top parameter
+func TestGetRateLimits(t *testing.T) {
+	s := newTestServer(t)
+	if code, _ := callHandler(t, s, s.getRateLimits, http.MethodGet, "", "mcp"); code != http.StatusServiceUnavailable {
+		t.Errorf("getRateLimits() without stats = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+
+	var gotTop int
+	s.SetRateLimitStats(func(topN int) models.RateLimitStats {
+		gotTop = topN
+		return models.RateLimitStats{Mutating: models.RateLimiterSnapshot{
+			Limit:         100,
+			WindowSeconds: 60,
+			ActiveBuckets: 1,
+			TopConsumers:  []models.RateLimitConsumer{{Key: "ip:203.0.113.7", Used: 40, Remaining: 60}},
+		}}
+	})
+
+	if code, _ := callHandler(t, s, s.getRateLimits, http.MethodGet, "", "ide"); code != http.StatusForbidden {
+		t.Errorf("getRateLimits() with IDE key = %d, want %d", code, http.StatusForbidden)
+	}
+	code, body := callHandler(t, s, s.getRateLimits, http.MethodGet, "", "mcp")
+	if code != http.StatusOK || gotTop != 10 {
+		t.Fatalf("getRateLimits() = %d with top %d, want %d with top 10", code, gotTop, http.StatusOK)
+	}
+	mutating, _ := body["mutating"].(map[string]interface{})
+	consumers, _ := mutating["top_consumers"].([]interface{})
+	if mutating["limit"] != float64(100) || len(consumers) != 1 {
+		t.Errorf("body = %v, want the mutating limiter with one consumer", body)
+	}
+}
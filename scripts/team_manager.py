@@ -1774,11 +1774,15 @@ class TeamManager:
             }, exc_info=True)
             raise
 
-    def assign_role(self, team_id: int, role_name: str, assignee: str) -> bool:
+    def assign_role(self, team_id: int, role_name: str, assignee: str, replace: bool = False) -> bool:
         """Assign a person to a role.
 
         Requires team-lead role (for their team) or admin role.
         SEC-008: Logs audit trail.
+
+        With replace, a role held by someone else is unassigned from them first;
+        the unassignment and the assignment are saved together and logged as two
+        audit events.
         """
         self.performance_metrics.start_operation("assign", team_id=team_id, role_name=role_name, assignee=assignee)
 
@@ -1844,23 +1848,40 @@ class TeamManager:
             if role.name == role_name:
                 # SEC-008: Capture before state for audit
                 previous_assignee = role.assigned_to
+                replaced = replace and previous_assignee is not None and previous_assignee != assignee
                 role.assigned_to = assignee
                 self.save()
 
                 # SEC-008: Log audit trail
                 if self.enable_audit and self.audit_logger:
+                    if replaced:
+                        self.audit_logger.log_action(
+                            "unassign_role",
+                            {
+                                "team_id": team_id,
+                                "team_name": team.name,
+                                "role_name": role_name,
+                                "before": previous_assignee,
+                                "after": None,
+                                "replaced_by": assignee
+                            },
+                            self.user_context
+                        )
                     self.audit_logger.log_action(
                         "assign_role",
                         {
                             "team_id": team_id,
                             "team_name": team.name,
                             "role_name": role_name,
-                            "before": previous_assignee,
+                            "before": None if replaced else previous_assignee,
                             "after": assignee
                         },
                         self.user_context
                     )
 
+                if replaced:
+                    print(f"✅ Unassigned {previous_assignee} from {role_name} in {team.name}")
+
                 self.logger.info("role_assigned", {
                     "team_id": team_id,
                     "team_name": team.name,
@@ -2926,6 +2947,8 @@ def main():
     assign_parser.add_argument("--team", type=int, required=True, help="Team ID")
     assign_parser.add_argument("--role", required=True, help="Role name")
     assign_parser.add_argument("--person", required=True, help="Person name")
+    assign_parser.add_argument("--replace", action="store_true",
+                               help="Unassign the current holder of the role first")
 
     # Unassign command
     unassign_parser = subparsers.add_parser("unassign", help="Remove person from role")
@@ -2992,6 +3015,7 @@ def main():
     audit_parser.add_argument("--team", type=int, help="Filter by team ID")
     audit_parser.add_argument("--limit", type=int, default=20, help="Maximum entries to show (default: 20)")
     audit_parser.add_argument("--recent", action="store_true", help="Show most recent entries")
+    audit_parser.add_argument("--format", choices=["table", "json"], default="table", help="Output format")
 
     # Team history command (FUNC-011)
     history_parser = subparsers.add_parser("team-history", help="Show history for a team")
@@ -3076,7 +3100,7 @@ def main():
         manager.initialize_project()
         print(f"\nTeams configuration saved to: {manager.config_path}")
 
-    elif args.command in ["list", "assign", "unassign", "start", "complete", "status", "validate-size", "delete-team", "delete-project", "list-backups", "restore", "audit", "import-csv", "export-csv", "import-json", "export-json"]:
+    elif args.command in ["list", "query", "assign", "unassign", "reassign", "start", "complete", "status", "validate-size", "delete-team", "delete-project", "list-backups", "restore", "audit", "team-history", "project-timeline", "import-csv", "export-csv", "import-json", "export-json"]:
         if args.command in ["delete-team", "delete-project"]:
             # For delete commands, project may not exist yet (delete-project)
             if args.command == "delete-team" and not manager.load():
@@ -3121,7 +3145,7 @@ def main():
                         print()
 
         elif args.command == "assign":
-            if manager.assign_role(args.team, args.role, args.person):
+            if manager.assign_role(args.team, args.role, args.person, replace=args.replace):
                 print(f"✅ Assigned {args.person} to {args.role} in Team {args.team}")
 
         elif args.command == "unassign":
@@ -3207,7 +3231,9 @@ def main():
                     filters["team_id"] = args.team
                 entries = manager.query_audit(**filters)
 
-            if entries:
+            if args.format == "json":
+                print(json.dumps(entries, indent=2))
+            elif entries:
                 print(f"\n📋 Audit log entries for '{args.project}':")
                 print(f"{'Timestamp':<25} {'User':<15} {'Action':<20} {'Details'}")
                 print("-" * 100)
@@ -19,7 +19,7 @@ sys.path.insert(0, os.path.dirname(os.path.dirname(os.path.abspath(__file__))))
 
 from scripts.team_manager import (
     TeamManager, Role, Team, validate_project_name,
-    UserContext, StructuredLogger, PermissionDenied
+    UserContext, StructuredLogger, PermissionDenied, AuditLogger
 )
 
 
@@ -317,6 +317,39 @@ class TestTeamManagerAssignRole(unittest.TestCase):
         for i, role in enumerate(team.roles):
             self.assertEqual(role.assigned_to, f"Person {i}")
 
+    def test_assign_role_replace_swaps_assignee(self):
+        """Test that replace unassigns the current holder and records both events."""
+        self.manager.audit_logger = AuditLogger("test-project", Path(self.temp_dir))
+        role = next(r for r in self.manager.teams[1].roles if r.name == "Business Relationship Manager")
+        role.assigned_to = None
+        self.manager.assign_role(1, role.name, "John Doe")
+
+        result = self.manager.assign_role(1, role.name, "Jane Roe", replace=True)
+        self.assertTrue(result)
+        self.assertEqual(role.assigned_to, "Jane Roe")
+
+        events = [
+            (e["action"], e["details"]["before"], e["details"]["after"])
+            for e in self.manager.audit_logger.query_audit_log(team_id=1)
+        ]
+        self.assertEqual(events, [
+            ("assign_role", None, "John Doe"),
+            ("unassign_role", "John Doe", None),
+            ("assign_role", None, "Jane Roe"),
+        ])
+
+    def test_assign_role_replace_empty_role(self):
+        """Test that replace on an unfilled role records only the assignment."""
+        self.manager.audit_logger = AuditLogger("test-project", Path(self.temp_dir))
+        role = next(r for r in self.manager.teams[1].roles if r.name == "Business Relationship Manager")
+        role.assigned_to = None
+
+        result = self.manager.assign_role(1, role.name, "Jane Roe", replace=True)
+        self.assertTrue(result)
+
+        actions = [e["action"] for e in self.manager.audit_logger.query_audit_log(team_id=1)]
+        self.assertEqual(actions, ["assign_role"])
+
     def test_assign_role_requires_permission(self):
         """Test that assign_role requires team-lead or admin permission."""
         # Create viewer context